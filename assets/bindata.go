@@ -1,6 +1,8 @@
 // Code generated for package assets by go-bindata DO NOT EDIT. (@generated)
 // sources:
 // templates/cloudformation/iam_user_osdCcsAdmin.json
+// templates/cloudformation/rosa_network.json
+// templates/pricing/aws_pricing.yaml
 package assets
 
 import (
@@ -83,6 +85,1088 @@ func templatesCloudformationIam_user_osdccsadminJson() (*asset, error) {
 	return a, nil
 }
 
+var _templatesCloudformationRosa_networkJson = []byte(`{
+  "AWSTemplateFormatVersion": "2010-09-09",
+  "Description": "VPC with public and private subnets, NAT gateways and load balancer role tags ready for ROSA clusters",
+  "Parameters": {
+    "NetworkName": {
+      "Type": "String",
+      "Description": "Name used to tag the network resources"
+    },
+    "VpcCidr": {
+      "Type": "String",
+      "Description": "CIDR block of the VPC",
+      "Default": "10.0.0.0/16"
+    },
+    "SubnetBits": {
+      "Type": "Number",
+      "Description": "Number of host bits of each subnet",
+      "Default": "13"
+    },
+    "AvailabilityZones": {
+      "Type": "List<AWS::EC2::AvailabilityZone::Name>",
+      "Description": "Availability zones to create subnets in"
+    },
+    "AvailabilityZoneCount": {
+      "Type": "Number",
+      "Description": "Number of availability zones to use",
+      "AllowedValues": [
+        "1",
+        "2",
+        "3"
+      ],
+      "Default": "1"
+    }
+  },
+  "Conditions": {
+    "HasAvailabilityZone2": {
+      "Fn::Not": [
+        {
+          "Fn::Equals": [
+            {
+              "Ref": "AvailabilityZoneCount"
+            },
+            "1"
+          ]
+        }
+      ]
+    },
+    "HasAvailabilityZone3": {
+      "Fn::Equals": [
+        {
+          "Ref": "AvailabilityZoneCount"
+        },
+        "3"
+      ]
+    }
+  },
+  "Resources": {
+    "VPC": {
+      "Type": "AWS::EC2::VPC",
+      "Properties": {
+        "CidrBlock": {
+          "Ref": "VpcCidr"
+        },
+        "EnableDnsSupport": true,
+        "EnableDnsHostnames": true,
+        "Tags": [
+          {
+            "Key": "Name",
+            "Value": {
+              "Fn::Join": [
+                "-",
+                [
+                  {
+                    "Ref": "NetworkName"
+                  },
+                  "vpc"
+                ]
+              ]
+            }
+          }
+        ]
+      }
+    },
+    "InternetGateway": {
+      "Type": "AWS::EC2::InternetGateway",
+      "Properties": {
+        "Tags": [
+          {
+            "Key": "Name",
+            "Value": {
+              "Fn::Join": [
+                "-",
+                [
+                  {
+                    "Ref": "NetworkName"
+                  },
+                  "igw"
+                ]
+              ]
+            }
+          }
+        ]
+      }
+    },
+    "InternetGatewayAttachment": {
+      "Type": "AWS::EC2::VPCGatewayAttachment",
+      "Properties": {
+        "VpcId": {
+          "Ref": "VPC"
+        },
+        "InternetGatewayId": {
+          "Ref": "InternetGateway"
+        }
+      }
+    },
+    "PublicRouteTable": {
+      "Type": "AWS::EC2::RouteTable",
+      "Properties": {
+        "VpcId": {
+          "Ref": "VPC"
+        },
+        "Tags": [
+          {
+            "Key": "Name",
+            "Value": {
+              "Fn::Join": [
+                "-",
+                [
+                  {
+                    "Ref": "NetworkName"
+                  },
+                  "public"
+                ]
+              ]
+            }
+          }
+        ]
+      }
+    },
+    "PublicDefaultRoute": {
+      "Type": "AWS::EC2::Route",
+      "DependsOn": "InternetGatewayAttachment",
+      "Properties": {
+        "RouteTableId": {
+          "Ref": "PublicRouteTable"
+        },
+        "DestinationCidrBlock": "0.0.0.0/0",
+        "GatewayId": {
+          "Ref": "InternetGateway"
+        }
+      }
+    },
+    "PublicSubnet1": {
+      "Type": "AWS::EC2::Subnet",
+      "Properties": {
+        "VpcId": {
+          "Ref": "VPC"
+        },
+        "AvailabilityZone": {
+          "Fn::Select": [
+            0,
+            {
+              "Ref": "AvailabilityZones"
+            }
+          ]
+        },
+        "CidrBlock": {
+          "Fn::Select": [
+            0,
+            {
+              "Fn::Cidr": [
+                {
+                  "Ref": "VpcCidr"
+                },
+                6,
+                {
+                  "Ref": "SubnetBits"
+                }
+              ]
+            }
+          ]
+        },
+        "Tags": [
+          {
+            "Key": "Name",
+            "Value": {
+              "Fn::Join": [
+                "-",
+                [
+                  {
+                    "Ref": "NetworkName"
+                  },
+                  "public-1"
+                ]
+              ]
+            }
+          },
+          {
+            "Key": "kubernetes.io/role/elb",
+            "Value": "1"
+          }
+        ]
+      }
+    },
+    "PrivateSubnet1": {
+      "Type": "AWS::EC2::Subnet",
+      "Properties": {
+        "VpcId": {
+          "Ref": "VPC"
+        },
+        "AvailabilityZone": {
+          "Fn::Select": [
+            0,
+            {
+              "Ref": "AvailabilityZones"
+            }
+          ]
+        },
+        "CidrBlock": {
+          "Fn::Select": [
+            3,
+            {
+              "Fn::Cidr": [
+                {
+                  "Ref": "VpcCidr"
+                },
+                6,
+                {
+                  "Ref": "SubnetBits"
+                }
+              ]
+            }
+          ]
+        },
+        "Tags": [
+          {
+            "Key": "Name",
+            "Value": {
+              "Fn::Join": [
+                "-",
+                [
+                  {
+                    "Ref": "NetworkName"
+                  },
+                  "private-1"
+                ]
+              ]
+            }
+          },
+          {
+            "Key": "kubernetes.io/role/internal-elb",
+            "Value": "1"
+          }
+        ]
+      }
+    },
+    "PublicSubnetRouteTableAssociation1": {
+      "Type": "AWS::EC2::SubnetRouteTableAssociation",
+      "Properties": {
+        "SubnetId": {
+          "Ref": "PublicSubnet1"
+        },
+        "RouteTableId": {
+          "Ref": "PublicRouteTable"
+        }
+      }
+    },
+    "NatGatewayEIP1": {
+      "Type": "AWS::EC2::EIP",
+      "DependsOn": "InternetGatewayAttachment",
+      "Properties": {
+        "Domain": "vpc",
+        "Tags": [
+          {
+            "Key": "Name",
+            "Value": {
+              "Fn::Join": [
+                "-",
+                [
+                  {
+                    "Ref": "NetworkName"
+                  },
+                  "nat-1"
+                ]
+              ]
+            }
+          }
+        ]
+      }
+    },
+    "NatGateway1": {
+      "Type": "AWS::EC2::NatGateway",
+      "Properties": {
+        "AllocationId": {
+          "Fn::GetAtt": [
+            "NatGatewayEIP1",
+            "AllocationId"
+          ]
+        },
+        "SubnetId": {
+          "Ref": "PublicSubnet1"
+        },
+        "Tags": [
+          {
+            "Key": "Name",
+            "Value": {
+              "Fn::Join": [
+                "-",
+                [
+                  {
+                    "Ref": "NetworkName"
+                  },
+                  "nat-1"
+                ]
+              ]
+            }
+          }
+        ]
+      }
+    },
+    "PrivateRouteTable1": {
+      "Type": "AWS::EC2::RouteTable",
+      "Properties": {
+        "VpcId": {
+          "Ref": "VPC"
+        },
+        "Tags": [
+          {
+            "Key": "Name",
+            "Value": {
+              "Fn::Join": [
+                "-",
+                [
+                  {
+                    "Ref": "NetworkName"
+                  },
+                  "private-1"
+                ]
+              ]
+            }
+          }
+        ]
+      }
+    },
+    "PrivateDefaultRoute1": {
+      "Type": "AWS::EC2::Route",
+      "Properties": {
+        "RouteTableId": {
+          "Ref": "PrivateRouteTable1"
+        },
+        "DestinationCidrBlock": "0.0.0.0/0",
+        "NatGatewayId": {
+          "Ref": "NatGateway1"
+        }
+      }
+    },
+    "PrivateSubnetRouteTableAssociation1": {
+      "Type": "AWS::EC2::SubnetRouteTableAssociation",
+      "Properties": {
+        "SubnetId": {
+          "Ref": "PrivateSubnet1"
+        },
+        "RouteTableId": {
+          "Ref": "PrivateRouteTable1"
+        }
+      }
+    },
+    "PublicSubnet2": {
+      "Type": "AWS::EC2::Subnet",
+      "Condition": "HasAvailabilityZone2",
+      "Properties": {
+        "VpcId": {
+          "Ref": "VPC"
+        },
+        "AvailabilityZone": {
+          "Fn::Select": [
+            1,
+            {
+              "Ref": "AvailabilityZones"
+            }
+          ]
+        },
+        "CidrBlock": {
+          "Fn::Select": [
+            1,
+            {
+              "Fn::Cidr": [
+                {
+                  "Ref": "VpcCidr"
+                },
+                6,
+                {
+                  "Ref": "SubnetBits"
+                }
+              ]
+            }
+          ]
+        },
+        "Tags": [
+          {
+            "Key": "Name",
+            "Value": {
+              "Fn::Join": [
+                "-",
+                [
+                  {
+                    "Ref": "NetworkName"
+                  },
+                  "public-2"
+                ]
+              ]
+            }
+          },
+          {
+            "Key": "kubernetes.io/role/elb",
+            "Value": "1"
+          }
+        ]
+      }
+    },
+    "PrivateSubnet2": {
+      "Type": "AWS::EC2::Subnet",
+      "Condition": "HasAvailabilityZone2",
+      "Properties": {
+        "VpcId": {
+          "Ref": "VPC"
+        },
+        "AvailabilityZone": {
+          "Fn::Select": [
+            1,
+            {
+              "Ref": "AvailabilityZones"
+            }
+          ]
+        },
+        "CidrBlock": {
+          "Fn::Select": [
+            4,
+            {
+              "Fn::Cidr": [
+                {
+                  "Ref": "VpcCidr"
+                },
+                6,
+                {
+                  "Ref": "SubnetBits"
+                }
+              ]
+            }
+          ]
+        },
+        "Tags": [
+          {
+            "Key": "Name",
+            "Value": {
+              "Fn::Join": [
+                "-",
+                [
+                  {
+                    "Ref": "NetworkName"
+                  },
+                  "private-2"
+                ]
+              ]
+            }
+          },
+          {
+            "Key": "kubernetes.io/role/internal-elb",
+            "Value": "1"
+          }
+        ]
+      }
+    },
+    "PublicSubnetRouteTableAssociation2": {
+      "Type": "AWS::EC2::SubnetRouteTableAssociation",
+      "Condition": "HasAvailabilityZone2",
+      "Properties": {
+        "SubnetId": {
+          "Ref": "PublicSubnet2"
+        },
+        "RouteTableId": {
+          "Ref": "PublicRouteTable"
+        }
+      }
+    },
+    "NatGatewayEIP2": {
+      "Type": "AWS::EC2::EIP",
+      "Condition": "HasAvailabilityZone2",
+      "DependsOn": "InternetGatewayAttachment",
+      "Properties": {
+        "Domain": "vpc",
+        "Tags": [
+          {
+            "Key": "Name",
+            "Value": {
+              "Fn::Join": [
+                "-",
+                [
+                  {
+                    "Ref": "NetworkName"
+                  },
+                  "nat-2"
+                ]
+              ]
+            }
+          }
+        ]
+      }
+    },
+    "NatGateway2": {
+      "Type": "AWS::EC2::NatGateway",
+      "Condition": "HasAvailabilityZone2",
+      "Properties": {
+        "AllocationId": {
+          "Fn::GetAtt": [
+            "NatGatewayEIP2",
+            "AllocationId"
+          ]
+        },
+        "SubnetId": {
+          "Ref": "PublicSubnet2"
+        },
+        "Tags": [
+          {
+            "Key": "Name",
+            "Value": {
+              "Fn::Join": [
+                "-",
+                [
+                  {
+                    "Ref": "NetworkName"
+                  },
+                  "nat-2"
+                ]
+              ]
+            }
+          }
+        ]
+      }
+    },
+    "PrivateRouteTable2": {
+      "Type": "AWS::EC2::RouteTable",
+      "Condition": "HasAvailabilityZone2",
+      "Properties": {
+        "VpcId": {
+          "Ref": "VPC"
+        },
+        "Tags": [
+          {
+            "Key": "Name",
+            "Value": {
+              "Fn::Join": [
+                "-",
+                [
+                  {
+                    "Ref": "NetworkName"
+                  },
+                  "private-2"
+                ]
+              ]
+            }
+          }
+        ]
+      }
+    },
+    "PrivateDefaultRoute2": {
+      "Type": "AWS::EC2::Route",
+      "Condition": "HasAvailabilityZone2",
+      "Properties": {
+        "RouteTableId": {
+          "Ref": "PrivateRouteTable2"
+        },
+        "DestinationCidrBlock": "0.0.0.0/0",
+        "NatGatewayId": {
+          "Ref": "NatGateway2"
+        }
+      }
+    },
+    "PrivateSubnetRouteTableAssociation2": {
+      "Type": "AWS::EC2::SubnetRouteTableAssociation",
+      "Condition": "HasAvailabilityZone2",
+      "Properties": {
+        "SubnetId": {
+          "Ref": "PrivateSubnet2"
+        },
+        "RouteTableId": {
+          "Ref": "PrivateRouteTable2"
+        }
+      }
+    },
+    "PublicSubnet3": {
+      "Type": "AWS::EC2::Subnet",
+      "Condition": "HasAvailabilityZone3",
+      "Properties": {
+        "VpcId": {
+          "Ref": "VPC"
+        },
+        "AvailabilityZone": {
+          "Fn::Select": [
+            2,
+            {
+              "Ref": "AvailabilityZones"
+            }
+          ]
+        },
+        "CidrBlock": {
+          "Fn::Select": [
+            2,
+            {
+              "Fn::Cidr": [
+                {
+                  "Ref": "VpcCidr"
+                },
+                6,
+                {
+                  "Ref": "SubnetBits"
+                }
+              ]
+            }
+          ]
+        },
+        "Tags": [
+          {
+            "Key": "Name",
+            "Value": {
+              "Fn::Join": [
+                "-",
+                [
+                  {
+                    "Ref": "NetworkName"
+                  },
+                  "public-3"
+                ]
+              ]
+            }
+          },
+          {
+            "Key": "kubernetes.io/role/elb",
+            "Value": "1"
+          }
+        ]
+      }
+    },
+    "PrivateSubnet3": {
+      "Type": "AWS::EC2::Subnet",
+      "Condition": "HasAvailabilityZone3",
+      "Properties": {
+        "VpcId": {
+          "Ref": "VPC"
+        },
+        "AvailabilityZone": {
+          "Fn::Select": [
+            2,
+            {
+              "Ref": "AvailabilityZones"
+            }
+          ]
+        },
+        "CidrBlock": {
+          "Fn::Select": [
+            5,
+            {
+              "Fn::Cidr": [
+                {
+                  "Ref": "VpcCidr"
+                },
+                6,
+                {
+                  "Ref": "SubnetBits"
+                }
+              ]
+            }
+          ]
+        },
+        "Tags": [
+          {
+            "Key": "Name",
+            "Value": {
+              "Fn::Join": [
+                "-",
+                [
+                  {
+                    "Ref": "NetworkName"
+                  },
+                  "private-3"
+                ]
+              ]
+            }
+          },
+          {
+            "Key": "kubernetes.io/role/internal-elb",
+            "Value": "1"
+          }
+        ]
+      }
+    },
+    "PublicSubnetRouteTableAssociation3": {
+      "Type": "AWS::EC2::SubnetRouteTableAssociation",
+      "Condition": "HasAvailabilityZone3",
+      "Properties": {
+        "SubnetId": {
+          "Ref": "PublicSubnet3"
+        },
+        "RouteTableId": {
+          "Ref": "PublicRouteTable"
+        }
+      }
+    },
+    "NatGatewayEIP3": {
+      "Type": "AWS::EC2::EIP",
+      "Condition": "HasAvailabilityZone3",
+      "DependsOn": "InternetGatewayAttachment",
+      "Properties": {
+        "Domain": "vpc",
+        "Tags": [
+          {
+            "Key": "Name",
+            "Value": {
+              "Fn::Join": [
+                "-",
+                [
+                  {
+                    "Ref": "NetworkName"
+                  },
+                  "nat-3"
+                ]
+              ]
+            }
+          }
+        ]
+      }
+    },
+    "NatGateway3": {
+      "Type": "AWS::EC2::NatGateway",
+      "Condition": "HasAvailabilityZone3",
+      "Properties": {
+        "AllocationId": {
+          "Fn::GetAtt": [
+            "NatGatewayEIP3",
+            "AllocationId"
+          ]
+        },
+        "SubnetId": {
+          "Ref": "PublicSubnet3"
+        },
+        "Tags": [
+          {
+            "Key": "Name",
+            "Value": {
+              "Fn::Join": [
+                "-",
+                [
+                  {
+                    "Ref": "NetworkName"
+                  },
+                  "nat-3"
+                ]
+              ]
+            }
+          }
+        ]
+      }
+    },
+    "PrivateRouteTable3": {
+      "Type": "AWS::EC2::RouteTable",
+      "Condition": "HasAvailabilityZone3",
+      "Properties": {
+        "VpcId": {
+          "Ref": "VPC"
+        },
+        "Tags": [
+          {
+            "Key": "Name",
+            "Value": {
+              "Fn::Join": [
+                "-",
+                [
+                  {
+                    "Ref": "NetworkName"
+                  },
+                  "private-3"
+                ]
+              ]
+            }
+          }
+        ]
+      }
+    },
+    "PrivateDefaultRoute3": {
+      "Type": "AWS::EC2::Route",
+      "Condition": "HasAvailabilityZone3",
+      "Properties": {
+        "RouteTableId": {
+          "Ref": "PrivateRouteTable3"
+        },
+        "DestinationCidrBlock": "0.0.0.0/0",
+        "NatGatewayId": {
+          "Ref": "NatGateway3"
+        }
+      }
+    },
+    "PrivateSubnetRouteTableAssociation3": {
+      "Type": "AWS::EC2::SubnetRouteTableAssociation",
+      "Condition": "HasAvailabilityZone3",
+      "Properties": {
+        "SubnetId": {
+          "Ref": "PrivateSubnet3"
+        },
+        "RouteTableId": {
+          "Ref": "PrivateRouteTable3"
+        }
+      }
+    },
+    "S3Endpoint": {
+      "Type": "AWS::EC2::VPCEndpoint",
+      "Properties": {
+        "VpcId": {
+          "Ref": "VPC"
+        },
+        "ServiceName": {
+          "Fn::Join": [
+            "",
+            [
+              "com.amazonaws.",
+              {
+                "Ref": "AWS::Region"
+              },
+              ".s3"
+            ]
+          ]
+        },
+        "VpcEndpointType": "Gateway",
+        "RouteTableIds": [
+          {
+            "Ref": "PrivateRouteTable1"
+          },
+          {
+            "Fn::If": [
+              "HasAvailabilityZone2",
+              {
+                "Ref": "PrivateRouteTable2"
+              },
+              {
+                "Ref": "AWS::NoValue"
+              }
+            ]
+          },
+          {
+            "Fn::If": [
+              "HasAvailabilityZone3",
+              {
+                "Ref": "PrivateRouteTable3"
+              },
+              {
+                "Ref": "AWS::NoValue"
+              }
+            ]
+          }
+        ]
+      }
+    }
+  },
+  "Outputs": {
+    "VpcId": {
+      "Description": "ID of the VPC",
+      "Value": {
+        "Ref": "VPC"
+      }
+    },
+    "PublicSubnet1": {
+      "Description": "ID of the public subnet in availability zone 1",
+      "Value": {
+        "Ref": "PublicSubnet1"
+      }
+    },
+    "PrivateSubnet1": {
+      "Description": "ID of the private subnet in availability zone 1",
+      "Value": {
+        "Ref": "PrivateSubnet1"
+      }
+    },
+    "PublicSubnet2": {
+      "Condition": "HasAvailabilityZone2",
+      "Description": "ID of the public subnet in availability zone 2",
+      "Value": {
+        "Ref": "PublicSubnet2"
+      }
+    },
+    "PrivateSubnet2": {
+      "Condition": "HasAvailabilityZone2",
+      "Description": "ID of the private subnet in availability zone 2",
+      "Value": {
+        "Ref": "PrivateSubnet2"
+      }
+    },
+    "PublicSubnet3": {
+      "Condition": "HasAvailabilityZone3",
+      "Description": "ID of the public subnet in availability zone 3",
+      "Value": {
+        "Ref": "PublicSubnet3"
+      }
+    },
+    "PrivateSubnet3": {
+      "Condition": "HasAvailabilityZone3",
+      "Description": "ID of the private subnet in availability zone 3",
+      "Value": {
+        "Ref": "PrivateSubnet3"
+      }
+    }
+  }
+}
+`)
+
+func templatesCloudformationRosa_networkJsonBytes() ([]byte, error) {
+	return _templatesCloudformationRosa_networkJson, nil
+}
+
+func templatesCloudformationRosa_networkJson() (*asset, error) {
+	bytes, err := templatesCloudformationRosa_networkJsonBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/cloudformation/rosa_network.json", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesPricingAws_pricingYaml = []byte(`# Approximate AWS on-demand list prices (Linux, USD) used by 'rosa estimate cost'.
+# Prices change over time and vary per region. To use up to date prices, copy this
+# file, update it and pass it with '--pricing-file', or refresh the cached copy with
+# 'rosa estimate cost --refresh-pricing-from <url-or-path>'.
+currency: USD
+effective_date: "2024-04-01"
+rosa:
+  # Service fee charged per vCPU of every worker node (0.171 per 4 vCPU)
+  worker_vcpu_hour: 0.04275
+  # Service fee charged per hosted control plane
+  hosted_control_plane_hour: 0.25
+regions:
+  us-east-1:
+    ebs_gp3_gib_month: 0.08
+    load_balancer_hour: 0.0225
+    nat_gateway_hour: 0.045
+    instances:
+      c5.xlarge: 0.17
+      c5.2xlarge: 0.34
+      c5.4xlarge: 0.68
+      m5.xlarge: 0.192
+      m5.2xlarge: 0.384
+      m5.4xlarge: 0.768
+      m5.8xlarge: 1.536
+      m5.12xlarge: 2.304
+      m6a.xlarge: 0.1728
+      m6a.2xlarge: 0.3456
+      m6g.xlarge: 0.154
+      m6g.2xlarge: 0.308
+      m6i.xlarge: 0.192
+      m6i.2xlarge: 0.384
+      m6i.4xlarge: 0.768
+      m7i.xlarge: 0.2016
+      m7i.2xlarge: 0.4032
+      r5.xlarge: 0.252
+      r5.2xlarge: 0.504
+      r5.4xlarge: 1.008
+      r6i.xlarge: 0.252
+      r6i.2xlarge: 0.504
+      g4dn.xlarge: 0.526
+  us-east-2:
+    ebs_gp3_gib_month: 0.08
+    load_balancer_hour: 0.0225
+    nat_gateway_hour: 0.045
+    instances:
+      c5.xlarge: 0.17
+      c5.2xlarge: 0.34
+      c5.4xlarge: 0.68
+      m5.xlarge: 0.192
+      m5.2xlarge: 0.384
+      m5.4xlarge: 0.768
+      m5.8xlarge: 1.536
+      m5.12xlarge: 2.304
+      m6a.xlarge: 0.1728
+      m6a.2xlarge: 0.3456
+      m6g.xlarge: 0.154
+      m6g.2xlarge: 0.308
+      m6i.xlarge: 0.192
+      m6i.2xlarge: 0.384
+      m6i.4xlarge: 0.768
+      m7i.xlarge: 0.2016
+      m7i.2xlarge: 0.4032
+      r5.xlarge: 0.252
+      r5.2xlarge: 0.504
+      r5.4xlarge: 1.008
+      r6i.xlarge: 0.252
+      r6i.2xlarge: 0.504
+      g4dn.xlarge: 0.526
+  us-west-2:
+    ebs_gp3_gib_month: 0.08
+    load_balancer_hour: 0.0225
+    nat_gateway_hour: 0.045
+    instances:
+      c5.xlarge: 0.17
+      c5.2xlarge: 0.34
+      c5.4xlarge: 0.68
+      m5.xlarge: 0.192
+      m5.2xlarge: 0.384
+      m5.4xlarge: 0.768
+      m5.8xlarge: 1.536
+      m5.12xlarge: 2.304
+      m6a.xlarge: 0.1728
+      m6a.2xlarge: 0.3456
+      m6g.xlarge: 0.154
+      m6g.2xlarge: 0.308
+      m6i.xlarge: 0.192
+      m6i.2xlarge: 0.384
+      m6i.4xlarge: 0.768
+      m7i.xlarge: 0.2016
+      m7i.2xlarge: 0.4032
+      r5.xlarge: 0.252
+      r5.2xlarge: 0.504
+      r5.4xlarge: 1.008
+      r6i.xlarge: 0.252
+      r6i.2xlarge: 0.504
+      g4dn.xlarge: 0.526
+  eu-west-1:
+    ebs_gp3_gib_month: 0.088
+    load_balancer_hour: 0.0252
+    nat_gateway_hour: 0.048
+    instances:
+      c5.xlarge: 0.192
+      c5.2xlarge: 0.384
+      c5.4xlarge: 0.768
+      m5.xlarge: 0.214
+      m5.2xlarge: 0.428
+      m5.4xlarge: 0.856
+      m5.8xlarge: 1.712
+      m5.12xlarge: 2.568
+      m6i.xlarge: 0.214
+      m6i.2xlarge: 0.428
+      m6i.4xlarge: 0.856
+      r5.xlarge: 0.282
+      r5.2xlarge: 0.564
+      r5.4xlarge: 1.128
+      r6i.xlarge: 0.282
+      r6i.2xlarge: 0.564
+  eu-central-1:
+    ebs_gp3_gib_month: 0.0952
+    load_balancer_hour: 0.027
+    nat_gateway_hour: 0.052
+    instances:
+      c5.xlarge: 0.194
+      c5.2xlarge: 0.388
+      c5.4xlarge: 0.776
+      m5.xlarge: 0.23
+      m5.2xlarge: 0.46
+      m5.4xlarge: 0.92
+      m5.8xlarge: 1.84
+      m5.12xlarge: 2.76
+      m6i.xlarge: 0.23
+      m6i.2xlarge: 0.46
+      m6i.4xlarge: 0.92
+      r5.xlarge: 0.304
+      r5.2xlarge: 0.608
+      r5.4xlarge: 1.216
+      r6i.xlarge: 0.304
+      r6i.2xlarge: 0.608
+`)
+
+func templatesPricingAws_pricingYamlBytes() ([]byte, error) {
+	return _templatesPricingAws_pricingYaml, nil
+}
+
+func templatesPricingAws_pricingYaml() (*asset, error) {
+	bytes, err := templatesPricingAws_pricingYamlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/pricing/aws_pricing.yaml", size: 0, mode: os.FileMode(0), modTime: time.Unix(0, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 // Asset loads and returns the asset for the given name.
 // It returns an error if the asset could not be found or
 // could not be loaded.
@@ -136,6 +1220,8 @@ func AssetNames() []string {
 // _bindata is a table, holding each asset generator, mapped to its name.
 var _bindata = map[string]func() (*asset, error){
 	"templates/cloudformation/iam_user_osdCcsAdmin.json": templatesCloudformationIam_user_osdccsadminJson,
+	"templates/cloudformation/rosa_network.json": templatesCloudformationRosa_networkJson,
+	"templates/pricing/aws_pricing.yaml": templatesPricingAws_pricingYaml,
 }
 
 // AssetDir returns the file names below a certain
@@ -182,6 +1268,10 @@ var _bintree = &bintree{nil, map[string]*bintree{
 	"templates": &bintree{nil, map[string]*bintree{
 		"cloudformation": &bintree{nil, map[string]*bintree{
 			"iam_user_osdCcsAdmin.json": &bintree{templatesCloudformationIam_user_osdccsadminJson, map[string]*bintree{}},
+			"rosa_network.json": &bintree{templatesCloudformationRosa_networkJson, map[string]*bintree{}},
+		}},
+		"pricing": &bintree{nil, map[string]*bintree{
+			"aws_pricing.yaml": &bintree{templatesPricingAws_pricingYaml, map[string]*bintree{}},
 		}},
 	}},
 }}
@@ -0,0 +1,330 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinepool
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/helper"
+	"github.com/openshift/rosa/pkg/helper/machinepools"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var args struct {
+	machinePool      string
+	toCluster        string
+	name             string
+	subnet           string
+	availabilityZone string
+}
+
+var Cmd = &cobra.Command{
+	Use:     "machinepool",
+	Aliases: []string{"machinepools", "machine-pool", "machine-pools"},
+	Short:   "Copy a machine pool",
+	Long: "Create a machine pool with the same instance type, replicas or autoscaling, labels, taints, " +
+		"tags, disk size, tuning configs, spot instance settings and node drain grace period as an " +
+		"existing machine pool, in the same cluster or in another cluster.\n\n" +
+		"When copying to another cluster, the subnet and availability zone of the machine pool are kept " +
+		"if the target cluster has them. Otherwise a subnet of the target cluster in the same availability " +
+		"zone is used. Additional security groups are only copied within the same cluster.",
+	Example: `  # Copy machine pool "mp-1" of cluster "mycluster" to machine pool "mp-2" of the same cluster
+  rosa copy machinepool --cluster=mycluster --machinepool=mp-1 --name=mp-2
+
+  # Copy machine pool "mp-1" of cluster "staging" to cluster "prod"
+  rosa copy machinepool --cluster=staging --machinepool=mp-1 --to-cluster=prod --name=mp-1
+
+  # Copy a machine pool to another cluster and place it in a specific subnet
+  rosa copy machinepool --cluster=staging --machinepool=mp-1 --to-cluster=prod --name=mp-1 \
+    --subnet=subnet-0a1b2c3d4e5f`,
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+var machinePoolKeyRE = regexp.MustCompile(`^[a-z]([-a-z0-9]*[a-z0-9])?$`)
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	ocm.AddClusterFlag(Cmd)
+	flags.StringVar(
+		&args.machinePool,
+		"machinepool",
+		"",
+		"Machine pool of the cluster to copy.",
+	)
+	flags.StringVar(
+		&args.toCluster,
+		"to-cluster",
+		"",
+		"Name or ID of the cluster to create the copy in. Defaults to the cluster of the machine pool.",
+	)
+	flags.StringVar(
+		&args.name,
+		"name",
+		"",
+		"Name of the new machine pool.",
+	)
+	flags.StringVar(
+		&args.subnet,
+		"subnet",
+		"",
+		"Subnet of the target cluster to create the new machine pool in.",
+	)
+	flags.StringVar(
+		&args.availabilityZone,
+		"availability-zone",
+		"",
+		"Availability zone of the target cluster to create the new machine pool in.",
+	)
+}
+
+func run(cmd *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS().WithOCM()
+	defer r.Cleanup()
+
+	err := runWithRuntime(r, cmd)
+	if err != nil {
+		r.Reporter.Errorf(err.Error())
+		os.Exit(1)
+	}
+}
+
+func runWithRuntime(r *rosa.Runtime, _ *cobra.Command) error {
+	if !machinePoolKeyRE.MatchString(args.machinePool) {
+		return fmt.Errorf("Expected a valid identifier for the machine pool to copy with '--machinepool'")
+	}
+	if !machinePoolKeyRE.MatchString(args.name) {
+		return fmt.Errorf("Expected a valid name for the new machine pool with '--name'")
+	}
+	if args.subnet != "" && args.availabilityZone != "" {
+		return fmt.Errorf("Setting both subnet and availability zone is not supported. " +
+			"Please select subnet or availability zone to create the new machine pool in")
+	}
+
+	clusterKey := r.GetClusterKey()
+	cluster := r.FetchCluster()
+	if cluster.State() != cmv1.ClusterStateReady {
+		return fmt.Errorf("Cluster '%s' is not yet ready", clusterKey)
+	}
+
+	targetKey := clusterKey
+	target := cluster
+	if args.toCluster != "" && args.toCluster != clusterKey {
+		if !ocm.IsValidClusterKey(args.toCluster) {
+			return fmt.Errorf("Cluster name, identifier or external identifier '%s' isn't valid: it "+
+				"must contain only letters, digits, dashes and underscores", args.toCluster)
+		}
+		var err error
+		targetKey = args.toCluster
+		target, err = r.OCMClient.GetCluster(targetKey, r.Creator)
+		if err != nil {
+			return fmt.Errorf("Failed to get cluster '%s': %v", targetKey, err)
+		}
+		if target.State() != cmv1.ClusterStateReady {
+			return fmt.Errorf("Cluster '%s' is not yet ready", targetKey)
+		}
+	}
+	sameCluster := target.ID() == cluster.ID()
+	if sameCluster && args.name == args.machinePool {
+		return fmt.Errorf("Expected a name for the new machine pool different from '%s'", args.machinePool)
+	}
+
+	isHypershift := cluster.Hypershift().Enabled()
+	if isHypershift != target.Hypershift().Enabled() {
+		return fmt.Errorf("Machine pools can't be copied between Hosted Control Plane and classic clusters")
+	}
+
+	var err error
+	if isHypershift {
+		err = copyNodePool(r, cluster, target, sameCluster)
+	} else {
+		err = copyMachinePool(r, cluster, target, sameCluster)
+	}
+	if err != nil {
+		return err
+	}
+
+	r.Reporter.Infof("Machine pool '%s' of cluster '%s' has been copied to machine pool '%s' of cluster '%s'",
+		args.machinePool, clusterKey, args.name, targetKey)
+	r.Reporter.Infof("To view all machine pools, run 'rosa list machinepools --cluster %s'", targetKey)
+	return nil
+}
+
+func copyMachinePool(r *rosa.Runtime, cluster *cmv1.Cluster, target *cmv1.Cluster, sameCluster bool) error {
+	source, exists, err := r.OCMClient.GetMachinePool(cluster.ID(), args.machinePool)
+	if err != nil {
+		return fmt.Errorf("Failed to get machine pool '%s': %v", args.machinePool, err)
+	}
+	if !exists {
+		return fmt.Errorf("Machine pool '%s' not found", args.machinePool)
+	}
+	_, exists, err = r.OCMClient.GetMachinePool(target.ID(), args.name)
+	if err != nil {
+		return fmt.Errorf("Failed to get machine pool '%s': %v", args.name, err)
+	}
+	if exists {
+		return fmt.Errorf("Machine pool '%s' already exists", args.name)
+	}
+
+	warnSecurityGroups(r, sameCluster, source.AWS().AdditionalSecurityGroupIds())
+	builder := machinepools.CopyMachinePool(source, sameCluster).ID(args.name)
+	switch {
+	case args.subnet != "":
+		builder.Subnets(args.subnet)
+	case args.availabilityZone != "":
+		if !helper.Contains(target.Nodes().AvailabilityZones(), args.availabilityZone) {
+			return fmt.Errorf("Availability zone '%s' doesn't belong to the availability zones of the "+
+				"target cluster %v", args.availabilityZone, target.Nodes().AvailabilityZones())
+		}
+		builder.AvailabilityZones(args.availabilityZone)
+	case sameCluster:
+		if len(source.Subnets()) > 0 {
+			builder.Subnets(source.Subnets()...)
+		} else if len(source.AvailabilityZones()) > 0 {
+			builder.AvailabilityZones(source.AvailabilityZones()...)
+		}
+	case len(source.AvailabilityZones()) > 1:
+		// A multi availability zone machine pool spans all the availability zones of the target cluster
+		if !target.MultiAZ() {
+			return fmt.Errorf("Machine pool '%s' spans several availability zones and the target cluster "+
+				"has a single availability zone, specify '--availability-zone' or '--subnet'", args.machinePool)
+		}
+	case len(source.Subnets()) == 1 && helper.Contains(target.AWS().SubnetIDs(), source.Subnets()[0]):
+		builder.Subnets(source.Subnets()...)
+	case len(source.AvailabilityZones()) == 1:
+		availabilityZone := source.AvailabilityZones()[0]
+		if !helper.Contains(target.Nodes().AvailabilityZones(), availabilityZone) {
+			return fmt.Errorf("Availability zone '%s' of machine pool '%s' doesn't belong to the target "+
+				"cluster, specify '--availability-zone' or '--subnet'", availabilityZone, args.machinePool)
+		}
+		builder.AvailabilityZones(availabilityZone)
+	}
+
+	machinePool, err := builder.Build()
+	if err != nil {
+		return fmt.Errorf("Failed to create machine pool '%s': %v", args.name, err)
+	}
+	r.Reporter.Debugf("Creating machine pool '%s' on cluster '%s'", args.name, target.ID())
+	_, err = r.OCMClient.CreateMachinePool(target.ID(), machinePool)
+	if err != nil {
+		return fmt.Errorf("Failed to add machine pool '%s': %v", args.name, err)
+	}
+	return nil
+}
+
+func copyNodePool(r *rosa.Runtime, cluster *cmv1.Cluster, target *cmv1.Cluster, sameCluster bool) error {
+	source, exists, err := r.OCMClient.GetNodePool(cluster.ID(), args.machinePool)
+	if err != nil {
+		return fmt.Errorf("Failed to get machine pool '%s': %v", args.machinePool, err)
+	}
+	if !exists {
+		return fmt.Errorf("Machine pool '%s' not found", args.machinePool)
+	}
+	_, exists, err = r.OCMClient.GetNodePool(target.ID(), args.name)
+	if err != nil {
+		return fmt.Errorf("Failed to get machine pool '%s': %v", args.name, err)
+	}
+	if exists {
+		return fmt.Errorf("Machine pool '%s' already exists", args.name)
+	}
+
+	warnSecurityGroups(r, sameCluster, source.AWSNodePool().AdditionalSecurityGroupIds())
+	builder := machinepools.CopyNodePool(source, sameCluster).ID(args.name)
+	subnet := args.subnet
+	if subnet == "" {
+		availabilityZone := args.availabilityZone
+		if availabilityZone == "" && (sameCluster || helper.Contains(target.AWS().SubnetIDs(), source.Subnet())) {
+			subnet = source.Subnet()
+		} else {
+			if availabilityZone == "" {
+				availabilityZone = source.AvailabilityZone()
+			}
+			subnet, err = getPrivateSubnet(r, target, availabilityZone)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	builder.Subnet(subnet)
+
+	if !sameCluster {
+		tuningConfigs := source.TuningConfigs()
+		if len(tuningConfigs) > 0 {
+			availableTuningConfigs, err := r.OCMClient.GetTuningConfigsName(target.ID())
+			if err != nil {
+				return fmt.Errorf("Failed to get the tuning configs of the target cluster: %v", err)
+			}
+			for _, tuningConfig := range tuningConfigs {
+				if !helper.Contains(availableTuningConfigs, tuningConfig) {
+					return fmt.Errorf("Tuning config '%s' of machine pool '%s' doesn't exist in the target "+
+						"cluster, create it with 'rosa create tuning-config'", tuningConfig, args.machinePool)
+				}
+			}
+		}
+	}
+
+	nodePool, err := builder.Build()
+	if err != nil {
+		return fmt.Errorf("Failed to create machine pool '%s': %v", args.name, err)
+	}
+	r.Reporter.Debugf("Creating machine pool '%s' on cluster '%s'", args.name, target.ID())
+	_, err = r.OCMClient.CreateNodePool(target.ID(), nodePool)
+	if err != nil {
+		return fmt.Errorf("Failed to add machine pool '%s': %v", args.name, err)
+	}
+	return nil
+}
+
+func warnSecurityGroups(r *rosa.Runtime, sameCluster bool, securityGroupIds []string) {
+	if !sameCluster && len(securityGroupIds) > 0 {
+		r.Reporter.Warnf("Additional security groups %v of machine pool '%s' belong to the VPC of its "+
+			"cluster and aren't copied", securityGroupIds, args.machinePool)
+	}
+}
+
+// getPrivateSubnet returns the private subnet of the VPC of the cluster in the availability zone
+func getPrivateSubnet(r *rosa.Runtime, cluster *cmv1.Cluster, availabilityZone string) (string, error) {
+	privateSubnets, err := r.AWSClient.GetVPCPrivateSubnets(cluster.AWS().SubnetIDs()[0])
+	if err != nil {
+		return "", err
+	}
+	var subnets []string
+	for _, privateSubnet := range privateSubnets {
+		if *privateSubnet.AvailabilityZone == availabilityZone &&
+			helper.Contains(cluster.AWS().SubnetIDs(), *privateSubnet.SubnetId) {
+			subnets = append(subnets, *privateSubnet.SubnetId)
+		}
+	}
+	switch len(subnets) {
+	case 0:
+		return "", fmt.Errorf("Failed to find a private subnet of the target cluster for '%s' availability "+
+			"zone, specify '--subnet'", availabilityZone)
+	case 1:
+		return subnets[0], nil
+	default:
+		return "", fmt.Errorf("There are several private subnets of the target cluster for '%s' "+
+			"availability zone, specify '--subnet' with one of %v", availabilityZone, subnets)
+	}
+}
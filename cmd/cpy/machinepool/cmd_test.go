@@ -0,0 +1,180 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinepool
+
+import (
+	"io"
+	"net/http"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	. "github.com/openshift-online/ocm-sdk-go/testing"
+	"github.com/spf13/pflag"
+	"go.uber.org/mock/gomock"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/test"
+)
+
+var _ = Describe("Copy machinepool", func() {
+	const targetClusterID = "target-cluster-id"
+
+	var (
+		testRuntime   test.TestingRuntime
+		mockAwsClient *aws.MockClient
+		body          []byte
+	)
+
+	classicCluster := test.MockCluster(func(c *cmv1.ClusterBuilder) {
+		c.State(cmv1.ClusterStateReady)
+		c.MultiAZ(true)
+		c.Nodes(cmv1.NewClusterNodes().AvailabilityZones("us-east-1a", "us-east-1b", "us-east-1c"))
+	})
+	hcpCluster := test.MockCluster(func(c *cmv1.ClusterBuilder) {
+		c.State(cmv1.ClusterStateReady)
+		c.Hypershift(cmv1.NewHypershift().Enabled(true))
+		c.AWS(cmv1.NewAWS().SubnetIDs("subnet-src-a", "subnet-src-b"))
+	})
+	hcpTarget := test.MockCluster(func(c *cmv1.ClusterBuilder) {
+		c.ID(targetClusterID)
+		c.Name("prod")
+		c.State(cmv1.ClusterStateReady)
+		c.Hypershift(cmv1.NewHypershift().Enabled(true))
+		c.AWS(cmv1.NewAWS().SubnetIDs("subnet-dst-a", "subnet-dst-b"))
+	})
+
+	machinePool, err := cmv1.NewMachinePool().ID("mp-1").InstanceType("m5.xlarge").Replicas(3).
+		AvailabilityZones("us-east-1a", "us-east-1b", "us-east-1c").
+		AWS(cmv1.NewAWSMachinePool().AdditionalSecurityGroupIds("sg-1")).
+		Build()
+	Expect(err).NotTo(HaveOccurred())
+	nodePool, err := cmv1.NewNodePool().ID("np-1").Replicas(2).
+		Subnet("subnet-src-a").AvailabilityZone("us-east-1a").
+		AWSNodePool(cmv1.NewAWSNodePool().InstanceType("m5.xlarge").AdditionalSecurityGroupIds("sg-1")).
+		Build()
+	Expect(err).NotTo(HaveOccurred())
+
+	captureCreate := func(path string) http.HandlerFunc {
+		return ghttp.CombineHandlers(
+			ghttp.VerifyRequest(http.MethodPost, path),
+			func(w http.ResponseWriter, r *http.Request) {
+				var err error
+				body, err = io.ReadAll(r.Body)
+				Expect(err).NotTo(HaveOccurred())
+			},
+			RespondWithJSON(http.StatusCreated, "{}"),
+		)
+	}
+
+	BeforeEach(func() {
+		testRuntime.InitRuntime()
+		mockAwsClient = aws.NewMockClient(gomock.NewController(GinkgoT()))
+		testRuntime.RosaRuntime.AWSClient = mockAwsClient
+		body = nil
+		// Reset flags to avoid any side effect on other tests
+		Cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+			if flag.Name == "cluster" {
+				return
+			}
+			flag.Value.Set(flag.DefValue)
+			flag.Changed = false
+		})
+	})
+
+	It("Fails without a name for the new machine pool", func() {
+		Cmd.Flags().Set("machinepool", "mp-1")
+		_, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+		Expect(err).To(MatchError("Expected a valid name for the new machine pool with '--name'"))
+	})
+
+	It("Fails to copy a machine pool onto itself", func() {
+		Cmd.Flags().Set("machinepool", "mp-1")
+		Cmd.Flags().Set("name", "mp-1")
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK,
+			test.FormatClusterList([]*cmv1.Cluster{classicCluster})))
+		_, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+		Expect(err).To(MatchError("Expected a name for the new machine pool different from 'mp-1'"))
+	})
+
+	It("Fails to copy between classic and Hosted Control Plane clusters", func() {
+		Cmd.Flags().Set("machinepool", "mp-1")
+		Cmd.Flags().Set("name", "mp-1")
+		Cmd.Flags().Set("to-cluster", "prod")
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK,
+			test.FormatClusterList([]*cmv1.Cluster{classicCluster})))
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK,
+			test.FormatClusterList([]*cmv1.Cluster{hcpTarget})))
+		_, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+		Expect(err).To(MatchError(
+			"Machine pools can't be copied between Hosted Control Plane and classic clusters"))
+	})
+
+	It("Copies a machine pool within the same cluster", func() {
+		Cmd.Flags().Set("machinepool", "mp-1")
+		Cmd.Flags().Set("name", "mp-2")
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK,
+			test.FormatClusterList([]*cmv1.Cluster{classicCluster})))
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, test.FormatResource(machinePool)))
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusNotFound, "{}"))
+		testRuntime.ApiServer.AppendHandlers(captureCreate(
+			"/api/clusters_mgmt/v1/clusters/" + test.MockClusterID + "/machine_pools"))
+		stdout, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stdout).To(ContainSubstring(
+			"Machine pool 'mp-1' of cluster 'cluster1' has been copied to machine pool 'mp-2' of cluster 'cluster1'"))
+		created, err := cmv1.UnmarshalMachinePool(body)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(created.ID()).To(Equal("mp-2"))
+		Expect(created.InstanceType()).To(Equal("m5.xlarge"))
+		Expect(created.Replicas()).To(Equal(3))
+		Expect(created.AvailabilityZones()).To(Equal([]string{"us-east-1a", "us-east-1b", "us-east-1c"}))
+		Expect(created.AWS().AdditionalSecurityGroupIds()).To(Equal([]string{"sg-1"}))
+	})
+
+	It("Copies a node pool to another cluster in the same availability zone", func() {
+		Cmd.Flags().Set("machinepool", "np-1")
+		Cmd.Flags().Set("name", "np-1")
+		Cmd.Flags().Set("to-cluster", "prod")
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK,
+			test.FormatClusterList([]*cmv1.Cluster{hcpCluster})))
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK,
+			test.FormatClusterList([]*cmv1.Cluster{hcpTarget})))
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, test.FormatResource(nodePool)))
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusNotFound, "{}"))
+		testRuntime.ApiServer.AppendHandlers(captureCreate(
+			"/api/clusters_mgmt/v1/clusters/" + targetClusterID + "/node_pools"))
+		mockAwsClient.EXPECT().GetVPCPrivateSubnets("subnet-dst-a").Return([]ec2types.Subnet{
+			{SubnetId: awssdk.String("subnet-dst-a"), AvailabilityZone: awssdk.String("us-east-1a")},
+			{SubnetId: awssdk.String("subnet-dst-b"), AvailabilityZone: awssdk.String("us-east-1b")},
+		}, nil)
+		_, stderr, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stderr).To(ContainSubstring(
+			"Additional security groups [sg-1] of machine pool 'np-1' belong to the VPC of its cluster"))
+		created, err := cmv1.UnmarshalNodePool(body)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(created.ID()).To(Equal("np-1"))
+		Expect(created.Subnet()).To(Equal("subnet-dst-a"))
+		Expect(created.Replicas()).To(Equal(2))
+		Expect(created.AWSNodePool().InstanceType()).To(Equal("m5.xlarge"))
+		Expect(created.AWSNodePool().AdditionalSecurityGroupIds()).To(BeEmpty())
+	})
+})
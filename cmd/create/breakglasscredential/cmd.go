@@ -9,11 +9,13 @@ import (
 	"github.com/openshift/rosa/pkg/breakglasscredential"
 	"github.com/openshift/rosa/pkg/externalauthprovider"
 	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/interactive/confirm"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/rosa"
 )
 
 var breakGlassCredentialArgs *breakglasscredential.BreakGlassCredentialArgs
+var kubeconfigArgs *breakglasscredential.KubeconfigArgs
 
 var Cmd = makeCmd()
 
@@ -24,7 +26,10 @@ func makeCmd() *cobra.Command {
 		Short:   "Create a break glass credential for a cluster.",
 		Long:    "Create a break glass credential for a hosted control plane cluster with external authentication enabled.",
 		Example: `  # Interactively create a break glass credential to a cluster named "mycluster"
-  rosa create break-glass-credential --cluster=mycluster --interactive`,
+  rosa create break-glass-credential --cluster=mycluster --interactive
+
+  # Create a break glass credential and merge its kubeconfig into ~/.kube/config
+  rosa create break-glass-credential --cluster=mycluster --merge`,
 		Run:  run,
 		Args: cobra.NoArgs,
 	}
@@ -33,7 +38,9 @@ func makeCmd() *cobra.Command {
 func init() {
 	ocm.AddClusterFlag(Cmd)
 	interactive.AddFlag(Cmd.Flags())
+	confirm.AddFlag(Cmd.Flags())
 	breakGlassCredentialArgs = breakglasscredential.AddBreakGlassCredentialFlags(Cmd)
+	kubeconfigArgs = breakglasscredential.AddKubeconfigFlags(Cmd)
 }
 
 func run(cmd *cobra.Command, argv []string) {
@@ -68,23 +75,45 @@ func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command, argv []string) error {
 			clusterKey, err)
 	}
 
+	if kubeconfigArgs.Enabled() && !kubeconfigArgs.ConfirmOverwrite() {
+		return nil
+	}
+
 	credentialResponse, err := breakglasscredential.CreateBreakGlass(cluster, clusterKey, args, r)
 	if err != nil {
 		return err
 	}
 
-	kubeconfig, err := r.OCMClient.PollKubeconfig(cluster.ID(), credentialResponse.ID())
+	if r.Reporter.IsTerminal() {
+		r.Reporter.Infof("Waiting for break glass credential '%s' to be issued", credentialResponse.ID())
+	}
+	credential, err := r.OCMClient.PollBreakGlassCredential(cluster.ID(), credentialResponse.ID())
 	if err != nil {
 		return fmt.Errorf("An error occurred while polling for kubeconfig: %v", err)
 	}
 
 	r.Reporter.Infof("Successfully created a break glass credential for cluster '%s'.",
 		clusterKey)
+	r.Reporter.Infof("The break glass credential expires at %s",
+		credential.ExpirationTimestamp().Format("Jan _2 2006 15:04:05 MST"))
+	if kubeconfigArgs.Enabled() {
+		path, contextName, err := kubeconfigArgs.SaveKubeconfig(credential.Kubeconfig(), cluster.Name(),
+			credential.Username())
+		if err != nil {
+			return err
+		}
+		if contextName != "" {
+			r.Reporter.Infof("Merged the kubeconfig into '%s' and switched to context '%s'", path, contextName)
+		} else {
+			r.Reporter.Infof("Wrote the kubeconfig to '%s'", path)
+		}
+		return nil
+	}
 	r.Reporter.Infof(
 		"To retrieve only the kubeconfig for this credential "+
 			"use: 'rosa describe break-glass-credential %s -c %s --kubeconfig'",
 		credentialResponse.ID(), clusterKey)
-	fmt.Print(kubeconfig)
+	fmt.Print(credential.Kubeconfig())
 
 	return nil
 }
@@ -93,6 +93,9 @@ var args struct {
 
 	// Simulate creating a cluster
 	dryRun bool
+	// Skip the local checks of the VPC topology
+	skipNetworkPreflight bool
+	skipKMSKeyValidation bool
 	// Create a fake cluster with no AWS resources
 	fakeCluster bool
 	// Set custom properties in cluster spec
@@ -657,6 +660,20 @@ func initFlags(cmd *cobra.Command) {
 		"Simulate creating the cluster.",
 	)
 
+	flags.BoolVar(
+		&args.skipNetworkPreflight,
+		"skip-network-preflight",
+		false,
+		"Skip the local checks of the VPC topology when installing into existing subnets.",
+	)
+
+	flags.BoolVar(
+		&args.skipKMSKeyValidation,
+		"skip-kms-key-validation",
+		false,
+		"Skip the check that the key policy of '--kms-key-arn' allows the cluster roles to use the key.",
+	)
+
 	flags.BoolVar(
 		&args.fakeCluster,
 		"fake-cluster",
@@ -2273,6 +2290,24 @@ func run(cmd *cobra.Command, _ []string) {
 		}
 	}
 
+	// The route tables of a shared VPC aren't visible from the cluster account, so its
+	// topology can't be checked locally
+	if len(subnetIDs) > 0 && !isSharedVPC && !args.skipNetworkPreflight {
+		err = runNetworkPreflight(r, awsClient, aws.NetworkPreflightSpec{
+			SubnetIDs:         subnetIDs,
+			AvailabilityZones: availabilityZones,
+			MachineCIDR:       &machineCIDR,
+			ServiceCIDR:       &serviceCIDR,
+			PodCIDR:           &podCIDR,
+			Private:           privateLink,
+			HostedCP:          isHostedCP,
+		})
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+	}
+
 	// Select availability zones for a non-BYOVPC cluster
 	var selectAvailabilityZones bool
 	if !useExistingVPC && !subnetsProvided {
@@ -2357,6 +2392,24 @@ func run(cmd *cobra.Command, _ []string) {
 		os.Exit(1)
 	}
 
+	if kmsKeyARN != "" && isSTS && !args.skipKMSKeyValidation {
+		ebsCSIOperatorRoleARN := getOperatorRoleARN(computedOperatorIamRoleList, aws.EBSCSIOperatorNamespace,
+			aws.EBSCSIOperatorName)
+		keyRoles := aws.KMSKeyRoles{
+			Installer:      roleARN,
+			Support:        supportRoleARN,
+			Worker:         workerRoleARN,
+			EBSCSIOperator: ebsCSIOperatorRoleARN,
+		}
+		err = validateKMSKeyPolicy(r, awsClient, kmsKeyARN, func(policy *aws.PolicyDocument) []aws.MissingKMSKeyGrant {
+			return aws.GetMissingKMSKeyGrants(policy, keyRoles)
+		})
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+	}
+
 	// Compute node instance type:
 	computeMachineType := args.computeMachineType
 	computeMachineTypeList, err := r.OCMClient.GetAvailableMachineTypesInRegion(region, availabilityZones, roleARN,
@@ -2712,6 +2765,19 @@ func run(cmd *cobra.Command, _ []string) {
 		os.Exit(1)
 	}
 
+	if etcdEncryptionKmsARN != "" && isSTS && !args.skipKMSKeyValidation {
+		kmsProviderRoleARN := getOperatorRoleARN(computedOperatorIamRoleList, aws.KMSProviderOperatorNamespace,
+			aws.KMSProviderOperatorName)
+		err = validateKMSKeyPolicy(r, awsClient, etcdEncryptionKmsARN,
+			func(policy *aws.PolicyDocument) []aws.MissingKMSKeyGrant {
+				return aws.GetMissingEtcdKMSKeyGrants(policy, roleARN, kmsProviderRoleARN)
+			})
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			os.Exit(1)
+		}
+	}
+
 	disableWorkloadMonitoring := args.disableWorkloadMonitoring
 	if interactive.Enabled() {
 		disableWorkloadMonitoring, err = interactive.GetBool(interactive.Input{
@@ -3473,6 +3539,69 @@ func filterPrivateSubnets(initialSubnets []ec2types.Subnet, r *rosa.Runtime) []e
 	return filteredSubnets
 }
 
+// runNetworkPreflight checks the topology of the VPC of the subnets before the install. Warnings
+// don't stop the install, failures do as the install would otherwise fail after several minutes.
+func runNetworkPreflight(r *rosa.Runtime, awsClient aws.Client, spec aws.NetworkPreflightSpec) error {
+	r.Reporter.Infof("Running local preflight checks of the VPC network")
+	result, err := awsClient.RunNetworkPreflight(spec)
+	if err != nil {
+		return fmt.Errorf("Failed to run network preflight: %v", err)
+	}
+	for _, check := range result.Warnings() {
+		r.Reporter.Warnf("%s: %s", check.Name, check.Message)
+	}
+	failures := result.Failures()
+	if len(failures) == 0 {
+		return nil
+	}
+	messages := make([]string, 0, len(failures))
+	for _, check := range failures {
+		messages = append(messages, fmt.Sprintf("  - %s: %s", check.Name, check.Message))
+	}
+	return fmt.Errorf("Network preflight failed for VPC '%s':\n%s\n"+
+		"Fix the VPC configuration or use '--skip-network-preflight' to skip these checks",
+		result.VpcID, strings.Join(messages, "\n"))
+}
+
+// validateKMSKeyPolicy checks that the key policy allows the cluster roles to use the key, as the
+// install otherwise fails when the key is first used. Keys that can't be read are not checked, and
+// actions only granted to the account are reported as warnings as the IAM policies of the roles
+// decide on them.
+func validateKMSKeyPolicy(r *rosa.Runtime, awsClient aws.Client, kmsKeyARN string,
+	missingGrants func(*aws.PolicyDocument) []aws.MissingKMSKeyGrant) error {
+	policy, err := awsClient.GetKMSKeyPolicy(kmsKeyARN)
+	if err != nil {
+		r.Reporter.Warnf("Unable to check key policy of '%s': %v", kmsKeyARN, err)
+		return nil
+	}
+	var messages []string
+	for _, grant := range missingGrants(policy) {
+		if len(grant.IAMActions) > 0 {
+			r.Reporter.Warnf("Key policy of '%s' only allows '%s' to %s through the IAM policies of the account, "+
+				"make sure the role is allowed to use the key", kmsKeyARN, grant.RoleARN,
+				strings.Join(grant.IAMActions, ", "))
+		}
+		if len(grant.Actions) > 0 {
+			messages = append(messages, fmt.Sprintf("  - %s: %s", grant.RoleARN, strings.Join(grant.Actions, ", ")))
+		}
+	}
+	if len(messages) == 0 {
+		return nil
+	}
+	return fmt.Errorf("Key policy of '%s' does not allow the cluster roles to use the key:\n%s\n"+
+		"Update the key policy or use '--skip-kms-key-validation' to skip this check",
+		kmsKeyARN, strings.Join(messages, "\n"))
+}
+
+func getOperatorRoleARN(operatorRoles []ocm.OperatorIAMRole, namespace string, name string) string {
+	for _, role := range operatorRoles {
+		if role.Namespace == namespace && role.Name == name {
+			return role.RoleARN
+		}
+	}
+	return ""
+}
+
 // filterCidrRangeSubnets filters the initial set of subnets to those that are part of the machine network,
 // and not part of the service network
 func filterCidrRangeSubnets(
@@ -498,6 +498,83 @@ var _ = Describe("clusterHasLongNameWithoutDomainPrefix()", func() {
 	)
 })
 
+var _ = Describe("validateKMSKeyPolicy()", func() {
+	var (
+		r          *rosa.Runtime
+		mockClient *mock.MockClient
+	)
+
+	const kmsKeyARN = "arn:aws:kms:us-east-1:123456789012:key/1234abcd-12ab-44cd-86ef-1234567890ab"
+	keyRoles := mock.KMSKeyRoles{
+		Installer:      "arn:aws:iam::123456789012:role/ManagedOpenShift-Installer-Role",
+		Support:        "arn:aws:iam::123456789012:role/ManagedOpenShift-Support-Role",
+		Worker:         "arn:aws:iam::123456789012:role/ManagedOpenShift-Worker-Role",
+		EBSCSIOperator: "arn:aws:iam::123456789012:role/mycluster-openshift-cluster-csi-drivers-ebs-cloud-credentials",
+	}
+
+	missingGrants := func(policy *mock.PolicyDocument) []mock.MissingKMSKeyGrant {
+		return mock.GetMissingKMSKeyGrants(policy, keyRoles)
+	}
+
+	BeforeEach(func() {
+		r = rosa.NewRuntime()
+		mockClient = mock.NewMockClient(gomock.NewController(GinkgoT()))
+	})
+
+	It("Passes when the key policy allows the cluster roles", func() {
+		policy, err := mock.ParsePolicyDocument(mock.KMSKeyPolicy("aws", "123456789012", keyRoles))
+		Expect(err).NotTo(HaveOccurred())
+		mockClient.EXPECT().GetKMSKeyPolicy(kmsKeyARN).Return(policy, nil)
+		Expect(validateKMSKeyPolicy(r, mockClient, kmsKeyARN, missingGrants)).To(Succeed())
+	})
+
+	It("Only warns when the roles are granted the key through the account", func() {
+		policy, err := mock.ParsePolicyDocument(mock.KMSKeyPolicy("aws", "123456789012", mock.KMSKeyRoles{
+			Installer: keyRoles.Installer,
+		}))
+		Expect(err).NotTo(HaveOccurred())
+		mockClient.EXPECT().GetKMSKeyPolicy(kmsKeyARN).Return(policy, nil)
+		Expect(validateKMSKeyPolicy(r, mockClient, kmsKeyARN, missingGrants)).To(Succeed())
+	})
+
+	It("Fails with the missing grants", func() {
+		policy, err := mock.ParsePolicyDocument(`{
+			"Version": "2012-10-17",
+			"Statement": [
+				{"Effect": "Allow", "Principal": {"AWS": "` + keyRoles.Installer + `"}, "Action": "kms:*", "Resource": "*"}
+			]
+		}`)
+		Expect(err).NotTo(HaveOccurred())
+		mockClient.EXPECT().GetKMSKeyPolicy(kmsKeyARN).Return(policy, nil)
+		err = validateKMSKeyPolicy(r, mockClient, kmsKeyARN, missingGrants)
+		Expect(err).To(MatchError(ContainSubstring(keyRoles.EBSCSIOperator)))
+		Expect(err).NotTo(MatchError(ContainSubstring(keyRoles.Installer)))
+	})
+
+	It("Checks the grants of the etcd encryption key", func() {
+		kmsProviderRoleARN := "arn:aws:iam::123456789012:role/mycluster-kube-system-kms-provider"
+		policy, err := mock.ParsePolicyDocument(`{
+			"Version": "2012-10-17",
+			"Statement": [
+				{"Effect": "Allow", "Principal": {"AWS": "` + keyRoles.Installer + `"}, "Action": "kms:*", "Resource": "*"},
+				{"Effect": "Allow", "Principal": {"AWS": "` + kmsProviderRoleARN + `"},
+				 "Action": ["kms:Encrypt", "kms:DescribeKey"], "Resource": "*"}
+			]
+		}`)
+		Expect(err).NotTo(HaveOccurred())
+		mockClient.EXPECT().GetKMSKeyPolicy(kmsKeyARN).Return(policy, nil)
+		err = validateKMSKeyPolicy(r, mockClient, kmsKeyARN, func(policy *mock.PolicyDocument) []mock.MissingKMSKeyGrant {
+			return mock.GetMissingEtcdKMSKeyGrants(policy, keyRoles.Installer, kmsProviderRoleARN)
+		})
+		Expect(err).To(MatchError(ContainSubstring(kmsProviderRoleARN + ": kms:Decrypt")))
+	})
+
+	It("Doesn't fail when the key policy can't be read", func() {
+		mockClient.EXPECT().GetKMSKeyPolicy(kmsKeyARN).Return(nil, fmt.Errorf("AccessDeniedException"))
+		Expect(validateKMSKeyPolicy(r, mockClient, kmsKeyARN, missingGrants)).To(Succeed())
+	})
+})
+
 func mustParseCIDR(s string) *net.IPNet {
 	_, ipnet, err := net.ParseCIDR(s)
 	Expect(err).To(BeNil())
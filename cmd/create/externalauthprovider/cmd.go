@@ -30,6 +30,10 @@ import (
 
 var externalAuthProvidersArgs *externalauthprovider.ExternalAuthProvidersArgs
 
+var args struct {
+	discover bool
+}
+
 const argsPrefix string = ""
 
 var Cmd = &cobra.Command{
@@ -38,7 +42,12 @@ var Cmd = &cobra.Command{
 	Short:   "Create an external authentication provider for a cluster.",
 	Long:    "Configure a cluster to use an external authentication provider instead of an internal oidc provider.",
 	Example: `  # Interactively create an external authentication provider to a cluster named "mycluster"
-  rosa create external-auth-provider --cluster=mycluster --interactive`,
+  rosa create external-auth-provider --cluster=mycluster --interactive
+
+  # Create an external authentication provider after validating the OpenID configuration and
+  # signing keys of its issuer
+  rosa create external-auth-provider --cluster=mycluster --name=entra-id --issuer-audiences=abc \
+    --issuer-url=https://login.microsoftonline.com/<tenant-id>/v2.0 --discover`,
 	Run:  run,
 	Args: cobra.NoArgs,
 }
@@ -49,6 +58,14 @@ func init() {
 	ocm.AddClusterFlag(Cmd)
 	interactive.AddFlag(flags)
 	externalAuthProvidersArgs = externalauthprovider.AddExternalAuthProvidersFlags(Cmd, argsPrefix)
+
+	flags.BoolVar(
+		&args.discover,
+		"discover",
+		false,
+		"Fetch the OpenID configuration and signing keys of the token issuer and validate them "+
+			"before creating the external authentication provider.",
+	)
 }
 
 func run(cmd *cobra.Command, argv []string) {
@@ -84,6 +101,14 @@ func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command, argv []string) error {
 			clusterKey, err)
 	}
 
+	if args.discover {
+		err = discoverIssuer(r, externalAuthProvidersArgs)
+		if err != nil {
+			return fmt.Errorf("failed to create an external authentication provider for cluster '%s': %s",
+				clusterKey, err)
+		}
+	}
+
 	err = externalAuthService.CreateExternalAuthProvider(cluster, clusterKey, externalAuthProvidersArgs, r)
 	if err != nil {
 		return err
@@ -95,3 +120,17 @@ func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command, argv []string) error {
 
 	return nil
 }
+
+func discoverIssuer(r *rosa.Runtime, externalAuthProvidersArgs *externalauthprovider.ExternalAuthProvidersArgs) error {
+	discovery, err := externalAuthProvidersArgs.DiscoverIssuer()
+	if err != nil {
+		return err
+	}
+	r.Reporter.Infof("Found %d signing keys for token issuer '%s'", len(discovery.Keys),
+		discovery.Configuration.Issuer)
+	for _, claim := range discovery.UnsupportedClaims(externalAuthProvidersArgs.ClaimMappings()...) {
+		r.Reporter.Warnf("Claim '%s' is not listed in the claims supported by token issuer '%s'",
+			claim, discovery.Configuration.Issuer)
+	}
+	return nil
+}
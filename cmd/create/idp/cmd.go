@@ -83,7 +83,7 @@ var args struct {
 }
 
 var validIdps = []string{"github", "gitlab", "google", "htpasswd", "ldap", "openid"}
-var validMappingMethods = []string{"add", "claim", "generate", "lookup"}
+var ValidMappingMethods = []string{"add", "claim", "generate", "lookup"}
 
 var idRE = regexp.MustCompile(`(?i)^[0-9a-z]+([-_][0-9a-z]+)*$`)
 
@@ -128,7 +128,7 @@ func init() {
 		"claim",
 		fmt.Sprintf(
 			"Specifies how new identities are mapped to users when they log in. Options are %s",
-			validMappingMethods,
+			ValidMappingMethods,
 		),
 	)
 	flags.StringVar(
@@ -534,23 +534,23 @@ func getMappingMethod(cmd *cobra.Command, mappingMethod string) (string, error)
 	if interactive.Enabled() {
 		usage := fmt.Sprintf("%s\n  For more information see the documentation:\n  %s",
 			cmd.Flags().Lookup("mapping-method").Usage,
-			instructionsURLBase+"understanding-idp_config-identity-providers")
+			InstructionsURLBase+"understanding-idp_config-identity-providers")
 		mappingMethod, err = interactive.GetOption(interactive.Input{
 			Question: "Mapping method",
 			Help:     usage,
-			Options:  validMappingMethods,
+			Options:  ValidMappingMethods,
 			Default:  mappingMethod,
 			Required: true,
 		})
 	}
 	isValidMappingMethod := false
-	for _, validMappingMethod := range validMappingMethods {
+	for _, validMappingMethod := range ValidMappingMethods {
 		if mappingMethod == validMappingMethod {
 			isValidMappingMethod = true
 		}
 	}
 	if !isValidMappingMethod {
-		err = fmt.Errorf("Expected a valid mapping method. Options are %s", validMappingMethods)
+		err = fmt.Errorf("Expected a valid mapping method. Options are %s", ValidMappingMethods)
 	}
 	return mappingMethod, err
 }
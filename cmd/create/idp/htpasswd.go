@@ -138,7 +138,7 @@ func getUserList(cmd *cobra.Command, r *rosa.Runtime) (userList map[string]strin
 
 	//if htpasswdFile provided, process users in the file and return
 	if htpasswdFile != "" {
-		err := parseHtpasswordFile(&userList, htpasswdFile)
+		err := ParseHtpasswordFile(&userList, htpasswdFile)
 		if err != nil {
 			r.Reporter.Errorf(
 				"Failed to load Htpasswd file '%s': %v", htpasswdFile, err)
@@ -268,7 +268,8 @@ func clusterAdminValidator(val interface{}) error {
 	return fmt.Errorf("can only validate strings, got '%v'", val)
 }
 
-func parseHtpasswordFile(usersList *map[string]string, filePath string) error {
+// ParseHtpasswordFile loads the users and hashed passwords of a htpasswd file
+func ParseHtpasswordFile(usersList *map[string]string, filePath string) error {
 
 	//A standard wellformed htpasswd file has rows of colon separated usernames and passwords
 	//e.g.
@@ -58,7 +58,7 @@ var _ = Describe("IDP Tests", func() {
 
 				//parse Temp File
 				userList := make(map[string]string)
-				err := parseHtpasswordFile(&userList, fileName)
+				err := ParseHtpasswordFile(&userList, fileName)
 
 				// Compare Results
 
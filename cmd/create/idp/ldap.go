@@ -40,7 +40,7 @@ func buildLdapIdp(cmd *cobra.Command,
 	}
 
 	if interactive.Enabled() {
-		instructionsURL := instructionsURLBase + "config-ldap-idp_config-identity-providers"
+		instructionsURL := InstructionsURLBase + "config-ldap-idp_config-identity-providers"
 		err = interactive.PrintHelp(interactive.Help{
 			Message: "To use LDAP as an identity provider, you must first register the application:",
 			Steps: []string{
@@ -62,14 +62,14 @@ func buildLdapIdp(cmd *cobra.Command,
 			Required: true,
 			Validators: []interactive.Validator{
 				interactive.IsURL,
-				validateLdapURL,
+				ValidateLdapURL,
 			},
 		})
 		if err != nil {
 			return idpBuilder, fmt.Errorf("Expected a valid LDAP URL: %s", err)
 		}
 	}
-	err = validateLdapURL(ldapURL)
+	err = ValidateLdapURL(ldapURL)
 	if err != nil {
 		return idpBuilder, err
 	}
@@ -242,7 +242,7 @@ func buildLdapIdp(cmd *cobra.Command,
 	return
 }
 
-func validateLdapURL(val interface{}) error {
+func ValidateLdapURL(val interface{}) error {
 	ldapURL := fmt.Sprintf("%v", val)
 	parsedLdapURL, err := url.ParseRequestURI(ldapURL)
 	if err != nil {
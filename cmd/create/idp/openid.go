@@ -31,7 +31,7 @@ import (
 	"github.com/openshift/rosa/pkg/ocm"
 )
 
-const instructionsURLBase = "https://docs.openshift.com/dedicated/identity_providers/" +
+const InstructionsURLBase = "https://docs.openshift.com/dedicated/identity_providers/" +
 	"config-identity-providers.html#"
 
 func buildOpenidIdp(cmd *cobra.Command,
@@ -52,7 +52,7 @@ func buildOpenidIdp(cmd *cobra.Command,
 	}
 
 	if interactive.Enabled() {
-		instructionsURL := instructionsURLBase + "config-openid-idp_config-identity-providers"
+		instructionsURL := InstructionsURLBase + "config-openid-idp_config-identity-providers"
 		oauthURL, err := ocm.BuildOAuthURL(cluster, idpType)
 		if err != nil {
 			return idpBuilder, fmt.Errorf("Error building OAuth URL: %v", err)
@@ -103,7 +103,7 @@ func buildOpenidIdp(cmd *cobra.Command,
 			Required: true,
 			Validators: []interactive.Validator{
 				interactive.IsURL,
-				validateOpenidIssuerURL,
+				ValidateOpenidIssuerURL,
 			},
 		})
 		if err != nil {
@@ -111,7 +111,7 @@ func buildOpenidIdp(cmd *cobra.Command,
 		}
 	}
 
-	err = validateOpenidIssuerURL(issuerURL)
+	err = ValidateOpenidIssuerURL(issuerURL)
 	if err != nil {
 		return idpBuilder, err
 	}
@@ -244,7 +244,7 @@ separated by commas.`,
 	return
 }
 
-func validateOpenidIssuerURL(val interface{}) error {
+func ValidateOpenidIssuerURL(val interface{}) error {
 	issuerURL := fmt.Sprintf("%v", val)
 	parsedIssuerURL, err := url.ParseRequestURI(issuerURL)
 	if err != nil {
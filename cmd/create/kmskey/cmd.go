@@ -0,0 +1,175 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kmskey
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/aws/tags"
+	"github.com/openshift/rosa/pkg/helper/roles"
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var args struct {
+	clusterName         string
+	prefix              string
+	operatorRolesPrefix string
+	hostedCP            bool
+	alias               string
+}
+
+var Cmd = &cobra.Command{
+	Use:     "kms-key",
+	Aliases: []string{"kmskey"},
+	Short:   "Create a customer managed KMS key for a cluster",
+	Long: "Create a symmetric customer managed AWS KMS key whose key policy allows the installer, support " +
+		"and worker account roles and the EBS CSI driver operator role to use it. The roles don't need to " +
+		"exist yet. The key can then be used with 'rosa create cluster --kms-key-arn'.",
+	Example: `  # Create a KMS key for cluster "mycluster"
+  rosa create kms-key --cluster-name=mycluster
+
+  # Create a KMS key for a Hosted Control Plane cluster with existing operator roles
+  rosa create kms-key --cluster-name=mycluster --operator-roles-prefix=mycluster-a1b2 --hosted-cp`,
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+
+	flags.StringVar(
+		&args.clusterName,
+		"cluster-name",
+		"",
+		"Name of the cluster that will use the key.",
+	)
+
+	flags.StringVar(
+		&args.prefix,
+		"prefix",
+		aws.DefaultPrefix,
+		"Prefix of the account roles of the cluster.",
+	)
+
+	flags.StringVar(
+		&args.operatorRolesPrefix,
+		"operator-roles-prefix",
+		"",
+		"Prefix of the operator roles of the cluster. Defaults to a prefix generated from the cluster name, "+
+			"which must then be passed to 'rosa create cluster'.",
+	)
+
+	flags.BoolVar(
+		&args.hostedCP,
+		"hosted-cp",
+		false,
+		"Allow the account roles of Hosted Control Plane clusters.",
+	)
+
+	flags.StringVar(
+		&args.alias,
+		"alias",
+		"",
+		"Alias to create for the key.",
+	)
+
+	confirm.AddFlag(flags)
+	output.AddFlag(Cmd)
+}
+
+// KMSKey is the created key and the roles its key policy allows
+type KMSKey struct {
+	ARN                 string   `json:"arn"`
+	OperatorRolesPrefix string   `json:"operator_roles_prefix"`
+	Roles               []string `json:"roles"`
+}
+
+func run(cmd *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS()
+	defer r.Cleanup()
+
+	err := runWithRuntime(r, cmd)
+	if err != nil {
+		r.Reporter.Errorf(err.Error())
+		os.Exit(1)
+	}
+}
+
+func runWithRuntime(r *rosa.Runtime, _ *cobra.Command) error {
+	if !ocm.IsValidClusterName(args.clusterName) {
+		return fmt.Errorf("Expected a valid cluster name with '--cluster-name'")
+	}
+	operatorRolesPrefix := args.operatorRolesPrefix
+	if operatorRolesPrefix == "" {
+		operatorRolesPrefix = roles.GeOperatorRolePrefixFromClusterName(args.clusterName)
+	}
+	if !aws.RoleNameRE.MatchString(operatorRolesPrefix) {
+		return fmt.Errorf("Expected valid operator roles prefix matching %s", aws.RoleNameRE.String())
+	}
+
+	keyRoles := aws.GetKMSKeyRoles(r.Creator.Partition, r.Creator.AccountID, args.prefix, operatorRolesPrefix,
+		args.hostedCP)
+	if !output.HasFlag() {
+		r.Reporter.Infof("Creating KMS key for cluster '%s' allowing roles:", args.clusterName)
+		for _, roleARN := range keyRoles.ARNs() {
+			fmt.Printf("  %s\n", roleARN)
+		}
+	}
+	if !confirm.Prompt(true, "Create KMS key for cluster '%s'?", args.clusterName) {
+		return nil
+	}
+
+	keyARN, err := r.AWSClient.CreateKMSKey(
+		fmt.Sprintf("Customer managed key for ROSA cluster '%s'", args.clusterName),
+		args.alias,
+		aws.KMSKeyPolicy(r.Creator.Partition, r.Creator.AccountID, keyRoles),
+		map[string]string{
+			tags.ClusterName:   args.clusterName,
+			tags.RedHatManaged: tags.True,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	if output.HasFlag() {
+		return output.Print(&KMSKey{
+			ARN:                 keyARN,
+			OperatorRolesPrefix: operatorRolesPrefix,
+			Roles:               keyRoles.ARNs(),
+		})
+	}
+	r.Reporter.Infof("Created KMS key '%s'", keyARN)
+	r.Reporter.Infof("To create a cluster encrypted with this key, run:")
+	fmt.Printf("  rosa create cluster --cluster-name=%s --operator-roles-prefix=%s --kms-key-arn=%s%s\n",
+		args.clusterName, operatorRolesPrefix, keyARN, hostedCPFlag(args.hostedCP))
+	return nil
+}
+
+func hostedCPFlag(hostedCP bool) string {
+	if hostedCP {
+		return " --hosted-cp"
+	}
+	return ""
+}
@@ -0,0 +1,64 @@
+package kmskey
+
+import (
+	"go.uber.org/mock/gomock"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/test"
+)
+
+var _ = Describe("Create KMS key", func() {
+	var (
+		t             *test.TestingRuntime
+		mockAwsClient *aws.MockClient
+	)
+
+	const keyARN = "arn:aws:kms:us-east-1:123:key/key-id"
+
+	BeforeEach(func() {
+		t = test.NewTestRuntime()
+		t.RosaRuntime.Creator.Partition = "aws"
+		mockAwsClient = aws.NewMockClient(gomock.NewController(GinkgoT()))
+		t.RosaRuntime.AWSClient = mockAwsClient
+		output.SetOutput("")
+		Cmd.Flags().Set("yes", "true")
+		Cmd.Flags().Set("cluster-name", "mycluster")
+		DeferCleanup(func() {
+			Cmd.Flags().Set("yes", "false")
+			Cmd.Flags().Set("operator-roles-prefix", "")
+			Cmd.Flags().Set("hosted-cp", "false")
+		})
+	})
+
+	It("Creates a key allowing the cluster roles", func() {
+		Cmd.Flags().Set("operator-roles-prefix", "mycluster-a1b2")
+		keyRoles := aws.GetKMSKeyRoles("aws", "123", aws.DefaultPrefix, "mycluster-a1b2", false)
+		mockAwsClient.EXPECT().CreateKMSKey("Customer managed key for ROSA cluster 'mycluster'", "",
+			aws.KMSKeyPolicy("aws", "123", keyRoles), gomock.Any()).Return(keyARN, nil)
+		stdout, _, err := test.RunWithOutputCapture(runWithRuntime, t.RosaRuntime, Cmd)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stdout).To(ContainSubstring(keyRoles.EBSCSIOperator))
+		Expect(stdout).To(ContainSubstring("rosa create cluster --cluster-name=mycluster " +
+			"--operator-roles-prefix=mycluster-a1b2 --kms-key-arn=" + keyARN + "\n"))
+	})
+
+	It("Generates the operator roles prefix from the cluster name", func() {
+		Cmd.Flags().Set("hosted-cp", "true")
+		mockAwsClient.EXPECT().CreateKMSKey(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(keyARN, nil)
+		stdout, _, err := test.RunWithOutputCapture(runWithRuntime, t.RosaRuntime, Cmd)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stdout).To(ContainSubstring("ManagedOpenShift-HCP-ROSA-Installer-Role"))
+		Expect(stdout).To(MatchRegexp(`--operator-roles-prefix=mycluster-\w{4} --kms-key-arn=.* --hosted-cp`))
+	})
+
+	It("Fails without a cluster name", func() {
+		Cmd.Flags().Set("cluster-name", "")
+		_, _, err := test.RunWithOutputCapture(runWithRuntime, t.RosaRuntime, Cmd)
+		Expect(err).To(MatchError("Expected a valid cluster name with '--cluster-name'"))
+	})
+})
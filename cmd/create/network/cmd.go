@@ -0,0 +1,199 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/arguments"
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var args struct {
+	name              string
+	vpcCIDR           string
+	azCount           int
+	availabilityZones []string
+	private           bool
+}
+
+var Cmd = &cobra.Command{
+	Use:     "network",
+	Aliases: []string{"vpc"},
+	Short:   "Create a VPC ready to install a cluster",
+	Long: "Create a VPC with a public and a private subnet in each availability zone, an internet " +
+		"gateway and a NAT gateway per availability zone, using an AWS CloudFormation stack. " +
+		"The resulting subnets can be used with 'rosa create cluster --subnet-ids'.",
+	Example: `  # Create a single availability zone network named "mynetwork"
+  rosa create network --name=mynetwork
+
+  # Create a network across three availability zones with a custom CIDR
+  rosa create network --name=mynetwork --az-count=3 --vpc-cidr=10.1.0.0/16
+
+  # Create a network in specific availability zones
+  rosa create network --name=mynetwork --region=us-east-1 --availability-zones=us-east-1a,us-east-1b`,
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+
+	flags.StringVar(
+		&args.name,
+		"name",
+		"rosa-network",
+		"Name of the network. It is used as the CloudFormation stack name and to tag the created resources.",
+	)
+
+	flags.StringVar(
+		&args.vpcCIDR,
+		"vpc-cidr",
+		"10.0.0.0/16",
+		"IPv4 CIDR block of the VPC. The prefix length must be between /16 and /24.",
+	)
+
+	flags.IntVar(
+		&args.azCount,
+		"az-count",
+		1,
+		fmt.Sprintf("Number of availability zones to create subnets in, up to %d.", aws.MaxNetworkAvailabilityZones),
+	)
+
+	flags.StringSliceVar(
+		&args.availabilityZones,
+		"availability-zones",
+		nil,
+		"Availability zones to create subnets in. Format should be a comma-separated list. "+
+			"Defaults to the first '--az-count' availability zones of the region.",
+	)
+
+	flags.BoolVar(
+		&args.private,
+		"private",
+		false,
+		"Print the subnet IDs to use for a private cluster, which only needs the private subnets.",
+	)
+
+	confirm.AddFlag(flags)
+	output.AddFlag(Cmd)
+}
+
+func run(cmd *cobra.Command, _ []string) {
+	r := rosa.NewRuntime()
+	defer r.Cleanup()
+
+	// Get AWS region
+	region, err := aws.GetRegion(arguments.GetRegion())
+	if err != nil {
+		r.Reporter.Errorf("Error getting region: %v", err)
+		os.Exit(1)
+	}
+
+	// Create the AWS client:
+	r.AWSClient, err = aws.NewClient().
+		Logger(r.Logger).
+		Region(region).
+		Build()
+	if err != nil {
+		r.Reporter.Errorf("Error creating AWS client: %v", err)
+		os.Exit(1)
+	}
+
+	err = runWithRuntime(r, cmd)
+	if err != nil {
+		r.Reporter.Errorf(err.Error())
+		os.Exit(1)
+	}
+}
+
+func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command) error {
+	availabilityZones := args.availabilityZones
+	if len(availabilityZones) == 0 {
+		if args.azCount < 1 || args.azCount > aws.MaxNetworkAvailabilityZones {
+			return fmt.Errorf("Number of availability zones must be between 1 and %d",
+				aws.MaxNetworkAvailabilityZones)
+		}
+		regionZones, err := r.AWSClient.DescribeAvailabilityZones()
+		if err != nil {
+			return fmt.Errorf("Failed to get availability zones: %v", err)
+		}
+		if len(regionZones) < args.azCount {
+			return fmt.Errorf("Region has %d availability zones but %d were requested",
+				len(regionZones), args.azCount)
+		}
+		availabilityZones = regionZones[:args.azCount]
+	} else if cmd.Flags().Changed("az-count") && len(availabilityZones) != args.azCount {
+		return fmt.Errorf("Number of availability zones %d does not match '--az-count' %d",
+			len(availabilityZones), args.azCount)
+	}
+
+	spec := aws.NetworkStackSpec{
+		Name:              args.name,
+		VpcCIDR:           args.vpcCIDR,
+		AvailabilityZones: availabilityZones,
+	}
+	err := aws.ValidateNetworkStackSpec(spec)
+	if err != nil {
+		return err
+	}
+
+	if !output.HasFlag() {
+		r.Reporter.Infof("Creating network '%s' with CIDR %s in availability zones %s",
+			spec.Name, spec.VpcCIDR, strings.Join(spec.AvailabilityZones, ", "))
+	}
+	if !confirm.Prompt(true, "Create network '%s'?", spec.Name) {
+		return nil
+	}
+	if r.Reporter.IsTerminal() && !output.HasFlag() {
+		r.Reporter.Infof("Waiting for CloudFormation stack '%s' to complete. This may take several minutes",
+			spec.Name)
+	}
+
+	network, err := r.AWSClient.CreateNetworkStack(spec)
+	if err != nil {
+		return err
+	}
+
+	if output.HasFlag() {
+		return output.Print(network)
+	}
+
+	r.Reporter.Infof("Network '%s' is ready", network.StackName)
+	fmt.Printf("VPC ID:           %s\n", network.VpcID)
+	fmt.Printf("Public subnets:   %s\n", strings.Join(network.PublicSubnets, ", "))
+	fmt.Printf("Private subnets:  %s\n", strings.Join(network.PrivateSubnets, ", "))
+	r.Reporter.Infof("To create a cluster in this network, run:")
+	fmt.Printf("  rosa create cluster --subnet-ids=%s%s\n",
+		strings.Join(network.SubnetIDs(args.private), ","), privateFlag(args.private))
+
+	return nil
+}
+
+func privateFlag(private bool) string {
+	if private {
+		return " --private"
+	}
+	return ""
+}
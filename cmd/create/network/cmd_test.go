@@ -0,0 +1,88 @@
+package network
+
+import (
+	"go.uber.org/mock/gomock"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/test"
+)
+
+var _ = Describe("Create network", func() {
+	var (
+		t             *test.TestingRuntime
+		mockAwsClient *aws.MockClient
+	)
+
+	networkStack := &aws.NetworkStack{
+		StackName:      "rosa-network",
+		VpcID:          "vpc-1",
+		PublicSubnets:  []string{"subnet-public-1", "subnet-public-2"},
+		PrivateSubnets: []string{"subnet-private-1", "subnet-private-2"},
+	}
+
+	BeforeEach(func() {
+		t = test.NewTestRuntime()
+		mockAwsClient = aws.NewMockClient(gomock.NewController(GinkgoT()))
+		t.RosaRuntime.AWSClient = mockAwsClient
+		output.SetOutput("")
+		Cmd.Flags().Set("yes", "true")
+		DeferCleanup(func() {
+			Cmd.Flags().Set("yes", "false")
+			Cmd.Flags().Set("az-count", "1")
+			Cmd.Flags().Set("private", "false")
+			Cmd.Flags().Lookup("az-count").Changed = false
+			args.availabilityZones = nil
+		})
+	})
+
+	It("Creates the network in the first availability zones of the region", func() {
+		Cmd.Flags().Set("az-count", "2")
+		mockAwsClient.EXPECT().DescribeAvailabilityZones().Return(
+			[]string{"us-east-1a", "us-east-1b", "us-east-1c"}, nil)
+		mockAwsClient.EXPECT().CreateNetworkStack(aws.NetworkStackSpec{
+			Name:              "rosa-network",
+			VpcCIDR:           "10.0.0.0/16",
+			AvailabilityZones: []string{"us-east-1a", "us-east-1b"},
+		}).Return(networkStack, nil)
+		stdout, _, err := test.RunWithOutputCapture(runWithRuntime, t.RosaRuntime, Cmd)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stdout).To(ContainSubstring("VPC ID:           vpc-1"))
+		Expect(stdout).To(ContainSubstring(
+			"rosa create cluster --subnet-ids=subnet-private-1,subnet-private-2,subnet-public-1,subnet-public-2\n"))
+	})
+
+	It("Prints only the private subnets for private clusters", func() {
+		args.availabilityZones = []string{"us-east-1b"}
+		Cmd.Flags().Set("private", "true")
+		mockAwsClient.EXPECT().CreateNetworkStack(aws.NetworkStackSpec{
+			Name:              "rosa-network",
+			VpcCIDR:           "10.0.0.0/16",
+			AvailabilityZones: []string{"us-east-1b"},
+		}).Return(networkStack, nil)
+		stdout, _, err := test.RunWithOutputCapture(runWithRuntime, t.RosaRuntime, Cmd)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stdout).To(ContainSubstring(
+			"rosa create cluster --subnet-ids=subnet-private-1,subnet-private-2 --private\n"))
+	})
+
+	It("Fails when the region does not have enough availability zones", func() {
+		Cmd.Flags().Set("az-count", "3")
+		mockAwsClient.EXPECT().DescribeAvailabilityZones().Return([]string{"us-east-1a"}, nil)
+		_, _, err := test.RunWithOutputCapture(runWithRuntime, t.RosaRuntime, Cmd)
+		Expect(err).To(MatchError("Region has 1 availability zones but 3 were requested"))
+	})
+
+	It("Fails with an invalid VPC CIDR", func() {
+		Cmd.Flags().Set("vpc-cidr", "10.0.0.0/8")
+		DeferCleanup(func() {
+			Cmd.Flags().Set("vpc-cidr", "10.0.0.0/16")
+		})
+		mockAwsClient.EXPECT().DescribeAvailabilityZones().Return([]string{"us-east-1a"}, nil)
+		_, _, err := test.RunWithOutputCapture(runWithRuntime, t.RosaRuntime, Cmd)
+		Expect(err).To(MatchError(ContainSubstring("prefix length must be between /16 and /24")))
+	})
+})
@@ -0,0 +1,289 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sharedvpcresources
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/aws"
+	awscb "github.com/openshift/rosa/pkg/aws/commandbuilder"
+	"github.com/openshift/rosa/pkg/aws/tags"
+	"github.com/openshift/rosa/pkg/helper"
+	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+const (
+	trustPolicyFile      = "shared_vpc_trust_policy.json"
+	permissionPolicyFile = "shared_vpc_permission_policy.json"
+)
+
+var args struct {
+	vpcOwnerProfile     string
+	prefix              string
+	operatorRolesPrefix string
+	hostedCP            bool
+	vpcID               string
+	clusterName         string
+	baseDomain          string
+}
+
+var Cmd = &cobra.Command{
+	Use:     "shared-vpc-resources",
+	Aliases: []string{"sharedvpcresources"},
+	Short:   "Create the resources of the VPC owner account needed by a shared VPC cluster",
+	Long: "Create the role and private hosted zone a cluster installed in a VPC shared by another AWS " +
+		"account needs. The role is created in the VPC owner account, grants the permissions to manage " +
+		"the DNS records of the private hosted zone and trusts the installer and ingress operator roles " +
+		"of the cluster owner account. The current AWS credentials must belong to the cluster owner account.",
+	Example: `  # Create the shared VPC resources for cluster "mycluster"
+  rosa create shared-vpc-resources --vpc-owner-profile=vpc-owner --operator-roles-prefix=mycluster \
+    --vpc-id=vpc-0123456789abcdef0 --cluster-name=mycluster --base-domain=1vo8.p1.openshiftapps.com
+
+  # Print the AWS CLI commands to run with the VPC owner account instead of running them
+  rosa create shared-vpc-resources --vpc-owner-profile=vpc-owner --operator-roles-prefix=mycluster \
+    --vpc-id=vpc-0123456789abcdef0 --cluster-name=mycluster --base-domain=1vo8.p1.openshiftapps.com \
+    --mode=manual`,
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+
+	flags.StringVar(
+		&args.vpcOwnerProfile,
+		"vpc-owner-profile",
+		"",
+		"AWS profile of the account that owns the shared VPC.",
+	)
+
+	flags.StringVar(
+		&args.prefix,
+		"prefix",
+		aws.DefaultPrefix,
+		"Prefix of the account roles of the cluster owner account.",
+	)
+
+	flags.StringVar(
+		&args.operatorRolesPrefix,
+		"operator-roles-prefix",
+		"",
+		"Prefix of the operator roles of the cluster.",
+	)
+
+	flags.BoolVar(
+		&args.hostedCP,
+		"hosted-cp",
+		false,
+		"Trust the installer role of Hosted Control Plane clusters.",
+	)
+
+	flags.StringVar(
+		&args.vpcID,
+		"vpc-id",
+		"",
+		"ID of the shared VPC to associate the private hosted zone with.",
+	)
+
+	flags.StringVar(
+		&args.clusterName,
+		"cluster-name",
+		"",
+		"Name of the cluster that will be installed in the shared VPC.",
+	)
+
+	flags.StringVar(
+		&args.baseDomain,
+		"base-domain",
+		"",
+		"Base DNS domain reserved with 'rosa create dns-domain'. "+
+			"The private hosted zone is named '<cluster-name>.<base-domain>'.",
+	)
+
+	interactive.AddModeFlag(Cmd)
+	confirm.AddFlag(flags)
+	output.AddFlag(Cmd)
+}
+
+// SharedVPCResources are the resources of the VPC owner account to pass to 'rosa create cluster'
+type SharedVPCResources struct {
+	RoleARN        string `json:"role_arn"`
+	HostedZoneID   string `json:"hosted_zone_id,omitempty"`
+	HostedZoneName string `json:"hosted_zone_name"`
+	BaseDomain     string `json:"base_domain"`
+}
+
+func run(cmd *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS()
+	defer r.Cleanup()
+
+	if args.vpcOwnerProfile == "" {
+		r.Reporter.Errorf("Expected the AWS profile of the VPC owner account with '--vpc-owner-profile'")
+		os.Exit(1)
+	}
+
+	vpcOwnerClient, err := aws.NewClient().
+		Logger(r.Logger).
+		Region(r.AWSClient.GetRegion()).
+		Profile(args.vpcOwnerProfile).
+		Build()
+	if err != nil {
+		r.Reporter.Errorf("Error creating AWS client for profile '%s': %v", args.vpcOwnerProfile, err)
+		os.Exit(1)
+	}
+
+	err = runWithRuntime(r, vpcOwnerClient, cmd)
+	if err != nil {
+		r.Reporter.Errorf(err.Error())
+		os.Exit(1)
+	}
+}
+
+func runWithRuntime(r *rosa.Runtime, vpcOwnerClient aws.Client, cmd *cobra.Command) error {
+	mode, err := interactive.GetMode()
+	if err != nil {
+		return err
+	}
+	if mode == "" {
+		mode = interactive.ModeAuto
+	}
+
+	if args.operatorRolesPrefix == "" {
+		return fmt.Errorf("Expected the prefix of the operator roles with '--operator-roles-prefix'")
+	}
+	if args.vpcID == "" {
+		return fmt.Errorf("Expected the ID of the shared VPC with '--vpc-id'")
+	}
+	if !ocm.IsValidClusterName(args.clusterName) {
+		return fmt.Errorf("Expected a valid cluster name with '--cluster-name'")
+	}
+	if args.baseDomain == "" {
+		return fmt.Errorf("Expected the reserved base domain with '--base-domain'")
+	}
+
+	vpcOwner, err := vpcOwnerClient.GetCreator()
+	if err != nil {
+		return fmt.Errorf("Failed to get the VPC owner account: %v", err)
+	}
+	if vpcOwner.AccountID == r.Creator.AccountID {
+		return fmt.Errorf("Profile '%s' belongs to the cluster owner account '%s', a shared VPC must be "+
+			"owned by a different account", args.vpcOwnerProfile, r.Creator.AccountID)
+	}
+
+	roleName := aws.GetSharedVPCRoleName(args.prefix)
+	trustedRoleARNs := aws.GetSharedVPCTrustedRoleARNs(r.Creator.Partition, r.Creator.AccountID,
+		args.prefix, args.operatorRolesPrefix, args.hostedCP)
+	roleTags := map[string]string{
+		tags.RolePrefix:    args.prefix,
+		tags.RoleType:      aws.SharedVPCRoleType,
+		tags.RedHatManaged: tags.True,
+	}
+	resources := &SharedVPCResources{
+		RoleARN:        aws.GetRoleARN(vpcOwner.AccountID, roleName, "", vpcOwner.Partition),
+		HostedZoneName: fmt.Sprintf("%s.%s", args.clusterName, args.baseDomain),
+		BaseDomain:     args.baseDomain,
+	}
+
+	switch mode {
+	case interactive.ModeAuto:
+		if !output.HasFlag() {
+			r.Reporter.Infof("Creating shared VPC resources in account '%s' using profile '%s'",
+				vpcOwner.AccountID, args.vpcOwnerProfile)
+		}
+		if !confirm.Prompt(true, "Create role '%s' and hosted zone '%s'?", roleName, resources.HostedZoneName) {
+			return nil
+		}
+		resources.RoleARN, err = vpcOwnerClient.EnsureSharedVPCRole(roleName, trustedRoleARNs, roleTags)
+		if err != nil {
+			return err
+		}
+		if !output.HasFlag() {
+			r.Reporter.Infof("Role '%s' trusts %v", resources.RoleARN, trustedRoleARNs)
+		}
+		hostedZone, err := vpcOwnerClient.EnsurePrivateHostedZone(resources.HostedZoneName, args.vpcID,
+			vpcOwnerClient.GetRegion())
+		if err != nil {
+			return err
+		}
+		resources.HostedZoneID = hostedZone.ID
+		if output.HasFlag() {
+			return output.Print(resources)
+		}
+		r.Reporter.Infof("Private hosted zone '%s' with ID '%s' is associated with VPC '%s'",
+			resources.HostedZoneName, resources.HostedZoneID, args.vpcID)
+		r.Reporter.Infof("To create a cluster in the shared VPC, run:")
+		fmt.Printf("  rosa create cluster --cluster-name=%s --shared-vpc-role-arn=%s "+
+			"--private-hosted-zone-id=%s --base-domain=%s\n",
+			args.clusterName, resources.RoleARN, resources.HostedZoneID, resources.BaseDomain)
+	case interactive.ModeManual:
+		err = helper.SaveDocument(aws.SharedVPCTrustPolicy(trustedRoleARNs), trustPolicyFile)
+		if err != nil {
+			return fmt.Errorf("Failed to save '%s': %v", trustPolicyFile, err)
+		}
+		err = helper.SaveDocument(aws.SharedVPCPermissionPolicy(), permissionPolicyFile)
+		if err != nil {
+			return fmt.Errorf("Failed to save '%s': %v", permissionPolicyFile, err)
+		}
+		if r.Reporter.IsTerminal() {
+			r.Reporter.Infof("All policy files saved to the current directory")
+			r.Reporter.Infof("Run the following commands to create the shared VPC resources:\n")
+		}
+		fmt.Println(buildCommands(roleName, roleTags, resources, vpcOwnerClient.GetRegion()))
+	default:
+		return fmt.Errorf("Invalid mode. Allowed values are %s", interactive.Modes)
+	}
+
+	return nil
+}
+
+func buildCommands(roleName string, roleTags map[string]string, resources *SharedVPCResources,
+	region string) string {
+	createRole := awscb.NewIAMCommandBuilder().
+		SetCommand(awscb.CreateRole).
+		AddParam(awscb.RoleName, roleName).
+		AddParam(awscb.AssumeRolePolicyDocument, fmt.Sprintf("file://%s", trustPolicyFile)).
+		AddTags(roleTags).
+		AddParam(awscb.Profile, args.vpcOwnerProfile).
+		Build()
+	putRolePolicy := awscb.NewIAMCommandBuilder().
+		SetCommand(awscb.PutRolePolicy).
+		AddParam(awscb.RoleName, roleName).
+		AddParam(awscb.PolicyName, aws.GetPolicyName(roleName)).
+		AddParam(awscb.PolicyDocument, fmt.Sprintf("file://%s", permissionPolicyFile)).
+		AddParam(awscb.Profile, args.vpcOwnerProfile).
+		Build()
+	createHostedZone := awscb.NewRoute53CommandBuilder().
+		SetCommand(awscb.CreateHostedZone).
+		AddParam(awscb.Name, resources.HostedZoneName).
+		AddParam(awscb.VPC, fmt.Sprintf("VPCRegion=%s,VPCId=%s", region, args.vpcID)).
+		AddParam(awscb.CallerReference, fmt.Sprintf("rosa-%s-%d", args.clusterName, time.Now().Unix())).
+		AddParam(awscb.HostedZoneConfig, "PrivateZone=true").
+		AddParam(awscb.Profile, args.vpcOwnerProfile).
+		Build()
+	createCluster := fmt.Sprintf("rosa create cluster --cluster-name=%s --shared-vpc-role-arn=%s "+
+		"--private-hosted-zone-id=<hosted zone ID> --base-domain=%s",
+		args.clusterName, resources.RoleARN, resources.BaseDomain)
+	return awscb.JoinCommands([]string{createRole, putRolePolicy, createHostedZone, createCluster})
+}
@@ -0,0 +1,99 @@
+package sharedvpcresources
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+	"go.uber.org/mock/gomock"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+	"github.com/openshift/rosa/pkg/test"
+)
+
+var _ = Describe("Create shared VPC resources", func() {
+	var (
+		t              *test.TestingRuntime
+		vpcOwnerClient *aws.MockClient
+		run            func(*rosa.Runtime, *cobra.Command) error
+	)
+
+	trustedRoleARNs := []string{
+		"arn:aws:iam::123:role/ManagedOpenShift-Installer-Role",
+		"arn:aws:iam::123:role/mycluster-openshift-ingress-operator-cloud-credentials",
+	}
+
+	BeforeEach(func() {
+		t = test.NewTestRuntime()
+		t.RosaRuntime.Creator.Partition = "aws"
+		vpcOwnerClient = aws.NewMockClient(gomock.NewController(GinkgoT()))
+		vpcOwnerClient.EXPECT().GetRegion().Return("us-east-1").AnyTimes()
+		run = func(r *rosa.Runtime, cmd *cobra.Command) error {
+			return runWithRuntime(r, vpcOwnerClient, cmd)
+		}
+		output.SetOutput("")
+		Cmd.Flags().Set("yes", "true")
+		Cmd.Flags().Set("vpc-owner-profile", "vpc-owner")
+		Cmd.Flags().Set("operator-roles-prefix", "mycluster")
+		Cmd.Flags().Set("vpc-id", "vpc-1")
+		Cmd.Flags().Set("cluster-name", "mycluster")
+		Cmd.Flags().Set("base-domain", "example.com")
+		DeferCleanup(func() {
+			Cmd.Flags().Set("yes", "false")
+			Cmd.Flags().Set("mode", "")
+			Cmd.Flags().Set("vpc-owner-profile", "")
+			Cmd.Flags().Set("operator-roles-prefix", "")
+		})
+	})
+
+	It("Creates the role and the private hosted zone in the VPC owner account", func() {
+		vpcOwnerClient.EXPECT().GetCreator().Return(&aws.Creator{AccountID: "456", Partition: "aws"}, nil)
+		vpcOwnerClient.EXPECT().EnsureSharedVPCRole("ManagedOpenShift-Shared-VPC-Role", trustedRoleARNs,
+			gomock.Any()).Return("arn:aws:iam::456:role/ManagedOpenShift-Shared-VPC-Role", nil)
+		vpcOwnerClient.EXPECT().EnsurePrivateHostedZone("mycluster.example.com", "vpc-1", "us-east-1").
+			Return(&aws.HostedZone{ID: "ZPRIVATE", Name: "mycluster.example.com.", Private: true}, nil)
+		stdout, _, err := test.RunWithOutputCapture(run, t.RosaRuntime, Cmd)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stdout).To(ContainSubstring("rosa create cluster --cluster-name=mycluster " +
+			"--shared-vpc-role-arn=arn:aws:iam::456:role/ManagedOpenShift-Shared-VPC-Role " +
+			"--private-hosted-zone-id=ZPRIVATE --base-domain=example.com\n"))
+	})
+
+	It("Prints the commands to run in manual mode", func() {
+		dir := GinkgoT().TempDir()
+		wd, err := os.Getwd()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(os.Chdir(dir)).To(Succeed())
+		DeferCleanup(os.Chdir, wd)
+
+		Cmd.Flags().Set("mode", "manual")
+		vpcOwnerClient.EXPECT().GetCreator().Return(&aws.Creator{AccountID: "456", Partition: "aws"}, nil)
+		stdout, _, err := test.RunWithOutputCapture(run, t.RosaRuntime, Cmd)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stdout).To(ContainSubstring("aws iam create-role"))
+		Expect(stdout).To(ContainSubstring("--assume-role-policy-document file://shared_vpc_trust_policy.json"))
+		Expect(stdout).To(ContainSubstring("aws iam put-role-policy"))
+		Expect(stdout).To(ContainSubstring("aws route53 create-hosted-zone"))
+		Expect(stdout).To(ContainSubstring("--vpc VPCRegion=us-east-1,VPCId=vpc-1"))
+		Expect(stdout).To(ContainSubstring("--profile vpc-owner"))
+		Expect(dir + "/shared_vpc_trust_policy.json").To(BeARegularFile())
+		Expect(dir + "/shared_vpc_permission_policy.json").To(BeARegularFile())
+	})
+
+	It("Fails when the VPC owner is the cluster owner account", func() {
+		vpcOwnerClient.EXPECT().GetCreator().Return(&aws.Creator{AccountID: "123", Partition: "aws"}, nil)
+		_, _, err := test.RunWithOutputCapture(run, t.RosaRuntime, Cmd)
+		Expect(err).To(MatchError("Profile 'vpc-owner' belongs to the cluster owner account '123', " +
+			"a shared VPC must be owned by a different account"))
+	})
+
+	It("Fails without the operator roles prefix", func() {
+		Cmd.Flags().Set("operator-roles-prefix", "")
+		_, _, err := test.RunWithOutputCapture(run, t.RosaRuntime, Cmd)
+		Expect(err).To(MatchError("Expected the prefix of the operator roles with '--operator-roles-prefix'"))
+	})
+})
@@ -0,0 +1,13 @@
+package sharedvpcresources_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestSharedVPCResources(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Create shared VPC resources Suite")
+}
@@ -25,6 +25,7 @@ import (
 
 	"github.com/openshift/rosa/pkg/breakglasscredential"
 	"github.com/openshift/rosa/pkg/externalauthprovider"
+	"github.com/openshift/rosa/pkg/interactive/confirm"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/output"
 	"github.com/openshift/rosa/pkg/rosa"
@@ -36,7 +37,10 @@ var Cmd = &cobra.Command{
 	Short:   "Show details of a break glass credential on a cluster",
 	Long:    "Show details of a break glass credential on a cluster.",
 	Example: `  # Show details of a break glass credential with ID "12345" on a cluster named "mycluster"
-  rosa describe break-glass-credential 12345 --cluster=mycluster `,
+  rosa describe break-glass-credential 12345 --cluster=mycluster
+
+  # Write the kubeconfig of a break glass credential with ID "12345" to a file
+  rosa describe break-glass-credential 12345 --cluster=mycluster --kubeconfig-out=./break-glass.kubeconfig`,
 	Run:  run,
 	Args: cobra.MaximumNArgs(2),
 }
@@ -46,6 +50,8 @@ var args struct {
 	kubeconfig bool
 }
 
+var kubeconfigArgs *breakglasscredential.KubeconfigArgs
+
 func init() {
 	flags := Cmd.Flags()
 	flags.SortFlags = false
@@ -64,6 +70,9 @@ func init() {
 		false,
 		"Retrieve the kubeconfig from the break glass credential",
 	)
+
+	kubeconfigArgs = breakglasscredential.AddKubeconfigFlags(Cmd)
+	confirm.AddFlag(flags)
 }
 
 func run(cmd *cobra.Command, argv []string) {
@@ -96,7 +105,7 @@ func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command, argv []string) error {
 	}
 
 	r.Reporter.Debugf("Fetching the break glass credential '%s' for cluster '%s'", breakGlassCredentialId, clusterKey)
-	if !getKubeconfig {
+	if !getKubeconfig && !kubeconfigArgs.Enabled() {
 		r.Reporter.Infof(
 			"To retrieve only the kubeconfig for this credential "+
 				"use: 'rosa describe break-glass-credential %s -c %s --kubeconfig'",
@@ -113,6 +122,10 @@ func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command, argv []string) error {
 			breakGlassCredentialId, clusterKey)
 	}
 
+	if kubeconfigArgs.Enabled() {
+		return saveKubeconfig(r, cluster, clusterKey, breakGlassCredentialConfig)
+	}
+
 	if output.HasFlag() {
 		var formattedOutput map[string]interface{}
 		formattedOutput, err = breakglasscredential.FormatBreakGlassCredentialOutput(breakGlassCredentialConfig)
@@ -136,6 +149,41 @@ func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command, argv []string) error {
 	return nil
 }
 
+// saveKubeconfig writes the kubeconfig of the credential to a file, waiting for the credential to be
+// issued if needed
+func saveKubeconfig(r *rosa.Runtime, cluster *cmv1.Cluster, clusterKey string,
+	credential *cmv1.BreakGlassCredential) error {
+	if !kubeconfigArgs.ConfirmOverwrite() {
+		return nil
+	}
+	if credential.Status() == cmv1.BreakGlassCredentialStatusExpired {
+		return fmt.Errorf("Break glass credential '%s' for cluster '%s' has expired.",
+			credential.ID(), clusterKey)
+	}
+	if credential.Kubeconfig() == "" {
+		r.Reporter.Infof("Waiting for break glass credential '%s' to be issued", credential.ID())
+		var err error
+		credential, err = r.OCMClient.PollBreakGlassCredential(cluster.ID(), credential.ID())
+		if err != nil {
+			return err
+		}
+	}
+
+	path, contextName, err := kubeconfigArgs.SaveKubeconfig(credential.Kubeconfig(), cluster.Name(),
+		credential.Username())
+	if err != nil {
+		return err
+	}
+	if contextName != "" {
+		r.Reporter.Infof("Merged the kubeconfig into '%s' and switched to context '%s'", path, contextName)
+	} else {
+		r.Reporter.Infof("Wrote the kubeconfig to '%s'", path)
+	}
+	r.Reporter.Infof("The break glass credential expires at %s",
+		credential.ExpirationTimestamp().Format("Jan _2 2006 15:04:05 MST"))
+	return nil
+}
+
 func describeBreakGlassCredential(r *rosa.Runtime, cluster *cmv1.Cluster,
 	clusterKey string, config *cmv1.BreakGlassCredential) string {
 	breakGlassCredentialOutput := fmt.Sprintf("\n"+
@@ -30,6 +30,8 @@ import (
 	"github.com/openshift/rosa/cmd/describe/kubeletconfig"
 	"github.com/openshift/rosa/cmd/describe/machinepool"
 	"github.com/openshift/rosa/cmd/describe/service"
+	"github.com/openshift/rosa/cmd/describe/servicelog"
+	"github.com/openshift/rosa/cmd/describe/subscription"
 	"github.com/openshift/rosa/cmd/describe/tuningconfigs"
 	"github.com/openshift/rosa/cmd/describe/upgrade"
 	"github.com/openshift/rosa/pkg/arguments"
@@ -51,6 +53,7 @@ func init() {
 		machinePoolCommand, kubeletconfig.Cmd,
 		autoscaler.NewDescribeAutoscalerCommand(), ingressCommand,
 		externalauthprovider.Cmd, breakglasscredential.Cmd,
+		servicelog.Cmd, subscription.Cmd,
 	}
 	for _, cmd := range cmds {
 		Cmd.AddCommand(cmd)
@@ -66,6 +69,7 @@ func init() {
 		admin.Cmd, breakglasscredential.Cmd,
 		externalauthprovider.Cmd, installation.Cmd,
 		kubeletconfig.Cmd, upgrade.Cmd, ingressCommand,
+		servicelog.Cmd, subscription.Cmd,
 	}
 	arguments.MarkRegionDeprecated(Cmd, globallyAvailableCommands)
 }
@@ -0,0 +1,140 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicelog
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	slv1 "github.com/openshift-online/ocm-sdk-go/servicelogs/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var Cmd = &cobra.Command{
+	Use:     "service-log",
+	Aliases: []string{"service-logs", "servicelog", "servicelogs"},
+	Short:   "Show details of a service log of a cluster",
+	Long:    "Show details of a service log that Red Hat posted for a cluster.",
+	Example: `  # Show details of the service log with ID "12345" of a cluster named "mycluster"
+  rosa describe service-log 12345 --cluster=mycluster`,
+	Run:  run,
+	Args: cobra.MaximumNArgs(1),
+}
+
+var args struct {
+	id string
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+	ocm.AddClusterFlag(Cmd)
+	flags.StringVar(
+		&args.id,
+		"id",
+		"",
+		"ID of the service log of the cluster to describe",
+	)
+	output.AddFlag(Cmd)
+}
+
+func run(cmd *cobra.Command, argv []string) {
+	r := rosa.NewRuntime().WithOCM()
+	defer r.Cleanup()
+	err := runWithRuntime(r, cmd, argv)
+	if err != nil {
+		r.Reporter.Errorf(err.Error())
+		os.Exit(1)
+	}
+}
+
+func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command, argv []string) error {
+	id := args.id
+	// Allow the user to also set the service log id as positional parameter
+	if len(argv) == 1 && !cmd.Flag("id").Changed {
+		id = argv[0]
+	}
+	if id == "" {
+		return fmt.Errorf("You need to specify a service log id with the '--id' parameter")
+	}
+	clusterKey := r.GetClusterKey()
+	cluster := r.FetchCluster()
+
+	r.Reporter.Debugf("Fetching the service log '%s' for cluster '%s'", id, clusterKey)
+	logEntry, exists, err := r.OCMClient.GetServiceLog(id)
+	if err != nil {
+		return fmt.Errorf("Failed to get service log '%s' for cluster '%s': %v", id, clusterKey, err)
+	}
+	// Service logs are stored outside of the cluster, make sure this one was posted for it
+	if !exists || !belongsToCluster(logEntry, cluster) {
+		return fmt.Errorf("Service log '%s' not found for cluster '%s'", id, clusterKey)
+	}
+
+	if output.HasFlag() {
+		return output.Print(logEntry)
+	}
+	fmt.Print(formatServiceLog(logEntry))
+	return nil
+}
+
+func belongsToCluster(logEntry *slv1.LogEntry, cluster *cmv1.Cluster) bool {
+	if logEntry.ClusterID() != "" && logEntry.ClusterID() == cluster.ID() {
+		return true
+	}
+	return logEntry.ClusterUUID() != "" && logEntry.ClusterUUID() == cluster.ExternalID()
+}
+
+func formatServiceLog(logEntry *slv1.LogEntry) string {
+	builder := make([]string, 0)
+	builder = append(builder, fmt.Sprintf(`
+%-35s%s
+%-35s%s
+%-35s%s
+%-35s%s
+%-35s%s
+%-35s%s
+%-35s%s
+`,
+		"ID:", logEntry.ID(),
+		"Cluster ID:", logEntry.ClusterID(),
+		"Timestamp:", logEntry.Timestamp().UTC().Format("2006-01-02 15:04:05 MST"),
+		"Severity:", logEntry.Severity(),
+		"Log Type:", logEntry.LogType(),
+		"Service:", logEntry.ServiceName(),
+		"Summary:", logEntry.Summary()))
+	if logEntry.Description() != "" {
+		builder = append(builder, fmt.Sprintf(`
+%s
+%s
+`, "Description:", strings.TrimSpace(logEntry.Description())))
+	}
+	if len(logEntry.DocReferences()) > 0 {
+		builder = append(builder, fmt.Sprintf(`
+%s
+`, "Documentation:"))
+		for _, reference := range logEntry.DocReferences() {
+			builder = append(builder, fmt.Sprintf(" - %s\n", reference))
+		}
+	}
+	return strings.Join(builder, "")
+}
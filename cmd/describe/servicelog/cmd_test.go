@@ -0,0 +1,101 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicelog
+
+import (
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	slv1 "github.com/openshift-online/ocm-sdk-go/servicelogs/v1"
+	. "github.com/openshift-online/ocm-sdk-go/testing"
+
+	"github.com/openshift/rosa/pkg/test"
+)
+
+var _ = Describe("Describe service log", func() {
+	var testRuntime test.TestingRuntime
+	var logEntry *slv1.LogEntry
+
+	cluster := test.FormatClusterList([]*cmv1.Cluster{test.MockCluster(func(c *cmv1.ClusterBuilder) {
+		c.State(cmv1.ClusterStateReady)
+	})})
+
+	BeforeEach(func() {
+		testRuntime.InitRuntime()
+		DeferCleanup(func() {
+			args.id = ""
+		})
+
+		timestamp, err := time.Parse(time.RFC3339, "2024-01-31T10:00:00Z")
+		Expect(err).ToNot(HaveOccurred())
+		logEntry, err = slv1.NewLogEntry().ID("log1").ClusterID(test.MockClusterID).Timestamp(timestamp).
+			Severity(slv1.SeverityError).LogType(slv1.LogTypeClusterStateUpdates).ServiceName("SREManualAction").
+			Summary("Cluster is in limited support").
+			Description("The cluster is in limited support because the account role was deleted.\n").
+			DocReferences("https://docs.openshift.com/rosa/support.html").Build()
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("Fails without a service log id", func() {
+		_, _, err := test.RunWithOutputCaptureAndArgv(runWithRuntime, testRuntime.RosaRuntime, Cmd, &[]string{})
+		Expect(err).To(MatchError("You need to specify a service log id with the '--id' parameter"))
+	})
+
+	It("Shows the details of the service log", func() {
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, cluster))
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, test.FormatResource(logEntry)))
+		stdout, _, err := test.RunWithOutputCaptureAndArgv(runWithRuntime, testRuntime.RosaRuntime, Cmd,
+			&[]string{"log1"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(stdout).To(Equal(`
+ID:                                log1
+Cluster ID:                        ` + test.MockClusterID + `
+Timestamp:                         2024-01-31 10:00:00 UTC
+Severity:                          Error
+Log Type:                          cluster-state-updates
+Service:                           SREManualAction
+Summary:                           Cluster is in limited support
+
+Description:
+The cluster is in limited support because the account role was deleted.
+
+Documentation:
+ - https://docs.openshift.com/rosa/support.html
+`))
+	})
+
+	It("Fails when the service log doesn't exist", func() {
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, cluster))
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusNotFound, "{}"))
+		_, _, err := test.RunWithOutputCaptureAndArgv(runWithRuntime, testRuntime.RosaRuntime, Cmd,
+			&[]string{"log1"})
+		Expect(err).To(MatchError("Service log 'log1' not found for cluster 'cluster1'"))
+	})
+
+	It("Fails when the service log belongs to another cluster", func() {
+		other, err := slv1.NewLogEntry().ID("log1").ClusterID("other").Build()
+		Expect(err).ToNot(HaveOccurred())
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, cluster))
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, test.FormatResource(other)))
+		_, _, err = test.RunWithOutputCaptureAndArgv(runWithRuntime, testRuntime.RosaRuntime, Cmd,
+			&[]string{"log1"})
+		Expect(err).To(MatchError("Service log 'log1' not found for cluster 'cluster1'"))
+	})
+})
@@ -0,0 +1,129 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package subscription
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	amsv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var Cmd = &cobra.Command{
+	Use:     "subscription",
+	Aliases: []string{"subscriptions"},
+	Short:   "Show details of the subscription of a cluster",
+	Long: "Show details of the subscription of a cluster, such as its support level, billing model " +
+		"and consumption.",
+	Example: `  # Show details of the subscription of a cluster named "mycluster"
+  rosa describe subscription --cluster=mycluster`,
+	Run:  run,
+	Args: cobra.NoArgs,
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+	ocm.AddClusterFlag(Cmd)
+	output.AddFlag(Cmd)
+}
+
+func run(cmd *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithOCM()
+	defer r.Cleanup()
+	err := runWithRuntime(r, cmd)
+	if err != nil {
+		r.Reporter.Errorf(err.Error())
+		os.Exit(1)
+	}
+}
+
+func runWithRuntime(r *rosa.Runtime, _ *cobra.Command) error {
+	clusterKey := r.GetClusterKey()
+	cluster := r.FetchCluster()
+
+	subscriptionID := cluster.Subscription().ID()
+	if subscriptionID == "" {
+		return fmt.Errorf("Cluster '%s' has no subscription", clusterKey)
+	}
+	r.Reporter.Debugf("Loading subscription '%s' of cluster '%s'", subscriptionID, clusterKey)
+	subscription, exists, err := r.OCMClient.GetSubscriptionBySubscriptionID(subscriptionID)
+	if err != nil {
+		return fmt.Errorf("Failed to get subscription of cluster '%s': %v", clusterKey, err)
+	}
+	if !exists {
+		return fmt.Errorf("Subscription '%s' of cluster '%s' not found", subscriptionID, clusterKey)
+	}
+
+	if output.HasFlag() {
+		return output.Print(subscription)
+	}
+	fmt.Print(formatSubscription(subscription))
+	return nil
+}
+
+func formatSubscription(subscription *amsv1.Subscription) string {
+	builder := make([]string, 0)
+	builder = append(builder, fmt.Sprintf(`
+%-35s%s
+%-35s%s
+%-35s%s
+%-35s%s
+%-35s%s
+%-35s%s
+%-35s%s
+%-35s%s
+%-35s%s
+%-35s%s
+%-35s%d
+%-35s%d
+%-35s%s
+%-35s%s
+`,
+		"ID:", subscription.ID(),
+		"Cluster ID:", subscription.ClusterID(),
+		"External ID:", subscription.ExternalClusterID(),
+		"Display Name:", subscription.DisplayName(),
+		"Status:", subscription.Status(),
+		"Plan:", subscription.Plan().ID(),
+		"Support Level:", valueOrNone(subscription.SupportLevel()),
+		"Service Level:", valueOrNone(subscription.ServiceLevel()),
+		"Billing Model:", valueOrNone(string(subscription.ClusterBillingModel())),
+		"Marketplace Billing Account:", valueOrNone(subscription.BillingMarketplaceAccount()),
+		"vCPU Total:", subscription.CpuTotal(),
+		"Socket Total:", subscription.SocketTotal(),
+		"Creator:", valueOrNone(ocm.FormatSubscriptionCreator(subscription)),
+		"Created:", subscription.CreatedAt().UTC().Format("2006-01-02 15:04:05 MST")))
+	if !subscription.TrialEndDate().IsZero() {
+		builder = append(builder, fmt.Sprintf("%-35s%s\n",
+			"Trial End Date:", subscription.TrialEndDate().UTC().Format("2006-01-02 15:04:05 MST")))
+	}
+	return strings.Join(builder, "")
+}
+
+func valueOrNone(value string) string {
+	if value == "" {
+		return "None"
+	}
+	return value
+}
@@ -0,0 +1,107 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package subscription
+
+import (
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	amsv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	. "github.com/openshift-online/ocm-sdk-go/testing"
+
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/test"
+)
+
+var _ = Describe("Describe subscription", func() {
+	var testRuntime test.TestingRuntime
+	var subscription *amsv1.Subscription
+
+	cluster := test.FormatClusterList([]*cmv1.Cluster{test.MockCluster(func(c *cmv1.ClusterBuilder) {
+		c.State(cmv1.ClusterStateReady)
+		c.Subscription(cmv1.NewSubscription().ID("sub1"))
+	})})
+
+	BeforeEach(func() {
+		testRuntime.InitRuntime()
+		DeferCleanup(func() {
+			output.SetOutput("")
+		})
+
+		createdAt, err := time.Parse(time.RFC3339, "2024-01-31T10:00:00Z")
+		Expect(err).ToNot(HaveOccurred())
+		subscription, err = amsv1.NewSubscription().ID("sub1").ClusterID(test.MockClusterID).
+			ExternalClusterID("1f2e3d4c").DisplayName("cluster1").Status("Active").
+			Plan(amsv1.NewPlan().ID("MOA")).SupportLevel("Premium").ServiceLevel("L1-L3").
+			ClusterBillingModel(amsv1.BillingModelMarketplaceAWS).BillingMarketplaceAccount("123456789012").
+			CpuTotal(24).SocketTotal(12).CreatedAt(createdAt).
+			Creator(amsv1.NewAccount().ID("acc1").Username("jdoe").Email("jdoe@example.com")).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("Shows the details of the subscription of the cluster", func() {
+		testRuntime.ApiServer.AppendHandlers(
+			RespondWithJSON(http.StatusOK, cluster),
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodGet, "/api/accounts_mgmt/v1/subscriptions/sub1"),
+				RespondWithJSON(http.StatusOK, test.FormatResource(subscription)),
+			),
+		)
+		stdout, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(stdout).To(Equal(`
+ID:                                sub1
+Cluster ID:                        ` + test.MockClusterID + `
+External ID:                       1f2e3d4c
+Display Name:                      cluster1
+Status:                            Active
+Plan:                              MOA
+Support Level:                     Premium
+Service Level:                     L1-L3
+Billing Model:                     marketplace-aws
+Marketplace Billing Account:       123456789012
+vCPU Total:                        24
+Socket Total:                      12
+Creator:                           jdoe (jdoe@example.com)
+Created:                           2024-01-31 10:00:00 UTC
+`))
+	})
+
+	It("Prints the subscription as JSON", func() {
+		output.SetOutput("json")
+		testRuntime.ApiServer.AppendHandlers(
+			RespondWithJSON(http.StatusOK, cluster),
+			RespondWithJSON(http.StatusOK, test.FormatResource(subscription)),
+		)
+		stdout, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(stdout).To(ContainSubstring(`"support_level": "Premium"`))
+		Expect(stdout).To(ContainSubstring(`"cluster_billing_model": "marketplace-aws"`))
+	})
+
+	It("Fails when the cluster has no subscription", func() {
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK,
+			test.FormatClusterList([]*cmv1.Cluster{test.MockCluster(nil)})))
+		_, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+		Expect(err).To(MatchError("Cluster 'cluster1' has no subscription"))
+	})
+})
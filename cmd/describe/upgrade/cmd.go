@@ -19,11 +19,15 @@ package upgrade
 import (
 	"fmt"
 	"os"
+	"sort"
 	"strings"
+	"text/tabwriter"
+	"time"
 
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/spf13/cobra"
 
+	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/interactive/confirm"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/rosa"
@@ -93,34 +97,49 @@ func runWithRuntime(r *rosa.Runtime) error {
 
 func describeHypershiftUpgrades(r *rosa.Runtime, clusterID string, nodePoolID string) error {
 	clusterKey := r.GetClusterKey()
+	upgrades := make([]ocm.HypershiftUpgrader, 0)
 	if args.nodePool == "" {
-		upgrades, err := r.OCMClient.GetControlPlaneUpgradePolicies(clusterID)
+		controlPlaneUpgrades, err := r.OCMClient.GetControlPlaneUpgradePolicies(clusterID)
 		if err != nil {
 			return fmt.Errorf("Failed to get upgrades for cluster '%s': %v", clusterKey, err)
 		}
-		if len(upgrades) < 1 {
+		if len(controlPlaneUpgrades) < 1 {
 			r.Reporter.Infof("No scheduled upgrades for cluster '%s'", clusterKey)
 			return nil
 		}
-
-		for _, upgrade := range upgrades {
-			fmt.Print(formatHypershiftUpgrade(upgrade))
+		for _, upgrade := range controlPlaneUpgrades {
+			upgrades = append(upgrades, upgrade)
 		}
 	} else {
-		_, upgrades, err := r.OCMClient.GetHypershiftNodePoolUpgrades(clusterID, clusterKey, nodePoolID)
+		_, nodePoolUpgrades, err := r.OCMClient.GetHypershiftNodePoolUpgrades(clusterID, clusterKey, nodePoolID)
 		if err != nil {
 			return fmt.Errorf("Failed to get upgrades for machine pool '%s' in cluster '%s': %v", nodePoolID,
 				clusterKey, err)
 		}
-		if upgrades == nil || len(upgrades) < 1 {
+		if len(nodePoolUpgrades) < 1 {
 			r.Reporter.Infof("No scheduled upgrades for machine pool '%s' in cluster '%s'", nodePoolID, clusterKey)
 			return nil
 		}
+		for _, upgrade := range nodePoolUpgrades {
+			upgrades = append(upgrades, upgrade)
+		}
+	}
 
-		for _, upgrade := range upgrades {
-			fmt.Print(formatHypershiftUpgrade(upgrade))
+	history := make([]upgradeRun, 0)
+	for _, upgrade := range upgrades {
+		if isUpgradeHistory(upgrade.State()) {
+			history = append(history, upgradeRun{
+				id:           upgrade.ID(),
+				version:      upgrade.Version(),
+				scheduleType: upgrade.ScheduleType(),
+				state:        upgrade.State(),
+				nextRun:      upgrade.NextRun(),
+			})
+			continue
 		}
+		fmt.Print(formatHypershiftUpgrade(upgrade))
 	}
+	fmt.Print(formatUpgradeHistory(history))
 	return nil
 }
 
@@ -143,6 +162,7 @@ func formatHypershiftUpgrade(upgrade ocm.HypershiftUpgrader) string {
 		builder = append(builder, fmt.Sprintf(`
 %-35s%s
 `, "Schedule At:", upgrade.Schedule()))
+		builder = append(builder, formatNextRuns(upgrade.ScheduleType(), upgrade.Schedule(), upgrade.NextRun()))
 		builder = append(builder, fmt.Sprintf(`
 %-35s%t
 `, "Enable minor version upgrades:", upgrade.EnableMinorVersionUpgrades()))
@@ -172,6 +192,7 @@ func formatClassicUpgrade(upgrade *cmv1.UpgradePolicy, upgradeState *cmv1.Upgrad
 		builder = append(builder, fmt.Sprintf(`
 %-35s%s
 `, "Schedule At:", upgrade.Schedule()))
+		builder = append(builder, formatNextRuns(upgrade.ScheduleType(), upgrade.Schedule(), upgrade.NextRun()))
 	}
 	if upgrade.Version() != "" {
 		builder = append(builder, fmt.Sprintf(`
@@ -181,22 +202,102 @@ func formatClassicUpgrade(upgrade *cmv1.UpgradePolicy, upgradeState *cmv1.Upgrad
 	return strings.Join(builder, "")
 }
 
+// formatNextRuns prints the upcoming occurrences of an automatic upgrade schedule, starting at its next run
+func formatNextRuns(scheduleType cmv1.ScheduleType, schedule string, nextRun time.Time) string {
+	if scheduleType != cmv1.ScheduleTypeAutomatic {
+		return ""
+	}
+	from := time.Now()
+	if !nextRun.IsZero() {
+		// The next run is itself an occurrence of the schedule, so start right before it
+		from = nextRun.Add(-time.Second)
+	}
+	runs, err := interactive.GetUpcomingUpgradeRuns(schedule, from, interactive.UpcomingUpgradeRuns)
+	if err != nil || len(runs) == 0 {
+		return ""
+	}
+	builder := make([]string, 0)
+	builder = append(builder, fmt.Sprintf("\n%-35s%s\n", "Next Runs:", interactive.FormatUpgradeRun(runs[0])))
+	for _, run := range runs[1:] {
+		builder = append(builder, fmt.Sprintf("%-35s%s\n", "", interactive.FormatUpgradeRun(run)))
+	}
+	return strings.Join(builder, "")
+}
+
+// upgradeRun holds the details of an upgrade policy that already ran
+type upgradeRun struct {
+	id           string
+	version      string
+	scheduleType cmv1.ScheduleType
+	state        *cmv1.UpgradePolicyState
+	nextRun      time.Time
+}
+
+// isUpgradeHistory returns true for upgrade policies that reached a final state
+func isUpgradeHistory(state *cmv1.UpgradePolicyState) bool {
+	switch state.Value() {
+	case cmv1.UpgradePolicyStateValueCompleted,
+		cmv1.UpgradePolicyStateValueFailed,
+		cmv1.UpgradePolicyStateValueCancelled:
+		return true
+	}
+	return false
+}
+
+// formatUpgradeHistory prints the upgrade runs that already happened, most recent first
+func formatUpgradeHistory(history []upgradeRun) string {
+	if len(history) == 0 {
+		return ""
+	}
+	sort.SliceStable(history, func(i, j int) bool {
+		return history[i].nextRun.After(history[j].nextRun)
+	})
+	var builder strings.Builder
+	builder.WriteString("\nUpgrade History:\n")
+	writer := tabwriter.NewWriter(&builder, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "ID\tVERSION\tSCHEDULE TYPE\tSTATE\tRUN AT\tDESCRIPTION\n")
+	for _, run := range history {
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			run.id,
+			run.version,
+			run.scheduleType,
+			run.state.Value(),
+			run.nextRun.Format("2006-01-02 15:04 MST"),
+			run.state.Description(),
+		)
+	}
+	writer.Flush()
+	return builder.String()
+}
+
 func describeClassicUpgrades(r *rosa.Runtime, clusterID string) error {
 	upgrades, err := r.OCMClient.GetUpgradePolicies(clusterID)
 	if err != nil {
 		return fmt.Errorf("Failed to get upgrade with cluster id '%s': %v", clusterID, err)
 	}
-	_, upgradeState, err := r.OCMClient.GetScheduledUpgrade(clusterID)
-	if err != nil {
-		return fmt.Errorf("Failed to get scheduled upgrades for cluster '%s': %v", clusterID, err)
-	}
 	if len(upgrades) < 1 {
 		r.Reporter.Infof("No scheduled upgrades for cluster id '%s'", clusterID)
 		return nil
 	}
 
+	history := make([]upgradeRun, 0)
 	for _, upgrade := range upgrades {
+		upgradeState, err := r.OCMClient.GetUpgradePolicyState(clusterID, upgrade.ID())
+		if err != nil {
+			return fmt.Errorf("Failed to get state of upgrade '%s' for cluster '%s': %v", upgrade.ID(), clusterID, err)
+		}
+		if isUpgradeHistory(upgradeState) {
+			history = append(history, upgradeRun{
+				id:           upgrade.ID(),
+				version:      upgrade.Version(),
+				scheduleType: upgrade.ScheduleType(),
+				state:        upgradeState,
+				nextRun:      upgrade.NextRun(),
+			})
+			continue
+		}
 		fmt.Print(formatClassicUpgrade(upgrade, upgradeState))
 	}
+	fmt.Print(formatUpgradeHistory(history))
 	return nil
 }
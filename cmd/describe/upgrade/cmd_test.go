@@ -10,7 +10,9 @@ import (
 	"github.com/onsi/gomega/format"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	. "github.com/openshift-online/ocm-sdk-go/testing"
+	"github.com/spf13/cobra"
 
+	"github.com/openshift/rosa/pkg/rosa"
 	"github.com/openshift/rosa/pkg/test"
 )
 
@@ -110,6 +112,99 @@ Version:                           4.12.25
 		})
 	})
 
+	Context("Automatic upgrades and history", func() {
+		var testRuntime test.TestingRuntime
+		var clusterID = "cluster1"
+		var nextRun time.Time
+
+		BeforeEach(func() {
+			testRuntime.InitRuntime()
+			var err error
+			nextRun, err = time.Parse(time.RFC3339, "2024-03-02T02:30:00Z")
+			Expect(err).To(BeNil())
+			local := time.Local
+			DeferCleanup(func() {
+				time.Local = local
+			})
+			time.Local = time.UTC
+		})
+		It("Shows the next runs of an automatic upgrade", func() {
+			upgradeState, err := cmv1.NewUpgradePolicyState().Value("scheduled").Build()
+			Expect(err).To(BeNil())
+			upgradePolicy, err := cmv1.NewUpgradePolicy().ID("id1").ClusterID("id1").
+				ScheduleType(cmv1.ScheduleTypeAutomatic).NextRun(nextRun).Schedule("30 2 * * 6").Build()
+			Expect(err).To(BeNil())
+			result := formatClassicUpgrade(upgradePolicy, upgradeState)
+			Expect(result).To(Equal(`
+ID:                                id1
+Cluster ID:                        id1
+Next Run:                          2024-03-02 02:30 UTC
+Upgrade State:                     scheduled
+
+Schedule At:                       30 2 * * 6
+
+Next Runs:                         2024-03-02 02:30 UTC
+                                   2024-03-09 02:30 UTC
+                                   2024-03-16 02:30 UTC
+                                   2024-03-23 02:30 UTC
+                                   2024-03-30 02:30 UTC
+`))
+		})
+		It("Lists prior runs of classic upgrades as history", func() {
+			scheduled, err := cmv1.NewUpgradePolicy().ID("id1").ClusterID(clusterID).
+				ScheduleType(cmv1.ScheduleTypeManual).UpgradeType(cmv1.UpgradeTypeOSD).Version("4.14.8").
+				NextRun(nextRun).Build()
+			Expect(err).To(BeNil())
+			completed, err := cmv1.NewUpgradePolicy().ID("id0").ClusterID(clusterID).
+				ScheduleType(cmv1.ScheduleTypeManual).UpgradeType(cmv1.UpgradeTypeOSD).Version("4.14.5").
+				NextRun(nextRun.AddDate(0, -1, 0)).Build()
+			Expect(err).To(BeNil())
+			testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK,
+				test.FormatUpgradePolicyList([]*cmv1.UpgradePolicy{scheduled, completed})))
+			testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK,
+				`{"value": "scheduled", "description": "Upgrade scheduled."}`))
+			testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK,
+				`{"value": "completed", "description": "Upgrade completed."}`))
+			stdout, _, err := test.RunWithOutputCapture(func(r *rosa.Runtime, _ *cobra.Command) error {
+				return describeClassicUpgrades(r, clusterID)
+			}, testRuntime.RosaRuntime, Cmd)
+			Expect(err).To(BeNil())
+			Expect(stdout).To(ContainSubstring("ID:                                id1"))
+			Expect(stdout).ToNot(ContainSubstring("ID:                                id0"))
+			Expect(stdout).To(ContainSubstring(`
+Upgrade History:
+ID   VERSION  SCHEDULE TYPE  STATE      RUN AT                DESCRIPTION
+id0  4.14.5   manual         completed  2024-02-02 02:30 UTC  Upgrade completed.
+`))
+		})
+		It("Lists prior runs of control plane upgrades as history", func() {
+			completed, err := cmv1.NewControlPlaneUpgradePolicy().ID("id0").ClusterID(clusterID).
+				ScheduleType(cmv1.ScheduleTypeAutomatic).Schedule("30 2 * * 6").Version("4.14.5").
+				State(cmv1.NewUpgradePolicyState().Value(cmv1.UpgradePolicyStateValueCompleted)).
+				NextRun(nextRun.AddDate(0, 0, -7)).Build()
+			Expect(err).To(BeNil())
+			failed, err := cmv1.NewControlPlaneUpgradePolicy().ID("id1").ClusterID(clusterID).
+				ScheduleType(cmv1.ScheduleTypeAutomatic).Schedule("30 2 * * 6").Version("4.14.8").
+				State(cmv1.NewUpgradePolicyState().Value(cmv1.UpgradePolicyStateValueFailed).
+					Description("Upgrade failed.")).
+				NextRun(nextRun).Build()
+			Expect(err).To(BeNil())
+			testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK,
+				test.FormatControlPlaneUpgradePolicyList([]*cmv1.ControlPlaneUpgradePolicy{completed, failed})))
+			args.nodePool = ""
+			stdout, _, err := test.RunWithOutputCapture(func(r *rosa.Runtime, _ *cobra.Command) error {
+				return describeHypershiftUpgrades(r, clusterID, "")
+			}, testRuntime.RosaRuntime, Cmd)
+			Expect(err).To(BeNil())
+			Expect(stdout).To(Equal(`
+Upgrade History:
+ID   VERSION  SCHEDULE TYPE  STATE      RUN AT                DESCRIPTION
+id1  4.14.8   automatic      failed     2024-03-02 02:30 UTC  Upgrade failed.
+id0  4.14.5   automatic      completed  2024-02-24 02:30 UTC  
+`))
+		})
+	})
+
 	Context("Describe Hypershift upgrade", func() {
 		var testRuntime test.TestingRuntime
 		var clusterID = "cluster1"
@@ -20,10 +20,13 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/spf13/cobra"
+	errors "github.com/zgalor/weberr"
 
+	"github.com/openshift/rosa/cmd/dlt/oidcconfig"
 	"github.com/openshift/rosa/cmd/dlt/oidcprovider"
 	"github.com/openshift/rosa/cmd/dlt/operatorrole"
 	uninstallLogs "github.com/openshift/rosa/cmd/logs/uninstall"
@@ -38,14 +41,31 @@ var args struct {
 	watch      bool
 	bestEffort bool
 	mode       string
+
+	// Wait for the cluster uninstallation and clean up the resources left in AWS
+	wait                bool
+	waitTimeout         time.Duration
+	cleanupRoles        bool
+	cleanupOidcProvider bool
+	cleanupOidcConfig   bool
 }
 
+// waitInterval is the time between two checks of the state of the uninstalling cluster
+var waitInterval = 30 * time.Second
+
+// maxWaitFailures is the number of consecutive failures to get the state of the uninstalling
+// cluster after which the wait is abandoned
+const maxWaitFailures = 5
+
 var Cmd = &cobra.Command{
 	Use:   "cluster",
 	Short: "Delete cluster",
 	Long:  "Delete cluster.",
 	Example: `  # Delete a cluster named "mycluster"
-  rosa delete cluster --cluster=mycluster`,
+  rosa delete cluster --cluster=mycluster
+
+  # Delete a cluster and, once it is uninstalled, its operator roles and OIDC provider
+  rosa delete cluster --cluster=mycluster --cleanup-roles --cleanup-oidc-provider --mode auto`,
 	Run:  run,
 	Args: cobra.NoArgs,
 }
@@ -73,31 +93,136 @@ func init() {
 		false,
 		"Watch cluster uninstallation logs.",
 	)
+
+	flags.BoolVar(
+		&args.wait,
+		"wait",
+		false,
+		"Wait for the cluster to be uninstalled.",
+	)
+
+	flags.DurationVar(
+		&args.waitTimeout,
+		"wait-timeout",
+		2*time.Hour,
+		"Maximum time to wait for the cluster to be uninstalled. Once it expires, the commands to clean up "+
+			"the resources left in AWS are printed instead.",
+	)
+
+	flags.BoolVar(
+		&args.cleanupRoles,
+		"cleanup-roles",
+		false,
+		"Once the cluster is uninstalled, delete its operator roles unless other clusters use them. "+
+			"Implies --wait.",
+	)
+
+	flags.BoolVar(
+		&args.cleanupOidcProvider,
+		"cleanup-oidc-provider",
+		false,
+		"Once the cluster is uninstalled, delete its OIDC provider unless other clusters use it. "+
+			"Implies --wait.",
+	)
+
+	flags.BoolVar(
+		&args.cleanupOidcConfig,
+		"cleanup-oidc-config",
+		false,
+		"Once the cluster is uninstalled, delete its reusable OIDC config, including its OIDC provider, "+
+			"unless other clusters use it. Implies --wait.",
+	)
+
+	interactive.AddModeFlag(Cmd)
 }
 
-func run(_ *cobra.Command, _ []string) {
+func run(cmd *cobra.Command, _ []string) {
 	r := rosa.NewRuntime().WithAWS().WithOCM()
 	defer r.Cleanup()
 
 	clusterKey := r.GetClusterKey()
 
+	cleanup := args.cleanupRoles || args.cleanupOidcProvider || args.cleanupOidcConfig
+	wait := args.wait || cleanup
+	if args.watch && wait {
+		r.Reporter.Errorf("The '--watch' option can't be used together with '--wait' or the cleanup options")
+		os.Exit(1)
+	}
+	if args.waitTimeout <= 0 {
+		r.Reporter.Errorf("The '--wait-timeout' option must be positive")
+		os.Exit(1)
+	}
+
+	mode, err := interactive.GetMode()
+	if err != nil {
+		r.Reporter.Errorf("%s", err)
+		os.Exit(1)
+	}
+
 	if args.bestEffort {
 		r.Reporter.Warnf("Deleting cluster '%s' with 'best effort' means that certain resources may be left behind"+
 			" in AWS account '%s'. These resources will need to be deleted manually.", clusterKey, r.Creator.AccountID)
 	}
 
+	if cleanup {
+		cluster := r.FetchCluster()
+		if cluster.AWS().STS().RoleARN() == "" {
+			r.Reporter.Warnf("Cluster '%s' doesn't use STS, there are no operator roles or OIDC resources "+
+				"to clean up", clusterKey)
+			cleanup = false
+		} else {
+			plan, err := buildCleanupPlan(r, cluster)
+			if err != nil {
+				r.Reporter.Errorf("%s", err)
+				os.Exit(1)
+			}
+			r.Reporter.Infof("Once cluster '%s' is uninstalled, the following resources will be cleaned up:\n%s",
+				clusterKey, plan.format())
+			if mode == "" && confirm.Yes() {
+				mode = interactive.ModeAuto
+			} else if mode == "" {
+				mode, err = interactive.GetOptionMode(cmd, interactive.ModeAuto, "Cleanup mode")
+				if err != nil {
+					r.Reporter.Errorf("Expected a valid cleanup mode: %s", err)
+					os.Exit(1)
+				}
+			}
+		}
+	}
+
 	if !confirm.Confirm("delete cluster %s", clusterKey) {
 		os.Exit(0)
 	}
 
 	cluster := r.FetchCluster()
 
-	err := handleClusterDelete(r, cluster, clusterKey, args.bestEffort)
+	err = handleClusterDelete(r, cluster, clusterKey, args.bestEffort)
 	if err != nil {
 		r.Reporter.Errorf("%s", err)
 		os.Exit(1)
 	}
 
+	if wait {
+		err = waitForUninstall(r, cluster, clusterKey, args.waitTimeout)
+		if err != nil {
+			r.Reporter.Errorf("%s", err)
+			if cleanup {
+				r.Reporter.Infof("Once the cluster is uninstalled use the following commands to remove the " +
+					"aws resources left behind:")
+				fmt.Print(buildCommands(cluster), "\n")
+			}
+			os.Exit(1)
+		}
+		if cleanup {
+			err = cleanupResources(r, cluster, mode)
+			if err != nil {
+				r.Reporter.Errorf("%s", err)
+				os.Exit(1)
+			}
+		}
+		return
+	}
+
 	if cluster.AWS().STS().RoleARN() != "" {
 		interactive.Enable()
 		r.Reporter.Infof(
@@ -162,3 +287,168 @@ func buildCommands(cluster *cmv1.Cluster) string {
 	commands = append(commands, deleteOperatorRole, deleteOIDCProvider)
 	return strings.Join(commands, "\n")
 }
+
+// waitForUninstall polls the state of the cluster until it is removed or the timeout expires. Only a
+// missing cluster ends the wait, other errors are retried a few times as the resources are deleted
+// right after it.
+func waitForUninstall(r *rosa.Runtime, cluster *cmv1.Cluster, clusterKey string, timeout time.Duration) error {
+	r.Reporter.Infof("Waiting for cluster '%s' to be uninstalled", clusterKey)
+	deadline := time.Now().Add(timeout)
+	failures := 0
+	for {
+		if time.Now().After(deadline) {
+			return fmt.Errorf("Timed out after %s waiting for cluster '%s' to be uninstalled, run "+
+				"'rosa logs uninstall -c %s' for details", timeout, clusterKey, clusterKey)
+		}
+		state, err := r.OCMClient.GetClusterState(cluster.ID())
+		if err != nil && errors.GetType(err) != errors.NotFound {
+			failures++
+			if failures >= maxWaitFailures {
+				return fmt.Errorf("Failed to get the state of cluster '%s': %v", clusterKey, err)
+			}
+			r.Reporter.Debugf("Failed to get the state of cluster '%s', retrying: %v", clusterKey, err)
+			time.Sleep(waitInterval)
+			continue
+		}
+		if err != nil || state == cmv1.ClusterState("") {
+			break
+		}
+		failures = 0
+		if state == cmv1.ClusterStateError {
+			return fmt.Errorf("Cluster '%s' failed to uninstall, run 'rosa logs uninstall -c %s' "+
+				"for details", clusterKey, clusterKey)
+		}
+		time.Sleep(waitInterval)
+	}
+	r.Reporter.Infof("Cluster '%s' completed uninstallation", clusterKey)
+	return nil
+}
+
+// cleanupPlan describes the AWS resources of a cluster that are left after its uninstallation
+type cleanupPlan struct {
+	operatorRoles       []string
+	operatorRolesPrefix string
+	operatorRolesShared bool
+	oidcEndpointURL     string
+	oidcProviderShared  bool
+	oidcConfigID        string
+	oidcConfigShared    bool
+}
+
+// buildCleanupPlan finds the resources of the cluster that can be cleaned up, the ones that other
+// clusters also use are marked as shared and kept
+func buildCleanupPlan(r *rosa.Runtime, cluster *cmv1.Cluster) (*cleanupPlan, error) {
+	sts := cluster.AWS().STS()
+	plan := &cleanupPlan{
+		operatorRolesPrefix: sts.OperatorRolePrefix(),
+		oidcEndpointURL:     sts.OIDCEndpointURL(),
+	}
+	for _, operatorIAMRole := range sts.OperatorIAMRoles() {
+		plan.operatorRoles = append(plan.operatorRoles, operatorIAMRole.RoleARN())
+	}
+	if ocm.IsOidcConfigReusable(cluster) {
+		plan.oidcConfigID = sts.OidcConfig().ID()
+	}
+
+	var err error
+	if args.cleanupRoles && plan.operatorRolesPrefix != "" {
+		plan.operatorRolesShared, err = r.OCMClient.HasOtherClusterUsingOperatorRolesPrefix(
+			plan.operatorRolesPrefix, cluster.ID())
+		if err != nil {
+			return nil, fmt.Errorf("Failed to check if other clusters use operator roles prefix '%s': %v",
+				plan.operatorRolesPrefix, err)
+		}
+	}
+	if args.cleanupOidcProvider && plan.oidcEndpointURL != "" {
+		plan.oidcProviderShared, err = r.OCMClient.HasOtherClusterUsingOidcProvider(
+			plan.oidcEndpointURL, r.Creator.AccountID, cluster.ID())
+		if err != nil {
+			return nil, fmt.Errorf("Failed to check if other clusters use OIDC provider '%s': %v",
+				plan.oidcEndpointURL, err)
+		}
+	}
+	if args.cleanupOidcConfig && plan.oidcConfigID != "" {
+		plan.oidcConfigShared, err = r.OCMClient.HasOtherClusterUsingOidcEndpointUrl(
+			plan.oidcEndpointURL, cluster.ID())
+		if err != nil {
+			return nil, fmt.Errorf("Failed to check if other clusters use OIDC config '%s': %v",
+				plan.oidcConfigID, err)
+		}
+	}
+	return plan, nil
+}
+
+func (p *cleanupPlan) format() string {
+	lines := []string{}
+	if args.cleanupRoles {
+		if p.operatorRolesShared {
+			lines = append(lines, fmt.Sprintf("Operator roles: skipped, prefix '%s' is used by other clusters",
+				p.operatorRolesPrefix))
+		} else {
+			lines = append(lines, "Operator roles:")
+			for _, role := range p.operatorRoles {
+				lines = append(lines, fmt.Sprintf(" - %s", role))
+			}
+		}
+	}
+	if args.cleanupOidcProvider {
+		if p.oidcProviderShared {
+			lines = append(lines, fmt.Sprintf("OIDC provider: skipped, '%s' is used by other clusters",
+				p.oidcEndpointURL))
+		} else {
+			lines = append(lines, fmt.Sprintf("OIDC provider: %s", p.oidcEndpointURL))
+		}
+	}
+	if args.cleanupOidcConfig {
+		switch {
+		case p.oidcConfigID == "":
+			lines = append(lines, "OIDC config: skipped, the cluster doesn't use a reusable OIDC config")
+		case p.oidcConfigShared:
+			lines = append(lines, fmt.Sprintf("OIDC config: skipped, '%s' is used by other clusters",
+				p.oidcConfigID))
+		default:
+			lines = append(lines, fmt.Sprintf("OIDC config: %s", p.oidcConfigID))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// cleanupResources deletes the resources of the uninstalled cluster with the delete commands of
+// each resource, checking again that no other cluster started using them in the meantime
+func cleanupResources(r *rosa.Runtime, cluster *cmv1.Cluster, mode string) error {
+	plan, err := buildCleanupPlan(r, cluster)
+	if err != nil {
+		return err
+	}
+	if args.cleanupRoles {
+		if plan.operatorRolesShared {
+			r.Reporter.Infof("Skipping operator roles with prefix '%s', other clusters use them",
+				plan.operatorRolesPrefix)
+		} else {
+			if ocm.IsOidcConfigReusable(cluster) {
+				operatorrole.Cmd.Flags().Set(operatorrole.PrefixFlag, plan.operatorRolesPrefix)
+			} else {
+				operatorrole.Cmd.Flags().Set("cluster", cluster.ID())
+			}
+			operatorrole.Cmd.Flags().Set(interactive.Mode, mode)
+			operatorrole.Cmd.Run(operatorrole.Cmd, []string{})
+		}
+	}
+	if args.cleanupOidcProvider {
+		if plan.oidcProviderShared {
+			r.Reporter.Infof("Skipping OIDC provider '%s', other clusters use it", plan.oidcEndpointURL)
+		} else {
+			oidcprovider.Cmd.Run(oidcprovider.Cmd, []string{"", mode, plan.oidcEndpointURL})
+		}
+	}
+	if args.cleanupOidcConfig && plan.oidcConfigID != "" {
+		if plan.oidcConfigShared {
+			r.Reporter.Infof("Skipping OIDC config '%s', other clusters use it", plan.oidcConfigID)
+		} else {
+			oidcconfig.Cmd.Flags().Set(oidcconfig.OidcConfigIdFlag, plan.oidcConfigID)
+			oidcconfig.Cmd.Flags().Set(interactive.Mode, mode)
+			oidcconfig.Cmd.Run(oidcconfig.Cmd, []string{})
+		}
+	}
+	return nil
+}
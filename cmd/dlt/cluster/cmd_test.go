@@ -0,0 +1,180 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	. "github.com/openshift-online/ocm-sdk-go/testing"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/rosa"
+	"github.com/openshift/rosa/pkg/test"
+)
+
+const (
+	oidcEndpointURL = "https://oidc.example.com/2abc"
+	noClusters      = `{"kind": "ClusterList", "page": 1, "size": 0, "total": 0, "items": []}`
+	otherCluster    = `{"kind": "ClusterList", "page": 1, "size": 1, "total": 1, "items": [` +
+		`{"kind": "Cluster", "id": "other"}]}`
+)
+
+var _ = Describe("Delete cluster", func() {
+	var testRuntime test.TestingRuntime
+
+	cluster := test.MockCluster(func(c *cmv1.ClusterBuilder) {
+		c.AWS(cmv1.NewAWS().STS(cmv1.NewSTS().
+			RoleARN("arn:aws:iam::123:role/ManagedOpenShift-Installer-Role").
+			OperatorRolePrefix("mycluster").
+			OIDCEndpointURL(oidcEndpointURL).
+			OidcConfig(cmv1.NewOidcConfig().ID("2abc").Reusable(true)).
+			OperatorIAMRoles(
+				cmv1.NewOperatorIAMRole().RoleARN("arn:aws:iam::123:role/mycluster-openshift-ingress-operator"),
+				cmv1.NewOperatorIAMRole().RoleARN("arn:aws:iam::123:role/mycluster-kube-system-capa-controller"),
+			)))
+	})
+
+	BeforeEach(func() {
+		testRuntime.InitRuntime()
+		DeferCleanup(func() {
+			args.cleanupRoles = false
+			args.cleanupOidcProvider = false
+			args.cleanupOidcConfig = false
+		})
+	})
+
+	Context("Cleanup plan", func() {
+		It("Lists the resources to clean up", func() {
+			args.cleanupRoles = true
+			args.cleanupOidcProvider = true
+			args.cleanupOidcConfig = true
+			testRuntime.ApiServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyFormKV("search", "aws.sts.operator_iam_roles.role_arn like '%/mycluster-%' "+
+						"AND id != '"+test.MockClusterID+"'"),
+					RespondWithJSON(http.StatusOK, noClusters),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyFormKV("search", "aws.sts.oidc_endpoint_url = '"+oidcEndpointURL+"' "+
+						"AND aws.sts.role_arn like '%123%' AND id != '"+test.MockClusterID+"'"),
+					RespondWithJSON(http.StatusOK, noClusters),
+				),
+				RespondWithJSON(http.StatusOK, noClusters),
+			)
+			plan, err := buildCleanupPlan(testRuntime.RosaRuntime, cluster)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(plan.format()).To(Equal("Operator roles:\n" +
+				" - arn:aws:iam::123:role/mycluster-openshift-ingress-operator\n" +
+				" - arn:aws:iam::123:role/mycluster-kube-system-capa-controller\n" +
+				"OIDC provider: " + oidcEndpointURL + "\n" +
+				"OIDC config: 2abc"))
+		})
+
+		It("Skips the resources used by other clusters", func() {
+			args.cleanupRoles = true
+			args.cleanupOidcProvider = true
+			testRuntime.ApiServer.AppendHandlers(
+				RespondWithJSON(http.StatusOK, otherCluster),
+				RespondWithJSON(http.StatusOK, otherCluster),
+			)
+			plan, err := buildCleanupPlan(testRuntime.RosaRuntime, cluster)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(plan.format()).To(Equal(
+				"Operator roles: skipped, prefix 'mycluster' is used by other clusters\n" +
+					"OIDC provider: skipped, '" + oidcEndpointURL + "' is used by other clusters"))
+		})
+
+		It("Skips the OIDC config of a cluster without a reusable one", func() {
+			args.cleanupOidcConfig = true
+			plan, err := buildCleanupPlan(testRuntime.RosaRuntime, test.MockCluster(nil))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(plan.format()).To(Equal(
+				"OIDC config: skipped, the cluster doesn't use a reusable OIDC config"))
+		})
+	})
+
+	Context("Wait for uninstall", func() {
+		BeforeEach(func() {
+			interval := waitInterval
+			waitInterval = time.Millisecond
+			DeferCleanup(func() {
+				waitInterval = interval
+			})
+		})
+
+		It("Waits until the cluster is removed", func() {
+			testRuntime.ApiServer.AppendHandlers(
+				RespondWithJSON(http.StatusOK, `{"kind": "ClusterStatus", "state": "uninstalling"}`),
+				RespondWithJSON(http.StatusOK, `{"kind": "ClusterStatus", "state": "uninstalling"}`),
+				RespondWithJSON(http.StatusNotFound, `{"kind": "Error", "id": "404", "reason": "Not found"}`),
+			)
+			stdout, _, err := test.RunWithOutputCapture(func(_ *rosa.Runtime, _ *cobra.Command) error {
+				return waitForUninstall(testRuntime.RosaRuntime, cluster, "cluster1", time.Hour)
+			}, testRuntime.RosaRuntime, Cmd)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stdout).To(Equal("INFO: Waiting for cluster 'cluster1' to be uninstalled\n" +
+				"INFO: Cluster 'cluster1' completed uninstallation\n"))
+			Expect(testRuntime.ApiServer.ReceivedRequests()).To(HaveLen(3))
+		})
+
+		It("Retries the errors other than a missing cluster", func() {
+			testRuntime.ApiServer.AppendHandlers(
+				RespondWithJSON(http.StatusInternalServerError, `{"kind": "Error", "id": "500", "reason": "Boom"}`),
+				RespondWithJSON(http.StatusOK, `{"kind": "ClusterStatus", "state": "uninstalling"}`),
+				RespondWithJSON(http.StatusNotFound, `{"kind": "Error", "id": "404", "reason": "Not found"}`),
+			)
+			err := waitForUninstall(testRuntime.RosaRuntime, cluster, "cluster1", time.Hour)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(testRuntime.ApiServer.ReceivedRequests()).To(HaveLen(3))
+		})
+
+		It("Fails when the state of the cluster keeps failing", func() {
+			testRuntime.ApiServer.RouteToHandler(http.MethodGet,
+				"/api/clusters_mgmt/v1/clusters/"+test.MockClusterID+"/status",
+				RespondWithJSON(http.StatusInternalServerError, `{"kind": "Error", "id": "500", "reason": "Boom"}`))
+			stdout, _, err := test.RunWithOutputCapture(func(_ *rosa.Runtime, _ *cobra.Command) error {
+				return waitForUninstall(testRuntime.RosaRuntime, cluster, "cluster1", time.Hour)
+			}, testRuntime.RosaRuntime, Cmd)
+			Expect(err).To(MatchError("Failed to get the state of cluster 'cluster1': Boom"))
+			Expect(stdout).ToNot(ContainSubstring("completed uninstallation"))
+		})
+
+		It("Fails when the cluster is still uninstalling at the end of the wait", func() {
+			testRuntime.ApiServer.RouteToHandler(http.MethodGet,
+				"/api/clusters_mgmt/v1/clusters/"+test.MockClusterID+"/status",
+				RespondWithJSON(http.StatusOK, `{"kind": "ClusterStatus", "state": "uninstalling"}`))
+			err := waitForUninstall(testRuntime.RosaRuntime, cluster, "cluster1", 10*time.Millisecond)
+			Expect(err).To(MatchError("Timed out after 10ms waiting for cluster 'cluster1' to be uninstalled, " +
+				"run 'rosa logs uninstall -c cluster1' for details"))
+			Expect(testRuntime.ApiServer.ReceivedRequests()).ToNot(BeEmpty())
+		})
+
+		It("Fails when the uninstallation fails", func() {
+			testRuntime.ApiServer.AppendHandlers(
+				RespondWithJSON(http.StatusOK, `{"kind": "ClusterStatus", "state": "error"}`),
+			)
+			err := waitForUninstall(testRuntime.RosaRuntime, cluster, "cluster1", time.Hour)
+			Expect(err).To(MatchError("Cluster 'cluster1' failed to uninstall, " +
+				"run 'rosa logs uninstall -c cluster1' for details"))
+		})
+	})
+})
@@ -53,6 +53,10 @@ var args struct {
 
 	// Audit log forwarding
 	auditLogRoleARN string
+
+	// Notification contacts
+	addNotificationContacts    []string
+	removeNotificationContacts []string
 }
 
 var Cmd = &cobra.Command{
@@ -147,6 +151,22 @@ func init() {
 		"",
 		"The ARN of the role that is used to forward audit logs to AWS CloudWatch.",
 	)
+
+	// Notification contacts
+	flags.StringSliceVar(
+		&args.addNotificationContacts,
+		"add-notification-contact",
+		nil,
+		"A comma-separated list of usernames or emails of the users to add to the notification "+
+			"contacts of the cluster.",
+	)
+	flags.StringSliceVar(
+		&args.removeNotificationContacts,
+		"remove-notification-contact",
+		nil,
+		"A comma-separated list of usernames or emails of the users to remove from the notification "+
+			"contacts of the cluster.",
+	)
 }
 
 func run(cmd *cobra.Command, _ []string) {
@@ -160,7 +180,8 @@ func run(cmd *cobra.Command, _ []string) {
 		changedFlags := false
 		for _, flag := range []string{"expiration-time", "expiration", "private",
 			"disable-workload-monitoring", "http-proxy", "https-proxy", "no-proxy",
-			"additional-trust-bundle-file", "audit-log-arn"} {
+			"additional-trust-bundle-file", "audit-log-arn", "add-notification-contact",
+			"remove-notification-contact"} {
 			if cmd.Flags().Changed(flag) {
 				changedFlags = true
 			}
@@ -588,6 +609,14 @@ func run(cmd *cobra.Command, _ []string) {
 		}
 	}
 
+	if len(args.addNotificationContacts) > 0 || len(args.removeNotificationContacts) > 0 {
+		err = updateNotificationContacts(r, cluster, args.addNotificationContacts, args.removeNotificationContacts)
+		if err != nil {
+			r.Reporter.Errorf("Failed to update notification contacts: %v", err)
+			os.Exit(1)
+		}
+	}
+
 	r.Reporter.Debugf("Updating cluster '%s'", clusterKey)
 	err = r.OCMClient.UpdateCluster(cluster.ID(), r.Creator, clusterConfig)
 	if err != nil {
@@ -597,6 +626,47 @@ func run(cmd *cobra.Command, _ []string) {
 	r.Reporter.Infof("Updated cluster '%s'", clusterKey)
 }
 
+// updateNotificationContacts adds and removes the notification contacts of the subscription of
+// the cluster. Contacts are given by username or email, removal looks up the matching account.
+func updateNotificationContacts(r *rosa.Runtime, cluster *cmv1.Cluster, add []string, remove []string) error {
+	subscriptionID := cluster.Subscription().ID()
+	if subscriptionID == "" {
+		return fmt.Errorf("Cluster '%s' has no subscription", cluster.Name())
+	}
+	for _, contact := range add {
+		r.Reporter.Debugf("Adding notification contact '%s'", contact)
+		_, err := r.OCMClient.AddNotificationContact(subscriptionID, contact)
+		if err != nil {
+			return fmt.Errorf("Failed to add notification contact '%s': %v", contact, err)
+		}
+	}
+	if len(remove) == 0 {
+		return nil
+	}
+	contacts, err := r.OCMClient.GetNotificationContacts(subscriptionID)
+	if err != nil {
+		return err
+	}
+	for _, contact := range remove {
+		var accountID string
+		for _, account := range contacts {
+			if account.Username() == contact || strings.EqualFold(account.Email(), contact) {
+				accountID = account.ID()
+				break
+			}
+		}
+		if accountID == "" {
+			return fmt.Errorf("User '%s' is not a notification contact of cluster '%s'", contact, cluster.Name())
+		}
+		r.Reporter.Debugf("Removing notification contact '%s'", contact)
+		err = r.OCMClient.DeleteNotificationContact(subscriptionID, accountID)
+		if err != nil {
+			return fmt.Errorf("Failed to remove notification contact '%s': %v", contact, err)
+		}
+	}
+	return nil
+}
+
 // warnUserForOAuthHCPVisibility is a method for HCP only that checks if the user has public ingress and warns them
 // about how changing cluster visibility may impact them
 func warnUserForOAuthHCPVisibility(r *rosa.Runtime, clusterKey string, cluster *cmv1.Cluster,
@@ -22,6 +22,7 @@ import (
 	"github.com/openshift/rosa/cmd/edit/addon"
 	"github.com/openshift/rosa/cmd/edit/autoscaler"
 	"github.com/openshift/rosa/cmd/edit/cluster"
+	"github.com/openshift/rosa/cmd/edit/idp"
 	"github.com/openshift/rosa/cmd/edit/ingress"
 	"github.com/openshift/rosa/cmd/edit/kubeletconfig"
 	"github.com/openshift/rosa/cmd/edit/machinepool"
@@ -49,6 +50,7 @@ func init() {
 	Cmd.AddCommand(tuningconfigs.Cmd)
 	Cmd.AddCommand(autoscaler.Cmd)
 	Cmd.AddCommand(kubeletconfig.Cmd)
+	Cmd.AddCommand(idp.Cmd)
 
 	flags := Cmd.PersistentFlags()
 	arguments.AddProfileFlag(flags)
@@ -61,6 +63,7 @@ func init() {
 		service.Cmd, cluster.Cmd,
 		ingress.Cmd, kubeletconfig.Cmd,
 		machinepool.Cmd, tuningconfigs.Cmd,
+		idp.Cmd,
 	}
 	arguments.MarkRegionDeprecated(Cmd, globallyAvailableCommands)
 }
@@ -0,0 +1,568 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package idp
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/cmd/create/idp"
+	"github.com/openshift/rosa/pkg/helper"
+	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var args struct {
+	idpName string
+
+	clientID      string
+	clientSecret  string
+	mappingMethod string
+	caPath        string
+
+	// GitHub
+	githubHostname      string
+	githubOrganizations string
+	githubTeams         string
+
+	// GitLab
+	gitlabURL string
+
+	// Google
+	googleHostedDomain string
+
+	// LDAP
+	ldapURL          string
+	ldapInsecure     bool
+	ldapBindDN       string
+	ldapBindPassword string
+	ldapIDs          string
+	ldapUsernames    string
+	ldapDisplayNames string
+	ldapEmails       string
+
+	// OpenID
+	openidIssuerURL string
+	openidEmail     string
+	openidName      string
+	openidUsername  string
+	openidGroups    string
+	openidScopes    string
+}
+
+// Flags that can be edited for each type of identity provider, in addition to the mapping method
+var idpTypeFlags = map[cmv1.IdentityProviderType][]string{
+	cmv1.IdentityProviderTypeGithub: {"client-id", "client-secret", "ca", "hostname", "organizations", "teams"},
+	cmv1.IdentityProviderTypeGitlab: {"client-id", "client-secret", "ca", "host-url"},
+	cmv1.IdentityProviderTypeGoogle: {"client-id", "client-secret", "hosted-domain"},
+	cmv1.IdentityProviderTypeLDAP: {"ca", "url", "insecure", "bind-dn", "bind-password", "id-attributes",
+		"username-attributes", "name-attributes", "email-attributes"},
+	cmv1.IdentityProviderTypeOpenID: {"client-id", "client-secret", "ca", "issuer-url", "email-claims",
+		"name-claims", "username-claims", "groups-claims", "extra-scopes"},
+	cmv1.IdentityProviderTypeHtpasswd: {},
+}
+
+var Cmd = &cobra.Command{
+	Use:     "idp",
+	Aliases: []string{"idps"},
+	Short:   "Edit IDP for cluster",
+	Long: "Edit an existing identity provider of a cluster, keeping the identities of the users that " +
+		"already logged in with it.",
+	Example: `  # Rotate the client secret of the GitHub identity provider "github-1" of a cluster named "mycluster"
+  rosa edit idp --cluster=mycluster --name=github-1 --client-secret=<new-secret>
+
+  # Restrict the GitHub identity provider "github-1" to members of a team
+  rosa edit idp --cluster=mycluster --name=github-1 --teams=myorg/myteam
+
+  # Edit an identity provider following interactive prompts
+  rosa edit idp --cluster=mycluster --name=openid-1 --interactive`,
+	Run:  run,
+	Args: cobra.NoArgs,
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	ocm.AddClusterFlag(Cmd)
+
+	flags.StringVar(
+		&args.idpName,
+		"name",
+		"",
+		"Name of the identity provider to edit.\n",
+	)
+
+	flags.StringVar(
+		&args.mappingMethod,
+		"mapping-method",
+		"",
+		fmt.Sprintf(
+			"Specifies how new identities are mapped to users when they log in. Options are %s",
+			idp.ValidMappingMethods,
+		),
+	)
+	flags.StringVar(
+		&args.clientID,
+		"client-id",
+		"",
+		"Client ID from the registered application.",
+	)
+	flags.StringVar(
+		&args.clientSecret,
+		"client-secret",
+		"",
+		"Client Secret from the registered application.",
+	)
+	flags.StringVar(
+		&args.caPath,
+		"ca",
+		"",
+		"Path to PEM-encoded certificate file to use when making requests to the server.\n",
+	)
+
+	// GitHub
+	flags.StringVar(
+		&args.githubHostname,
+		"hostname",
+		"",
+		"GitHub: Optional domain to use with a hosted instance of GitHub Enterprise.",
+	)
+	flags.StringVar(
+		&args.githubOrganizations,
+		"organizations",
+		"",
+		"GitHub: Only users that are members of at least one of the listed organizations will be allowed to log in.",
+	)
+	flags.StringVar(
+		&args.githubTeams,
+		"teams",
+		"",
+		"GitHub: Only users that are members of at least one of the listed teams will be allowed to log in. "+
+			"The format is <org>/<team>.\n",
+	)
+
+	// GitLab
+	flags.StringVar(
+		&args.gitlabURL,
+		"host-url",
+		"",
+		"GitLab: The host URL of a GitLab provider.\n",
+	)
+
+	// Google
+	flags.StringVar(
+		&args.googleHostedDomain,
+		"hosted-domain",
+		"",
+		"Google: Restrict users to a Google Apps domain.\n",
+	)
+
+	// LDAP
+	flags.StringVar(
+		&args.ldapURL,
+		"url",
+		"",
+		"LDAP: An RFC 2255 URL which specifies the LDAP search parameters to use.",
+	)
+	flags.BoolVar(
+		&args.ldapInsecure,
+		"insecure",
+		false,
+		"LDAP: Do not make TLS connections to the server.",
+	)
+	flags.StringVar(
+		&args.ldapBindDN,
+		"bind-dn",
+		"",
+		"LDAP: DN to bind with during the search phase.",
+	)
+	flags.StringVar(
+		&args.ldapBindPassword,
+		"bind-password",
+		"",
+		"LDAP: Password to bind with during the search phase.",
+	)
+	flags.StringVar(
+		&args.ldapIDs,
+		"id-attributes",
+		"",
+		"LDAP: The list of attributes whose values should be used as the user ID.",
+	)
+	flags.StringVar(
+		&args.ldapUsernames,
+		"username-attributes",
+		"",
+		"LDAP: The list of attributes whose values should be used as the preferred username.",
+	)
+	flags.StringVar(
+		&args.ldapDisplayNames,
+		"name-attributes",
+		"",
+		"LDAP: The list of attributes whose values should be used as the display name.",
+	)
+	flags.StringVar(
+		&args.ldapEmails,
+		"email-attributes",
+		"",
+		"LDAP: The list of attributes whose values should be used as the email address.\n",
+	)
+
+	// OpenID
+	flags.StringVar(
+		&args.openidIssuerURL,
+		"issuer-url",
+		"",
+		"OpenID: The URL that the OpenID Provider asserts as the Issuer Identifier. "+
+			"It must use the https scheme with no URL query parameters or fragment.",
+	)
+	flags.StringVar(
+		&args.openidEmail,
+		"email-claims",
+		"",
+		"OpenID: List of claims to use as the email address.",
+	)
+	flags.StringVar(
+		&args.openidName,
+		"name-claims",
+		"",
+		"OpenID: List of claims to use as the display name.",
+	)
+	flags.StringVar(
+		&args.openidUsername,
+		"username-claims",
+		"",
+		"OpenID: List of claims to use as the preferred username when provisioning a user.",
+	)
+	flags.StringVar(
+		&args.openidGroups,
+		"groups-claims",
+		"",
+		"OpenID: List of claims to use as the groups names.",
+	)
+	flags.StringVar(
+		&args.openidScopes,
+		"extra-scopes",
+		"",
+		"OpenID: List of scopes to request, in addition to the 'openid' scope, during the authorization token request.\n",
+	)
+}
+
+func run(cmd *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithOCM()
+	defer r.Cleanup()
+
+	err := runWithRuntime(r, cmd)
+	if err != nil {
+		r.Reporter.Errorf(err.Error())
+		os.Exit(1)
+	}
+}
+
+func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command) error {
+	clusterKey := r.GetClusterKey()
+
+	cluster := r.FetchCluster()
+	if cluster.State() != cmv1.ClusterStateReady {
+		return fmt.Errorf("Cluster '%s' is not yet ready", clusterKey)
+	}
+	if cluster.ExternalAuthConfig().Enabled() {
+		return fmt.Errorf("Editing IDP is not supported for clusters with external authentication configured.")
+	}
+
+	r.Reporter.Debugf("Loading identity providers for cluster '%s'", clusterKey)
+	idps, err := r.OCMClient.GetIdentityProviders(cluster.ID())
+	if err != nil {
+		return fmt.Errorf("Failed to get identity providers for cluster '%s': %v", clusterKey, err)
+	}
+
+	idpName := strings.TrimSpace(args.idpName)
+	if idpName == "" && interactive.Enabled() {
+		var names []string
+		for _, item := range idps {
+			names = append(names, item.Name())
+		}
+		idpName, err = interactive.GetOption(interactive.Input{
+			Question: "Identity provider name",
+			Help:     cmd.Flags().Lookup("name").Usage,
+			Options:  names,
+			Required: true,
+		})
+		if err != nil {
+			return fmt.Errorf("Expected a valid name for the identity provider: %s", err)
+		}
+	}
+	if idpName == "" {
+		return fmt.Errorf("Expected the name of the identity provider to edit with '--name'")
+	}
+
+	var current *cmv1.IdentityProvider
+	for _, item := range idps {
+		if item.Name() == idpName {
+			current = item
+		}
+	}
+	if current == nil {
+		return fmt.Errorf("Identity provider '%s' not found on cluster '%s'", idpName, clusterKey)
+	}
+
+	for _, flag := range editableFlags() {
+		if cmd.Flags().Changed(flag) && !helper.Contains(idpTypeFlags[current.Type()], flag) {
+			return fmt.Errorf("Flag '--%s' is not supported for identity providers of type '%s'",
+				flag, ocm.IdentityProviderType(current))
+		}
+	}
+	if !isEditSetViaCLI(cmd) && !interactive.Enabled() {
+		interactive.Enable()
+		r.Reporter.Infof("Enabling interactive mode")
+	}
+
+	patch, changed, err := buildIdpPatch(cmd, current)
+	if err != nil {
+		return fmt.Errorf("Failed to edit IDP '%s' of cluster '%s': %v", idpName, clusterKey, err)
+	}
+	if !changed {
+		r.Reporter.Infof("No changes to identity provider '%s' of cluster '%s'", idpName, clusterKey)
+		return nil
+	}
+
+	idpPatch, err := patch.Build()
+	if err != nil {
+		return fmt.Errorf("Failed to edit IDP '%s' of cluster '%s': %v", idpName, clusterKey, err)
+	}
+	r.Reporter.Debugf("Updating identity provider '%s' of cluster '%s'", idpName, clusterKey)
+	_, err = r.OCMClient.UpdateIdentityProvider(cluster.ID(), current.ID(), idpPatch)
+	if err != nil {
+		return fmt.Errorf("Failed to edit IDP '%s' of cluster '%s': %v", idpName, clusterKey, err)
+	}
+
+	r.Reporter.Infof("Identity provider '%s' of cluster '%s' has been updated.\n"+
+		"   It may take several minutes for the changes to become active.", idpName, clusterKey)
+	return nil
+}
+
+// buildIdpPatch builds the patch of the identity provider with only the values that differ from the
+// current ones, so secrets that are not given are kept
+func buildIdpPatch(cmd *cobra.Command, current *cmv1.IdentityProvider) (*cmv1.IdentityProviderBuilder,
+	bool, error) {
+	patch := cmv1.NewIdentityProvider()
+	changed := false
+
+	mappingMethod, err := getMappingMethod(cmd, string(current.MappingMethod()))
+	if err != nil {
+		return nil, false, err
+	}
+	if mappingMethod != string(current.MappingMethod()) {
+		patch.MappingMethod(cmv1.IdentityProviderMappingMethod(mappingMethod))
+		changed = true
+	}
+
+	switch current.Type() {
+	case cmv1.IdentityProviderTypeGithub:
+		builder, typeChanged, err := buildGithubPatch(cmd, current.Github())
+		if err != nil {
+			return nil, false, err
+		}
+		if typeChanged {
+			patch.Github(builder)
+			changed = true
+		}
+	case cmv1.IdentityProviderTypeGitlab:
+		builder, typeChanged, err := buildGitlabPatch(cmd, current.Gitlab())
+		if err != nil {
+			return nil, false, err
+		}
+		if typeChanged {
+			patch.Gitlab(builder)
+			changed = true
+		}
+	case cmv1.IdentityProviderTypeGoogle:
+		builder, typeChanged, err := buildGooglePatch(cmd, current.Google(), mappingMethod)
+		if err != nil {
+			return nil, false, err
+		}
+		if typeChanged {
+			patch.Google(builder)
+			changed = true
+		}
+	case cmv1.IdentityProviderTypeLDAP:
+		builder, typeChanged, err := buildLdapPatch(cmd, current.LDAP())
+		if err != nil {
+			return nil, false, err
+		}
+		if typeChanged {
+			patch.LDAP(builder)
+			changed = true
+		}
+	case cmv1.IdentityProviderTypeOpenID:
+		builder, typeChanged, err := buildOpenidPatch(cmd, current.OpenID())
+		if err != nil {
+			return nil, false, err
+		}
+		if typeChanged {
+			patch.OpenID(builder)
+			changed = true
+		}
+	}
+
+	return patch.Type(current.Type()), changed, nil
+}
+
+func editableFlags() []string {
+	flags := []string{}
+	for _, typeFlags := range idpTypeFlags {
+		for _, flag := range typeFlags {
+			if !helper.Contains(flags, flag) {
+				flags = append(flags, flag)
+			}
+		}
+	}
+	return flags
+}
+
+func isEditSetViaCLI(cmd *cobra.Command) bool {
+	for _, flag := range append(editableFlags(), "mapping-method") {
+		if cmd.Flags().Changed(flag) {
+			return true
+		}
+	}
+	return false
+}
+
+func getMappingMethod(cmd *cobra.Command, current string) (string, error) {
+	mappingMethod := current
+	if cmd.Flags().Changed("mapping-method") {
+		mappingMethod = args.mappingMethod
+	} else if interactive.Enabled() {
+		var err error
+		mappingMethod, err = interactive.GetOption(interactive.Input{
+			Question: "Mapping method",
+			Help:     cmd.Flags().Lookup("mapping-method").Usage,
+			Options:  idp.ValidMappingMethods,
+			Default:  current,
+			Required: true,
+		})
+		if err != nil {
+			return "", fmt.Errorf("Expected a valid mapping method: %s", err)
+		}
+	}
+	if !helper.Contains(idp.ValidMappingMethods, mappingMethod) {
+		return "", fmt.Errorf("Expected a valid mapping method. Options are %s", idp.ValidMappingMethods)
+	}
+	return mappingMethod, nil
+}
+
+// getString returns the value of the flag when it is set. Otherwise it prompts for the value in
+// interactive mode, defaulting to the current value of the identity provider.
+func getString(cmd *cobra.Command, flag string, question string, current string, required bool,
+	validators ...interactive.Validator) (string, error) {
+	value := current
+	if cmd.Flags().Changed(flag) {
+		value, _ = cmd.Flags().GetString(flag)
+	} else if interactive.Enabled() {
+		var err error
+		value, err = interactive.GetString(interactive.Input{
+			Question:   question,
+			Help:       cmd.Flags().Lookup(flag).Usage,
+			Default:    current,
+			Required:   required,
+			Validators: validators,
+		})
+		if err != nil {
+			return "", fmt.Errorf("Expected a valid value for '%s': %s", flag, err)
+		}
+	}
+	value = strings.TrimSpace(value)
+	if value == "" {
+		if required {
+			return "", fmt.Errorf("Expected a value for '%s'", flag)
+		}
+		return value, nil
+	}
+	for _, validator := range validators {
+		err := validator(value)
+		if err != nil {
+			return "", err
+		}
+	}
+	return value, nil
+}
+
+// getList works as getString for comma separated lists
+func getList(cmd *cobra.Command, flag string, question string, current []string, required bool,
+	validators ...interactive.Validator) ([]string, error) {
+	value, err := getString(cmd, flag, question, strings.Join(current, ","), required, validators...)
+	if err != nil {
+		return nil, err
+	}
+	return helper.HandleEmptyStringOnSlice(strings.Split(value, ",")), nil
+}
+
+// getSecret returns the value of the flag when it is set, or prompts for it in interactive mode. An
+// empty value keeps the current secret.
+func getSecret(cmd *cobra.Command, flag string, question string) (string, error) {
+	if cmd.Flags().Changed(flag) {
+		value, _ := cmd.Flags().GetString(flag)
+		return value, nil
+	}
+	if !interactive.Enabled() {
+		return "", nil
+	}
+	value, err := interactive.GetPassword(interactive.Input{
+		Question: question,
+		Help:     fmt.Sprintf("%s\nLeave empty to keep the current value.", cmd.Flags().Lookup(flag).Usage),
+	})
+	if err != nil {
+		return "", fmt.Errorf("Expected a valid value for '%s': %s", flag, err)
+	}
+	return value, nil
+}
+
+// getCA returns the contents of the certificate bundle given with the 'ca' flag or prompted for in
+// interactive mode. An empty value keeps the current certificate bundle.
+func getCA(cmd *cobra.Command) (string, error) {
+	caPath := args.caPath
+	if !cmd.Flags().Changed("ca") && interactive.Enabled() {
+		var err error
+		caPath, err = interactive.GetCert(interactive.Input{
+			Question: "CA file path",
+			Help: fmt.Sprintf("%s\nLeave empty to keep the current certificate bundle.",
+				cmd.Flags().Lookup("ca").Usage),
+		})
+		if err != nil {
+			return "", fmt.Errorf("Expected a valid certificate bundle: %s", err)
+		}
+	}
+	if caPath == "" {
+		return "", nil
+	}
+	cert, err := os.ReadFile(caPath)
+	if err != nil {
+		return "", fmt.Errorf("Expected a valid certificate bundle: %s", err)
+	}
+	return string(cert), nil
+}
+
+func listChanged(value []string, current []string) bool {
+	return !slices.Equal(value, current)
+}
@@ -0,0 +1,177 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package idp
+
+import (
+	"io"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	. "github.com/openshift-online/ocm-sdk-go/testing"
+	"github.com/spf13/pflag"
+
+	"github.com/openshift/rosa/pkg/test"
+)
+
+var _ = Describe("Edit idp", func() {
+	var (
+		testRuntime test.TestingRuntime
+		patch       *cmv1.IdentityProvider
+	)
+
+	mockClusterReady := test.MockCluster(func(c *cmv1.ClusterBuilder) {
+		c.State(cmv1.ClusterStateReady)
+	})
+	clusterReady := test.FormatClusterList([]*cmv1.Cluster{mockClusterReady})
+
+	githubIdp, err := cmv1.NewIdentityProvider().ID("github-id").Name("github-1").
+		Type(cmv1.IdentityProviderTypeGithub).
+		MappingMethod(cmv1.IdentityProviderMappingMethodClaim).
+		Github(cmv1.NewGithubIdentityProvider().ClientID("client").Organizations("myorg")).
+		Build()
+	Expect(err).NotTo(HaveOccurred())
+	ldapIdp, err := cmv1.NewIdentityProvider().ID("ldap-id").Name("ldap-1").
+		Type(cmv1.IdentityProviderTypeLDAP).
+		MappingMethod(cmv1.IdentityProviderMappingMethodClaim).
+		LDAP(cmv1.NewLDAPIdentityProvider().URL("ldap://ldap.example.com/ou=users").Insecure(true).
+			Attributes(cmv1.NewLDAPAttributes().ID("dn").PreferredUsername("uid"))).
+		Build()
+	Expect(err).NotTo(HaveOccurred())
+	idps := test.FormatIDPList([]*cmv1.IdentityProvider{githubIdp, ldapIdp})
+
+	respondWithPatch := func(idpID string) http.HandlerFunc {
+		return ghttp.CombineHandlers(
+			ghttp.VerifyRequest(http.MethodPatch,
+				"/api/clusters_mgmt/v1/clusters/"+test.MockClusterID+"/identity_providers/"+idpID),
+			func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				Expect(err).NotTo(HaveOccurred())
+				patch, err = cmv1.UnmarshalIdentityProvider(body)
+				Expect(err).NotTo(HaveOccurred())
+			},
+			RespondWithJSON(http.StatusOK, "{}"),
+		)
+	}
+
+	BeforeEach(func() {
+		testRuntime.InitRuntime()
+		patch = nil
+		// Reset flags to avoid any side effect on other tests
+		Cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+			if flag.Name == "cluster" {
+				return
+			}
+			flag.Value.Set(flag.DefValue)
+			flag.Changed = false
+		})
+	})
+
+	It("Fails if the identity provider does not exist", func() {
+		Cmd.Flags().Set("name", "openid-1")
+		Cmd.Flags().Set("client-secret", "secret")
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, clusterReady))
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, idps))
+		_, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+		Expect(err).To(MatchError("Identity provider 'openid-1' not found on cluster 'cluster1'"))
+	})
+
+	It("Fails with a flag of another type of identity provider", func() {
+		Cmd.Flags().Set("name", "github-1")
+		Cmd.Flags().Set("bind-dn", "cn=admin")
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, clusterReady))
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, idps))
+		_, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+		Expect(err).To(MatchError("Flag '--bind-dn' is not supported for identity providers of type 'GitHub'"))
+	})
+
+	It("Rotates the client secret of a GitHub identity provider", func() {
+		Cmd.Flags().Set("name", "github-1")
+		Cmd.Flags().Set("client-secret", "new-secret")
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, clusterReady))
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, idps))
+		testRuntime.ApiServer.AppendHandlers(respondWithPatch("github-id"))
+		stdout, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stdout).To(ContainSubstring("Identity provider 'github-1' of cluster 'cluster1' has been updated"))
+		Expect(patch.Type()).To(Equal(cmv1.IdentityProviderTypeGithub))
+		_, ok := patch.GetMappingMethod()
+		Expect(ok).To(BeFalse())
+		Expect(patch.Github().ClientSecret()).To(Equal("new-secret"))
+		_, ok = patch.Github().GetClientID()
+		Expect(ok).To(BeFalse())
+		_, ok = patch.Github().GetOrganizations()
+		Expect(ok).To(BeFalse())
+	})
+
+	It("Switches a GitHub identity provider from organizations to teams", func() {
+		Cmd.Flags().Set("name", "github-1")
+		Cmd.Flags().Set("teams", "myorg/team1,myorg/team2")
+		Cmd.Flags().Set("mapping-method", "lookup")
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, clusterReady))
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, idps))
+		testRuntime.ApiServer.AppendHandlers(respondWithPatch("github-id"))
+		_, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(patch.MappingMethod()).To(Equal(cmv1.IdentityProviderMappingMethodLookup))
+		Expect(patch.Github().Teams()).To(Equal([]string{"myorg/team1", "myorg/team2"}))
+		organizations, ok := patch.Github().GetOrganizations()
+		Expect(ok).To(BeTrue())
+		Expect(organizations).To(BeEmpty())
+	})
+
+	It("Fails with an invalid GitHub team", func() {
+		Cmd.Flags().Set("name", "github-1")
+		Cmd.Flags().Set("teams", "team1")
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, clusterReady))
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, idps))
+		_, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+		Expect(err).To(MatchError(ContainSubstring("Expected a GitHub team to follow the form '<org>/<team>'")))
+	})
+
+	It("Updates the bind credentials and attributes of an LDAP identity provider", func() {
+		Cmd.Flags().Set("name", "ldap-1")
+		Cmd.Flags().Set("bind-dn", "cn=admin")
+		Cmd.Flags().Set("bind-password", "password")
+		Cmd.Flags().Set("email-attributes", "mail")
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, clusterReady))
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, idps))
+		testRuntime.ApiServer.AppendHandlers(respondWithPatch("ldap-id"))
+		_, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(patch.LDAP().BindDN()).To(Equal("cn=admin"))
+		Expect(patch.LDAP().BindPassword()).To(Equal("password"))
+		Expect(patch.LDAP().Attributes().ID()).To(Equal([]string{"dn"}))
+		Expect(patch.LDAP().Attributes().PreferredUsername()).To(Equal([]string{"uid"}))
+		Expect(patch.LDAP().Attributes().Email()).To(Equal([]string{"mail"}))
+		_, ok := patch.LDAP().GetURL()
+		Expect(ok).To(BeFalse())
+	})
+
+	It("Does not update the identity provider without changes", func() {
+		Cmd.Flags().Set("name", "ldap-1")
+		Cmd.Flags().Set("mapping-method", "claim")
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, clusterReady))
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, idps))
+		stdout, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stdout).To(Equal("INFO: No changes to identity provider 'ldap-1' of cluster 'cluster1'\n"))
+		Expect(patch).To(BeNil())
+	})
+})
@@ -0,0 +1,124 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package idp
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/interactive"
+)
+
+func buildGithubPatch(cmd *cobra.Command,
+	current *cmv1.GithubIdentityProvider) (*cmv1.GithubIdentityProviderBuilder, bool, error) {
+	builder := cmv1.NewGithubIdentityProvider()
+	changed := false
+
+	clientID, err := getString(cmd, "client-id", "Client ID", current.ClientID(), true)
+	if err != nil {
+		return nil, false, err
+	}
+	if clientID != current.ClientID() {
+		builder.ClientID(clientID)
+		changed = true
+	}
+
+	clientSecret, err := getSecret(cmd, "client-secret", "Client Secret")
+	if err != nil {
+		return nil, false, err
+	}
+	if clientSecret != "" {
+		builder.ClientSecret(clientSecret)
+		changed = true
+	}
+
+	if cmd.Flags().Changed("organizations") && cmd.Flags().Changed("teams") {
+		return nil, false, errors.New("GitHub IDP only allows either organizations or teams, but not both")
+	}
+	restrictType := "organizations"
+	if len(current.Teams()) > 0 || cmd.Flags().Changed("teams") {
+		restrictType = "teams"
+	}
+	if cmd.Flags().Changed("organizations") {
+		restrictType = "organizations"
+	}
+	if interactive.Enabled() && !cmd.Flags().Changed("organizations") && !cmd.Flags().Changed("teams") {
+		restrictType, err = interactive.GetOption(interactive.Input{
+			Question: "Restrict to members of",
+			Help: "GitHub authentication lets you use either GitHub organizations or GitHub teams " +
+				"to restrict access.",
+			Options:  []string{"organizations", "teams"},
+			Default:  restrictType,
+			Required: true,
+		})
+		if err != nil {
+			return nil, false, fmt.Errorf("Expected a valid option: %s", err)
+		}
+	}
+	var organizations, teams []string
+	if restrictType == "organizations" {
+		organizations, err = getList(cmd, "organizations", "GitHub organizations", current.Organizations(), true)
+	} else {
+		teams, err = getList(cmd, "teams", "GitHub teams", current.Teams(), true, validateGithubTeams)
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	// Switching between organizations and teams clears the other one
+	if listChanged(organizations, current.Organizations()) || listChanged(teams, current.Teams()) {
+		builder.Organizations(organizations...).Teams(teams...)
+		changed = true
+	}
+
+	hostname, err := getString(cmd, "hostname", "GitHub Enterprise Hostname", current.Hostname(), false,
+		interactive.IsValidHostname)
+	if err != nil {
+		return nil, false, err
+	}
+	if hostname != current.Hostname() {
+		builder.Hostname(hostname)
+		changed = true
+	}
+	if hostname == "" && cmd.Flags().Changed("ca") {
+		return nil, false, errors.New("CA is not expected when not using a hosted instance of Github Enterprise")
+	}
+	if hostname != "" {
+		ca, err := getCA(cmd)
+		if err != nil {
+			return nil, false, err
+		}
+		if ca != "" {
+			builder.CA(ca)
+			changed = true
+		}
+	}
+
+	return builder, changed, nil
+}
+
+func validateGithubTeams(val interface{}) error {
+	for _, team := range strings.Split(fmt.Sprintf("%v", val), ",") {
+		if len(strings.Split(team, "/")) != 2 {
+			return fmt.Errorf("Expected a GitHub team to follow the form '<org>/<team>'")
+		}
+	}
+	return nil
+}
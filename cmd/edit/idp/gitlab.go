@@ -0,0 +1,68 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package idp
+
+import (
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/cmd/create/idp"
+)
+
+func buildGitlabPatch(cmd *cobra.Command,
+	current *cmv1.GitlabIdentityProvider) (*cmv1.GitlabIdentityProviderBuilder, bool, error) {
+	builder := cmv1.NewGitlabIdentityProvider()
+	changed := false
+
+	gitlabURL, err := getString(cmd, "host-url", "URL", current.URL(), true, idp.ValidateGitlabHostURL)
+	if err != nil {
+		return nil, false, err
+	}
+	if gitlabURL != current.URL() {
+		builder.URL(gitlabURL)
+		changed = true
+	}
+
+	clientID, err := getString(cmd, "client-id", "Application ID", current.ClientID(), true)
+	if err != nil {
+		return nil, false, err
+	}
+	if clientID != current.ClientID() {
+		builder.ClientID(clientID)
+		changed = true
+	}
+
+	clientSecret, err := getSecret(cmd, "client-secret", "Secret")
+	if err != nil {
+		return nil, false, err
+	}
+	if clientSecret != "" {
+		builder.ClientSecret(clientSecret)
+		changed = true
+	}
+
+	ca, err := getCA(cmd)
+	if err != nil {
+		return nil, false, err
+	}
+	if ca != "" {
+		builder.CA(ca)
+		changed = true
+	}
+
+	return builder, changed, nil
+}
@@ -0,0 +1,61 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package idp
+
+import (
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/cmd/create/idp"
+)
+
+func buildGooglePatch(cmd *cobra.Command, current *cmv1.GoogleIdentityProvider,
+	mappingMethod string) (*cmv1.GoogleIdentityProviderBuilder, bool, error) {
+	builder := cmv1.NewGoogleIdentityProvider()
+	changed := false
+
+	clientID, err := getString(cmd, "client-id", "Client ID", current.ClientID(), true)
+	if err != nil {
+		return nil, false, err
+	}
+	if clientID != current.ClientID() {
+		builder.ClientID(clientID)
+		changed = true
+	}
+
+	clientSecret, err := getSecret(cmd, "client-secret", "Client Secret")
+	if err != nil {
+		return nil, false, err
+	}
+	if clientSecret != "" {
+		builder.ClientSecret(clientSecret)
+		changed = true
+	}
+
+	// The hosted domain is only optional with the lookup mapping method
+	hostedDomain, err := getString(cmd, "hosted-domain", "Hosted domain", current.HostedDomain(),
+		mappingMethod != "lookup", idp.ValidateGoogleHostedDomain)
+	if err != nil {
+		return nil, false, err
+	}
+	if hostedDomain != current.HostedDomain() {
+		builder.HostedDomain(hostedDomain)
+		changed = true
+	}
+
+	return builder, changed, nil
+}
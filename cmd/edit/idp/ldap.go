@@ -0,0 +1,131 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package idp
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/cmd/create/idp"
+	"github.com/openshift/rosa/pkg/interactive"
+)
+
+func buildLdapPatch(cmd *cobra.Command,
+	current *cmv1.LDAPIdentityProvider) (*cmv1.LDAPIdentityProviderBuilder, bool, error) {
+	builder := cmv1.NewLDAPIdentityProvider()
+	changed := false
+
+	ldapURL, err := getString(cmd, "url", "LDAP URL", current.URL(), true, idp.ValidateLdapURL)
+	if err != nil {
+		return nil, false, err
+	}
+	if ldapURL != current.URL() {
+		builder.URL(ldapURL)
+		changed = true
+	}
+
+	needsSecure := strings.HasPrefix(ldapURL, "ldaps")
+	ldapInsecure := current.Insecure()
+	if cmd.Flags().Changed("insecure") {
+		ldapInsecure = args.ldapInsecure
+	} else if interactive.Enabled() && !needsSecure {
+		ldapInsecure, err = interactive.GetBool(interactive.Input{
+			Question: "Insecure",
+			Help:     cmd.Flags().Lookup("insecure").Usage,
+			Default:  ldapInsecure,
+		})
+		if err != nil {
+			return nil, false, fmt.Errorf("Expected a valid insecure value: %s", err)
+		}
+	}
+	if needsSecure && ldapInsecure {
+		return nil, false, errors.New("Cannot use insecure connection on ldaps URLs")
+	}
+	if ldapInsecure != current.Insecure() {
+		builder.Insecure(ldapInsecure)
+		changed = true
+	}
+
+	if ldapInsecure && cmd.Flags().Changed("ca") {
+		return nil, false, errors.New("Cannot use certificate bundle with an insecure connection")
+	}
+	if !ldapInsecure {
+		ca, err := getCA(cmd)
+		if err != nil {
+			return nil, false, err
+		}
+		if ca != "" {
+			builder.CA(ca)
+			changed = true
+		}
+	}
+
+	bindDN, err := getString(cmd, "bind-dn", "Bind DN", current.BindDN(), false)
+	if err != nil {
+		return nil, false, err
+	}
+	if bindDN != current.BindDN() {
+		builder.BindDN(bindDN)
+		changed = true
+	}
+	if bindDN != "" {
+		bindPassword, err := getSecret(cmd, "bind-password", "Bind password")
+		if err != nil {
+			return nil, false, err
+		}
+		if bindPassword != "" {
+			builder.BindPassword(bindPassword)
+			changed = true
+		}
+	} else if cmd.Flags().Changed("bind-password") {
+		return nil, false, errors.New("Cannot use a bind password without a bind DN")
+	}
+
+	attributes := current.Attributes()
+	ids, err := getList(cmd, "id-attributes", "ID", attributes.ID(), true)
+	if err != nil {
+		return nil, false, err
+	}
+	usernames, err := getList(cmd, "username-attributes", "Preferred username",
+		attributes.PreferredUsername(), false)
+	if err != nil {
+		return nil, false, err
+	}
+	names, err := getList(cmd, "name-attributes", "Name", attributes.Name(), false)
+	if err != nil {
+		return nil, false, err
+	}
+	emails, err := getList(cmd, "email-attributes", "Email", attributes.Email(), false)
+	if err != nil {
+		return nil, false, err
+	}
+	if listChanged(ids, attributes.ID()) || listChanged(usernames, attributes.PreferredUsername()) ||
+		listChanged(names, attributes.Name()) || listChanged(emails, attributes.Email()) {
+		builder.Attributes(cmv1.NewLDAPAttributes().
+			ID(ids...).
+			PreferredUsername(usernames...).
+			Name(names...).
+			Email(emails...))
+		changed = true
+	}
+
+	return builder, changed, nil
+}
@@ -0,0 +1,111 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package idp
+
+import (
+	"errors"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/cmd/create/idp"
+)
+
+func buildOpenidPatch(cmd *cobra.Command,
+	current *cmv1.OpenIDIdentityProvider) (*cmv1.OpenIDIdentityProviderBuilder, bool, error) {
+	builder := cmv1.NewOpenIDIdentityProvider()
+	changed := false
+
+	clientID, err := getString(cmd, "client-id", "Client ID", current.ClientID(), true)
+	if err != nil {
+		return nil, false, err
+	}
+	if clientID != current.ClientID() {
+		builder.ClientID(clientID)
+		changed = true
+	}
+
+	clientSecret, err := getSecret(cmd, "client-secret", "Client Secret")
+	if err != nil {
+		return nil, false, err
+	}
+	if clientSecret != "" {
+		builder.ClientSecret(clientSecret)
+		changed = true
+	}
+
+	issuerURL, err := getString(cmd, "issuer-url", "Issuer URL", current.Issuer(), true,
+		idp.ValidateOpenidIssuerURL)
+	if err != nil {
+		return nil, false, err
+	}
+	if issuerURL != current.Issuer() {
+		builder.Issuer(issuerURL)
+		changed = true
+	}
+
+	ca, err := getCA(cmd)
+	if err != nil {
+		return nil, false, err
+	}
+	if ca != "" {
+		builder.CA(ca)
+		changed = true
+	}
+
+	claims := current.Claims()
+	emails, err := getList(cmd, "email-claims", "Email", claims.Email(), false)
+	if err != nil {
+		return nil, false, err
+	}
+	names, err := getList(cmd, "name-claims", "Name", claims.Name(), false)
+	if err != nil {
+		return nil, false, err
+	}
+	usernames, err := getList(cmd, "username-claims", "Preferred username", claims.PreferredUsername(), false)
+	if err != nil {
+		return nil, false, err
+	}
+	groups, err := getList(cmd, "groups-claims", "Groups", claims.Groups(), false)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(emails) == 0 && len(names) == 0 && len(usernames) == 0 && len(groups) == 0 {
+		return nil, false, errors.New("At least one claim is required: [email-claims name-claims " +
+			"username-claims groups-claims]")
+	}
+	if listChanged(emails, claims.Email()) || listChanged(names, claims.Name()) ||
+		listChanged(usernames, claims.PreferredUsername()) || listChanged(groups, claims.Groups()) {
+		builder.Claims(cmv1.NewOpenIDClaims().
+			Email(emails...).
+			Name(names...).
+			PreferredUsername(usernames...).
+			Groups(groups...))
+		changed = true
+	}
+
+	scopes, err := getList(cmd, "extra-scopes", "Extra scopes", current.ExtraScopes(), false)
+	if err != nil {
+		return nil, false, err
+	}
+	if listChanged(scopes, current.ExtraScopes()) {
+		builder.ExtraScopes(scopes...)
+		changed = true
+	}
+
+	return builder, changed, nil
+}
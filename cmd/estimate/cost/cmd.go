@@ -0,0 +1,307 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cost
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/openshift/rosa/pkg/arguments"
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/estimate"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var args struct {
+	hostedCP           bool
+	multiAZ            bool
+	instanceType       string
+	replicas           int
+	autoscalingEnabled bool
+	minReplicas        int
+	maxReplicas        int
+	diskSize           string
+	hoursPerMonth      float64
+	pricingFile        string
+	refreshPricingFrom string
+}
+
+var Cmd = &cobra.Command{
+	Use:     "cost",
+	Aliases: []string{"costs"},
+	Short:   "Estimate the cost of a cluster or machine pool",
+	Long: "Estimate the hourly and monthly cost of a cluster or of a machine pool added to an existing " +
+		"cluster. The estimate includes EC2 instances, EBS volumes, load balancers, NAT gateways and the " +
+		"ROSA service fees, using a pricing table that can be overridden with '--pricing-file'.",
+	Example: `  # Estimate the cost of a multi-AZ classic cluster with 3 m5.2xlarge compute nodes
+  rosa estimate cost --multi-az --compute-machine-type=m5.2xlarge --replicas=3
+
+  # Estimate the cost of a hosted control plane cluster with autoscaling compute nodes
+  rosa estimate cost --hosted-cp --region=us-east-2 --enable-autoscaling --min-replicas=2 --max-replicas=6
+
+  # Estimate the cost of adding a machine pool to an existing cluster
+  rosa estimate cost --cluster=mycluster --compute-machine-type=r5.xlarge --replicas=3
+
+  # Refresh the cached pricing table and use it for the estimate
+  rosa estimate cost --refresh-pricing-from=https://example.com/pricing.yaml`,
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+const (
+	instanceTypeFlag = "compute-machine-type"
+	diskSizeFlag     = "worker-disk-size"
+	replicasFlag     = "replicas"
+)
+
+// normalizeFlags accepts the names that the machine pool commands use for the instance type and disk
+// size flags
+func normalizeFlags(flags *pflag.FlagSet, name string) pflag.NormalizedName {
+	switch name {
+	case "instance-type":
+		name = instanceTypeFlag
+	case "disk-size":
+		name = diskSizeFlag
+	}
+	return arguments.NormalizeFlags(flags, name)
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SetNormalizeFunc(normalizeFlags)
+
+	ocm.AddOptionalClusterFlag(Cmd)
+
+	flags.BoolVar(
+		&args.hostedCP,
+		"hosted-cp",
+		false,
+		"Estimate a cluster with a hosted control plane.",
+	)
+
+	flags.BoolVar(
+		&args.multiAZ,
+		"multi-az",
+		false,
+		"Estimate a cluster deployed to multiple availability zones.",
+	)
+
+	flags.StringVar(
+		&args.instanceType,
+		instanceTypeFlag,
+		"m5.xlarge",
+		"Instance type for the compute nodes or the machine pool. Can also be set with '--instance-type'.",
+	)
+
+	flags.IntVar(
+		&args.replicas,
+		replicasFlag,
+		0,
+		"Number of compute nodes. Defaults to 2, or 3 for multi-AZ classic clusters.",
+	)
+
+	flags.BoolVar(
+		&args.autoscalingEnabled,
+		"enable-autoscaling",
+		false,
+		"Estimate autoscaling compute nodes, between '--min-replicas' and '--max-replicas'.",
+	)
+
+	flags.IntVar(
+		&args.minReplicas,
+		"min-replicas",
+		0,
+		"Minimum number of compute nodes.",
+	)
+
+	flags.IntVar(
+		&args.maxReplicas,
+		"max-replicas",
+		0,
+		"Maximum number of compute nodes.",
+	)
+
+	flags.StringVar(
+		&args.diskSize,
+		diskSizeFlag,
+		"",
+		"Root disk size of the compute nodes or the machine pool nodes, for example '300GiB'. "+
+			"Defaults to 300 GiB. Can also be set with '--disk-size'.",
+	)
+
+	flags.Float64Var(
+		&args.hoursPerMonth,
+		"hours-per-month",
+		estimate.DefaultHoursPerMonth,
+		"Number of hours used to calculate the monthly cost.",
+	)
+
+	flags.StringVar(
+		&args.pricingFile,
+		"pricing-file",
+		"",
+		fmt.Sprintf("Path to a pricing table in YAML or JSON format. Overrides the cached and bundled "+
+			"pricing tables. Can also be set with the %s environment variable.", estimate.PricingFileEnvVar),
+	)
+
+	flags.StringVar(
+		&args.refreshPricingFrom,
+		"refresh-pricing-from",
+		"",
+		"URL or path of a pricing table to validate and cache for this and later estimates.",
+	)
+
+	output.AddFlag(Cmd)
+}
+
+func run(cmd *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithOCM()
+	defer r.Cleanup()
+	err := runWithRuntime(r, cmd)
+	if err != nil {
+		r.Reporter.Errorf(err.Error())
+		os.Exit(1)
+	}
+}
+
+func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command) error {
+	if args.refreshPricingFrom != "" {
+		path, err := estimate.RefreshPricingTable(args.refreshPricingFrom)
+		if err != nil {
+			return err
+		}
+		if !output.HasFlag() {
+			r.Reporter.Infof("Saved pricing table to '%s'", path)
+		}
+	}
+
+	table, err := estimate.LoadPricingTable(args.pricingFile)
+	if err != nil {
+		return err
+	}
+
+	spec := estimate.Spec{
+		HostedCP:      args.hostedCP,
+		MultiAZ:       args.multiAZ,
+		InstanceType:  args.instanceType,
+		HoursPerMonth: args.hoursPerMonth,
+	}
+
+	if cmd.Flags().Changed("cluster") {
+		cluster := r.FetchCluster()
+		spec.Region = cluster.Region().ID()
+		spec.HostedCP = cluster.Hypershift().Enabled()
+		spec.MultiAZ = cluster.MultiAZ()
+		spec.MachinePoolOnly = true
+	} else {
+		spec.Region, err = aws.GetRegion(arguments.GetRegion())
+		if err != nil {
+			return err
+		}
+		if spec.Region == "" {
+			return fmt.Errorf("Region is not set. Use '--region' to set the region")
+		}
+	}
+
+	if args.autoscalingEnabled {
+		if cmd.Flags().Changed(replicasFlag) {
+			return fmt.Errorf("Replicas can't be set when autoscaling is enabled, " +
+				"use '--min-replicas' and '--max-replicas' instead")
+		}
+		if args.minReplicas < 1 || args.maxReplicas < args.minReplicas {
+			return fmt.Errorf("Invalid autoscaling range: '--min-replicas' must be at least 1 " +
+				"and not greater than '--max-replicas'")
+		}
+		spec.MinReplicas = args.minReplicas
+		spec.MaxReplicas = args.maxReplicas
+	} else {
+		replicas := args.replicas
+		if !cmd.Flags().Changed(replicasFlag) {
+			replicas = 2
+			if spec.MultiAZ && !spec.HostedCP {
+				replicas = 3
+			}
+		}
+		if replicas < 0 {
+			return fmt.Errorf("Invalid number of replicas: %d", replicas)
+		}
+		spec.MinReplicas = replicas
+		spec.MaxReplicas = replicas
+	}
+
+	spec.DiskSizeGiB, err = ocm.ParseDiskSizeToGigibyte(args.diskSize)
+	if err != nil {
+		return fmt.Errorf("Invalid disk size: %v", err)
+	}
+
+	r.Reporter.Debugf("Fetching instance types")
+	machineTypes, err := r.OCMClient.GetAvailableMachineTypes()
+	if err != nil {
+		return fmt.Errorf("Failed to fetch instance types: %v", err)
+	}
+	machineType := machineTypes.Find(spec.InstanceType)
+	if machineType == nil {
+		return fmt.Errorf("Instance type '%s' is not supported", spec.InstanceType)
+	}
+	spec.InstanceVCPU = int(machineType.MachineType.CPU().Value())
+
+	result, err := estimate.Calculate(spec, table)
+	if err != nil {
+		return err
+	}
+
+	if output.HasFlag() {
+		return output.Print(result)
+	}
+
+	r.Reporter.Infof("Estimated cost in region '%s' using %s prices as of %s",
+		result.Region, result.Currency, result.EffectiveDate)
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "CATEGORY\tDESCRIPTION\tQUANTITY\tUNIT PRICE\tHOURLY\tMONTHLY\n")
+	for _, item := range result.Items {
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%.4f/%s\t%s\t%s\n",
+			item.Category,
+			item.Description,
+			formatRange("%g", item.MinQuantity, item.MaxQuantity),
+			item.UnitPrice,
+			item.Unit,
+			formatRange("%.2f", item.MinHourly, item.MaxHourly),
+			formatRange("%.2f", item.MinHourly*result.HoursPerMonth, item.MaxHourly*result.HoursPerMonth),
+		)
+	}
+	fmt.Fprintf(writer, "TOTAL\t\t\t\t%s\t%s\n",
+		formatRange("%.2f", result.MinHourly, result.MaxHourly),
+		formatRange("%.2f", result.MinMonthly, result.MaxMonthly),
+	)
+	writer.Flush()
+	r.Reporter.Infof("Estimates use on-demand prices and exclude data transfer, snapshots, support and taxes")
+
+	return nil
+}
+
+func formatRange(format string, min float64, max float64) string {
+	if min == max {
+		return fmt.Sprintf(format, min)
+	}
+	return fmt.Sprintf(format+"-"+format, min, max)
+}
@@ -0,0 +1,118 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cost
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	. "github.com/openshift-online/ocm-sdk-go/testing"
+	"github.com/spf13/pflag"
+
+	"github.com/openshift/rosa/pkg/estimate"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/test"
+)
+
+const (
+	pricing = `currency: USD
+effective_date: "2024-06-01"
+rosa:
+  worker_vcpu_hour: 0.171
+  hosted_control_plane_hour: 0.25
+regions:
+  us-east-1:
+    ebs_gp3_gib_month: 0.08
+    load_balancer_hour: 0.0225
+    nat_gateway_hour: 0.045
+    instances:
+      m5.xlarge: 0.192
+      r5.xlarge: 0.252
+`
+	machineTypes = `{"kind": "MachineTypeList", "page": 1, "size": 1, "total": 1, "items": [` +
+		`{"kind": "MachineType", "id": "r5.xlarge", "category": "memory_optimized", ` +
+		`"cpu": {"value": 4, "unit": "vCPU"}, "memory": {"value": 34359738368, "unit": "B"}}]}`
+	currentAccount = `{"kind": "Account", "id": "acc1", "organization": {"kind": "Organization", "id": "org1"}}`
+	noQuotaCosts   = `{"kind": "QuotaCostList", "page": 1, "size": 0, "total": 0, "items": []}`
+)
+
+var _ = Describe("Estimate cost", func() {
+	var testRuntime test.TestingRuntime
+
+	BeforeEach(func() {
+		testRuntime.InitRuntime()
+		pricingFile := filepath.Join(GinkgoT().TempDir(), "pricing.yaml")
+		Expect(os.WriteFile(pricingFile, []byte(pricing), 0600)).To(Succeed())
+		GinkgoT().Setenv(estimate.PricingFileEnvVar, pricingFile)
+		GinkgoT().Setenv("AWS_REGION", "us-east-1")
+		// Reset flags to avoid any side effect on other tests
+		Cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+			flag.Value.Set(flag.DefValue)
+			flag.Changed = false
+		})
+		output.SetOutput("")
+	})
+
+	It("Accepts the machine pool names of the instance type and disk size flags", func() {
+		Expect(Cmd.ParseFlags([]string{"--instance-type=r5.xlarge", "--disk-size=500GiB"})).To(Succeed())
+		Expect(args.instanceType).To(Equal("r5.xlarge"))
+		Expect(args.diskSize).To(Equal("500GiB"))
+		Expect(Cmd.Flags().Changed(instanceTypeFlag)).To(BeTrue())
+	})
+
+	It("Rejects replicas together with autoscaling", func() {
+		Expect(Cmd.ParseFlags([]string{"--enable-autoscaling", "--min-replicas=2", "--max-replicas=4",
+			"--replicas=3"})).To(Succeed())
+		_, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+		Expect(err).To(MatchError("Replicas can't be set when autoscaling is enabled, " +
+			"use '--min-replicas' and '--max-replicas' instead"))
+	})
+
+	It("Estimates a machine pool of an existing cluster", func() {
+		cluster := test.MockCluster(func(c *cmv1.ClusterBuilder) {
+			c.Region(cmv1.NewCloudRegion().ID("us-east-1"))
+			c.State(cmv1.ClusterStateReady)
+		})
+		Expect(Cmd.ParseFlags([]string{"--cluster=cluster1", "--instance-type=r5.xlarge", "--replicas=3",
+			"--disk-size=500GiB", "-o", "json"})).To(Succeed())
+		testRuntime.ApiServer.AppendHandlers(
+			RespondWithJSON(http.StatusOK, test.FormatClusterList([]*cmv1.Cluster{cluster})),
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodGet, "/api/clusters_mgmt/v1/machine_types"),
+				RespondWithJSON(http.StatusOK, machineTypes),
+			),
+			RespondWithJSON(http.StatusOK, currentAccount),
+			RespondWithJSON(http.StatusOK, noQuotaCosts),
+		)
+		stdout, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+		Expect(err).NotTo(HaveOccurred())
+		result := estimate.Estimate{}
+		Expect(json.Unmarshal([]byte(stdout), &result)).To(Succeed())
+		Expect(result.Region).To(Equal("us-east-1"))
+		Expect(result.Items).To(HaveLen(3))
+		Expect(result.Items[0].Description).To(Equal("Worker nodes (r5.xlarge)"))
+		Expect(result.Items[0].MinQuantity).To(BeEquivalentTo(3))
+		Expect(result.Items[1].Description).To(Equal("Worker root volumes (500 GiB gp3)"))
+		Expect(result.Items[2].Description).To(Equal("Worker service fee (4 vCPU per node)"))
+	})
+})
@@ -0,0 +1,120 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package billingaccounts
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	amsv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var Cmd = &cobra.Command{
+	Use:     "billing-accounts",
+	Aliases: []string{"billing-account", "billingaccounts", "billingaccount"},
+	Short:   "List AWS billing accounts",
+	Long: "List the AWS billing accounts linked to the organization through the AWS marketplace, " +
+		"with the dimensions of their contracts.",
+	Example: `  # List the AWS billing accounts of the organization
+  rosa list billing-accounts`,
+	Run:  run,
+	Args: cobra.NoArgs,
+}
+
+func init() {
+	output.AddFlag(Cmd)
+}
+
+func run(cmd *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithOCM()
+	defer r.Cleanup()
+	err := runWithRuntime(r, cmd)
+	if err != nil {
+		r.Reporter.Errorf(err.Error())
+		os.Exit(1)
+	}
+}
+
+func runWithRuntime(r *rosa.Runtime, _ *cobra.Command) error {
+	r.Reporter.Debugf("Loading billing accounts of the organization")
+	cloudAccounts, err := r.OCMClient.GetCloudAccounts()
+	if err != nil {
+		return fmt.Errorf("Failed to get billing accounts: %v", err)
+	}
+	cloudAccounts = uniqueAWSAccounts(cloudAccounts)
+
+	if output.HasFlag() {
+		return output.Print(cloudAccounts)
+	}
+	if len(cloudAccounts) == 0 {
+		r.Reporter.Infof("There are no billing accounts linked to the organization. Go to " +
+			"https://console.aws.amazon.com/rosa/home#/get-started to enable ROSA with HCP " +
+			"for your intended billing account.")
+		return nil
+	}
+	printBillingAccounts(os.Stdout, cloudAccounts)
+	return nil
+}
+
+// uniqueAWSAccounts keeps the AWS accounts, which are returned once per quota they are linked to
+func uniqueAWSAccounts(cloudAccounts []*amsv1.CloudAccount) []*amsv1.CloudAccount {
+	result := []*amsv1.CloudAccount{}
+	seen := map[string]bool{}
+	for _, account := range cloudAccounts {
+		if account.CloudProviderID() != "aws" || seen[account.CloudAccountID()] {
+			continue
+		}
+		seen[account.CloudAccountID()] = true
+		result = append(result, account)
+	}
+	return result
+}
+
+func printBillingAccounts(w io.Writer, cloudAccounts []*amsv1.CloudAccount) {
+	format := "Jan 02, 2006"
+	writer := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "ACCOUNT ID\tCONTRACT ENABLED\tSTART DATE\tEND DATE\tVCPUS\tCLUSTERS\n")
+	for _, account := range cloudAccounts {
+		if len(account.Contracts()) == 0 {
+			fmt.Fprintf(writer, "%s\tNo\t-\t-\t-\t-\n", account.CloudAccountID())
+			continue
+		}
+		for _, contract := range account.Contracts() {
+			numberOfVCPUs, numberOfClusters := ocm.GetNumsOfVCPUsAndClusters(contract.Dimensions())
+			enabled := "No"
+			if numberOfVCPUs > 0 || numberOfClusters > 0 {
+				enabled = "Yes"
+			}
+			fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%d\t%d\n",
+				account.CloudAccountID(),
+				enabled,
+				contract.StartDate().Format(format),
+				contract.EndDate().Format(format),
+				numberOfVCPUs,
+				numberOfClusters,
+			)
+		}
+	}
+	writer.Flush()
+}
@@ -0,0 +1,80 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package billingaccounts
+
+import (
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	amsv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
+	. "github.com/openshift-online/ocm-sdk-go/testing"
+
+	"github.com/openshift/rosa/pkg/test"
+)
+
+const currentAccount = `{"kind": "Account", "id": "acc1", "username": "jdoe", ` +
+	`"organization": {"kind": "Organization", "id": "org1"}}`
+
+var _ = Describe("List billing accounts", func() {
+	var testRuntime test.TestingRuntime
+
+	BeforeEach(func() {
+		testRuntime.InitRuntime()
+	})
+
+	It("Lists the billing accounts with their contracts", func() {
+		startDate, err := time.Parse(time.RFC3339, "2024-01-01T00:00:00Z")
+		Expect(err).ToNot(HaveOccurred())
+		endDate, err := time.Parse(time.RFC3339, "2025-01-01T00:00:00Z")
+		Expect(err).ToNot(HaveOccurred())
+		withContract := amsv1.NewCloudAccount().CloudAccountID("123456789012").CloudProviderID("aws").
+			Contracts(amsv1.NewContract().StartDate(startDate).EndDate(endDate).Dimensions(
+				amsv1.NewContractDimension().Name("four_vcpu_hour").Value("64"),
+				amsv1.NewContractDimension().Name("control_plane").Value("4"),
+			))
+		withoutContract := amsv1.NewCloudAccount().CloudAccountID("210987654321").CloudProviderID("aws")
+		quotaCost, err := amsv1.NewQuotaCost().QuotaID("cluster|byoc|moa|marketplace").
+			CloudAccounts(withContract, withoutContract, withContract).Build()
+		Expect(err).ToNot(HaveOccurred())
+		testRuntime.ApiServer.AppendHandlers(
+			RespondWithJSON(http.StatusOK, currentAccount),
+			ghttp.CombineHandlers(
+				ghttp.VerifyFormKV("fetchCloudAccounts", "true"),
+				RespondWithJSON(http.StatusOK, test.FormatQuotaCostList([]*amsv1.QuotaCost{quotaCost})),
+			),
+		)
+		stdout, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(stdout).To(Equal(
+			"ACCOUNT ID    CONTRACT ENABLED  START DATE    END DATE      VCPUS  CLUSTERS\n" +
+				"123456789012  Yes               Jan 01, 2024  Jan 01, 2025  64     4\n" +
+				"210987654321  No                -             -             -      -\n"))
+	})
+
+	It("Reports when no billing account is linked", func() {
+		testRuntime.ApiServer.AppendHandlers(
+			RespondWithJSON(http.StatusOK, currentAccount),
+			RespondWithJSON(http.StatusOK, test.FormatQuotaCostList([]*amsv1.QuotaCost{})),
+		)
+		stdout, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(stdout).To(ContainSubstring("INFO: There are no billing accounts linked to the organization"))
+	})
+})
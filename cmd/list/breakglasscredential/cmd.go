@@ -4,9 +4,12 @@ import (
 	"fmt"
 	"os"
 	"text/tabwriter"
+	"time"
 
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/spf13/cobra"
 
+	"github.com/openshift/rosa/pkg/breakglasscredential"
 	"github.com/openshift/rosa/pkg/externalauthprovider"
 	"github.com/openshift/rosa/pkg/ocm"
 	"github.com/openshift/rosa/pkg/output"
@@ -19,14 +22,28 @@ var Cmd = &cobra.Command{
 	Short:   "List break glass credential",
 	Long:    "List break glass credential for a cluster.",
 	Example: `  # List all break glass credentials for a cluster named 'mycluster'"
-  rosa list break-glass-credentials -c mycluster`,
+  rosa list break-glass-credentials -c mycluster
+
+  # List only the break glass credentials that can still be used
+  rosa list break-glass-credentials -c mycluster --active`,
 	Run:  run,
 	Args: cobra.NoArgs,
 }
 
+var args struct {
+	active bool
+}
+
 func init() {
 	ocm.AddClusterFlag(Cmd)
 	output.AddFlag(Cmd)
+
+	Cmd.Flags().BoolVar(
+		&args.active,
+		"active",
+		false,
+		"Only list the break glass credentials that are not expired, revoked or failed.",
+	)
 }
 
 func run(cmd *cobra.Command, _ []string) {
@@ -56,6 +73,17 @@ func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command) error {
 		return fmt.Errorf("failed to get break glass credentials for cluster '%s': %v", clusterKey, err)
 	}
 
+	now := time.Now()
+	if args.active {
+		var active []*cmv1.BreakGlassCredential
+		for _, credential := range breakGlassCredentials {
+			if breakglasscredential.IsUsable(credential, now) {
+				active = append(active, credential)
+			}
+		}
+		breakGlassCredentials = active
+	}
+
 	if output.HasFlag() {
 		err = output.Print(breakGlassCredentials)
 		if err != nil {
@@ -72,15 +100,33 @@ func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command) error {
 	// Create the writer that will be used to print the tabulated results:
 	writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
 
-	fmt.Fprintf(writer, "ID\tUSERNAME\tSTATUS\n")
+	unusable := 0
+	fmt.Fprintf(writer, "ID\tUSERNAME\tSTATUS\tEXPIRES AT\n")
 	for _, credential := range breakGlassCredentials {
-		fmt.Fprintf(writer, "%s\t%s\t%s\n",
+		if !breakglasscredential.IsUsable(credential, now) {
+			unusable++
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n",
 			credential.ID(),
 			credential.Username(),
-			credential.Status(),
+			breakglasscredential.GetStatus(credential, now),
+			formatExpiration(credential),
 		)
 	}
 	writer.Flush()
 
+	if unusable > 0 {
+		r.Reporter.Warnf("%d break glass credentials of cluster '%s' are expired, revoked or failed "+
+			"and can't be used", unusable, clusterKey)
+	}
+
 	return nil
 }
+
+func formatExpiration(credential *cmv1.BreakGlassCredential) string {
+	expiration, ok := credential.GetExpirationTimestamp()
+	if !ok || expiration.IsZero() {
+		return ""
+	}
+	return expiration.Format("Jan _2 2006 15:04:05 MST")
+}
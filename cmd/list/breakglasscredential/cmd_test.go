@@ -2,6 +2,7 @@ package breakglasscredential
 
 import (
 	"net/http"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2/dsl/core"
 	. "github.com/onsi/gomega"
@@ -28,6 +29,7 @@ var _ = Describe("list break-glass-credential", func() {
 			testRuntime.InitRuntime()
 			// Reset flag to avoid any side effect on other tests
 			Cmd.Flags().Set("output", "")
+			Cmd.Flags().Set("active", "false")
 		})
 
 		It("Warning with zero results", func() {
@@ -38,5 +40,44 @@ var _ = Describe("list break-glass-credential", func() {
 			Expect(stderr).To(Equal(""))
 			Expect(stdout).To(Equal("INFO: There are no break glass credentials for cluster 'cluster1'\n"))
 		})
+
+		It("Reports issued credentials past their expiration as expired", func() {
+			expired, err := cmv1.NewBreakGlassCredential().ID("expired").Username("username").
+				Status(cmv1.BreakGlassCredentialStatusIssued).
+				ExpirationTimestamp(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)).Build()
+			Expect(err).NotTo(HaveOccurred())
+			active, err := cmv1.NewBreakGlassCredential().ID("active").Username("username").
+				Status(cmv1.BreakGlassCredentialStatusIssued).
+				ExpirationTimestamp(time.Now().Add(time.Hour)).Build()
+			Expect(err).NotTo(HaveOccurred())
+			testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, hypershiftClusterReady))
+			testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK,
+				test.FormatBreakGlassCredentialList([]*cmv1.BreakGlassCredential{expired, active})))
+			stdout, stderr, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+			Expect(err).To(BeNil())
+			Expect(stdout).To(MatchRegexp(`expired\s+username\s+expired\s+Jan  1 2024 00:00:00 UTC`))
+			Expect(stdout).To(MatchRegexp(`active\s+username\s+issued`))
+			Expect(stderr).To(ContainSubstring("WARN: 1 break glass credentials of cluster 'cluster1' are " +
+				"expired, revoked or failed and can't be used"))
+		})
+
+		It("Lists only the active credentials", func() {
+			revoked, err := cmv1.NewBreakGlassCredential().ID("revoked").Username("username").
+				Status(cmv1.BreakGlassCredentialStatusRevoked).Build()
+			Expect(err).NotTo(HaveOccurred())
+			active, err := cmv1.NewBreakGlassCredential().ID("active").Username("username").
+				Status(cmv1.BreakGlassCredentialStatusIssued).
+				ExpirationTimestamp(time.Now().Add(time.Hour)).Build()
+			Expect(err).NotTo(HaveOccurred())
+			testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, hypershiftClusterReady))
+			testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK,
+				test.FormatBreakGlassCredentialList([]*cmv1.BreakGlassCredential{revoked, active})))
+			Cmd.Flags().Set("active", "true")
+			stdout, stderr, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+			Expect(err).To(BeNil())
+			Expect(stdout).To(MatchRegexp(`active\s+username\s+issued`))
+			Expect(stdout).NotTo(ContainSubstring("revoked"))
+			Expect(stderr).To(BeEmpty())
+		})
 	})
 })
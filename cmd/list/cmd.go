@@ -21,6 +21,7 @@ import (
 
 	"github.com/openshift/rosa/cmd/list/accountroles"
 	"github.com/openshift/rosa/cmd/list/addon"
+	"github.com/openshift/rosa/cmd/list/billingaccounts"
 	"github.com/openshift/rosa/cmd/list/breakglasscredential"
 	"github.com/openshift/rosa/cmd/list/cluster"
 	"github.com/openshift/rosa/cmd/list/dnsdomains"
@@ -34,9 +35,13 @@ import (
 	"github.com/openshift/rosa/cmd/list/oidcconfig"
 	"github.com/openshift/rosa/cmd/list/oidcprovider"
 	"github.com/openshift/rosa/cmd/list/operatorroles"
+	"github.com/openshift/rosa/cmd/list/quota"
+	"github.com/openshift/rosa/cmd/list/quotarequests"
 	"github.com/openshift/rosa/cmd/list/region"
 	"github.com/openshift/rosa/cmd/list/rhRegion"
 	"github.com/openshift/rosa/cmd/list/service"
+	"github.com/openshift/rosa/cmd/list/servicelogs"
+	"github.com/openshift/rosa/cmd/list/subscriptions"
 	"github.com/openshift/rosa/cmd/list/tuningconfigs"
 	"github.com/openshift/rosa/cmd/list/upgrade"
 	"github.com/openshift/rosa/cmd/list/user"
@@ -76,6 +81,11 @@ func init() {
 	Cmd.AddCommand(rhRegion.Cmd)
 	Cmd.AddCommand(externalauthprovider.Cmd)
 	Cmd.AddCommand(breakglasscredential.Cmd)
+	Cmd.AddCommand(quotarequests.Cmd)
+	Cmd.AddCommand(servicelogs.Cmd)
+	Cmd.AddCommand(subscriptions.Cmd)
+	Cmd.AddCommand(quota.Cmd)
+	Cmd.AddCommand(billingaccounts.Cmd)
 	flags := Cmd.PersistentFlags()
 	arguments.AddProfileFlag(flags)
 	arguments.AddRegionFlag(flags)
@@ -89,7 +99,8 @@ func init() {
 		gates.Cmd, idp.Cmd, ingress.Cmd, machinepool.Cmd,
 		operatorroles.Cmd, region.Cmd, rhRegion.Cmd,
 		service.Cmd, tuningconfigs.Cmd, upgrade.Cmd,
-		user.Cmd, version.Cmd,
+		user.Cmd, version.Cmd, servicelogs.Cmd,
+		subscriptions.Cmd, quota.Cmd, billingaccounts.Cmd,
 	}
 	arguments.MarkRegionDeprecated(Cmd, globallyAvailableCommands)
 }
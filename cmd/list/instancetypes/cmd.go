@@ -19,12 +19,16 @@ package instancetypes
 import (
 	"fmt"
 	"os"
+	"sort"
+	"strings"
 	"text/tabwriter"
 
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/spf13/cobra"
 
 	"github.com/openshift/rosa/pkg/arguments"
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/estimate"
 	"github.com/openshift/rosa/pkg/helper"
 	"github.com/openshift/rosa/pkg/interactive"
 	"github.com/openshift/rosa/pkg/interactive/confirm"
@@ -43,7 +47,14 @@ func makeCmd() *cobra.Command {
 		Short:   "List Instance types",
 		Long:    "List Instance types that are available for use with ROSA.",
 		Example: `  # List all instance types
-	rosa list instance-types`,
+	rosa list instance-types
+
+  # List the arm64 instance types with at least 8 vCPUs and 32 GiB of memory, cheapest first
+  rosa list instance-types --arch=arm64 --min-vcpu=8 --min-memory=32 --sort-by=price
+
+  # List the instance types of the m6i and r6i families and their availability in each availability zone
+  rosa list instance-types --region=us-east-1 --family=m6i,r6i \
+    --availability-zones=us-east-1a,us-east-1b,us-east-1c`,
 		Run:  run,
 		Args: cobra.NoArgs,
 	}
@@ -60,12 +71,30 @@ var args struct {
 	installerRoleArn     string
 	externalId           string
 	hostedClusterEnabled bool
+	minVCPU              int
+	minMemory            float64
+	arch                 string
+	gpu                  bool
+	families             []string
+	sortBy               string
+	onlyWithQuota        bool
+	availabilityZones    []string
+	pricingFile          string
 }
 
 const (
 	InstallerRoleArnFlag = "role-arn"
+
+	sortByPrice  = "price"
+	sortByCPU    = "cpu"
+	sortByMemory = "memory"
+
+	gib = 1 << 30
 )
 
+var validArchitectures = []string{ocm.ArchitectureAmd64, ocm.ArchitectureArm64}
+var validSortBy = []string{sortByPrice, sortByCPU, sortByMemory}
+
 func initFlags(cmd *cobra.Command) {
 	flags := cmd.Flags()
 
@@ -92,6 +121,73 @@ func initFlags(cmd *cobra.Command) {
 		"STS Role ARN with get secrets permission.",
 	)
 
+	flags.IntVar(
+		&args.minVCPU,
+		"min-vcpu",
+		0,
+		"List only the instance types with at least this number of vCPUs.",
+	)
+
+	flags.Float64Var(
+		&args.minMemory,
+		"min-memory",
+		0,
+		"List only the instance types with at least this amount of memory in GiB.",
+	)
+
+	flags.StringVar(
+		&args.arch,
+		"arch",
+		"",
+		fmt.Sprintf("List only the instance types with this CPU architecture. Options are %s.",
+			helper.SliceToSortedString(validArchitectures)),
+	)
+
+	flags.BoolVar(
+		&args.gpu,
+		"gpu",
+		false,
+		"List only the accelerated computing instance types.",
+	)
+
+	flags.StringSliceVar(
+		&args.families,
+		"family",
+		nil,
+		"List only the instance types of these families, separated by commas, e.g. 'm6i,r6i'.",
+	)
+
+	flags.StringVar(
+		&args.sortBy,
+		"sort-by",
+		"",
+		fmt.Sprintf("Sort the instance types in ascending order. Options are %s.",
+			helper.SliceToSortedString(validSortBy)),
+	)
+
+	flags.BoolVar(
+		&args.onlyWithQuota,
+		"only-with-quota",
+		false,
+		"List only the instance types the organization has enough quota for.",
+	)
+
+	flags.StringSliceVar(
+		&args.availabilityZones,
+		"availability-zones",
+		nil,
+		"Show whether the instance types are available in each of these availability zones of the region, "+
+			"separated by commas. Requires '--region'.",
+	)
+
+	flags.StringVar(
+		&args.pricingFile,
+		"pricing-file",
+		"",
+		fmt.Sprintf("Path to a pricing table in YAML or JSON format used to sort by price. Can also be set "+
+			"with the %s environment variable.", estimate.PricingFileEnvVar),
+	)
+
 	arguments.AddRegionFlag(flags)
 	output.AddFlag(cmd)
 	confirm.AddFlag(flags)
@@ -115,10 +211,104 @@ func checkInteractiveModeNeeded(cmd *cobra.Command) {
 	}
 }
 
+func validateFilters(cmd *cobra.Command) error {
+	if args.arch != "" && !helper.Contains(validArchitectures, args.arch) {
+		return fmt.Errorf("Invalid architecture '%s', expected one of %s", args.arch,
+			helper.SliceToSortedString(validArchitectures))
+	}
+	if args.sortBy != "" && !helper.Contains(validSortBy, args.sortBy) {
+		return fmt.Errorf("Invalid sort field '%s', expected one of %s", args.sortBy,
+			helper.SliceToSortedString(validSortBy))
+	}
+	if args.minVCPU < 0 || args.minMemory < 0 {
+		return fmt.Errorf("Expected non negative values for '--min-vcpu' and '--min-memory'")
+	}
+	if len(args.availabilityZones) > 0 && !cmd.Flags().Changed("region") {
+		return fmt.Errorf("Availability zones can only be checked with '--region'")
+	}
+	return nil
+}
+
+// filterMachineTypes returns the machine types matching the filters of the flags
+func filterMachineTypes(machineTypes ocm.MachineTypeList) ocm.MachineTypeList {
+	return machineTypes.Filter(func(machineType *ocm.MachineType) bool {
+		if args.onlyWithQuota && !machineType.HasQuota(false) {
+			return false
+		}
+		if args.gpu && machineType.MachineType.Category() != ocm.AcceleratedComputing {
+			return false
+		}
+		if args.arch != "" && machineType.Architecture() != args.arch {
+			return false
+		}
+		if len(args.families) > 0 {
+			family, _, _ := strings.Cut(machineType.MachineType.ID(), ".")
+			if !helper.Contains(args.families, family) {
+				return false
+			}
+		}
+		if machineType.MachineType.CPU().Value() < float64(args.minVCPU) {
+			return false
+		}
+		return memoryGiB(machineType) >= args.minMemory
+	})
+}
+
+func memoryGiB(machineType *ocm.MachineType) float64 {
+	return machineType.MachineType.Memory().Value() / gib
+}
+
+// sortMachineTypes sorts the machine types in ascending order of the sort field. Machine types without
+// a price are listed last when sorting by price.
+func sortMachineTypes(machineTypes ocm.MachineTypeList, prices map[string]float64) {
+	sort.SliceStable(machineTypes.Items, func(i, j int) bool {
+		a, b := machineTypes.Items[i], machineTypes.Items[j]
+		switch args.sortBy {
+		case sortByPrice:
+			priceA, okA := prices[a.MachineType.ID()]
+			priceB, okB := prices[b.MachineType.ID()]
+			if okA != okB {
+				return okA
+			}
+			return priceA < priceB
+		case sortByCPU:
+			return a.MachineType.CPU().Value() < b.MachineType.CPU().Value()
+		case sortByMemory:
+			return a.MachineType.Memory().Value() < b.MachineType.Memory().Value()
+		}
+		return false
+	})
+}
+
+// getInstancePrices returns the hourly prices of the instance types in the region from the pricing table
+func getInstancePrices() (map[string]float64, error) {
+	region, err := aws.GetRegion(arguments.GetRegion())
+	if err != nil {
+		return nil, err
+	}
+	if region == "" {
+		return nil, fmt.Errorf("Region is not set. Use '--region' to set the region to sort by price")
+	}
+	table, err := estimate.LoadPricingTable(args.pricingFile)
+	if err != nil {
+		return nil, err
+	}
+	regionPricing, err := table.Region(region)
+	if err != nil {
+		return nil, err
+	}
+	return regionPricing.Instances, nil
+}
+
 func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command) error {
+	err := validateFilters(cmd)
+	if err != nil {
+		return err
+	}
 	checkInteractiveModeNeeded(cmd)
 	r.Reporter.Debugf("Fetching instance types")
 	var machineTypes ocm.MachineTypeList
+	zonesAvailability := map[string][]string{}
 	if cmd.Flags().Changed("region") {
 		if interactive.Enabled() || (confirm.Yes() && args.installerRoleArn == "") {
 			args.installerRoleArn = interactiveRoles.
@@ -147,6 +337,17 @@ func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command) error {
 			return fmt.Errorf("Failed to fetch instance types: %v", err)
 		}
 		machineTypes = availableMachineTypes
+
+		for _, availabilityZone := range args.availabilityZones {
+			r.Reporter.Debugf("Fetching instance types of availability zone '%s'", availabilityZone)
+			zoneMachineTypes, err := r.OCMClient.GetAvailableMachineTypesInRegion(arguments.GetRegion(),
+				[]string{availabilityZone}, roleArn, r.AWSClient)
+			if err != nil {
+				return fmt.Errorf("Failed to fetch instance types of availability zone '%s': %v",
+					availabilityZone, err)
+			}
+			zonesAvailability[availabilityZone] = zoneMachineTypes.IDs()
+		}
 	} else {
 		availableMachineTypes, err := r.OCMClient.GetAvailableMachineTypes()
 		if err != nil {
@@ -155,6 +356,17 @@ func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command) error {
 		machineTypes = availableMachineTypes
 	}
 
+	hasMachineTypes := len(machineTypes.Items) > 0
+	machineTypes = filterMachineTypes(machineTypes)
+	var prices map[string]float64
+	if args.sortBy == sortByPrice {
+		prices, err = getInstancePrices()
+		if err != nil {
+			return err
+		}
+	}
+	sortMachineTypes(machineTypes, prices)
+
 	if output.HasFlag() {
 		var instanceTypes []*cmv1.MachineType
 		for _, machine := range machineTypes.Items {
@@ -169,12 +381,23 @@ func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command) error {
 	}
 
 	if len(machineTypes.Items) == 0 {
+		if hasMachineTypes {
+			r.Reporter.Infof("There are no instance types matching the filters")
+			return nil
+		}
 		return fmt.Errorf("There are no machine types supported for your account. Contact Red Hat support.")
 	}
 
 	// Create the writer that will be used to print the tabulated results:
 	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintf(writer, "ID\tCATEGORY\tCPU_CORES\tMEMORY\n")
+	fmt.Fprintf(writer, "ID\tCATEGORY\tCPU_CORES\tMEMORY")
+	if prices != nil {
+		fmt.Fprintf(writer, "\tPRICE_PER_HOUR")
+	}
+	for _, availabilityZone := range args.availabilityZones {
+		fmt.Fprintf(writer, "\t%s", strings.ToUpper(availabilityZone))
+	}
+	fmt.Fprintf(writer, "\n")
 
 	for _, machine := range machineTypes.Items {
 		if !machine.Available {
@@ -182,11 +405,24 @@ func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command) error {
 		}
 		availableMachine := machine.MachineType
 		fmt.Fprintf(writer,
-			"%s\t%s\t%d\t%s\n",
+			"%s\t%s\t%d\t%s",
 			availableMachine.ID(), availableMachine.Category(), int(availableMachine.CPU().Value()),
 			ByteCountIEC(int(availableMachine.Memory().Value()),
 				availableMachine.Memory().Unit()),
 		)
+		if prices != nil {
+			price, ok := prices[availableMachine.ID()]
+			if ok {
+				fmt.Fprintf(writer, "\t%.4f", price)
+			} else {
+				fmt.Fprintf(writer, "\t-")
+			}
+		}
+		for _, availabilityZone := range args.availabilityZones {
+			fmt.Fprintf(writer, "\t%s",
+				output.PrintBool(helper.Contains(zonesAvailability[availabilityZone], availableMachine.ID())))
+		}
+		fmt.Fprintf(writer, "\n")
 	}
 	writer.Flush()
 
@@ -1,7 +1,11 @@
 package instancetypes
 
 import (
+	"fmt"
 	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"go.uber.org/mock/gomock"
@@ -179,6 +183,27 @@ var _ = Describe("list instance-types", func() {
 		]
 	}
 	`
+		machineType = func(id string, category string, cpu int, memoryGiB int, genericName string) string {
+			return fmt.Sprintf(`{
+			"kind": "MachineType",
+			"id": "%s",
+			"category": "%s",
+			"memory": {"value": %d, "unit": "B"},
+			"cpu": {"value": %d, "unit": "vCPU"},
+			"cloud_provider": {"kind": "CloudProviderLink", "id": "aws"},
+			"generic_name": "%s"
+		  }`, id, category, memoryGiB<<30, cpu, genericName)
+		}
+		machineTypeList = func(items ...string) string {
+			return fmt.Sprintf(`{"kind": "MachineTypeList", "page": 1, "size": %d, "total": %d, "items": [%s]}`,
+				len(items), len(items), strings.Join(items, ","))
+		}
+		m5xlarge              = machineType("m5.xlarge", "general_purpose", 4, 16, "standard-4")
+		m6g2xlarge            = machineType("m6g.2xlarge", "general_purpose", 8, 32, "standard-8-arm")
+		r6i2xlarge            = machineType("r6i.2xlarge", "memory_optimized", 8, 64, "highmem-8")
+		g4dn12large           = machineType("g4dn.12xlarge", "accelerated_computing", 48, 192, "t4-gpu-48")
+		machinesFilterSuccess = machineTypeList(m5xlarge, m6g2xlarge, r6i2xlarge, g4dn12large)
+
 		regionSuccessOutput = `INFO: Using fake_installer_arn for the Installer role
 ID             CATEGORY               CPU_CORES  MEMORY
 g4dn.12xlarge  accelerated_computing  48         192.0 GiB
@@ -377,4 +402,88 @@ g4dn.12xlarge  accelerated_computing  48         192.0 GiB
 		Expect(stderr).To(Equal(""))
 		Expect(stdout).To(Equal(""))
 	})
+
+	Context("Filters", func() {
+		appendMachineTypes := func(machineTypes string) {
+			apiServer.AppendHandlers(
+				RespondWithJSON(http.StatusOK, machineTypes),
+				RespondWithJSON(http.StatusOK, currentAccount),
+				RespondWithJSON(http.StatusOK, orgQuota),
+			)
+		}
+
+		It("Lists the instance types of an architecture", func() {
+			cmd.Flags().Set("arch", "arm64")
+			appendMachineTypes(machinesFilterSuccess)
+			stdout, _, err := test.RunWithOutputCapture(runWithRuntime, r, cmd)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stdout).To(Equal("ID           CATEGORY         CPU_CORES  MEMORY\n" +
+				"m6g.2xlarge  general_purpose  8          32.0 GiB\n"))
+		})
+
+		It("Lists the GPU instance types", func() {
+			cmd.Flags().Set("gpu", "true")
+			cmd.Flags().Set("only-with-quota", "true")
+			appendMachineTypes(machinesFilterSuccess)
+			stdout, _, err := test.RunWithOutputCapture(runWithRuntime, r, cmd)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stdout).To(Equal("ID             CATEGORY               CPU_CORES  MEMORY\n" +
+				"g4dn.12xlarge  accelerated_computing  48         192.0 GiB\n"))
+		})
+
+		It("Filters by size and family and sorts by memory", func() {
+			cmd.Flags().Set("min-vcpu", "8")
+			cmd.Flags().Set("min-memory", "32")
+			cmd.Flags().Set("family", "r6i,m6g,m5")
+			cmd.Flags().Set("sort-by", "memory")
+			appendMachineTypes(machineTypeList(r6i2xlarge, m5xlarge, m6g2xlarge))
+			stdout, _, err := test.RunWithOutputCapture(runWithRuntime, r, cmd)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stdout).To(Equal("ID           CATEGORY          CPU_CORES  MEMORY\n" +
+				"m6g.2xlarge  general_purpose   8          32.0 GiB\n" +
+				"r6i.2xlarge  memory_optimized  8          64.0 GiB\n"))
+		})
+
+		It("Reports that no instance type matches the filters", func() {
+			cmd.Flags().Set("min-vcpu", "128")
+			appendMachineTypes(machinesFilterSuccess)
+			stdout, _, err := test.RunWithOutputCapture(runWithRuntime, r, cmd)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stdout).To(Equal("INFO: There are no instance types matching the filters\n"))
+		})
+
+		It("Fails with an invalid sort field", func() {
+			cmd.Flags().Set("sort-by", "name")
+			_, _, err := test.RunWithOutputCapture(runWithRuntime, r, cmd)
+			Expect(err).To(MatchError("Invalid sort field 'name', expected one of [cpu, price, memory]"))
+		})
+
+		It("Fails to check availability zones without a region", func() {
+			cmd.Flags().Set("availability-zones", "us-east-1a")
+			_, _, err := test.RunWithOutputCapture(runWithRuntime, r, cmd)
+			Expect(err).To(MatchError("Availability zones can only be checked with '--region'"))
+		})
+
+		It("Sorts by price and shows the availability in each availability zone", func() {
+			pricingFile := filepath.Join(GinkgoT().TempDir(), "pricing.yaml")
+			Expect(os.WriteFile(pricingFile, []byte("regions:\n  us-east-1:\n    instances:\n"+
+				"      m5.xlarge: 0.192\n      m6g.2xlarge: 0.308\n      r6i.2xlarge: 0.504\n"), 0600)).To(Succeed())
+			cmd.Flags().Set("region", "us-east-1")
+			cmd.Flags().Set("availability-zones", "us-east-1a")
+			cmd.Flags().Set("sort-by", "price")
+			cmd.Flags().Set("pricing-file", pricingFile)
+			mockAwsClient.EXPECT().FindRoleARNs(aws.InstallerAccountRole, "").Return([]string{"fake_installer_arn"}, nil)
+			apiServer.AppendHandlers(RespondWithJSON(http.StatusOK, regionsSuccess))
+			appendMachineTypes(machineTypeList(r6i2xlarge, g4dn12large, m5xlarge, m6g2xlarge))
+			appendMachineTypes(machineTypeList(m5xlarge, r6i2xlarge))
+			stdout, _, err := test.RunWithOutputCapture(runWithRuntime, r, cmd)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stdout).To(HaveSuffix(
+				"ID             CATEGORY               CPU_CORES  MEMORY     PRICE_PER_HOUR  US-EAST-1A\n" +
+					"m5.xlarge      general_purpose        4          16.0 GiB   0.1920          Yes\n" +
+					"m6g.2xlarge    general_purpose        8          32.0 GiB   0.3080          No\n" +
+					"r6i.2xlarge    memory_optimized       8          64.0 GiB   0.5040          Yes\n" +
+					"g4dn.12xlarge  accelerated_computing  48         192.0 GiB  -               No\n"))
+		})
+	})
 })
@@ -0,0 +1,131 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quota
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var Cmd = &cobra.Command{
+	Use:     "quota",
+	Aliases: []string{"quotas"},
+	Short:   "List quota of the organization",
+	Long: "List the quota of the organization and the number of resources that can still be created " +
+		"with it, such as clusters, compute nodes of each instance family and add-ons.",
+	Example: `  # List the quota of the organization
+  rosa list quota
+
+  # List the quota of the compute nodes
+  rosa list quota --resource-type compute.node`,
+	Run:  run,
+	Args: cobra.NoArgs,
+}
+
+var args struct {
+	resourceTypes []string
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	flags.StringSliceVar(
+		&args.resourceTypes,
+		"resource-type",
+		[]string{},
+		"List only the quota of the given resource types, such as 'cluster', 'compute.node' or 'add-on'.",
+	)
+	output.AddFlag(Cmd)
+}
+
+func run(cmd *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithOCM()
+	defer r.Cleanup()
+	err := runWithRuntime(r, cmd)
+	if err != nil {
+		r.Reporter.Errorf(err.Error())
+		os.Exit(1)
+	}
+}
+
+func runWithRuntime(r *rosa.Runtime, _ *cobra.Command) error {
+	r.Reporter.Debugf("Loading quota of the organization")
+	quotaCosts, err := r.OCMClient.GetQuotaCosts()
+	if err != nil {
+		return fmt.Errorf("Failed to get quota: %v", err)
+	}
+
+	usages := filterQuotaUsages(ocm.GetQuotaUsages(quotaCosts), args.resourceTypes)
+	if output.HasFlag() {
+		return output.Print(usages)
+	}
+	if len(usages) == 0 {
+		r.Reporter.Infof("There is no quota for the organization")
+		return nil
+	}
+	printQuotaUsages(os.Stdout, usages)
+	return nil
+}
+
+func filterQuotaUsages(usages []*ocm.QuotaUsage, resourceTypes []string) []*ocm.QuotaUsage {
+	if len(resourceTypes) == 0 {
+		return usages
+	}
+	result := []*ocm.QuotaUsage{}
+	for _, usage := range usages {
+		for _, resourceType := range resourceTypes {
+			if strings.EqualFold(strings.TrimSpace(resourceType), usage.ResourceType) {
+				result = append(result, usage)
+				break
+			}
+		}
+	}
+	return result
+}
+
+func printQuotaUsages(w io.Writer, usages []*ocm.QuotaUsage) {
+	writer := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "RESOURCE TYPE\tRESOURCE NAME\tBILLING MODEL\tPRODUCT\tCOST\tALLOWED\tCONSUMED\tREMAINING\n")
+	for _, usage := range usages {
+		remaining := "Unlimited"
+		if !usage.Unlimited() {
+			remaining = strconv.Itoa(usage.Remaining())
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%d\t%d\t%d\t%s\n",
+			usage.ResourceType,
+			usage.ResourceName,
+			usage.BillingModel,
+			usage.Product,
+			usage.Cost,
+			usage.Allowed,
+			usage.Consumed,
+			remaining,
+		)
+	}
+	writer.Flush()
+}
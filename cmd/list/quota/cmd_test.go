@@ -0,0 +1,117 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quota
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	amsv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
+	. "github.com/openshift-online/ocm-sdk-go/testing"
+
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/test"
+)
+
+const currentAccount = `{"kind": "Account", "id": "acc1", "username": "jdoe", ` +
+	`"organization": {"kind": "Organization", "id": "org1"}}`
+
+var _ = Describe("List quota", func() {
+	var testRuntime test.TestingRuntime
+	var quotaCosts string
+
+	BeforeEach(func() {
+		testRuntime.InitRuntime()
+		DeferCleanup(func() {
+			args.resourceTypes = []string{}
+		})
+
+		gpuCost, err := amsv1.NewQuotaCost().QuotaID("compute.node|gpu").Allowed(40).Consumed(16).
+			RelatedResources(
+				amsv1.NewRelatedResource().ResourceType("compute.node").ResourceName("gpu-8").
+					BillingModel("standard").Product("ROSA").Cost(8),
+			).Build()
+		Expect(err).ToNot(HaveOccurred())
+		clusterCost, err := amsv1.NewQuotaCost().QuotaID("cluster|byoc|moa").Consumed(2).
+			RelatedResources(
+				amsv1.NewRelatedResource().ResourceType("cluster").ResourceName("any").
+					BillingModel("marketplace").Product("ROSA").Cost(0),
+			).Build()
+		Expect(err).ToNot(HaveOccurred())
+		quotaCosts = test.FormatQuotaCostList([]*amsv1.QuotaCost{gpuCost, clusterCost})
+	})
+
+	It("Lists the quota of the organization", func() {
+		testRuntime.ApiServer.AppendHandlers(
+			RespondWithJSON(http.StatusOK, currentAccount),
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodGet, "/api/accounts_mgmt/v1/organizations/org1/quota_cost"),
+				ghttp.VerifyFormKV("fetchRelatedResources", "true"),
+				RespondWithJSON(http.StatusOK, quotaCosts),
+			),
+		)
+		stdout, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(stdout).To(Equal(
+			"RESOURCE TYPE  RESOURCE NAME  BILLING MODEL  PRODUCT  COST  ALLOWED  CONSUMED  REMAINING\n" +
+				"cluster        any            marketplace    ROSA     0     0        2         Unlimited\n" +
+				"compute.node   gpu-8          standard       ROSA     8     40       16        3\n"))
+	})
+
+	It("Lists the quota of the given resource types", func() {
+		args.resourceTypes = []string{"Compute.Node"}
+		testRuntime.ApiServer.AppendHandlers(
+			RespondWithJSON(http.StatusOK, currentAccount),
+			RespondWithJSON(http.StatusOK, quotaCosts),
+		)
+		stdout, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(stdout).To(Equal(
+			"RESOURCE TYPE  RESOURCE NAME  BILLING MODEL  PRODUCT  COST  ALLOWED  CONSUMED  REMAINING\n" +
+				"compute.node   gpu-8          standard       ROSA     8     40       16        3\n"))
+	})
+
+	It("Prints the quota of the given resource types as JSON", func() {
+		args.resourceTypes = []string{"cluster"}
+		output.SetOutput("json")
+		DeferCleanup(func() {
+			output.SetOutput("")
+		})
+		testRuntime.ApiServer.AppendHandlers(
+			RespondWithJSON(http.StatusOK, currentAccount),
+			RespondWithJSON(http.StatusOK, quotaCosts),
+		)
+		stdout, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(stdout).To(MatchJSON(`[{"quota_id": "cluster|byoc|moa", "resource_type": "cluster", ` +
+			`"resource_name": "any", "billing_model": "marketplace", "product": "ROSA", "allowed": 0, ` +
+			`"consumed": 2, "cost": 0}]`))
+	})
+
+	It("Reports when there is no quota", func() {
+		args.resourceTypes = []string{"add-on"}
+		testRuntime.ApiServer.AppendHandlers(
+			RespondWithJSON(http.StatusOK, currentAccount),
+			RespondWithJSON(http.StatusOK, quotaCosts),
+		)
+		stdout, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(stdout).To(Equal("INFO: There is no quota for the organization\n"))
+	})
+})
@@ -0,0 +1,122 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quotarequests
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var Cmd = &cobra.Command{
+	Use:     "quota-requests",
+	Aliases: []string{"quota-request", "quotarequests"},
+	Short:   "List AWS service quota increase requests",
+	Long: "List the AWS service quota increase requests filed for the quotas needed " +
+		"to create a cluster, along with their status.",
+	Example: `  # List AWS service quota increase requests
+  rosa list quota-requests
+
+  # List only the requests that AWS has not resolved yet
+  rosa list quota-requests --pending`,
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+var args struct {
+	pending bool
+}
+
+func init() {
+	flags := Cmd.Flags()
+
+	flags.BoolVar(
+		&args.pending,
+		"pending",
+		false,
+		"List only quota increase requests that are still pending or have an open support case.",
+	)
+	output.AddFlag(Cmd)
+}
+
+func run(cmd *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS()
+	defer r.Cleanup()
+	err := runWithRuntime(r, cmd)
+	if err != nil {
+		r.Reporter.Errorf(err.Error())
+		os.Exit(1)
+	}
+}
+
+func runWithRuntime(r *rosa.Runtime, _ *cobra.Command) error {
+	r.Reporter.Debugf("Loading AWS service quota increase requests")
+	requests, err := r.AWSClient.ListServiceQuotaIncreaseRequests()
+	if err != nil {
+		return err
+	}
+
+	if args.pending {
+		var pending []aws.QuotaIncreaseRequest
+		for _, request := range requests {
+			if request.IsOpen() {
+				pending = append(pending, request)
+			}
+		}
+		requests = pending
+	}
+
+	if output.HasFlag() {
+		if requests == nil {
+			requests = []aws.QuotaIncreaseRequest{}
+		}
+		return output.Print(requests)
+	}
+
+	if len(requests) == 0 {
+		r.Reporter.Infof("There are no AWS service quota increase requests")
+		return nil
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "ID\tSERVICE\tQUOTA CODE\tQUOTA NAME\tREQUESTED VALUE\tSTATUS\tCREATED\n")
+	for _, request := range requests {
+		created := ""
+		if request.Created != nil {
+			created = request.Created.UTC().Format(time.RFC3339)
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%d\t%s\t%s\n",
+			request.ID,
+			request.ServiceCode,
+			request.QuotaCode,
+			request.QuotaName,
+			int(request.DesiredValue),
+			request.Status,
+			created,
+		)
+	}
+	writer.Flush()
+
+	return nil
+}
@@ -0,0 +1,222 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicelogs
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	slv1 "github.com/openshift-online/ocm-sdk-go/servicelogs/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var Cmd = &cobra.Command{
+	Use:     "service-logs",
+	Aliases: []string{"service-log", "servicelogs", "servicelog"},
+	Short:   "List service logs of a cluster",
+	Long: "List the service logs that Red Hat posted for a cluster, such as the reasons for limited " +
+		"support, upgrade notifications and actions required from the cluster owner.",
+	Example: `  # List the service logs of a cluster named 'mycluster'
+  rosa list service-logs -c mycluster
+
+  # List the warnings and errors of the last 7 days
+  rosa list service-logs -c mycluster --severity warning,error --since 168h
+
+  # List the service logs mentioning limited support and watch for new ones
+  rosa list service-logs -c mycluster --search "limited support" --watch`,
+	Run:  run,
+	Args: cobra.NoArgs,
+}
+
+var args struct {
+	severities []string
+	since      string
+	search     string
+	watch      bool
+}
+
+// watchInterval is the time between two checks for new service logs with '--watch'
+var watchInterval = 30 * time.Second
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	ocm.AddClusterFlag(Cmd)
+	flags.StringSliceVar(
+		&args.severities,
+		"severity",
+		[]string{},
+		fmt.Sprintf("List only the service logs with the given severities. Valid severities are %s.",
+			strings.Join(ocm.ServiceLogSeverities, ", ")),
+	)
+	flags.StringVar(
+		&args.since,
+		"since",
+		"",
+		"List only the service logs posted since the given duration (e.g. '48h') or date "+
+			"(e.g. '2024-01-31' or '2024-01-31T10:00:00Z').",
+	)
+	flags.StringVar(
+		&args.search,
+		"search",
+		"",
+		"List only the service logs whose summary or description contain the given text.",
+	)
+	flags.BoolVar(
+		&args.watch,
+		"watch",
+		false,
+		"After listing the service logs, watch for new ones.",
+	)
+	output.AddFlag(Cmd)
+}
+
+func run(cmd *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithOCM()
+	defer r.Cleanup()
+	err := runWithRuntime(r, cmd)
+	if err != nil {
+		r.Reporter.Errorf(err.Error())
+		os.Exit(1)
+	}
+}
+
+func runWithRuntime(r *rosa.Runtime, _ *cobra.Command) error {
+	if args.watch && output.HasFlag() {
+		return fmt.Errorf("The '--watch' option can't be used together with '--output'")
+	}
+	filter, err := buildFilter(time.Now())
+	if err != nil {
+		return err
+	}
+
+	clusterKey := r.GetClusterKey()
+	cluster := r.FetchCluster()
+
+	r.Reporter.Debugf("Loading service logs for cluster '%s'", clusterKey)
+	logEntries, err := r.OCMClient.GetServiceLogs(cluster.ID(), filter)
+	if err != nil {
+		return fmt.Errorf("Failed to get service logs for cluster '%s': %v", clusterKey, err)
+	}
+
+	if output.HasFlag() {
+		return output.Print(logEntries)
+	}
+
+	if len(logEntries) == 0 && !args.watch {
+		r.Reporter.Infof("There are no service logs for cluster '%s'", clusterKey)
+		return nil
+	}
+	printServiceLogs(os.Stdout, logEntries, true)
+
+	if !args.watch {
+		return nil
+	}
+	r.Reporter.Infof("Watching for new service logs of cluster '%s'", clusterKey)
+	seen := map[string]bool{}
+	for _, logEntry := range logEntries {
+		seen[logEntry.ID()] = true
+	}
+	for {
+		time.Sleep(watchInterval)
+		// Entries posted at the same time as the last one are listed again, the ones that were
+		// already printed are skipped
+		if len(logEntries) > 0 {
+			filter.Since = logEntries[len(logEntries)-1].Timestamp()
+		}
+		logEntries, err = r.OCMClient.GetServiceLogs(cluster.ID(), filter)
+		if err != nil {
+			return fmt.Errorf("Failed to watch service logs for cluster '%s': %v", clusterKey, err)
+		}
+		printServiceLogs(os.Stdout, newServiceLogs(logEntries, seen), false)
+	}
+}
+
+// buildFilter validates the filtering options of the command
+func buildFilter(now time.Time) (ocm.ServiceLogFilter, error) {
+	severities, err := ocm.ValidateServiceLogSeverities(args.severities)
+	if err != nil {
+		return ocm.ServiceLogFilter{}, err
+	}
+	filter := ocm.ServiceLogFilter{
+		Severities: severities,
+		Search:     strings.TrimSpace(args.search),
+	}
+	if args.since != "" {
+		filter.Since, err = parseSince(args.since, now)
+		if err != nil {
+			return ocm.ServiceLogFilter{}, err
+		}
+	}
+	return filter, nil
+}
+
+// parseSince accepts either a duration before now or a date
+func parseSince(since string, now time.Time) (time.Time, error) {
+	if duration, err := time.ParseDuration(since); err == nil {
+		if duration < 0 {
+			return time.Time{}, fmt.Errorf("The '--since' duration can't be negative")
+		}
+		return now.Add(-duration), nil
+	}
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		if date, err := time.Parse(layout, since); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("Invalid '--since' value '%s', expected a duration such as '48h' "+
+		"or a date such as '2024-01-31'", since)
+}
+
+// newServiceLogs returns the service logs that weren't seen yet and marks them as seen
+func newServiceLogs(logEntries []*slv1.LogEntry, seen map[string]bool) []*slv1.LogEntry {
+	result := []*slv1.LogEntry{}
+	for _, logEntry := range logEntries {
+		if seen[logEntry.ID()] {
+			continue
+		}
+		seen[logEntry.ID()] = true
+		result = append(result, logEntry)
+	}
+	return result
+}
+
+func printServiceLogs(w io.Writer, logEntries []*slv1.LogEntry, header bool) {
+	writer := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	if header {
+		fmt.Fprintf(writer, "ID\tTIMESTAMP\tSEVERITY\tSERVICE\tSUMMARY\n")
+	}
+	for _, logEntry := range logEntries {
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\n",
+			logEntry.ID(),
+			logEntry.Timestamp().UTC().Format("2006-01-02 15:04:05 MST"),
+			logEntry.Severity(),
+			logEntry.ServiceName(),
+			logEntry.Summary(),
+		)
+	}
+	writer.Flush()
+}
@@ -0,0 +1,149 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicelogs
+
+import (
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	slv1 "github.com/openshift-online/ocm-sdk-go/servicelogs/v1"
+	. "github.com/openshift-online/ocm-sdk-go/testing"
+
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/test"
+)
+
+var _ = Describe("List service logs", func() {
+	var testRuntime test.TestingRuntime
+	var logEntries []*slv1.LogEntry
+
+	cluster := test.FormatClusterList([]*cmv1.Cluster{test.MockCluster(func(c *cmv1.ClusterBuilder) {
+		c.State(cmv1.ClusterStateReady)
+	})})
+
+	BeforeEach(func() {
+		testRuntime.InitRuntime()
+		DeferCleanup(func() {
+			args.severities = []string{}
+			args.since = ""
+			args.search = ""
+			args.watch = false
+			output.SetOutput("")
+		})
+
+		timestamp, err := time.Parse(time.RFC3339, "2024-01-31T10:00:00Z")
+		Expect(err).ToNot(HaveOccurred())
+		upgrade, err := slv1.NewLogEntry().ID("log1").Timestamp(timestamp).Severity(slv1.SeverityInfo).
+			ServiceName("ClusterUpgrade").Summary("Cluster upgrade completed").Build()
+		Expect(err).ToNot(HaveOccurred())
+		limitedSupport, err := slv1.NewLogEntry().ID("log2").Timestamp(timestamp.Add(time.Hour)).
+			Severity(slv1.SeverityError).ServiceName("SREManualAction").
+			Summary("Cluster is in limited support").Build()
+		Expect(err).ToNot(HaveOccurred())
+		logEntries = []*slv1.LogEntry{upgrade, limitedSupport}
+	})
+
+	It("Lists the service logs of the cluster", func() {
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, cluster))
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, test.FormatServiceLogList(logEntries)))
+		stdout, stderr, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(stderr).To(BeEmpty())
+		Expect(stdout).To(Equal(
+			"ID    TIMESTAMP                SEVERITY  SERVICE          SUMMARY\n" +
+				"log1  2024-01-31 10:00:00 UTC  Info      ClusterUpgrade   Cluster upgrade completed\n" +
+				"log2  2024-01-31 11:00:00 UTC  Error     SREManualAction  Cluster is in limited support\n"))
+	})
+
+	It("Sends the filters to the service logs API", func() {
+		args.severities = []string{"error"}
+		args.since = "2024-01-31"
+		args.search = "limited support"
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, cluster))
+		testRuntime.ApiServer.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyFormKV("cluster_id", test.MockClusterID),
+			ghttp.VerifyFormKV("search", "severity IN ('Error') AND timestamp >= '2024-01-31T00:00:00Z' AND "+
+				"(summary ILIKE '%limited support%' OR description ILIKE '%limited support%')"),
+			RespondWithJSON(http.StatusOK, test.FormatServiceLogList(logEntries[1:])),
+		))
+		stdout, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(stdout).To(ContainSubstring("Cluster is in limited support"))
+		Expect(stdout).ToNot(ContainSubstring("Cluster upgrade completed"))
+	})
+
+	It("Prints the service logs as JSON", func() {
+		output.SetOutput("json")
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, cluster))
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, test.FormatServiceLogList(logEntries)))
+		stdout, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(stdout).To(ContainSubstring(`"id": "log2"`))
+		Expect(stdout).To(ContainSubstring(`"severity": "Error"`))
+	})
+
+	It("Informs when there are no service logs", func() {
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, cluster))
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, test.FormatServiceLogList(nil)))
+		stdout, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(stdout).To(Equal("INFO: There are no service logs for cluster 'cluster1'\n"))
+	})
+
+	It("Fails when watching with an output format", func() {
+		args.watch = true
+		output.SetOutput("json")
+		_, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+		Expect(err).To(MatchError("The '--watch' option can't be used together with '--output'"))
+	})
+
+	It("Fails with an invalid severity", func() {
+		args.severities = []string{"critical"}
+		_, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+		Expect(err).To(MatchError(ContainSubstring("invalid severity 'critical'")))
+	})
+
+	Context("parseSince", func() {
+		now := time.Date(2024, 1, 31, 12, 0, 0, 0, time.UTC)
+
+		It("Accepts a duration", func() {
+			since, err := parseSince("48h", now)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(since).To(Equal(time.Date(2024, 1, 29, 12, 0, 0, 0, time.UTC)))
+		})
+		It("Accepts a date and a time", func() {
+			since, err := parseSince("2024-01-02T03:04:05Z", now)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(since).To(Equal(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)))
+		})
+		It("Rejects anything else", func() {
+			_, err := parseSince("yesterday", now)
+			Expect(err).To(MatchError("Invalid '--since' value 'yesterday', expected a duration such as '48h' " +
+				"or a date such as '2024-01-31'"))
+		})
+	})
+
+	It("Only returns the service logs that weren't seen yet", func() {
+		seen := map[string]bool{"log1": true}
+		Expect(newServiceLogs(logEntries, seen)).To(Equal(logEntries[1:]))
+		Expect(newServiceLogs(logEntries, seen)).To(BeEmpty())
+	})
+})
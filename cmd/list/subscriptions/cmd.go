@@ -0,0 +1,171 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package subscriptions
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	amsv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var Cmd = &cobra.Command{
+	Use:     "subscriptions",
+	Aliases: []string{"subscription"},
+	Short:   "List subscriptions of clusters",
+	Long: "List the subscriptions of the ROSA clusters of the organization, including the clusters " +
+		"that were deleted.",
+	Example: `  # List the subscriptions of all the clusters of the organization
+  rosa list subscriptions
+
+  # List the active subscriptions billed through the AWS marketplace
+  rosa list subscriptions --status active --billing-model marketplace-aws
+
+  # List the subscriptions of the clusters whose name contains "prod"
+  rosa list subscriptions --search prod`,
+	Run:  run,
+	Args: cobra.NoArgs,
+}
+
+var args struct {
+	statuses      []string
+	billingModels []string
+	supportLevels []string
+	search        string
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	flags.StringSliceVar(
+		&args.statuses,
+		"status",
+		[]string{},
+		fmt.Sprintf("List only the subscriptions with the given statuses. Valid statuses are %s.",
+			strings.Join(ocm.SubscriptionStatuses, ", ")),
+	)
+	flags.StringSliceVar(
+		&args.billingModels,
+		"billing-model",
+		[]string{},
+		"List only the subscriptions with the given billing models, such as 'standard' or 'marketplace-aws'.",
+	)
+	flags.StringSliceVar(
+		&args.supportLevels,
+		"support-level",
+		[]string{},
+		fmt.Sprintf("List only the subscriptions with the given support levels. Valid support levels are %s.",
+			strings.Join(ocm.SubscriptionSupportLevels, ", ")),
+	)
+	flags.StringVar(
+		&args.search,
+		"search",
+		"",
+		"List only the subscriptions whose cluster name or ID contain the given text.",
+	)
+	output.AddFlag(Cmd)
+}
+
+func run(cmd *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithOCM()
+	defer r.Cleanup()
+	err := runWithRuntime(r, cmd)
+	if err != nil {
+		r.Reporter.Errorf(err.Error())
+		os.Exit(1)
+	}
+}
+
+func runWithRuntime(r *rosa.Runtime, _ *cobra.Command) error {
+	filter, err := buildFilter()
+	if err != nil {
+		return err
+	}
+	account, err := r.OCMClient.GetCurrentAccount()
+	if err != nil {
+		return fmt.Errorf("Failed to get current account: %v", err)
+	}
+	filter.OrganizationID = account.Organization().ID()
+
+	r.Reporter.Debugf("Loading subscriptions of organization '%s'", filter.OrganizationID)
+	subscriptions, err := r.OCMClient.GetSubscriptions(filter)
+	if err != nil {
+		return fmt.Errorf("Failed to get subscriptions: %v", err)
+	}
+
+	if output.HasFlag() {
+		return output.Print(subscriptions)
+	}
+	if len(subscriptions) == 0 {
+		r.Reporter.Infof("There are no subscriptions matching the given filters")
+		return nil
+	}
+	printSubscriptions(os.Stdout, subscriptions)
+	return nil
+}
+
+// buildFilter validates the filtering options of the command
+func buildFilter() (ocm.SubscriptionFilter, error) {
+	statuses, err := ocm.ValidateSubscriptionValues("status", args.statuses, ocm.SubscriptionStatuses)
+	if err != nil {
+		return ocm.SubscriptionFilter{}, err
+	}
+	supportLevels, err := ocm.ValidateSubscriptionValues("support level", args.supportLevels,
+		ocm.SubscriptionSupportLevels)
+	if err != nil {
+		return ocm.SubscriptionFilter{}, err
+	}
+	billingModels := make([]string, 0, len(args.billingModels))
+	for _, billingModel := range args.billingModels {
+		billingModels = append(billingModels, strings.ToLower(strings.TrimSpace(billingModel)))
+	}
+	return ocm.SubscriptionFilter{
+		Statuses:      statuses,
+		BillingModels: billingModels,
+		SupportLevels: supportLevels,
+		Search:        strings.TrimSpace(args.search),
+	}, nil
+}
+
+func printSubscriptions(w io.Writer, subscriptions []*amsv1.Subscription) {
+	writer := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "ID\tCLUSTER ID\tNAME\tSTATUS\tPLAN\tSUPPORT LEVEL\tBILLING MODEL\tVCPU\tSOCKETS\tCREATOR\n")
+	for _, subscription := range subscriptions {
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%d\t%d\t%s\n",
+			subscription.ID(),
+			subscription.ClusterID(),
+			subscription.DisplayName(),
+			subscription.Status(),
+			subscription.Plan().ID(),
+			subscription.SupportLevel(),
+			subscription.ClusterBillingModel(),
+			subscription.CpuTotal(),
+			subscription.SocketTotal(),
+			ocm.FormatSubscriptionCreator(subscription),
+		)
+	}
+	writer.Flush()
+}
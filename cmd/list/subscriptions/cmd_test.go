@@ -0,0 +1,91 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package subscriptions
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	amsv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
+	. "github.com/openshift-online/ocm-sdk-go/testing"
+
+	"github.com/openshift/rosa/pkg/test"
+)
+
+const currentAccount = `{"kind": "Account", "id": "acc1", "username": "jdoe", ` +
+	`"organization": {"kind": "Organization", "id": "org1"}}`
+
+var _ = Describe("List subscriptions", func() {
+	var testRuntime test.TestingRuntime
+
+	BeforeEach(func() {
+		testRuntime.InitRuntime()
+		DeferCleanup(func() {
+			args.statuses = []string{}
+			args.billingModels = []string{}
+			args.supportLevels = []string{}
+			args.search = ""
+		})
+	})
+
+	It("Fails with an invalid status", func() {
+		args.statuses = []string{"running"}
+		_, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+		Expect(err).To(MatchError("invalid status 'running', valid values are " +
+			"Active, Deprovisioned, Disconnected, Reserved, Stale, Archived"))
+	})
+
+	It("Lists the subscriptions of the organization matching the filters", func() {
+		args.statuses = []string{"active"}
+		args.billingModels = []string{"Marketplace-AWS"}
+		subscription, err := amsv1.NewSubscription().ID("sub1").ClusterID("cluster-id").
+			DisplayName("prod").Status("Active").Plan(amsv1.NewPlan().ID("MOA-HostedControlPlane")).
+			SupportLevel("Premium").ClusterBillingModel(amsv1.BillingModelMarketplaceAWS).
+			CpuTotal(24).SocketTotal(12).Creator(amsv1.NewAccount().ID("acc1").Username("jdoe")).
+			Build()
+		Expect(err).ToNot(HaveOccurred())
+		testRuntime.ApiServer.AppendHandlers(
+			RespondWithJSON(http.StatusOK, currentAccount),
+			ghttp.CombineHandlers(
+				ghttp.VerifyFormKV("search", "plan.id IN ('MOA', 'MOA-HostedControlPlane') AND "+
+					"organization_id = 'org1' AND status IN ('Active') AND "+
+					"cluster_billing_model IN ('marketplace-aws')"),
+				ghttp.VerifyFormKV("fetchAccounts", "true"),
+				RespondWithJSON(http.StatusOK, test.FormatSubscriptionList([]*amsv1.Subscription{subscription})),
+			),
+		)
+		stdout, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(stdout).To(Equal(
+			"ID    CLUSTER ID  NAME  STATUS  PLAN                    SUPPORT LEVEL  BILLING MODEL    " +
+				"VCPU  SOCKETS  CREATOR\n" +
+				"sub1  cluster-id  prod  Active  MOA-HostedControlPlane  Premium        marketplace-aws  " +
+				"24    12       jdoe\n"))
+	})
+
+	It("Reports when no subscription matches", func() {
+		testRuntime.ApiServer.AppendHandlers(
+			RespondWithJSON(http.StatusOK, currentAccount),
+			RespondWithJSON(http.StatusOK, test.FormatSubscriptionList([]*amsv1.Subscription{})),
+		)
+		stdout, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(stdout).To(Equal("INFO: There are no subscriptions matching the given filters\n"))
+	})
+})
@@ -0,0 +1,43 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replace
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/cmd/replace/machinepool"
+	"github.com/openshift/rosa/pkg/arguments"
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "replace",
+	Short: "Replace a specific resource",
+	Long:  "Replace a specific resource with a new one with different settings",
+	Args:  cobra.NoArgs,
+}
+
+func init() {
+	Cmd.AddCommand(machinepool.Cmd)
+
+	flags := Cmd.PersistentFlags()
+	arguments.AddProfileFlag(flags)
+	arguments.AddRegionFlag(flags)
+	confirm.AddFlag(flags)
+	globallyAvailableCommands := []*cobra.Command{machinepool.Cmd}
+	arguments.MarkRegionDeprecated(Cmd, globallyAvailableCommands)
+}
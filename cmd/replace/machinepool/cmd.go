@@ -0,0 +1,273 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinepool
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/helper/machinepools"
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var args struct {
+	machinePool   string
+	name          string
+	instanceType  string
+	scaleDownStep int
+	timeout       time.Duration
+}
+
+var Cmd = &cobra.Command{
+	Use:     "machinepool",
+	Aliases: []string{"machine-pool"},
+	Short:   "Replace a machine pool of a Hosted Control Plane cluster with a different instance type",
+	Long: "Replace a machine pool of a Hosted Control Plane cluster with a sibling machine pool with a " +
+		"different instance type. The sibling machine pool copies the other settings of the machine pool. " +
+		"Once its replicas are ready, the machine pool is scaled down in steps and deleted. The autoscaling " +
+		"of the machine pool is disabled at its current replicas before it is scaled down.\n\n" +
+		"If the replacement is interrupted, run the same command again to resume it.\n\n" +
+		"Only Hosted Control Plane clusters are supported: the replicas of the machine pools of classic " +
+		"clusters are not reported, so the replacement can't wait for them. To replace a machine pool of a " +
+		"classic cluster, create the new machine pool with 'rosa create machinepool' and delete the old one " +
+		"once its nodes are ready.",
+	Example: `  # Replace machine pool "workers" of cluster "mycluster" with a machine pool of r6i.2xlarge instances
+  rosa replace machinepool --cluster=mycluster --machinepool=workers --instance-type=r6i.2xlarge
+
+  # Replace a machine pool, naming the new machine pool and scaling down two replicas at a time
+  rosa replace machinepool --cluster=mycluster --machinepool=workers --instance-type=r6i.2xlarge \
+    --name=workers-r6i --scale-down-step=2`,
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+var machinePoolKeyRE = regexp.MustCompile(`^[a-z]([-a-z0-9]*[a-z0-9])?$`)
+
+// pollInterval is the time between two checks of the replicas of a machine pool
+var pollInterval = 30 * time.Second
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	ocm.AddClusterFlag(Cmd)
+	flags.StringVar(
+		&args.machinePool,
+		"machinepool",
+		"",
+		"Machine pool of the cluster to replace.",
+	)
+	flags.StringVar(
+		&args.instanceType,
+		"instance-type",
+		"",
+		"Instance type of the new machine pool.",
+	)
+	flags.StringVar(
+		&args.name,
+		"name",
+		"",
+		"Name of the new machine pool. Defaults to the name of the machine pool with a '-blue' or '-green' "+
+			"suffix.",
+	)
+	flags.IntVar(
+		&args.scaleDownStep,
+		"scale-down-step",
+		1,
+		"Number of replicas to remove from the machine pool at each step of the scale down.",
+	)
+	flags.DurationVar(
+		&args.timeout,
+		"timeout",
+		time.Hour,
+		"Maximum time to wait for the replicas of a machine pool at each step.",
+	)
+}
+
+func run(cmd *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS().WithOCM()
+	defer r.Cleanup()
+
+	err := runWithRuntime(r, cmd)
+	if err != nil {
+		r.Reporter.Errorf(err.Error())
+		os.Exit(1)
+	}
+}
+
+// replacementName alternates the '-blue' and '-green' suffixes so that a machine pool can be replaced
+// again by a machine pool with its original name
+func replacementName(machinePool string) string {
+	if base, ok := strings.CutSuffix(machinePool, "-blue"); ok {
+		return base + "-green"
+	}
+	if base, ok := strings.CutSuffix(machinePool, "-green"); ok {
+		return base + "-blue"
+	}
+	return machinePool + "-green"
+}
+
+// desiredReplicas returns the replicas the node pool has to reach to be ready
+func desiredReplicas(nodePool *cmv1.NodePool) int {
+	if autoscaling, ok := nodePool.GetAutoscaling(); ok {
+		return autoscaling.MinReplica()
+	}
+	return nodePool.Replicas()
+}
+
+func runWithRuntime(r *rosa.Runtime, _ *cobra.Command) error {
+	if !machinePoolKeyRE.MatchString(args.machinePool) {
+		return fmt.Errorf("Expected a valid identifier for the machine pool to replace with '--machinepool'")
+	}
+	if args.instanceType == "" {
+		return fmt.Errorf("Expected the instance type of the new machine pool with '--instance-type'")
+	}
+	if args.scaleDownStep < 1 {
+		return fmt.Errorf("Expected a scale down step greater than zero")
+	}
+	name := args.name
+	if name == "" {
+		name = replacementName(args.machinePool)
+	}
+	if !machinePoolKeyRE.MatchString(name) || name == args.machinePool {
+		return fmt.Errorf("Expected a valid name for the new machine pool different from '%s'", args.machinePool)
+	}
+
+	clusterKey := r.GetClusterKey()
+	cluster := r.FetchCluster()
+	if cluster.State() != cmv1.ClusterStateReady {
+		return fmt.Errorf("Cluster '%s' is not yet ready", clusterKey)
+	}
+	if !cluster.Hypershift().Enabled() {
+		return fmt.Errorf("Replacing machine pools is only supported for Hosted Control Plane clusters, " +
+			"as the replicas of the machine pools of classic clusters can't be observed. Create the new " +
+			"machine pool with 'rosa create machinepool' and delete the old one once its nodes are ready")
+	}
+
+	source, exists, err := r.OCMClient.GetNodePool(cluster.ID(), args.machinePool)
+	if err != nil {
+		return fmt.Errorf("Failed to get machine pool '%s': %v", args.machinePool, err)
+	}
+	replacement, replacementExists, err := r.OCMClient.GetNodePool(cluster.ID(), name)
+	if err != nil {
+		return fmt.Errorf("Failed to get machine pool '%s': %v", name, err)
+	}
+	if !exists {
+		if replacementExists {
+			r.Reporter.Infof("Machine pool '%s' of cluster '%s' has already been replaced by machine pool '%s'",
+				args.machinePool, clusterKey, name)
+			return nil
+		}
+		return fmt.Errorf("Machine pool '%s' not found", args.machinePool)
+	}
+
+	if replacementExists {
+		if replacement.AWSNodePool().InstanceType() != args.instanceType {
+			return fmt.Errorf("Machine pool '%s' already exists with instance type '%s'", name,
+				replacement.AWSNodePool().InstanceType())
+		}
+		r.Reporter.Infof("Resuming the replacement of machine pool '%s' by machine pool '%s'",
+			args.machinePool, name)
+	} else {
+		if source.AWSNodePool().InstanceType() == args.instanceType {
+			return fmt.Errorf("Machine pool '%s' already uses instance type '%s'", args.machinePool,
+				args.instanceType)
+		}
+		if !confirm.Confirm("replace machine pool '%s' of cluster '%s' by machine pool '%s' with instance "+
+			"type '%s'", args.machinePool, clusterKey, name, args.instanceType) {
+			return nil
+		}
+		builder := machinepools.CopyNodePool(source, true).
+			ID(name).
+			Subnet(source.Subnet()).
+			AWSNodePool(machinepools.CopyAWSNodePool(source.AWSNodePool(), true).InstanceType(args.instanceType))
+		if version, ok := source.GetVersion(); ok {
+			builder.Version(cmv1.NewVersion().ID(version.ID()))
+		}
+		nodePool, err := builder.Build()
+		if err != nil {
+			return fmt.Errorf("Failed to create machine pool '%s': %v", name, err)
+		}
+		replacement, err = r.OCMClient.CreateNodePool(cluster.ID(), nodePool)
+		if err != nil {
+			return fmt.Errorf("Failed to add machine pool '%s': %v", name, err)
+		}
+		r.Reporter.Infof("Created machine pool '%s' with instance type '%s'", name, args.instanceType)
+	}
+
+	replicas := desiredReplicas(replacement)
+	r.Reporter.Infof("Waiting for the %d replicas of machine pool '%s' to be ready", replicas, name)
+	_, err = r.OCMClient.PollNodePool(cluster.ID(), name, pollInterval, args.timeout,
+		func(nodePool *cmv1.NodePool) bool {
+			return nodePool.Status().CurrentReplicas() >= replicas
+		})
+	if err != nil {
+		return fmt.Errorf("Failed to wait for the replicas of machine pool '%s': %v", name, err)
+	}
+
+	// Replicas can't be set while autoscaling is enabled, so the machine pool is first pinned at its
+	// current replicas, which disables autoscaling
+	replicas = source.Replicas()
+	if _, ok := source.GetAutoscaling(); ok {
+		replicas = source.Status().CurrentReplicas()
+		r.Reporter.Infof("Disabling autoscaling of machine pool '%s' at %d replicas", args.machinePool, replicas)
+		nodePool, err := cmv1.NewNodePool().ID(args.machinePool).Replicas(replicas).Build()
+		if err != nil {
+			return err
+		}
+		_, err = r.OCMClient.UpdateNodePool(cluster.ID(), nodePool)
+		if err != nil {
+			return fmt.Errorf("Failed to disable autoscaling of machine pool '%s': %v", args.machinePool, err)
+		}
+	}
+	for replicas > 0 {
+		replicas = max(replicas-args.scaleDownStep, 0)
+		r.Reporter.Infof("Scaling machine pool '%s' down to %d replicas", args.machinePool, replicas)
+		nodePool, err := cmv1.NewNodePool().ID(args.machinePool).Replicas(replicas).Build()
+		if err != nil {
+			return err
+		}
+		_, err = r.OCMClient.UpdateNodePool(cluster.ID(), nodePool)
+		if err != nil {
+			return fmt.Errorf("Failed to scale down machine pool '%s': %v", args.machinePool, err)
+		}
+		target := replicas
+		_, err = r.OCMClient.PollNodePool(cluster.ID(), args.machinePool, pollInterval, args.timeout,
+			func(nodePool *cmv1.NodePool) bool {
+				return nodePool.Status().CurrentReplicas() <= target
+			})
+		if err != nil {
+			return fmt.Errorf("Failed to wait for the scale down of machine pool '%s': %v", args.machinePool, err)
+		}
+	}
+
+	err = r.OCMClient.DeleteNodePool(cluster.ID(), args.machinePool)
+	if err != nil {
+		return fmt.Errorf("Failed to delete machine pool '%s': %v", args.machinePool, err)
+	}
+	r.Reporter.Infof("Machine pool '%s' of cluster '%s' has been replaced by machine pool '%s'",
+		args.machinePool, clusterKey, name)
+	return nil
+}
@@ -0,0 +1,248 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinepool
+
+import (
+	"io"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	. "github.com/openshift-online/ocm-sdk-go/testing"
+	"github.com/spf13/pflag"
+
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/test"
+)
+
+var _ = Describe("Replace machinepool", func() {
+	DescribeTable("replacementName",
+		func(machinePool string, expected string) {
+			Expect(replacementName(machinePool)).To(Equal(expected))
+		},
+		Entry("Adds a green suffix", "workers", "workers-green"),
+		Entry("Switches from blue to green", "workers-blue", "workers-green"),
+		Entry("Switches from green to blue", "workers-green", "workers-blue"),
+	)
+
+	Context("Command", func() {
+		var (
+			testRuntime test.TestingRuntime
+			yesFlags    *pflag.FlagSet
+			requests    []string
+			created     *cmv1.NodePool
+		)
+
+		hcpCluster := test.FormatClusterList([]*cmv1.Cluster{test.MockCluster(func(c *cmv1.ClusterBuilder) {
+			c.State(cmv1.ClusterStateReady)
+			c.Hypershift(cmv1.NewHypershift().Enabled(true))
+		})})
+		nodePoolsPath := "/api/clusters_mgmt/v1/clusters/" + test.MockClusterID + "/node_pools"
+
+		buildNodePool := func(id string, instanceType string, replicas int, currentReplicas int) string {
+			nodePool, err := cmv1.NewNodePool().ID(id).Replicas(replicas).Subnet("subnet-1").
+				Version(cmv1.NewVersion().ID("openshift-v4.14.1")).
+				AWSNodePool(cmv1.NewAWSNodePool().InstanceType(instanceType)).
+				Status(cmv1.NewNodePoolStatus().CurrentReplicas(currentReplicas)).
+				Build()
+			Expect(err).NotTo(HaveOccurred())
+			return test.FormatResource(nodePool)
+		}
+
+		record := func(method string, path string, handler http.HandlerFunc) http.HandlerFunc {
+			return ghttp.CombineHandlers(
+				ghttp.VerifyRequest(method, path),
+				func(w http.ResponseWriter, r *http.Request) {
+					requests = append(requests, method+" "+path)
+				},
+				handler,
+			)
+		}
+
+		BeforeEach(func() {
+			testRuntime.InitRuntime()
+			requests = nil
+			created = nil
+			pollInterval = time.Millisecond
+			yesFlags = pflag.NewFlagSet("confirm", pflag.ContinueOnError)
+			confirm.AddFlag(yesFlags)
+			Expect(yesFlags.Set("yes", "true")).To(Succeed())
+			DeferCleanup(func() {
+				yesFlags.Set("yes", "false")
+			})
+			// Reset flags to avoid any side effect on other tests
+			Cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+				if flag.Name == "cluster" {
+					return
+				}
+				flag.Value.Set(flag.DefValue)
+				flag.Changed = false
+			})
+		})
+
+		It("Fails without an instance type", func() {
+			Cmd.Flags().Set("machinepool", "workers")
+			_, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+			Expect(err).To(MatchError(
+				"Expected the instance type of the new machine pool with '--instance-type'"))
+		})
+
+		It("Fails for classic clusters", func() {
+			Cmd.Flags().Set("machinepool", "workers")
+			Cmd.Flags().Set("instance-type", "r6i.2xlarge")
+			testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK,
+				test.FormatClusterList([]*cmv1.Cluster{test.MockCluster(func(c *cmv1.ClusterBuilder) {
+					c.State(cmv1.ClusterStateReady)
+				})})))
+			_, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(
+				"Replacing machine pools is only supported for Hosted Control Plane clusters"))
+		})
+
+		It("Creates the new machine pool, scales down the old one in steps and deletes it", func() {
+			Cmd.Flags().Set("machinepool", "workers")
+			Cmd.Flags().Set("instance-type", "r6i.2xlarge")
+			Cmd.Flags().Set("scale-down-step", "2")
+			testRuntime.ApiServer.AppendHandlers(
+				RespondWithJSON(http.StatusOK, hcpCluster),
+				RespondWithJSON(http.StatusOK, buildNodePool("workers", "m5.xlarge", 3, 3)),
+				RespondWithJSON(http.StatusNotFound, "{}"),
+				record(http.MethodPost, nodePoolsPath, ghttp.CombineHandlers(
+					func(w http.ResponseWriter, r *http.Request) {
+						body, err := io.ReadAll(r.Body)
+						Expect(err).NotTo(HaveOccurred())
+						created, err = cmv1.UnmarshalNodePool(body)
+						Expect(err).NotTo(HaveOccurred())
+					},
+					RespondWithJSON(http.StatusCreated, buildNodePool("workers-green", "r6i.2xlarge", 3, 0)),
+				)),
+				RespondWithJSON(http.StatusOK, buildNodePool("workers-green", "r6i.2xlarge", 3, 1)),
+				RespondWithJSON(http.StatusOK, buildNodePool("workers-green", "r6i.2xlarge", 3, 3)),
+				record(http.MethodPatch, nodePoolsPath+"/workers", RespondWithJSON(http.StatusOK, "{}")),
+				RespondWithJSON(http.StatusOK, buildNodePool("workers", "m5.xlarge", 1, 1)),
+				record(http.MethodPatch, nodePoolsPath+"/workers", RespondWithJSON(http.StatusOK, "{}")),
+				RespondWithJSON(http.StatusOK, buildNodePool("workers", "m5.xlarge", 0, 0)),
+				record(http.MethodDelete, nodePoolsPath+"/workers", RespondWithJSON(http.StatusNoContent, "")),
+			)
+			stdout, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requests).To(Equal([]string{
+				http.MethodPost + " " + nodePoolsPath,
+				http.MethodPatch + " " + nodePoolsPath + "/workers",
+				http.MethodPatch + " " + nodePoolsPath + "/workers",
+				http.MethodDelete + " " + nodePoolsPath + "/workers",
+			}))
+			Expect(created.ID()).To(Equal("workers-green"))
+			Expect(created.AWSNodePool().InstanceType()).To(Equal("r6i.2xlarge"))
+			Expect(created.Replicas()).To(Equal(3))
+			Expect(created.Subnet()).To(Equal("subnet-1"))
+			Expect(created.Version().ID()).To(Equal("openshift-v4.14.1"))
+			Expect(stdout).To(ContainSubstring("Scaling machine pool 'workers' down to 1 replicas"))
+			Expect(stdout).To(ContainSubstring("Scaling machine pool 'workers' down to 0 replicas"))
+			Expect(stdout).To(ContainSubstring(
+				"Machine pool 'workers' of cluster 'cluster1' has been replaced by machine pool 'workers-green'"))
+		})
+
+		It("Disables autoscaling of the machine pool before scaling it down", func() {
+			Cmd.Flags().Set("machinepool", "workers")
+			Cmd.Flags().Set("instance-type", "r6i.2xlarge")
+			Cmd.Flags().Set("scale-down-step", "2")
+			autoscalingNodePool, err := cmv1.NewNodePool().ID("workers").Subnet("subnet-1").
+				Autoscaling(cmv1.NewNodePoolAutoscaling().MinReplica(2).MaxReplica(6)).
+				AWSNodePool(cmv1.NewAWSNodePool().InstanceType("m5.xlarge")).
+				Status(cmv1.NewNodePoolStatus().CurrentReplicas(3)).
+				Build()
+			Expect(err).NotTo(HaveOccurred())
+			var patches []*cmv1.NodePool
+			recordPatch := record(http.MethodPatch, nodePoolsPath+"/workers", ghttp.CombineHandlers(
+				func(w http.ResponseWriter, r *http.Request) {
+					body, err := io.ReadAll(r.Body)
+					Expect(err).NotTo(HaveOccurred())
+					patch, err := cmv1.UnmarshalNodePool(body)
+					Expect(err).NotTo(HaveOccurred())
+					patches = append(patches, patch)
+				},
+				RespondWithJSON(http.StatusOK, "{}"),
+			))
+			testRuntime.ApiServer.AppendHandlers(
+				RespondWithJSON(http.StatusOK, hcpCluster),
+				RespondWithJSON(http.StatusOK, test.FormatResource(autoscalingNodePool)),
+				RespondWithJSON(http.StatusNotFound, "{}"),
+				record(http.MethodPost, nodePoolsPath,
+					RespondWithJSON(http.StatusCreated, buildNodePool("workers-green", "r6i.2xlarge", 2, 0))),
+				RespondWithJSON(http.StatusOK, buildNodePool("workers-green", "r6i.2xlarge", 2, 2)),
+				recordPatch,
+				recordPatch,
+				RespondWithJSON(http.StatusOK, buildNodePool("workers", "m5.xlarge", 1, 1)),
+				recordPatch,
+				RespondWithJSON(http.StatusOK, buildNodePool("workers", "m5.xlarge", 0, 0)),
+				record(http.MethodDelete, nodePoolsPath+"/workers", RespondWithJSON(http.StatusNoContent, "")),
+			)
+			stdout, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stdout).To(ContainSubstring("Disabling autoscaling of machine pool 'workers' at 3 replicas"))
+			Expect(patches).To(HaveLen(3))
+			for i, replicas := range []int{3, 1, 0} {
+				_, autoscaling := patches[i].GetAutoscaling()
+				Expect(autoscaling).To(BeFalse())
+				Expect(patches[i].Replicas()).To(Equal(replicas))
+			}
+			Expect(requests).To(HaveLen(5))
+		})
+
+		It("Resumes an interrupted replacement", func() {
+			Cmd.Flags().Set("machinepool", "workers")
+			Cmd.Flags().Set("instance-type", "r6i.2xlarge")
+			testRuntime.ApiServer.AppendHandlers(
+				RespondWithJSON(http.StatusOK, hcpCluster),
+				RespondWithJSON(http.StatusOK, buildNodePool("workers", "m5.xlarge", 1, 1)),
+				RespondWithJSON(http.StatusOK, buildNodePool("workers-green", "r6i.2xlarge", 3, 3)),
+				RespondWithJSON(http.StatusOK, buildNodePool("workers-green", "r6i.2xlarge", 3, 3)),
+				record(http.MethodPatch, nodePoolsPath+"/workers", RespondWithJSON(http.StatusOK, "{}")),
+				RespondWithJSON(http.StatusOK, buildNodePool("workers", "m5.xlarge", 0, 0)),
+				record(http.MethodDelete, nodePoolsPath+"/workers", RespondWithJSON(http.StatusNoContent, "")),
+			)
+			stdout, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stdout).To(ContainSubstring(
+				"Resuming the replacement of machine pool 'workers' by machine pool 'workers-green'"))
+			Expect(requests).To(Equal([]string{
+				http.MethodPatch + " " + nodePoolsPath + "/workers",
+				http.MethodDelete + " " + nodePoolsPath + "/workers",
+			}))
+		})
+
+		It("Reports a machine pool that has already been replaced", func() {
+			Cmd.Flags().Set("machinepool", "workers")
+			Cmd.Flags().Set("instance-type", "r6i.2xlarge")
+			testRuntime.ApiServer.AppendHandlers(
+				RespondWithJSON(http.StatusOK, hcpCluster),
+				RespondWithJSON(http.StatusNotFound, "{}"),
+				RespondWithJSON(http.StatusOK, buildNodePool("workers-green", "r6i.2xlarge", 3, 3)),
+			)
+			stdout, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stdout).To(ContainSubstring(
+				"Machine pool 'workers' of cluster 'cluster1' has already been replaced by machine pool " +
+					"'workers-green'"))
+		})
+	})
+})
@@ -0,0 +1,348 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package versions
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	ver "github.com/hashicorp/go-version"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/helper"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+// clusterPageSize is the number of clusters requested per page, GetClusters keeps requesting pages
+// until it gets one that isn't full so clusters beyond the first page are reported as well
+var clusterPageSize = 100
+
+const (
+	formatTable = "table"
+	formatCSV   = "csv"
+	formatHTML  = "html"
+)
+
+var formats = []string{formatTable, formatCSV, formatHTML}
+
+const (
+	rolesNotApplicable = "N/A"
+	rolesUnknown       = "Unknown"
+)
+
+var args struct {
+	all    bool
+	format string
+}
+
+var Cmd = &cobra.Command{
+	Use:   "versions",
+	Short: "Report the versions, upgrades and end of life of clusters",
+	Long: "Report the current version of each cluster along with the latest z-stream and minor upgrades " +
+		"available, the days left until the version reaches end of life, the scheduled upgrades and whether " +
+		"the account and operator role policies need to be upgraded before the next minor upgrade.",
+	Example: `  # Report the versions of the clusters of the current AWS account
+  rosa report versions
+
+  # Export the report of all clusters of the organization as CSV
+  rosa report versions --all --format csv > versions.csv
+
+  # Print the report of the clusters of the current AWS account as JSON
+  rosa report versions -o json`,
+	Run:  run,
+	Args: cobra.NoArgs,
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	flags.BoolVarP(
+		&args.all,
+		"all",
+		"a",
+		false,
+		"Report on all clusters across different AWS accounts under the same Red Hat organization",
+	)
+	flags.StringVar(
+		&args.format,
+		"format",
+		formatTable,
+		fmt.Sprintf("Format of the report. Allowed formats are %s", formats),
+	)
+	Cmd.RegisterFlagCompletionFunc("format", func(_ *cobra.Command, _ []string,
+		_ string) ([]string, cobra.ShellCompDirective) {
+		return formats, cobra.ShellCompDirectiveDefault
+	})
+	output.AddFlag(Cmd)
+}
+
+func run(cmd *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS().WithOCM()
+	defer r.Cleanup()
+	err := runWithRuntime(r, cmd)
+	if err != nil {
+		r.Reporter.Errorf(err.Error())
+		os.Exit(1)
+	}
+}
+
+// clusterVersionReport is a row of the report, the JSON field names are also used as CSV headers
+type clusterVersionReport struct {
+	ID                 string     `json:"id"`
+	Name               string     `json:"name"`
+	Version            string     `json:"version"`
+	ChannelGroup       string     `json:"channel_group"`
+	LatestZUpgrade     string     `json:"latest_z_upgrade,omitempty"`
+	LatestYUpgrade     string     `json:"latest_y_upgrade,omitempty"`
+	EndOfLife          *time.Time `json:"end_of_life,omitempty"`
+	DaysToEndOfLife    *int       `json:"days_to_end_of_life,omitempty"`
+	ScheduledUpgrade   string     `json:"scheduled_upgrade,omitempty"`
+	ScheduledUpgradeAt *time.Time `json:"scheduled_upgrade_at,omitempty"`
+	RolesUpgrade       string     `json:"roles_upgrade"`
+}
+
+func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command) error {
+	if !helper.Contains(formats, args.format) {
+		return fmt.Errorf("Invalid format '%s', allowed formats are %s", args.format, formats)
+	}
+	if output.HasFlag() && args.format != formatTable {
+		return fmt.Errorf("Flags '--format' and '--%s' are mutually exclusive", output.FLAG_NAME)
+	}
+
+	creator := r.Creator
+	if args.all {
+		creator = nil
+	}
+	clusters, err := r.OCMClient.GetClusters(creator, clusterPageSize)
+	if err != nil {
+		return fmt.Errorf("Failed to get clusters: %v", err)
+	}
+	if len(clusters) == 0 {
+		r.Reporter.Infof("There are no clusters to report on")
+		return nil
+	}
+	sort.Slice(clusters, func(i, j int) bool {
+		return clusters[i].Name() < clusters[j].Name()
+	})
+
+	builder := newReportBuilder(r)
+	reports := make([]clusterVersionReport, 0, len(clusters))
+	for _, cluster := range clusters {
+		reports = append(reports, builder.build(cluster))
+	}
+
+	if output.HasFlag() {
+		return output.Print(reports)
+	}
+	switch args.format {
+	case formatCSV:
+		return printCSV(os.Stdout, reports)
+	case formatHTML:
+		return printHTML(os.Stdout, reports)
+	}
+	printTable(os.Stdout, reports)
+	closeToEol := 0
+	for _, report := range reports {
+		if report.DaysToEndOfLife != nil && *report.DaysToEndOfLife <= ocm.CloseToEolDays {
+			closeToEol++
+		}
+	}
+	if closeToEol > 0 {
+		r.Reporter.Warnf("%d of %d clusters run a version that reaches end of life within %d days",
+			closeToEol, len(reports), ocm.CloseToEolDays)
+	}
+	return nil
+}
+
+// reportBuilder caches what is shared by the clusters of the report, like the end of life of
+// each version
+type reportBuilder struct {
+	r            *rosa.Runtime
+	now          time.Time
+	endOfLife    map[string]time.Time
+	credRequests map[bool]map[string]*cmv1.STSOperator
+}
+
+func newReportBuilder(r *rosa.Runtime) *reportBuilder {
+	return &reportBuilder{
+		r:            r,
+		now:          time.Now().UTC(),
+		endOfLife:    map[string]time.Time{},
+		credRequests: map[bool]map[string]*cmv1.STSOperator{},
+	}
+}
+
+func (b *reportBuilder) build(cluster *cmv1.Cluster) clusterVersionReport {
+	version := clusterVersion(cluster)
+	report := clusterVersionReport{
+		ID:           cluster.ID(),
+		Name:         cluster.Name(),
+		Version:      version,
+		ChannelGroup: cluster.Version().ChannelGroup(),
+	}
+	availableUpgrades := ocm.GetAvailableUpgradesByCluster(cluster)
+	report.LatestZUpgrade = latestUpgrade(availableUpgrades, func(availableUpgrade string) bool {
+		return ocm.GetVersionMinor(availableUpgrade) == ocm.GetVersionMinor(version)
+	})
+	report.LatestYUpgrade = latestUpgrade(availableUpgrades, func(availableUpgrade string) bool {
+		return ocm.GetVersionMinor(availableUpgrade) != ocm.GetVersionMinor(version)
+	})
+
+	endOfLife, err := b.getEndOfLife(version, report.ChannelGroup)
+	if err != nil {
+		b.r.Reporter.Warnf("Failed to get the end of life of version '%s' for cluster '%s': %v",
+			version, cluster.Name(), err)
+	} else if !endOfLife.IsZero() {
+		days := int(math.Floor(endOfLife.Sub(b.now).Hours() / 24))
+		report.EndOfLife = &endOfLife
+		report.DaysToEndOfLife = &days
+	}
+
+	err = b.setScheduledUpgrade(&report, cluster)
+	if err != nil {
+		b.r.Reporter.Warnf("Failed to get the scheduled upgrades of cluster '%s': %v", cluster.Name(), err)
+	}
+
+	// Role policies only need to be upgraded for the next minor version
+	targetMinor := ocm.GetVersionMinor(version)
+	if report.LatestYUpgrade != "" {
+		targetMinor = ocm.GetVersionMinor(report.LatestYUpgrade)
+	}
+	report.RolesUpgrade, err = b.getRolesUpgrade(cluster, targetMinor)
+	if err != nil {
+		b.r.Reporter.Warnf("Failed to check the role policies of cluster '%s': %v", cluster.Name(), err)
+	}
+	return report
+}
+
+func (b *reportBuilder) getEndOfLife(version string, channelGroup string) (time.Time, error) {
+	key := fmt.Sprintf("%s/%s", channelGroup, version)
+	if endOfLife, ok := b.endOfLife[key]; ok {
+		return endOfLife, nil
+	}
+	endOfLife, err := b.r.OCMClient.GetVersionEndOfLife(version, channelGroup)
+	if err != nil {
+		return endOfLife, err
+	}
+	b.endOfLife[key] = endOfLife
+	return endOfLife, nil
+}
+
+func (b *reportBuilder) setScheduledUpgrade(report *clusterVersionReport, cluster *cmv1.Cluster) error {
+	if ocm.IsHyperShiftCluster(cluster) {
+		scheduledUpgrade, err := b.r.OCMClient.GetControlPlaneScheduledUpgrade(cluster.ID())
+		if err != nil || scheduledUpgrade == nil {
+			return err
+		}
+		report.ScheduledUpgrade = scheduledUpgrade.Version()
+		if nextRun := scheduledUpgrade.NextRun(); !nextRun.IsZero() {
+			report.ScheduledUpgradeAt = &nextRun
+		}
+		return nil
+	}
+	scheduledUpgrade, _, err := b.r.OCMClient.GetScheduledUpgrade(cluster.ID())
+	if err != nil || scheduledUpgrade == nil {
+		return err
+	}
+	report.ScheduledUpgrade = scheduledUpgrade.Version()
+	if nextRun := scheduledUpgrade.NextRun(); !nextRun.IsZero() {
+		report.ScheduledUpgradeAt = &nextRun
+	}
+	return nil
+}
+
+// getRolesUpgrade checks whether the account and operator role policies of the cluster need to be
+// upgraded for the given minor version. The roles can only be checked when they are not managed by
+// Red Hat and they belong to the current AWS account.
+func (b *reportBuilder) getRolesUpgrade(cluster *cmv1.Cluster, minor string) (string, error) {
+	roleARN := cluster.AWS().STS().RoleARN()
+	if roleARN == "" || cluster.AWS().STS().ManagedPolicies() {
+		return rolesNotApplicable, nil
+	}
+	parsedARN, err := arn.Parse(roleARN)
+	if err != nil {
+		return rolesUnknown, err
+	}
+	if parsedARN.AccountID != b.r.Creator.AccountID {
+		return rolesUnknown, nil
+	}
+
+	upgradeNeeded, err := b.r.AWSClient.IsUpgradedNeededForAccountRolePoliciesUsingCluster(cluster, minor)
+	if err != nil {
+		return rolesUnknown, err
+	}
+	if upgradeNeeded {
+		return output.Yes, nil
+	}
+
+	isHypershift := ocm.IsHyperShiftCluster(cluster)
+	credRequests, ok := b.credRequests[isHypershift]
+	if !ok {
+		credRequests, err = b.r.OCMClient.GetCredRequests(isHypershift)
+		if err != nil {
+			return rolesUnknown, err
+		}
+		b.credRequests[isHypershift] = credRequests
+	}
+	operatorRolePolicyPrefix, err := aws.GetOperatorRolePolicyPrefixFromCluster(cluster, b.r.AWSClient)
+	if err != nil {
+		return rolesUnknown, err
+	}
+	upgradeNeeded, err = b.r.AWSClient.IsUpgradedNeededForOperatorRolePoliciesUsingCluster(cluster,
+		b.r.Creator.Partition, b.r.Creator.AccountID, minor, credRequests, operatorRolePolicyPrefix)
+	if err != nil {
+		return rolesUnknown, err
+	}
+	return output.PrintBool(upgradeNeeded), nil
+}
+
+func clusterVersion(cluster *cmv1.Cluster) string {
+	if version := cluster.Version().RawID(); version != "" {
+		return version
+	}
+	return cluster.OpenshiftVersion()
+}
+
+// latestUpgrade returns the greatest of the available upgrades accepted by the filter
+func latestUpgrade(availableUpgrades []string, filter func(string) bool) string {
+	var latest *ver.Version
+	for _, availableUpgrade := range availableUpgrades {
+		if !filter(availableUpgrade) {
+			continue
+		}
+		candidate, err := ver.NewVersion(availableUpgrade)
+		if err != nil {
+			continue
+		}
+		if latest == nil || candidate.GreaterThan(latest) {
+			latest = candidate
+		}
+	}
+	if latest == nil {
+		return ""
+	}
+	return latest.Original()
+}
@@ -0,0 +1,199 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package versions
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/ghttp"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	. "github.com/openshift-online/ocm-sdk-go/testing"
+	"go.uber.org/mock/gomock"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/test"
+)
+
+var _ = Describe("Report versions", func() {
+	var testRuntime test.TestingRuntime
+	var awsClient *aws.MockClient
+	var endOfLife time.Time
+
+	BeforeEach(func() {
+		testRuntime.InitRuntime()
+		awsClient = aws.NewMockClient(gomock.NewController(GinkgoT()))
+		testRuntime.RosaRuntime.AWSClient = awsClient
+		endOfLife = time.Now().UTC().Add(30*24*time.Hour + time.Hour)
+		DeferCleanup(func() {
+			args.all = false
+			args.format = formatTable
+			clusterPageSize = 100
+			output.SetOutput("")
+		})
+		args.format = formatTable
+	})
+
+	versionList := func(endOfLife time.Time) string {
+		return fmt.Sprintf(`{"kind": "VersionList", "page": 1, "size": 1, "total": 1, "items": [`+
+			`{"kind": "Version", "id": "openshift-v4.14.5", "raw_id": "4.14.5", "end_of_life_timestamp": "%s"}]}`,
+			endOfLife.Format(time.RFC3339))
+	}
+
+	It("Fails with an unknown format", func() {
+		args.format = "xlsx"
+		_, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+		Expect(err).To(MatchError("Invalid format 'xlsx', allowed formats are [table csv html]"))
+	})
+
+	It("Fails when both a format and an output are given", func() {
+		args.format = formatCSV
+		output.SetOutput("json")
+		_, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+		Expect(err).To(MatchError("Flags '--format' and '--output' are mutually exclusive"))
+	})
+
+	It("Reports that there are no clusters", func() {
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, test.FormatClusterList(nil)))
+		stdout, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+		Expect(err).To(BeNil())
+		Expect(stdout).To(Equal("INFO: There are no clusters to report on\n"))
+	})
+
+	It("Reports versions, upgrades, end of life, scheduled upgrades and roles", func() {
+		hosted := test.MockCluster(func(c *cmv1.ClusterBuilder) {
+			c.ID("hosted-id").Name("hosted")
+			c.Hypershift(cmv1.NewHypershift().Enabled(true))
+			c.Version(cmv1.NewVersion().ID("openshift-v4.14.5").RawID("4.14.5").ChannelGroup("stable").
+				AvailableUpgrades("4.14.6", "4.14.8", "4.15.2", "4.15.0"))
+			c.AWS(cmv1.NewAWS().STS(cmv1.NewSTS().
+				RoleARN("arn:aws:iam::123:role/prefix-HCP-ROSA-Installer-Role")))
+		})
+		classic := test.MockCluster(func(c *cmv1.ClusterBuilder) {
+			c.ID("classic-id").Name("classic")
+			c.Version(cmv1.NewVersion().ID("openshift-v4.14.5").RawID("4.14.5").ChannelGroup("stable"))
+			c.AWS(cmv1.NewAWS().STS(cmv1.NewSTS().
+				RoleARN("arn:aws:iam::456:role/prefix-Installer-Role")))
+		})
+		nextRun, err := time.Parse(time.RFC3339, "2024-03-02T02:30:00Z")
+		Expect(err).To(BeNil())
+		scheduled, err := cmv1.NewControlPlaneUpgradePolicy().ID("policy").
+			UpgradeType(cmv1.UpgradeTypeControlPlane).Version("4.14.8").NextRun(nextRun).Build()
+		Expect(err).To(BeNil())
+
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK,
+			test.FormatClusterList([]*cmv1.Cluster{hosted, classic})))
+		// The classic cluster is reported first, the end of life of its version is then reused
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, versionList(endOfLife)))
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK,
+			`{"kind": "UpgradePolicyList", "page": 1, "size": 0, "total": 0, "items": []}`))
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK,
+			test.FormatControlPlaneUpgradePolicyList([]*cmv1.ControlPlaneUpgradePolicy{scheduled})))
+		awsClient.EXPECT().IsUpgradedNeededForAccountRolePoliciesUsingCluster(gomock.Any(), "4.15").Return(true, nil)
+
+		stdout, stderr, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+		Expect(err).To(BeNil())
+		Expect(stdout).To(Equal(fmt.Sprintf(
+			"NAME     VERSION  CHANNEL GROUP  LATEST Z  LATEST Y  END OF LIFE  DAYS TO EOL  "+
+				"SCHEDULED UPGRADE               ROLES UPGRADE\n"+
+				"classic  4.14.5   stable                             %s   30           "+
+				"                                Unknown\n"+
+				"hosted   4.14.5   stable         4.14.8    4.15.2    %s   30           "+
+				"4.14.8 on 2024-03-02 02:30 UTC  Yes\n",
+			endOfLife.Format(time.DateOnly), endOfLife.Format(time.DateOnly))))
+		Expect(stderr).To(ContainSubstring(
+			"WARN: 2 of 2 clusters run a version that reaches end of life within 60 days"))
+	})
+
+	It("Reports the roles as not applicable when the policies are managed", func() {
+		cluster := test.MockCluster(func(c *cmv1.ClusterBuilder) {
+			c.ID("hosted-id").Name("hosted")
+			c.Hypershift(cmv1.NewHypershift().Enabled(true))
+			c.Version(cmv1.NewVersion().ID("openshift-v4.14.5").RawID("4.14.5").ChannelGroup("stable"))
+			c.AWS(cmv1.NewAWS().STS(cmv1.NewSTS().ManagedPolicies(true).
+				RoleARN("arn:aws:iam::123:role/prefix-HCP-ROSA-Installer-Role")))
+		})
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK,
+			test.FormatClusterList([]*cmv1.Cluster{cluster})))
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK,
+			`{"kind": "VersionList", "page": 1, "size": 0, "total": 0, "items": []}`))
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK,
+			test.FormatControlPlaneUpgradePolicyList(nil)))
+
+		output.SetOutput("json")
+		stdout, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+		Expect(err).To(BeNil())
+		Expect(stdout).To(MatchJSON(`[{
+			"id": "hosted-id",
+			"name": "hosted",
+			"version": "4.14.5",
+			"channel_group": "stable",
+			"roles_upgrade": "N/A"
+		}]`))
+	})
+
+	It("Reports the clusters of all the pages", func() {
+		clusters := []*cmv1.Cluster{}
+		for _, name := range []string{"cluster1", "cluster2"} {
+			clusters = append(clusters, test.MockCluster(func(c *cmv1.ClusterBuilder) {
+				c.ID(name + "-id").Name(name)
+				c.Hypershift(cmv1.NewHypershift().Enabled(true))
+				c.Version(cmv1.NewVersion().ID("openshift-v4.14.5").RawID("4.14.5").ChannelGroup("stable"))
+			}))
+		}
+		clusterPageSize = 1
+		testRuntime.ApiServer.AppendHandlers(
+			CombineHandlers(
+				VerifyFormKV("page", "1"),
+				RespondWithJSON(http.StatusOK, test.FormatClusterList(clusters[:1])),
+			),
+			CombineHandlers(
+				VerifyFormKV("page", "2"),
+				RespondWithJSON(http.StatusOK, test.FormatClusterList(clusters[1:])),
+			),
+			CombineHandlers(
+				VerifyFormKV("page", "3"),
+				RespondWithJSON(http.StatusOK, test.FormatClusterList(nil)),
+			),
+			RespondWithJSON(http.StatusOK,
+				`{"kind": "VersionList", "page": 1, "size": 0, "total": 0, "items": []}`),
+			RespondWithJSON(http.StatusOK, test.FormatControlPlaneUpgradePolicyList(nil)),
+			RespondWithJSON(http.StatusOK, test.FormatControlPlaneUpgradePolicyList(nil)),
+		)
+
+		output.SetOutput("json")
+		stdout, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+		Expect(err).To(BeNil())
+		Expect(stdout).To(MatchJSON(`[{
+			"id": "cluster1-id",
+			"name": "cluster1",
+			"version": "4.14.5",
+			"channel_group": "stable",
+			"roles_upgrade": "N/A"
+		}, {
+			"id": "cluster2-id",
+			"name": "cluster2",
+			"version": "4.14.5",
+			"channel_group": "stable",
+			"roles_upgrade": "N/A"
+		}]`))
+	})
+})
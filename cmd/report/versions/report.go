@@ -0,0 +1,158 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package versions
+
+import (
+	"encoding/csv"
+	"fmt"
+	"html/template"
+	"io"
+	"strconv"
+	"text/tabwriter"
+	"time"
+)
+
+var csvHeader = []string{
+	"id",
+	"name",
+	"version",
+	"channel_group",
+	"latest_z_upgrade",
+	"latest_y_upgrade",
+	"end_of_life",
+	"days_to_end_of_life",
+	"scheduled_upgrade",
+	"scheduled_upgrade_at",
+	"roles_upgrade",
+}
+
+func formatDate(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.DateOnly)
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format("2006-01-02 15:04 MST")
+}
+
+func formatDays(days *int) string {
+	if days == nil {
+		return ""
+	}
+	return strconv.Itoa(*days)
+}
+
+// formatScheduledUpgrade prints the version of the scheduled upgrade along with when it will run
+func formatScheduledUpgrade(report clusterVersionReport) string {
+	if report.ScheduledUpgrade == "" || report.ScheduledUpgradeAt == nil {
+		return report.ScheduledUpgrade
+	}
+	return fmt.Sprintf("%s on %s", report.ScheduledUpgrade, formatTime(report.ScheduledUpgradeAt))
+}
+
+func printTable(w io.Writer, reports []clusterVersionReport) {
+	writer := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "NAME\tVERSION\tCHANNEL GROUP\tLATEST Z\tLATEST Y\tEND OF LIFE\tDAYS TO EOL\t"+
+		"SCHEDULED UPGRADE\tROLES UPGRADE\n")
+	for _, report := range reports {
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			report.Name,
+			report.Version,
+			report.ChannelGroup,
+			report.LatestZUpgrade,
+			report.LatestYUpgrade,
+			formatDate(report.EndOfLife),
+			formatDays(report.DaysToEndOfLife),
+			formatScheduledUpgrade(report),
+			report.RolesUpgrade,
+		)
+	}
+	writer.Flush()
+}
+
+func printCSV(w io.Writer, reports []clusterVersionReport) error {
+	writer := csv.NewWriter(w)
+	err := writer.Write(csvHeader)
+	if err != nil {
+		return err
+	}
+	for _, report := range reports {
+		err = writer.Write([]string{
+			report.ID,
+			report.Name,
+			report.Version,
+			report.ChannelGroup,
+			report.LatestZUpgrade,
+			report.LatestYUpgrade,
+			formatDate(report.EndOfLife),
+			formatDays(report.DaysToEndOfLife),
+			report.ScheduledUpgrade,
+			formatTime(report.ScheduledUpgradeAt),
+			report.RolesUpgrade,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+var htmlReport = template.Must(template.New("report").Funcs(template.FuncMap{
+	"date":      formatDate,
+	"days":      formatDays,
+	"scheduled": formatScheduledUpgrade,
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>ROSA cluster versions</title>
+<style>
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; }
+</style>
+</head>
+<body>
+<h1>ROSA cluster versions</h1>
+<p>Generated on {{ .Generated }}</p>
+<table>
+<tr><th>Name</th><th>ID</th><th>Version</th><th>Channel group</th><th>Latest z</th><th>Latest y</th>` +
+	`<th>End of life</th><th>Days to EOL</th><th>Scheduled upgrade</th><th>Roles upgrade</th></tr>
+{{- range .Reports }}
+<tr><td>{{ .Name }}</td><td>{{ .ID }}</td><td>{{ .Version }}</td><td>{{ .ChannelGroup }}</td>` +
+	`<td>{{ .LatestZUpgrade }}</td><td>{{ .LatestYUpgrade }}</td><td>{{ date .EndOfLife }}</td>` +
+	`<td>{{ days .DaysToEndOfLife }}</td><td>{{ scheduled . }}</td><td>{{ .RolesUpgrade }}</td></tr>
+{{- end }}
+</table>
+</body>
+</html>
+`))
+
+func printHTML(w io.Writer, reports []clusterVersionReport) error {
+	return htmlReport.Execute(w, struct {
+		Generated string
+		Reports   []clusterVersionReport
+	}{
+		Generated: time.Now().UTC().Format("2006-01-02 15:04 MST"),
+		Reports:   reports,
+	})
+}
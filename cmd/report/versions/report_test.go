@@ -0,0 +1,78 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package versions
+
+import (
+	"bytes"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Report formats", func() {
+	var reports []clusterVersionReport
+
+	BeforeEach(func() {
+		endOfLife, err := time.Parse(time.RFC3339, "2024-06-27T00:00:00Z")
+		Expect(err).To(BeNil())
+		nextRun, err := time.Parse(time.RFC3339, "2024-03-02T02:30:00Z")
+		Expect(err).To(BeNil())
+		days := 45
+		reports = []clusterVersionReport{
+			{
+				ID:                 "id1",
+				Name:               "cluster1",
+				Version:            "4.14.5",
+				ChannelGroup:       "stable",
+				LatestZUpgrade:     "4.14.8",
+				LatestYUpgrade:     "4.15.2",
+				EndOfLife:          &endOfLife,
+				DaysToEndOfLife:    &days,
+				ScheduledUpgrade:   "4.14.8",
+				ScheduledUpgradeAt: &nextRun,
+				RolesUpgrade:       "No",
+			},
+			{
+				ID:           "id2",
+				Name:         "cluster<2>",
+				Version:      "4.15.2",
+				ChannelGroup: "stable",
+				RolesUpgrade: "N/A",
+			},
+		}
+	})
+
+	It("Prints the report as CSV", func() {
+		var b bytes.Buffer
+		Expect(printCSV(&b, reports)).To(Succeed())
+		Expect(b.String()).To(Equal(
+			"id,name,version,channel_group,latest_z_upgrade,latest_y_upgrade,end_of_life,days_to_end_of_life," +
+				"scheduled_upgrade,scheduled_upgrade_at,roles_upgrade\n" +
+				"id1,cluster1,4.14.5,stable,4.14.8,4.15.2,2024-06-27,45,4.14.8,2024-03-02 02:30 UTC,No\n" +
+				"id2,cluster<2>,4.15.2,stable,,,,,,,N/A\n"))
+	})
+
+	It("Prints the report as HTML", func() {
+		var b bytes.Buffer
+		Expect(printHTML(&b, reports)).To(Succeed())
+		Expect(b.String()).To(ContainSubstring("<tr><td>cluster1</td><td>id1</td><td>4.14.5</td><td>stable</td>" +
+			"<td>4.14.8</td><td>4.15.2</td><td>2024-06-27</td><td>45</td><td>4.14.8 on 2024-03-02 02:30 UTC</td>" +
+			"<td>No</td></tr>"))
+		Expect(b.String()).To(ContainSubstring("<td>cluster&lt;2&gt;</td>"))
+	})
+})
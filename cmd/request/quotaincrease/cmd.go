@@ -0,0 +1,178 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quotaincrease
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/arguments"
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var Cmd = &cobra.Command{
+	Use:     "quota-increase",
+	Aliases: []string{"quota-increases", "quotaincrease"},
+	Short:   "Request AWS service quota increases needed for cluster install",
+	Long: "Request increases for every AWS service quota that is lower than the value needed " +
+		"to create a cluster. The requests are filed with AWS Service Quotas and can be tracked " +
+		"with 'rosa list quota-requests'.",
+	Example: `  # Request the AWS quota increases needed to create a cluster
+  rosa request quota-increase
+
+  # Request the AWS quota increases in a different region without confirmation
+  rosa request quota-increase --region=us-west-2 --yes`,
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+
+	confirm.AddFlag(flags)
+	output.AddFlag(Cmd)
+}
+
+func run(cmd *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithOCM()
+	defer r.Cleanup()
+
+	// Get AWS region
+	region, err := aws.GetRegion(arguments.GetRegion())
+	if err != nil {
+		r.Reporter.Errorf("Error getting region: %v", err)
+		os.Exit(1)
+	}
+
+	// Create the AWS client:
+	r.AWSClient, err = aws.NewClient().
+		Logger(r.Logger).
+		Region(region).
+		Build()
+	if err != nil {
+		r.Reporter.Errorf("Error creating AWS client: %v", err)
+		os.Exit(1)
+	}
+
+	err = runWithRuntime(r, cmd)
+	if err != nil {
+		r.Reporter.Errorf(err.Error())
+		os.Exit(1)
+	}
+}
+
+func runWithRuntime(r *rosa.Runtime, _ *cobra.Command) error {
+	if r.Reporter.IsTerminal() && !output.HasFlag() {
+		r.Reporter.Infof("Validating AWS quota...")
+	}
+	shortfalls, err := r.AWSClient.GetQuotaShortfalls()
+	if err != nil {
+		return err
+	}
+	if len(shortfalls) == 0 {
+		if output.HasFlag() {
+			return output.Print([]aws.QuotaIncreaseRequest{})
+		}
+		r.Reporter.Infof("AWS quota ok. No quota increase requests are needed")
+		return nil
+	}
+
+	// AWS rejects a new request while another one for the same quota is still open,
+	// so we reuse those instead of filing duplicates
+	existingRequests, err := r.AWSClient.ListServiceQuotaIncreaseRequests()
+	if err != nil {
+		return err
+	}
+	openRequests := map[string]aws.QuotaIncreaseRequest{}
+	for _, request := range existingRequests {
+		if request.IsOpen() {
+			openRequests[quotaKey(request.ServiceCode, request.QuotaCode)] = request
+		}
+	}
+
+	var requests []aws.QuotaIncreaseRequest
+	var missing []aws.QuotaShortfall
+	for _, shortfall := range shortfalls {
+		if request, ok := openRequests[quotaKey(shortfall.ServiceCode, shortfall.QuotaCode)]; ok {
+			requests = append(requests, request)
+			continue
+		}
+		missing = append(missing, shortfall)
+	}
+
+	if len(requests) > 0 && !output.HasFlag() {
+		r.Reporter.Infof("The following AWS service quota increase requests are already open and are reused:")
+		for _, request := range requests {
+			fmt.Printf("  - Request %s for service %s quota code %s %s: requested %d, status %s\n",
+				request.ID, request.ServiceCode, request.QuotaCode, request.QuotaName,
+				int(request.DesiredValue), request.Status)
+		}
+	}
+
+	if len(missing) > 0 {
+		if !output.HasFlag() {
+			r.Reporter.Warnf("The following AWS service quotas are insufficient for cluster install:")
+			for _, shortfall := range missing {
+				fmt.Printf("  - Service %s quota code %s %s: current %d, required %d\n",
+					shortfall.ServiceCode, shortfall.QuotaCode, shortfall.QuotaName,
+					int(shortfall.CurrentValue), int(shortfall.DesiredValue))
+			}
+		}
+		if !confirm.Prompt(true, "Request increase of %d AWS service quotas?", len(missing)) {
+			return nil
+		}
+		for _, shortfall := range missing {
+			request, err := r.AWSClient.RequestServiceQuotaIncrease(shortfall)
+			if err != nil {
+				return err
+			}
+			requests = append(requests, request)
+		}
+	}
+
+	if output.HasFlag() {
+		return output.Print(requests)
+	}
+
+	r.Reporter.Infof("AWS service quota increase requests are pending. " +
+		"Run 'rosa list quota-requests' to track their status")
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "ID\tSERVICE\tQUOTA CODE\tQUOTA NAME\tREQUESTED VALUE\tSTATUS\n")
+	for _, request := range requests {
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%d\t%s\n",
+			request.ID,
+			request.ServiceCode,
+			request.QuotaCode,
+			request.QuotaName,
+			int(request.DesiredValue),
+			request.Status,
+		)
+	}
+	writer.Flush()
+
+	return nil
+}
+
+func quotaKey(serviceCode string, quotaCode string) string {
+	return serviceCode + "/" + quotaCode
+}
@@ -0,0 +1,121 @@
+package quotaincrease
+
+import (
+	"strings"
+
+	"go.uber.org/mock/gomock"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/test"
+)
+
+var _ = Describe("Request quota increase", func() {
+	var (
+		t             *test.TestingRuntime
+		mockAwsClient *aws.MockClient
+	)
+
+	shortfall := aws.QuotaShortfall{
+		ServiceCode:  "vpc",
+		QuotaCode:    "L-F678F1CE",
+		QuotaName:    "VPCs per Region",
+		CurrentValue: 1,
+		DesiredValue: 5,
+	}
+
+	BeforeEach(func() {
+		t = test.NewTestRuntime()
+		mockAwsClient = aws.NewMockClient(gomock.NewController(GinkgoT()))
+		t.RosaRuntime.AWSClient = mockAwsClient
+		output.SetOutput("")
+		Cmd.Flags().Set("yes", "true")
+		DeferCleanup(func() {
+			Cmd.Flags().Set("yes", "false")
+		})
+	})
+
+	It("Does not file requests when quota is sufficient", func() {
+		mockAwsClient.EXPECT().GetQuotaShortfalls().Return(nil, nil)
+		stdout, stderr, err := test.RunWithOutputCapture(runWithRuntime, t.RosaRuntime, Cmd)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stderr).To(BeEmpty())
+		Expect(stdout).To(Equal("INFO: AWS quota ok. No quota increase requests are needed\n"))
+	})
+
+	It("Files a request for each shortfall", func() {
+		mockAwsClient.EXPECT().GetQuotaShortfalls().Return([]aws.QuotaShortfall{shortfall}, nil)
+		mockAwsClient.EXPECT().ListServiceQuotaIncreaseRequests().Return(nil, nil)
+		mockAwsClient.EXPECT().RequestServiceQuotaIncrease(shortfall).Return(aws.QuotaIncreaseRequest{
+			ID:           "request-1",
+			ServiceCode:  "vpc",
+			QuotaCode:    "L-F678F1CE",
+			QuotaName:    "VPCs per Region",
+			DesiredValue: 5,
+			Status:       "PENDING",
+		}, nil)
+		stdout, _, err := test.RunWithOutputCapture(runWithRuntime, t.RosaRuntime, Cmd)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stdout).To(ContainSubstring("Service vpc quota code L-F678F1CE VPCs per Region: current 1, required 5"))
+		Expect(stdout).To(ContainSubstring("request-1  vpc      L-F678F1CE  VPCs per Region  5                PENDING"))
+	})
+
+	It("Reuses requests that are still open", func() {
+		mockAwsClient.EXPECT().GetQuotaShortfalls().Return([]aws.QuotaShortfall{shortfall}, nil)
+		mockAwsClient.EXPECT().ListServiceQuotaIncreaseRequests().Return([]aws.QuotaIncreaseRequest{
+			{
+				ID:           "request-0",
+				ServiceCode:  "vpc",
+				QuotaCode:    "L-F678F1CE",
+				QuotaName:    "VPCs per Region",
+				DesiredValue: 5,
+				Status:       "CASE_OPENED",
+			},
+		}, nil)
+		stdout, _, err := test.RunWithOutputCapture(runWithRuntime, t.RosaRuntime, Cmd)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stdout).NotTo(ContainSubstring("insufficient"))
+		Expect(stdout).To(ContainSubstring("request-0"))
+		Expect(stdout).To(ContainSubstring("CASE_OPENED"))
+	})
+
+	It("Prints the reused requests before requesting the missing increases", func() {
+		subnets := aws.QuotaShortfall{
+			ServiceCode:  "vpc",
+			QuotaCode:    "L-407747CB",
+			QuotaName:    "Subnets per VPC",
+			CurrentValue: 10,
+			DesiredValue: 20,
+		}
+		mockAwsClient.EXPECT().GetQuotaShortfalls().Return([]aws.QuotaShortfall{shortfall, subnets}, nil)
+		mockAwsClient.EXPECT().ListServiceQuotaIncreaseRequests().Return([]aws.QuotaIncreaseRequest{
+			{
+				ID:           "request-0",
+				ServiceCode:  "vpc",
+				QuotaCode:    "L-F678F1CE",
+				QuotaName:    "VPCs per Region",
+				DesiredValue: 5,
+				Status:       "CASE_OPENED",
+			},
+		}, nil)
+		mockAwsClient.EXPECT().RequestServiceQuotaIncrease(subnets).Return(aws.QuotaIncreaseRequest{
+			ID:           "request-1",
+			ServiceCode:  "vpc",
+			QuotaCode:    "L-407747CB",
+			QuotaName:    "Subnets per VPC",
+			DesiredValue: 20,
+			Status:       "PENDING",
+		}, nil)
+		stdout, _, err := test.RunWithOutputCapture(runWithRuntime, t.RosaRuntime, Cmd)
+		Expect(err).NotTo(HaveOccurred())
+		reused := "  - Request request-0 for service vpc quota code L-F678F1CE VPCs per Region: " +
+			"requested 5, status CASE_OPENED\n"
+		missing := "  - Service vpc quota code L-407747CB Subnets per VPC: current 10, required 20\n"
+		Expect(stdout).To(ContainSubstring(reused))
+		Expect(stdout).To(ContainSubstring(missing))
+		Expect(strings.Index(stdout, reused)).To(BeNumerically("<", strings.Index(stdout, missing)))
+	})
+})
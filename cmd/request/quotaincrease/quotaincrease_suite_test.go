@@ -0,0 +1,13 @@
+package quotaincrease_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestQuotaIncrease(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Quota increase Suite")
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/openshift/rosa/cmd/attach"
 	"github.com/openshift/rosa/cmd/completion"
 	"github.com/openshift/rosa/cmd/config"
+	"github.com/openshift/rosa/cmd/cpy"
 	"github.com/openshift/rosa/cmd/create"
 	"github.com/openshift/rosa/cmd/describe"
 	"github.com/openshift/rosa/cmd/detach"
@@ -33,6 +34,7 @@ import (
 	"github.com/openshift/rosa/cmd/docs"
 	"github.com/openshift/rosa/cmd/download"
 	"github.com/openshift/rosa/cmd/edit"
+	"github.com/openshift/rosa/cmd/estimate"
 	"github.com/openshift/rosa/cmd/grant"
 	"github.com/openshift/rosa/cmd/hibernate"
 	"github.com/openshift/rosa/cmd/initialize"
@@ -43,9 +45,15 @@ import (
 	"github.com/openshift/rosa/cmd/logout"
 	"github.com/openshift/rosa/cmd/logs"
 	"github.com/openshift/rosa/cmd/register"
+	"github.com/openshift/rosa/cmd/replace"
+	"github.com/openshift/rosa/cmd/report"
+	"github.com/openshift/rosa/cmd/request"
 	"github.com/openshift/rosa/cmd/resume"
 	"github.com/openshift/rosa/cmd/revoke"
+	"github.com/openshift/rosa/cmd/rotate"
+	"github.com/openshift/rosa/cmd/sync"
 	"github.com/openshift/rosa/cmd/token"
+	"github.com/openshift/rosa/cmd/transfer"
 	"github.com/openshift/rosa/cmd/uninstall"
 	"github.com/openshift/rosa/cmd/unlink"
 	"github.com/openshift/rosa/cmd/upgrade"
@@ -80,9 +88,11 @@ func init() {
 	root.AddCommand(create.Cmd)
 	root.AddCommand(describe.Cmd)
 	root.AddCommand(dlt.Cmd)
+	root.AddCommand(cpy.Cmd)
 	root.AddCommand(docs.Cmd)
 	root.AddCommand(download.Cmd)
 	root.AddCommand(edit.Cmd)
+	root.AddCommand(estimate.Cmd)
 	root.AddCommand(grant.Cmd)
 	root.AddCommand(list.Cmd)
 	root.AddCommand(initialize.Cmd)
@@ -91,7 +101,13 @@ func init() {
 	root.AddCommand(logout.Cmd)
 	root.AddCommand(logs.Cmd)
 	root.AddCommand(register.Cmd)
+	root.AddCommand(request.Cmd)
+	root.AddCommand(replace.Cmd)
+	root.AddCommand(report.Cmd)
+	root.AddCommand(transfer.Cmd)
 	root.AddCommand(revoke.Cmd)
+	root.AddCommand(rotate.Cmd)
+	root.AddCommand(sync.Cmd)
 	root.AddCommand(uninstall.Cmd)
 	root.AddCommand(upgrade.Cmd)
 	root.AddCommand(verify.Cmd)
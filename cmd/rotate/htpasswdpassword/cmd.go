@@ -0,0 +1,145 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package htpasswdpassword
+
+import (
+	"fmt"
+	"os"
+
+	idputils "github.com/openshift-online/ocm-common/pkg/idp/utils"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/object"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var args struct {
+	idpName  string
+	username string
+}
+
+var Cmd = &cobra.Command{
+	Use:     "htpasswd-password",
+	Aliases: []string{"htpasswdpassword"},
+	Short:   "Rotate the password of a user of an HTPasswd identity provider",
+	Long: "Generate a strong password for a user of an HTPasswd identity provider and print it once. " +
+		"The password can't be retrieved afterwards.",
+	Example: `  # Rotate the password of user "breakfix" of a cluster named "mycluster"
+  rosa rotate htpasswd-password --cluster=mycluster --user=breakfix
+
+  # Rotate the password of user "breakfix" of the "htpasswd-1" identity provider
+  rosa rotate htpasswd-password --cluster=mycluster --idp=htpasswd-1 --user=breakfix`,
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	ocm.AddClusterFlag(Cmd)
+	flags.StringVar(
+		&args.idpName,
+		"idp",
+		"",
+		"Name of the HTPasswd identity provider of the user. "+
+			"Can be omitted when the cluster has a single HTPasswd identity provider.",
+	)
+	flags.StringVar(
+		&args.username,
+		"user",
+		"",
+		"Username of the user to rotate the password of.",
+	)
+	output.AddFlag(Cmd)
+}
+
+func run(cmd *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithOCM()
+	defer r.Cleanup()
+
+	err := runWithRuntime(r, cmd)
+	if err != nil {
+		r.Reporter.Errorf(err.Error())
+		os.Exit(1)
+	}
+}
+
+func runWithRuntime(r *rosa.Runtime, _ *cobra.Command) error {
+	if args.username == "" {
+		return fmt.Errorf("Expected the username of the user with '--user'")
+	}
+
+	clusterKey := r.GetClusterKey()
+	cluster := r.FetchCluster()
+
+	idps, err := r.OCMClient.GetIdentityProviders(cluster.ID())
+	if err != nil {
+		return fmt.Errorf("Failed to get identity providers for cluster '%s': %v", clusterKey, err)
+	}
+	htpasswdIDP, err := ocm.FindHTPasswdIdentityProvider(idps, args.idpName)
+	if err != nil {
+		return fmt.Errorf("Failed to find the HTPasswd identity provider of cluster '%s': %v", clusterKey, err)
+	}
+
+	userList, err := r.OCMClient.GetHTPasswdUserList(cluster.ID(), htpasswdIDP.ID())
+	if err != nil {
+		return fmt.Errorf("Failed to get the users of identity provider '%s': %v", htpasswdIDP.Name(), err)
+	}
+	userID := ""
+	for _, user := range userList.Slice() {
+		if user.Username() == args.username {
+			userID = user.ID()
+		}
+	}
+	if userID == "" {
+		return fmt.Errorf("User '%s' not found in identity provider '%s' of cluster '%s'",
+			args.username, htpasswdIDP.Name(), clusterKey)
+	}
+
+	r.Reporter.Debugf("Generating a random password for user '%s'", args.username)
+	password, err := idputils.GenerateRandomPassword()
+	if err != nil {
+		return fmt.Errorf("Failed to generate a random password: %v", err)
+	}
+	hashedPassword, err := idputils.GenerateHTPasswdCompatibleHash(password)
+	if err != nil {
+		return fmt.Errorf("Failed to hash the password: %v", err)
+	}
+	err = r.OCMClient.UpdateHTPasswdUser(cluster.ID(), htpasswdIDP.ID(), userID, hashedPassword)
+	if err != nil {
+		return fmt.Errorf("Failed to update the password of user '%s': %v", args.username, err)
+	}
+
+	if output.HasFlag() {
+		return output.Print(object.Object{
+			"api_url":  cluster.API().URL(),
+			"idp":      htpasswdIDP.Name(),
+			"username": args.username,
+			"password": password,
+		})
+	}
+
+	r.Reporter.Infof("Password of user '%s' of identity provider '%s' of cluster '%s' has been rotated.",
+		args.username, htpasswdIDP.Name(), clusterKey)
+	r.Reporter.Infof("Please securely store this generated password, it won't be shown again.")
+	fmt.Printf("\n   %s\n\n", password)
+	r.Reporter.Infof("It may take several minutes for the new password to become active.")
+	return nil
+}
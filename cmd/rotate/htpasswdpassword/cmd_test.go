@@ -0,0 +1,103 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package htpasswdpassword
+
+import (
+	"io"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	. "github.com/openshift-online/ocm-sdk-go/testing"
+	"github.com/spf13/pflag"
+
+	"github.com/openshift/rosa/pkg/test"
+)
+
+var _ = Describe("Rotate htpasswd-password", func() {
+	var (
+		testRuntime test.TestingRuntime
+		patch       *cmv1.HTPasswdUser
+	)
+
+	mockClusterReady := test.MockCluster(func(c *cmv1.ClusterBuilder) {
+		c.State(cmv1.ClusterStateReady)
+	})
+	clusterReady := test.FormatClusterList([]*cmv1.Cluster{mockClusterReady})
+	htpasswdIdp, err := cmv1.NewIdentityProvider().ID("htpasswd-id").Name("htpasswd-1").
+		Type(cmv1.IdentityProviderTypeHtpasswd).Build()
+	Expect(err).NotTo(HaveOccurred())
+	idps := test.FormatIDPList([]*cmv1.IdentityProvider{htpasswdIdp})
+	user, err := cmv1.NewHTPasswdUser().ID("user-id").Username("breakfix").Build()
+	Expect(err).NotTo(HaveOccurred())
+	users := test.FormatHtpasswdUserList([]*cmv1.HTPasswdUser{user})
+
+	BeforeEach(func() {
+		testRuntime.InitRuntime()
+		patch = nil
+		// Reset flags to avoid any side effect on other tests
+		Cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+			if flag.Name == "cluster" {
+				return
+			}
+			flag.Value.Set(flag.DefValue)
+			flag.Changed = false
+		})
+	})
+
+	It("Fails without a user", func() {
+		_, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+		Expect(err).To(MatchError("Expected the username of the user with '--user'"))
+	})
+
+	It("Fails if the user does not exist", func() {
+		Cmd.Flags().Set("user", "eleven")
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, clusterReady))
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, idps))
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, users))
+		_, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+		Expect(err).To(MatchError(
+			"User 'eleven' not found in identity provider 'htpasswd-1' of cluster 'cluster1'"))
+	})
+
+	It("Rotates the password of the user", func() {
+		Cmd.Flags().Set("user", "breakfix")
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, clusterReady))
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, idps))
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, users))
+		testRuntime.ApiServer.AppendHandlers(ghttp.CombineHandlers(
+			ghttp.VerifyRequest(http.MethodPatch, "/api/clusters_mgmt/v1/clusters/"+test.MockClusterID+
+				"/identity_providers/htpasswd-id/htpasswd_users/user-id"),
+			func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				Expect(err).NotTo(HaveOccurred())
+				patch, err = cmv1.UnmarshalHTPasswdUser(body)
+				Expect(err).NotTo(HaveOccurred())
+			},
+			RespondWithJSON(http.StatusOK, "{}"),
+		))
+		stdout, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stdout).To(ContainSubstring("Password of user 'breakfix' of identity provider 'htpasswd-1' " +
+			"of cluster 'cluster1' has been rotated"))
+		Expect(patch.HashedPassword()).NotTo(BeEmpty())
+		_, ok := patch.GetUsername()
+		Expect(ok).To(BeFalse())
+	})
+})
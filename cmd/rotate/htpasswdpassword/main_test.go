@@ -0,0 +1,13 @@
+package htpasswdpassword
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestRotateHtpasswdPassword(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Rotate htpasswd-password suite")
+}
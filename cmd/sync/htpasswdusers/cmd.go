@@ -0,0 +1,280 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package htpasswdusers
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/cmd/create/idp"
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var args struct {
+	idpName         string
+	htpasswdFile    string
+	prune           bool
+	updatePasswords bool
+	dryRun          bool
+}
+
+var Cmd = &cobra.Command{
+	Use:     "htpasswd-users",
+	Aliases: []string{"htpasswdusers", "htpasswd-user"},
+	Short:   "Sync the users of an HTPasswd identity provider with a htpasswd file",
+	Long: "Add the users of a htpasswd file that are missing from an HTPasswd identity provider. " +
+		"The hashed passwords of the existing users can't be read back from the identity provider, so they " +
+		"are only reset to the ones of the file with '--update-passwords'. With '--prune' the users of the " +
+		"identity provider that are not in the file are removed.",
+	Example: `  # Sync the users of the "htpasswd-1" identity provider of a cluster named "mycluster"
+  rosa sync htpasswd-users --cluster=mycluster --idp=htpasswd-1 --from-file=users.htpasswd
+
+  # Sync the users and reset the passwords of the existing users to the ones of the file
+  rosa sync htpasswd-users --cluster=mycluster --idp=htpasswd-1 --from-file=users.htpasswd --update-passwords
+
+  # Sync the users and remove the ones that are not in the file
+  rosa sync htpasswd-users --cluster=mycluster --idp=htpasswd-1 --from-file=users.htpasswd --prune
+
+  # Show the changes without applying them
+  rosa sync htpasswd-users --cluster=mycluster --idp=htpasswd-1 --from-file=users.htpasswd --dry-run`,
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	ocm.AddClusterFlag(Cmd)
+	flags.StringVar(
+		&args.idpName,
+		"idp",
+		"",
+		"Name of the HTPasswd identity provider to sync. "+
+			"Can be omitted when the cluster has a single HTPasswd identity provider.",
+	)
+	flags.StringVar(
+		&args.htpasswdFile,
+		"from-file",
+		"",
+		"Path to a well formed htpasswd file with the desired users.",
+	)
+	flags.BoolVar(
+		&args.prune,
+		"prune",
+		false,
+		"Remove the users of the identity provider that are not in the htpasswd file.",
+	)
+	flags.BoolVar(
+		&args.updatePasswords,
+		"update-passwords",
+		false,
+		"Reset the passwords of the existing users to the hashed passwords of the htpasswd file.",
+	)
+	flags.BoolVar(
+		&args.dryRun,
+		"dry-run",
+		false,
+		"Show the changes without applying them.",
+	)
+}
+
+func run(cmd *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithOCM()
+	defer r.Cleanup()
+
+	err := runWithRuntime(r, cmd)
+	if err != nil {
+		r.Reporter.Errorf(err.Error())
+		os.Exit(1)
+	}
+}
+
+// userChange is a user of the identity provider to add, update or remove
+type userChange struct {
+	ID             string
+	Username       string
+	HashedPassword string
+}
+
+type syncPlan struct {
+	Add    []userChange
+	Update []userChange
+	Remove []userChange
+	// Unknown are the existing users whose password may differ from the file but isn't updated
+	Unknown []userChange
+}
+
+func (p *syncPlan) empty() bool {
+	return len(p.Add) == 0 && len(p.Update) == 0 && len(p.Remove) == 0
+}
+
+// planSync compares the desired users with the existing users of the identity provider. The API doesn't
+// return the hashed passwords of the existing users, so they can't be compared: the existing users are
+// updated when updatePasswords is set and reported as unknown otherwise.
+func planSync(desired map[string]string, existing []*cmv1.HTPasswdUser, prune bool,
+	updatePasswords bool) *syncPlan {
+	plan := &syncPlan{}
+	existingUsers := map[string]*cmv1.HTPasswdUser{}
+	for _, user := range existing {
+		existingUsers[user.Username()] = user
+	}
+
+	for username, hashedPassword := range desired {
+		user, ok := existingUsers[username]
+		if !ok {
+			plan.Add = append(plan.Add, userChange{Username: username, HashedPassword: hashedPassword})
+			continue
+		}
+		change := userChange{
+			ID:             user.ID(),
+			Username:       username,
+			HashedPassword: hashedPassword,
+		}
+		if updatePasswords {
+			plan.Update = append(plan.Update, change)
+		} else {
+			plan.Unknown = append(plan.Unknown, change)
+		}
+	}
+	if prune {
+		for username, user := range existingUsers {
+			if _, ok := desired[username]; !ok {
+				plan.Remove = append(plan.Remove, userChange{ID: user.ID(), Username: username})
+			}
+		}
+	}
+
+	for _, changes := range [][]userChange{plan.Add, plan.Update, plan.Remove, plan.Unknown} {
+		sort.Slice(changes, func(i, j int) bool {
+			return changes[i].Username < changes[j].Username
+		})
+	}
+	return plan
+}
+
+func runWithRuntime(r *rosa.Runtime, _ *cobra.Command) error {
+	if args.htpasswdFile == "" {
+		return fmt.Errorf("Expected the path of a htpasswd file with '--from-file'")
+	}
+	desired := map[string]string{}
+	err := idp.ParseHtpasswordFile(&desired, args.htpasswdFile)
+	if err != nil {
+		return fmt.Errorf("Failed to load htpasswd file '%s': %v", args.htpasswdFile, err)
+	}
+	if len(desired) == 0 {
+		return fmt.Errorf("Expected at least one user in htpasswd file '%s'", args.htpasswdFile)
+	}
+	for username := range desired {
+		err = idp.UsernameValidator(username)
+		if err != nil {
+			return err
+		}
+	}
+
+	clusterKey := r.GetClusterKey()
+	cluster := r.FetchCluster()
+
+	idps, err := r.OCMClient.GetIdentityProviders(cluster.ID())
+	if err != nil {
+		return fmt.Errorf("Failed to get identity providers for cluster '%s': %v", clusterKey, err)
+	}
+	htpasswdIDP, err := ocm.FindHTPasswdIdentityProvider(idps, args.idpName)
+	if err != nil {
+		return fmt.Errorf("Failed to find the HTPasswd identity provider of cluster '%s': %v", clusterKey, err)
+	}
+
+	userList, err := r.OCMClient.GetHTPasswdUserList(cluster.ID(), htpasswdIDP.ID())
+	if err != nil {
+		return fmt.Errorf("Failed to get the users of identity provider '%s': %v", htpasswdIDP.Name(), err)
+	}
+	plan := planSync(desired, userList.Slice(), args.prune, args.updatePasswords)
+
+	// Keep the cluster admin user unless it is explicitly managed by the file
+	for i, user := range plan.Remove {
+		if user.Username == idp.ClusterAdminUsername {
+			r.Reporter.Warnf("Keeping user '%s', delete it with 'rosa delete admin'", user.Username)
+			plan.Remove = append(plan.Remove[:i], plan.Remove[i+1:]...)
+			break
+		}
+	}
+
+	if len(plan.Unknown) > 0 {
+		r.Reporter.Infof("Password of %d existing users is unknown and isn't updated, "+
+			"use '--update-passwords' to reset them to the ones of the file", len(plan.Unknown))
+	}
+	if plan.empty() {
+		r.Reporter.Infof("Users of identity provider '%s' of cluster '%s' are in sync", htpasswdIDP.Name(),
+			clusterKey)
+		return nil
+	}
+	for _, user := range plan.Add {
+		r.Reporter.Infof("Adding user '%s'", user.Username)
+	}
+	for _, user := range plan.Update {
+		r.Reporter.Infof("Resetting the password of user '%s'", user.Username)
+	}
+	for _, user := range plan.Remove {
+		r.Reporter.Infof("Removing user '%s'", user.Username)
+	}
+	if args.dryRun {
+		return nil
+	}
+	if len(plan.Remove) > 0 && !confirm.Confirm("remove %d users from identity provider '%s'",
+		len(plan.Remove), htpasswdIDP.Name()) {
+		return nil
+	}
+
+	if len(plan.Add) > 0 {
+		var builders []*cmv1.HTPasswdUserBuilder
+		for _, user := range plan.Add {
+			builders = append(builders, cmv1.NewHTPasswdUser().Username(user.Username).
+				HashedPassword(user.HashedPassword))
+		}
+		users, err := cmv1.NewHTPasswdUserList().Items(builders...).Build()
+		if err != nil {
+			return err
+		}
+		err = r.OCMClient.AddHTPasswdUsers(users, cluster.ID(), htpasswdIDP.ID())
+		if err != nil {
+			return fmt.Errorf("Failed to add users to identity provider '%s': %v", htpasswdIDP.Name(), err)
+		}
+	}
+	for _, user := range plan.Update {
+		err = r.OCMClient.UpdateHTPasswdUser(cluster.ID(), htpasswdIDP.ID(), user.ID, user.HashedPassword)
+		if err != nil {
+			return fmt.Errorf("Failed to update the password of user '%s': %v", user.Username, err)
+		}
+	}
+	for _, user := range plan.Remove {
+		err = r.OCMClient.DeleteHTPasswdUser(user.Username, cluster.ID(), htpasswdIDP)
+		if err != nil {
+			return fmt.Errorf("Failed to remove user '%s': %v", user.Username, err)
+		}
+	}
+
+	r.Reporter.Infof("Synced the users of identity provider '%s' of cluster '%s': "+
+		"%d added, %d updated, %d removed", htpasswdIDP.Name(), clusterKey,
+		len(plan.Add), len(plan.Update), len(plan.Remove))
+	return nil
+}
@@ -0,0 +1,224 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package htpasswdusers
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	. "github.com/openshift-online/ocm-sdk-go/testing"
+	"github.com/spf13/pflag"
+
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/test"
+)
+
+var _ = Describe("Sync htpasswd-users", func() {
+	const (
+		hashV1 = "$apr1$hRY7OJWH$km1EYH.UIRjp6CzfZQz/g1"
+		hashV2 = "$apr1$Q58SO804$B/fECNWfn5xkJXJLvu0mF/"
+	)
+	usersPath := "/api/clusters_mgmt/v1/clusters/" + test.MockClusterID +
+		"/identity_providers/htpasswd-id/htpasswd_users"
+
+	// The API doesn't return the hashed passwords of the users
+	buildUser := func(id string, username string) *cmv1.HTPasswdUser {
+		user, err := cmv1.NewHTPasswdUser().ID(id).Username(username).Build()
+		Expect(err).NotTo(HaveOccurred())
+		return user
+	}
+
+	Context("planSync", func() {
+		existing := []*cmv1.HTPasswdUser{
+			buildUser("1", "eleven"),
+			buildUser("2", "vecna"),
+			buildUser("3", "hopper"),
+		}
+		desired := map[string]string{
+			"eleven": hashV1,
+			"vecna":  hashV2,
+			"dustin": hashV1,
+			"max":    hashV2,
+		}
+
+		It("Adds missing users and reports the passwords of existing users as unknown", func() {
+			plan := planSync(desired, existing, false, false)
+			Expect(plan.Add).To(Equal([]userChange{
+				{Username: "dustin", HashedPassword: hashV1},
+				{Username: "max", HashedPassword: hashV2},
+			}))
+			Expect(plan.Update).To(BeEmpty())
+			Expect(plan.Unknown).To(Equal([]userChange{
+				{ID: "1", Username: "eleven", HashedPassword: hashV1},
+				{ID: "2", Username: "vecna", HashedPassword: hashV2},
+			}))
+			Expect(plan.Remove).To(BeEmpty())
+		})
+
+		It("Updates the passwords of existing users when requested", func() {
+			plan := planSync(desired, existing, false, true)
+			Expect(plan.Update).To(Equal([]userChange{
+				{ID: "1", Username: "eleven", HashedPassword: hashV1},
+				{ID: "2", Username: "vecna", HashedPassword: hashV2},
+			}))
+			Expect(plan.Unknown).To(BeEmpty())
+		})
+
+		It("Removes extra users when pruning", func() {
+			plan := planSync(desired, existing, true, false)
+			Expect(plan.Remove).To(Equal([]userChange{{ID: "3", Username: "hopper"}}))
+		})
+
+		It("Is empty when the users are in sync", func() {
+			plan := planSync(map[string]string{"eleven": hashV1}, existing[:1], true, false)
+			Expect(plan.empty()).To(BeTrue())
+		})
+	})
+
+	Context("Command", func() {
+		var (
+			testRuntime test.TestingRuntime
+			methods     []string
+			yesFlags    *pflag.FlagSet
+		)
+
+		mockClusterReady := test.MockCluster(func(c *cmv1.ClusterBuilder) {
+			c.State(cmv1.ClusterStateReady)
+		})
+		clusterReady := test.FormatClusterList([]*cmv1.Cluster{mockClusterReady})
+		htpasswdIdp, err := cmv1.NewIdentityProvider().ID("htpasswd-id").Name("htpasswd-1").
+			Type(cmv1.IdentityProviderTypeHtpasswd).Build()
+		Expect(err).NotTo(HaveOccurred())
+		idps := test.FormatIDPList([]*cmv1.IdentityProvider{htpasswdIdp})
+		users := test.FormatHtpasswdUserList([]*cmv1.HTPasswdUser{
+			buildUser("1", "eleven"),
+			buildUser("2", "vecna"),
+			buildUser("3", "hopper"),
+			buildUser("4", "cluster-admin"),
+		})
+
+		recordRequest := func(method string, path string) http.HandlerFunc {
+			return ghttp.CombineHandlers(
+				ghttp.VerifyRequest(method, path),
+				func(w http.ResponseWriter, r *http.Request) {
+					_, err := io.ReadAll(r.Body)
+					Expect(err).NotTo(HaveOccurred())
+					methods = append(methods, method)
+				},
+				RespondWithJSON(http.StatusOK, "{}"),
+			)
+		}
+
+		writeHtpasswdFile := func(content string) string {
+			path := filepath.Join(GinkgoT().TempDir(), "users.htpasswd")
+			Expect(os.WriteFile(path, []byte(content), 0600)).To(Succeed())
+			return path
+		}
+
+		BeforeEach(func() {
+			testRuntime.InitRuntime()
+			methods = nil
+			yesFlags = pflag.NewFlagSet("confirm", pflag.ContinueOnError)
+			confirm.AddFlag(yesFlags)
+			// Reset flags to avoid any side effect on other tests
+			Cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+				if flag.Name == "cluster" {
+					return
+				}
+				flag.Value.Set(flag.DefValue)
+				flag.Changed = false
+			})
+		})
+
+		It("Fails without a htpasswd file", func() {
+			_, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+			Expect(err).To(MatchError("Expected the path of a htpasswd file with '--from-file'"))
+		})
+
+		It("Fails with a malformed htpasswd file", func() {
+			Cmd.Flags().Set("from-file", writeHtpasswdFile("eleven\n"))
+			_, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Malformed line"))
+		})
+
+		It("Reports users that are in sync", func() {
+			Cmd.Flags().Set("from-file", writeHtpasswdFile("eleven:"+hashV1+"\nvecna:"+hashV1+"\n"))
+			testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, clusterReady))
+			testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, idps))
+			testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, users))
+			stdout, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stdout).To(ContainSubstring(
+				"Users of identity provider 'htpasswd-1' of cluster 'cluster1' are in sync"))
+			Expect(stdout).To(ContainSubstring("Password of 2 existing users is unknown and isn't updated"))
+		})
+
+		It("Only prints the changes on dry run", func() {
+			Cmd.Flags().Set("from-file", writeHtpasswdFile("eleven:"+hashV1+"\nvecna:"+hashV2+
+				"\nmax:"+hashV1+"\n"))
+			Cmd.Flags().Set("prune", "true")
+			Cmd.Flags().Set("dry-run", "true")
+			testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, clusterReady))
+			testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, idps))
+			testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, users))
+			stdout, stderr, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stdout).To(ContainSubstring("Adding user 'max'"))
+			Expect(stdout).To(ContainSubstring("Password of 2 existing users is unknown and isn't updated"))
+			Expect(stdout).NotTo(ContainSubstring("Resetting the password"))
+			Expect(stdout).To(ContainSubstring("Removing user 'hopper'"))
+			Expect(stdout).NotTo(ContainSubstring("Removing user 'cluster-admin'"))
+			Expect(stderr).To(ContainSubstring(
+				"Keeping user 'cluster-admin', delete it with 'rosa delete admin'"))
+			Expect(methods).To(BeEmpty())
+		})
+
+		It("Adds, updates and removes users", func() {
+			Cmd.Flags().Set("from-file", writeHtpasswdFile("eleven:"+hashV1+"\nvecna:"+hashV2+
+				"\nmax:"+hashV1+"\n"))
+			Cmd.Flags().Set("prune", "true")
+			Cmd.Flags().Set("update-passwords", "true")
+			Expect(yesFlags.Set("yes", "true")).To(Succeed())
+			DeferCleanup(func() {
+				yesFlags.Set("yes", "false")
+			})
+			testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, clusterReady))
+			testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, idps))
+			testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, users))
+			testRuntime.ApiServer.AppendHandlers(recordRequest(http.MethodPost, usersPath+"/import"))
+			testRuntime.ApiServer.AppendHandlers(recordRequest(http.MethodPatch, usersPath+"/1"))
+			testRuntime.ApiServer.AppendHandlers(recordRequest(http.MethodPatch, usersPath+"/2"))
+			testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, users))
+			testRuntime.ApiServer.AppendHandlers(recordRequest(http.MethodDelete, usersPath+"/3"))
+			stdout, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(methods).To(Equal([]string{http.MethodPost, http.MethodPatch, http.MethodPatch,
+				http.MethodDelete}))
+			Expect(stdout).To(ContainSubstring("Resetting the password of user 'eleven'"))
+			Expect(stdout).To(ContainSubstring("Resetting the password of user 'vecna'"))
+			Expect(stdout).To(ContainSubstring("Synced the users of identity provider 'htpasswd-1' of cluster " +
+				"'cluster1': 1 added, 2 updated, 1 removed"))
+		})
+	})
+})
@@ -0,0 +1,13 @@
+package htpasswdusers
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestSyncHtpasswdUsers(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Sync htpasswd-users suite")
+}
@@ -0,0 +1,310 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package users
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/ghodss/yaml"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/cmd/create/idp"
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var args struct {
+	clusters  []string
+	usersFile string
+	prune     bool
+	dryRun    bool
+}
+
+var Cmd = &cobra.Command{
+	Use:     "users",
+	Aliases: []string{"user"},
+	Short:   "Sync the members of the admin groups of clusters with a file",
+	Long: "Grant the roles of a file to the users that are missing from the 'cluster-admins' and " +
+		"'dedicated-admins' groups of clusters. With '--prune' the users of the groups that are not in the " +
+		"file are revoked. Groups that are not in the file are left untouched.\n\n" +
+		"The file is a YAML or JSON map of group names to usernames, e.g.:\n\n" +
+		"  cluster-admins:\n" +
+		"  - alice\n" +
+		"  dedicated-admins:\n" +
+		"  - bob\n" +
+		"  - carol",
+	Example: `  # Sync the admin groups of a cluster named "mycluster"
+  rosa sync users --cluster=mycluster --from-file=groups.yaml
+
+  # Sync the admin groups and revoke the users that are not in the file
+  rosa sync users --cluster=mycluster --from-file=groups.yaml --prune
+
+  # Show the changes to the admin groups of several clusters without applying them
+  rosa sync users --clusters=mycluster,othercluster --from-file=groups.yaml --prune --dry-run`,
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+var validRoles = []string{"cluster-admins", "dedicated-admins"}
+var validRolesAliases = []string{"cluster-admin", "dedicated-admin"}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	ocm.AddOptionalClusterFlag(Cmd)
+	flags.StringSliceVar(
+		&args.clusters,
+		"clusters",
+		nil,
+		"Names or IDs of the clusters to sync, separated by commas. Can't be used with '--cluster'.",
+	)
+	flags.StringVar(
+		&args.usersFile,
+		"from-file",
+		"",
+		"Path to a YAML or JSON file mapping the 'cluster-admins' and 'dedicated-admins' groups "+
+			"to their users.",
+	)
+	flags.BoolVar(
+		&args.prune,
+		"prune",
+		false,
+		"Revoke the roles of the users that are not in the file.",
+	)
+	flags.BoolVar(
+		&args.dryRun,
+		"dry-run",
+		false,
+		"Show the changes without applying them.",
+	)
+}
+
+func run(cmd *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS().WithOCM()
+	defer r.Cleanup()
+
+	err := runWithRuntime(r, cmd)
+	if err != nil {
+		r.Reporter.Errorf(err.Error())
+		os.Exit(1)
+	}
+}
+
+// groupChange holds the users to add to and remove from a group of a cluster
+type groupChange struct {
+	Group  string
+	Add    []string
+	Remove []string
+}
+
+// loadGroups reads the desired members of each group from the file. Role aliases are accepted as
+// group names, as in 'rosa grant user'.
+func loadGroups(path string) (map[string][]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	content := map[string][]string{}
+	err = yaml.Unmarshal(data, &content)
+	if err != nil {
+		return nil, fmt.Errorf("Expected a map of group names to usernames: %v", err)
+	}
+	if len(content) == 0 {
+		return nil, fmt.Errorf("Expected at least one of %s", validRoles)
+	}
+
+	groups := map[string][]string{}
+	for group, usernames := range content {
+		for _, validAlias := range validRolesAliases {
+			if group == validAlias {
+				group = fmt.Sprintf("%ss", group)
+			}
+		}
+		isRoleValid := false
+		for _, validRole := range validRoles {
+			if group == validRole {
+				isRoleValid = true
+			}
+		}
+		if !isRoleValid {
+			return nil, fmt.Errorf("Group '%s' isn't valid, expected one of %s", group, validRoles)
+		}
+		if _, ok := groups[group]; ok {
+			return nil, fmt.Errorf("Group '%s' is listed more than once", group)
+		}
+		for _, username := range usernames {
+			if !ocm.IsValidUsername(username) {
+				return nil, fmt.Errorf("Username '%s' isn't valid: it must contain only letters, "+
+					"digits, dashes and underscores", username)
+			}
+			if username == idp.ClusterAdminUsername {
+				return nil, fmt.Errorf("Username '%s' is reserved for `rosa create/delete admin` command",
+					idp.ClusterAdminUsername)
+			}
+		}
+		groups[group] = usernames
+	}
+	return groups, nil
+}
+
+// planGroupSync compares the desired users of a group with its existing users
+func planGroupSync(group string, desired []string, existing []*cmv1.User, prune bool) *groupChange {
+	change := &groupChange{Group: group}
+	existingUsers := map[string]bool{}
+	for _, user := range existing {
+		existingUsers[user.ID()] = true
+	}
+	desiredUsers := map[string]bool{}
+	for _, username := range desired {
+		if !desiredUsers[username] && !existingUsers[username] {
+			change.Add = append(change.Add, username)
+		}
+		desiredUsers[username] = true
+	}
+	if prune {
+		for username := range existingUsers {
+			// The cluster admin user is managed by 'rosa create/delete admin'
+			if !desiredUsers[username] && username != idp.ClusterAdminUsername {
+				change.Remove = append(change.Remove, username)
+			}
+		}
+	}
+	sort.Strings(change.Add)
+	sort.Strings(change.Remove)
+	return change
+}
+
+func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command) error {
+	if args.usersFile == "" {
+		return fmt.Errorf("Expected the path of a file with the users of each group with '--from-file'")
+	}
+	groups, err := loadGroups(args.usersFile)
+	if err != nil {
+		return fmt.Errorf("Failed to load users file '%s': %v", args.usersFile, err)
+	}
+
+	clusterKeys := args.clusters
+	if cmd.Flags().Changed("cluster") {
+		if len(clusterKeys) > 0 {
+			return fmt.Errorf("Flags '--cluster' and '--clusters' are mutually exclusive")
+		}
+		clusterKeys = []string{r.GetClusterKey()}
+	}
+	if len(clusterKeys) == 0 {
+		return fmt.Errorf("Expected the clusters to sync with '--cluster' or '--clusters'")
+	}
+	for _, clusterKey := range clusterKeys {
+		if !ocm.IsValidClusterKey(clusterKey) {
+			return fmt.Errorf("Cluster name, identifier or external identifier '%s' isn't valid: it "+
+				"must contain only letters, digits, dashes and underscores", clusterKey)
+		}
+	}
+
+	failed := 0
+	for _, clusterKey := range clusterKeys {
+		err = syncCluster(r, clusterKey, groups)
+		if err != nil {
+			r.Reporter.Errorf("Failed to sync the users of cluster '%s': %v", clusterKey, err)
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("Failed to sync the users of %d of %d clusters", failed, len(clusterKeys))
+	}
+	return nil
+}
+
+func syncCluster(r *rosa.Runtime, clusterKey string, groups map[string][]string) error {
+	r.Reporter.Debugf("Loading cluster '%s'", clusterKey)
+	cluster, err := r.OCMClient.GetCluster(clusterKey, r.Creator)
+	if err != nil {
+		return err
+	}
+	if cluster.State() != cmv1.ClusterStateReady {
+		return fmt.Errorf("Cluster '%s' is not yet ready", clusterKey)
+	}
+
+	var changes []*groupChange
+	added, removed := 0, 0
+	for _, group := range validRoles {
+		desired, ok := groups[group]
+		if !ok {
+			continue
+		}
+		existing, err := r.OCMClient.GetUsers(cluster.ID(), group)
+		if err != nil {
+			return fmt.Errorf("Failed to get the users of group '%s': %v", group, err)
+		}
+		change := planGroupSync(group, desired, existing, args.prune)
+		for _, username := range change.Add {
+			r.Reporter.Infof("Granting role '%s' to user '%s' on cluster '%s'", group, username, clusterKey)
+		}
+		for _, username := range change.Remove {
+			r.Reporter.Infof("Revoking role '%s' from user '%s' on cluster '%s'", group, username, clusterKey)
+		}
+		changes = append(changes, change)
+		added += len(change.Add)
+		removed += len(change.Remove)
+	}
+
+	if added == 0 && removed == 0 {
+		r.Reporter.Infof("Users of cluster '%s' are in sync", clusterKey)
+		return nil
+	}
+	if args.dryRun {
+		return nil
+	}
+	// Declining the revocations still grants the missing roles
+	revoke := removed > 0 && confirm.Confirm("revoke the roles of %d users in cluster %s", removed, clusterKey)
+	if removed > 0 && !revoke {
+		r.Reporter.Warnf("Not revoking the roles of %d users in cluster '%s'", removed, clusterKey)
+		removed = 0
+		if added == 0 {
+			return nil
+		}
+	}
+
+	for _, change := range changes {
+		for _, username := range change.Add {
+			user, err := cmv1.NewUser().ID(username).Build()
+			if err != nil {
+				return err
+			}
+			_, err = r.OCMClient.CreateUser(cluster.ID(), change.Group, user)
+			if err != nil {
+				return fmt.Errorf("Failed to grant '%s' to user '%s': %v", change.Group, username, err)
+			}
+		}
+		if !revoke {
+			continue
+		}
+		for _, username := range change.Remove {
+			err = r.OCMClient.DeleteUser(cluster.ID(), change.Group, username)
+			if err != nil {
+				return fmt.Errorf("Failed to revoke '%s' from user '%s': %v", change.Group, username, err)
+			}
+		}
+	}
+
+	r.Reporter.Infof("Synced the users of cluster '%s': %d granted, %d revoked", clusterKey, added, removed)
+	return nil
+}
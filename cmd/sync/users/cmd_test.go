@@ -0,0 +1,216 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package users
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	. "github.com/openshift-online/ocm-sdk-go/testing"
+	"github.com/spf13/pflag"
+
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/test"
+)
+
+var _ = Describe("Sync users", func() {
+	buildUsers := func(usernames ...string) []*cmv1.User {
+		var users []*cmv1.User
+		for _, username := range usernames {
+			user, err := cmv1.NewUser().ID(username).Build()
+			Expect(err).NotTo(HaveOccurred())
+			users = append(users, user)
+		}
+		return users
+	}
+
+	writeUsersFile := func(content string) string {
+		path := filepath.Join(GinkgoT().TempDir(), "groups.yaml")
+		Expect(os.WriteFile(path, []byte(content), 0600)).To(Succeed())
+		return path
+	}
+
+	Context("loadGroups", func() {
+		It("Accepts role aliases", func() {
+			groups, err := loadGroups(writeUsersFile("cluster-admin:\n- alice\ndedicated-admins:\n- bob\n"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(groups).To(Equal(map[string][]string{
+				"cluster-admins":   {"alice"},
+				"dedicated-admins": {"bob"},
+			}))
+		})
+
+		It("Fails with an unknown group", func() {
+			_, err := loadGroups(writeUsersFile("admins:\n- alice\n"))
+			Expect(err).To(MatchError(
+				"Group 'admins' isn't valid, expected one of [cluster-admins dedicated-admins]"))
+		})
+
+		It("Fails with the reserved cluster admin user", func() {
+			_, err := loadGroups(writeUsersFile("cluster-admins:\n- cluster-admin\n"))
+			Expect(err).To(MatchError(
+				"Username 'cluster-admin' is reserved for `rosa create/delete admin` command"))
+		})
+	})
+
+	Context("planGroupSync", func() {
+		existing := buildUsers("alice", "bob", "cluster-admin")
+
+		It("Adds missing users", func() {
+			change := planGroupSync("cluster-admins", []string{"carol", "alice", "carol"}, existing, false)
+			Expect(change.Add).To(Equal([]string{"carol"}))
+			Expect(change.Remove).To(BeEmpty())
+		})
+
+		It("Removes extra users but the cluster admin when pruning", func() {
+			change := planGroupSync("cluster-admins", []string{"alice"}, existing, true)
+			Expect(change.Add).To(BeEmpty())
+			Expect(change.Remove).To(Equal([]string{"bob"}))
+		})
+	})
+
+	Context("Command", func() {
+		var (
+			testRuntime test.TestingRuntime
+			requests    []string
+			yesFlags    *pflag.FlagSet
+		)
+
+		mockClusterReady := test.MockCluster(func(c *cmv1.ClusterBuilder) {
+			c.State(cmv1.ClusterStateReady)
+		})
+		clusterReady := test.FormatClusterList([]*cmv1.Cluster{mockClusterReady})
+		groupsPath := "/api/clusters_mgmt/v1/clusters/" + test.MockClusterID + "/groups/"
+
+		recordRequest := func(method string, path string) http.HandlerFunc {
+			return ghttp.CombineHandlers(
+				ghttp.VerifyRequest(method, path),
+				func(w http.ResponseWriter, r *http.Request) {
+					requests = append(requests, method+" "+path)
+				},
+				RespondWithJSON(http.StatusOK, "{}"),
+			)
+		}
+
+		BeforeEach(func() {
+			testRuntime.InitRuntime()
+			requests = nil
+			yesFlags = pflag.NewFlagSet("confirm", pflag.ContinueOnError)
+			confirm.AddFlag(yesFlags)
+			// Reset flags to avoid any side effect on other tests
+			Cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+				if flag.Name == "cluster" {
+					return
+				}
+				if sliceValue, ok := flag.Value.(pflag.SliceValue); ok {
+					sliceValue.Replace(nil)
+				} else {
+					flag.Value.Set(flag.DefValue)
+				}
+				flag.Changed = false
+			})
+		})
+
+		It("Fails without clusters", func() {
+			Cmd.Flags().Set("from-file", writeUsersFile("cluster-admins:\n- alice\n"))
+			_, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+			Expect(err).To(MatchError("Expected the clusters to sync with '--cluster' or '--clusters'"))
+		})
+
+		It("Only prints the changes on dry run", func() {
+			Cmd.Flags().Set("from-file", writeUsersFile("cluster-admins:\n- alice\n- carol\n"))
+			Cmd.Flags().Set("clusters", "cluster1")
+			Cmd.Flags().Set("prune", "true")
+			Cmd.Flags().Set("dry-run", "true")
+			testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, clusterReady))
+			testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK,
+				test.FormatUserList(buildUsers("alice", "bob", "cluster-admin"))))
+			stdout, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stdout).To(ContainSubstring(
+				"Granting role 'cluster-admins' to user 'carol' on cluster 'cluster1'"))
+			Expect(stdout).To(ContainSubstring(
+				"Revoking role 'cluster-admins' from user 'bob' on cluster 'cluster1'"))
+			Expect(stdout).NotTo(ContainSubstring("user 'cluster-admin'"))
+			Expect(requests).To(BeEmpty())
+		})
+
+		It("Grants and revokes roles of each group", func() {
+			Cmd.Flags().Set("from-file", writeUsersFile(
+				"cluster-admins:\n- alice\ndedicated-admins:\n- bob\n- carol\n"))
+			Cmd.Flags().Set("clusters", "cluster1")
+			Cmd.Flags().Set("prune", "true")
+			Expect(yesFlags.Set("yes", "true")).To(Succeed())
+			DeferCleanup(func() {
+				yesFlags.Set("yes", "false")
+			})
+			testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, clusterReady))
+			testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK,
+				test.FormatUserList(buildUsers("alice", "dave"))))
+			testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK,
+				test.FormatUserList(buildUsers("bob"))))
+			testRuntime.ApiServer.AppendHandlers(
+				recordRequest(http.MethodDelete, groupsPath+"cluster-admins/users/dave"))
+			testRuntime.ApiServer.AppendHandlers(
+				recordRequest(http.MethodPost, groupsPath+"dedicated-admins/users"))
+			stdout, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requests).To(Equal([]string{
+				http.MethodDelete + " " + groupsPath + "cluster-admins/users/dave",
+				http.MethodPost + " " + groupsPath + "dedicated-admins/users",
+			}))
+			Expect(stdout).To(ContainSubstring("Synced the users of cluster 'cluster1': 1 granted, 1 revoked"))
+		})
+
+		It("Grants roles when the revocations are declined", func() {
+			Cmd.Flags().Set("from-file", writeUsersFile("cluster-admins:\n- alice\n"))
+			Cmd.Flags().Set("clusters", "cluster1")
+			Cmd.Flags().Set("prune", "true")
+			testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, clusterReady))
+			testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK,
+				test.FormatUserList(buildUsers("dave"))))
+			testRuntime.ApiServer.AppendHandlers(
+				recordRequest(http.MethodPost, groupsPath+"cluster-admins/users"))
+			stdout, stderr, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requests).To(Equal([]string{
+				http.MethodPost + " " + groupsPath + "cluster-admins/users",
+			}))
+			Expect(stderr).To(ContainSubstring("Not revoking the roles of 1 users in cluster 'cluster1'"))
+			Expect(stdout).To(ContainSubstring("Synced the users of cluster 'cluster1': 1 granted, 0 revoked"))
+		})
+
+		It("Reports the clusters that failed to sync", func() {
+			Cmd.Flags().Set("from-file", writeUsersFile("cluster-admins:\n- alice\n"))
+			Cmd.Flags().Set("clusters", "cluster1,cluster2")
+			testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, clusterReady))
+			testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK,
+				test.FormatUserList(buildUsers("alice"))))
+			testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK,
+				test.FormatClusterList([]*cmv1.Cluster{})))
+			stdout, stderr, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+			Expect(err).To(MatchError("Failed to sync the users of 1 of 2 clusters"))
+			Expect(stdout).To(ContainSubstring("Users of cluster 'cluster1' are in sync"))
+			Expect(stderr).To(ContainSubstring("Failed to sync the users of cluster 'cluster2'"))
+		})
+	})
+})
@@ -0,0 +1,13 @@
+package users
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestSyncUsers(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Sync users suite")
+}
@@ -0,0 +1,239 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "cluster",
+	Short: "Transfer the ownership of a cluster",
+	Long: "Transfer the ownership of a cluster to another user. The transfer stays pending until " +
+		"the recipient accepts it or the owner cancels it. Without any action flag the transfers " +
+		"of the cluster are listed.",
+	Example: `  # Initiate the transfer of a cluster named "mycluster" to the user "jdoe"
+  rosa transfer cluster --cluster=mycluster --to-user=jdoe
+
+  # Accept the pending transfer of a cluster as its recipient
+  rosa transfer cluster --cluster=mycluster --accept
+
+  # Cancel the pending transfer of a cluster
+  rosa transfer cluster --cluster=mycluster --cancel
+
+  # List the transfers of a cluster
+  rosa transfer cluster --cluster=mycluster`,
+	Run:  run,
+	Args: cobra.NoArgs,
+}
+
+var args struct {
+	toUser string
+	accept bool
+	cancel bool
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+	ocm.AddClusterFlag(Cmd)
+	flags.StringVar(
+		&args.toUser,
+		"to-user",
+		"",
+		"Username of the user the ownership of the cluster will be transferred to.",
+	)
+	flags.BoolVar(
+		&args.accept,
+		"accept",
+		false,
+		"Accept the pending transfer of the cluster to the current user.",
+	)
+	flags.BoolVar(
+		&args.cancel,
+		"cancel",
+		false,
+		"Cancel the pending transfer of the cluster.",
+	)
+	Cmd.MarkFlagsMutuallyExclusive("to-user", "accept", "cancel")
+	confirm.AddFlag(flags)
+}
+
+func run(cmd *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithOCM()
+	defer r.Cleanup()
+	err := runWithRuntime(r, cmd)
+	if err != nil {
+		r.Reporter.Errorf(err.Error())
+		os.Exit(1)
+	}
+}
+
+func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command) error {
+	clusterKey := r.GetClusterKey()
+
+	// The recipient of a transfer is usually not able to see the cluster yet, so in that case
+	// the key given is expected to be the external ID of the cluster
+	clusterUUID := clusterKey
+	r.Reporter.Debugf("Loading cluster '%s'", clusterKey)
+	cluster, err := r.OCMClient.GetCluster(clusterKey, nil)
+	if err == nil {
+		clusterUUID = cluster.ExternalID()
+	} else if !args.accept {
+		return fmt.Errorf("Failed to get cluster '%s': %v", clusterKey, err)
+	}
+
+	transfers, err := r.OCMClient.GetClusterTransfers(clusterUUID)
+	if err != nil {
+		return fmt.Errorf("Failed to get transfers of cluster '%s': %v", clusterKey, err)
+	}
+	pending := findPendingTransfer(transfers)
+
+	switch {
+	case cmd.Flags().Changed("to-user"):
+		return initiateTransfer(r, clusterKey, cluster, pending)
+	case args.accept:
+		return acceptTransfer(r, clusterKey, pending)
+	case args.cancel:
+		return cancelTransfer(r, clusterKey, pending)
+	}
+
+	if len(transfers) == 0 {
+		r.Reporter.Infof("There are no transfers for cluster '%s'", clusterKey)
+		return nil
+	}
+	printTransfers(transfers)
+	return nil
+}
+
+func initiateTransfer(r *rosa.Runtime, clusterKey string, cluster *cmv1.Cluster,
+	pending *ocm.ClusterTransfer) error {
+	if args.toUser == "" {
+		return fmt.Errorf("Expected a username with the '--to-user' parameter")
+	}
+	if cluster == nil {
+		return fmt.Errorf("Failed to get cluster '%s'", clusterKey)
+	}
+	if pending != nil {
+		return fmt.Errorf("Cluster '%s' already has a pending transfer to user '%s', "+
+			"cancel it first with 'rosa transfer cluster -c %s --cancel'",
+			clusterKey, pending.Recipient, clusterKey)
+	}
+	// The owner of the cluster is the creator of its subscription, which isn't always the current user
+	subscription, exists, err := r.OCMClient.GetSubscriptionBySubscriptionID(cluster.Subscription().ID())
+	if err != nil {
+		return fmt.Errorf("Failed to get the subscription of cluster '%s': %v", clusterKey, err)
+	}
+	owner := subscription.Creator().Username()
+	if !exists || owner == "" {
+		return fmt.Errorf("Failed to find the owner of cluster '%s'", clusterKey)
+	}
+	if owner == args.toUser {
+		return fmt.Errorf("Cluster '%s' is already owned by user '%s'", clusterKey, args.toUser)
+	}
+	if !confirm.Confirm("transfer the ownership of cluster '%s' from user '%s' to user '%s'",
+		clusterKey, owner, args.toUser) {
+		return nil
+	}
+	transfer, err := r.OCMClient.CreateClusterTransfer(cluster.ExternalID(), owner, args.toUser)
+	if err != nil {
+		return fmt.Errorf("Failed to transfer cluster '%s' to user '%s': %v", clusterKey, args.toUser, err)
+	}
+	r.Reporter.Infof("Initiated the transfer of cluster '%s' to user '%s'. The transfer needs to be "+
+		"accepted by the recipient before %s", clusterKey, args.toUser, formatTime(transfer.ExpirationDate))
+	return nil
+}
+
+func acceptTransfer(r *rosa.Runtime, clusterKey string, pending *ocm.ClusterTransfer) error {
+	if pending == nil {
+		return fmt.Errorf("There is no pending transfer for cluster '%s'", clusterKey)
+	}
+	account, err := r.OCMClient.GetCurrentAccount()
+	if err != nil {
+		return fmt.Errorf("Failed to get current account: %v", err)
+	}
+	if pending.Recipient != account.Username() {
+		return fmt.Errorf("The pending transfer of cluster '%s' is not addressed to user '%s'",
+			clusterKey, account.Username())
+	}
+	if !confirm.Confirm("accept the ownership of cluster '%s' from user '%s'", clusterKey, pending.Owner) {
+		return nil
+	}
+	_, err = r.OCMClient.UpdateClusterTransferStatus(pending.ID, ocm.ClusterTransferStatusAccepted)
+	if err != nil {
+		return fmt.Errorf("Failed to accept the transfer of cluster '%s': %v", clusterKey, err)
+	}
+	r.Reporter.Infof("Accepted the transfer of cluster '%s' from user '%s'", clusterKey, pending.Owner)
+	return nil
+}
+
+func cancelTransfer(r *rosa.Runtime, clusterKey string, pending *ocm.ClusterTransfer) error {
+	if pending == nil {
+		return fmt.Errorf("There is no pending transfer for cluster '%s'", clusterKey)
+	}
+	if !confirm.Confirm("cancel the transfer of cluster '%s' to user '%s'", clusterKey, pending.Recipient) {
+		return nil
+	}
+	_, err := r.OCMClient.UpdateClusterTransferStatus(pending.ID, ocm.ClusterTransferStatusRescinded)
+	if err != nil {
+		return fmt.Errorf("Failed to cancel the transfer of cluster '%s': %v", clusterKey, err)
+	}
+	r.Reporter.Infof("Cancelled the transfer of cluster '%s' to user '%s'", clusterKey, pending.Recipient)
+	return nil
+}
+
+func findPendingTransfer(transfers []*ocm.ClusterTransfer) *ocm.ClusterTransfer {
+	for _, transfer := range transfers {
+		if transfer.Status == ocm.ClusterTransferStatusPending {
+			return transfer
+		}
+	}
+	return nil
+}
+
+func printTransfers(transfers []*ocm.ClusterTransfer) {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "ID\tOWNER\tRECIPIENT\tSTATUS\tCREATED\tEXPIRES\n")
+	for _, transfer := range transfers {
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			transfer.ID,
+			transfer.Owner,
+			transfer.Recipient,
+			transfer.Status,
+			formatTime(transfer.CreatedAt),
+			formatTime(transfer.ExpirationDate),
+		)
+	}
+	writer.Flush()
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.UTC().Format("2006-01-02 15:04 MST")
+}
@@ -0,0 +1,160 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	. "github.com/openshift-online/ocm-sdk-go/testing"
+
+	"github.com/openshift/rosa/pkg/test"
+)
+
+const (
+	clusterUUID    = "1f2e3d4c-0000-1111-2222-333344445555"
+	currentAccount = `{"kind": "Account", "id": "acc1", "username": "owner"}`
+	subscription   = `{"kind": "Subscription", "id": "sub1", "creator": {"kind": "Account", "id": "acc2", ` +
+		`"username": "creator"}}`
+	noTransfers = `{"kind": "ClusterTransferList", "page": 1, "size": 0, "total": 0, "items": []}`
+	pendingList = `{"kind": "ClusterTransferList", "page": 1, "size": 1, "total": 1, "items": [` +
+		`{"id": "transfer1", "cluster_uuid": "` + clusterUUID + `", "owner": "owner", "recipient": "jdoe", ` +
+		`"status": "Pending", "created_at": "2024-05-01T10:00:00Z", "expiration_date": "2024-05-06T10:00:00Z"}]}`
+)
+
+var _ = Describe("Transfer cluster", func() {
+	var testRuntime test.TestingRuntime
+	var clusterList string
+
+	BeforeEach(func() {
+		testRuntime.InitRuntime()
+		cluster := test.MockCluster(func(c *cmv1.ClusterBuilder) {
+			c.ExternalID(clusterUUID)
+			c.Subscription(cmv1.NewSubscription().ID("sub1"))
+		})
+		clusterList = test.FormatClusterList([]*cmv1.Cluster{cluster})
+		Expect(Cmd.Flags().Set("yes", "true")).To(Succeed())
+		DeferCleanup(func() {
+			args.toUser = ""
+			args.accept = false
+			args.cancel = false
+			Cmd.Flags().Set("yes", "false")
+			Cmd.Flags().Lookup("to-user").Changed = false
+		})
+	})
+
+	It("Lists the transfers of the cluster", func() {
+		testRuntime.ApiServer.AppendHandlers(
+			RespondWithJSON(http.StatusOK, clusterList),
+			ghttp.CombineHandlers(
+				ghttp.VerifyFormKV("search", "cluster_uuid = '"+clusterUUID+"'"),
+				RespondWithJSON(http.StatusOK, pendingList),
+			),
+		)
+		stdout, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(stdout).To(Equal(
+			"ID         OWNER  RECIPIENT  STATUS   CREATED               EXPIRES\n" +
+				"transfer1  owner  jdoe       Pending  2024-05-01 10:00 UTC  2024-05-06 10:00 UTC\n"))
+	})
+
+	It("Initiates a transfer to another user", func() {
+		Expect(Cmd.Flags().Set("to-user", "jdoe")).To(Succeed())
+		testRuntime.ApiServer.AppendHandlers(
+			RespondWithJSON(http.StatusOK, clusterList),
+			RespondWithJSON(http.StatusOK, noTransfers),
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodGet, "/api/accounts_mgmt/v1/subscriptions/sub1"),
+				ghttp.VerifyFormKV("fetchAccounts", "true"),
+				RespondWithJSON(http.StatusOK, subscription),
+			),
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodPost, "/api/accounts_mgmt/v1/cluster_transfers"),
+				ghttp.VerifyJSON(`{"cluster_uuid": "`+clusterUUID+`", "owner": "creator", "recipient": "jdoe"}`),
+				RespondWithJSON(http.StatusCreated, `{"id": "transfer1", "status": "Pending", `+
+					`"expiration_date": "2024-05-06T10:00:00Z"}`),
+			),
+		)
+		stdout, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(stdout).To(Equal("INFO: Initiated the transfer of cluster 'cluster1' to user 'jdoe'. " +
+			"The transfer needs to be accepted by the recipient before 2024-05-06 10:00 UTC\n"))
+	})
+
+	It("Fails to initiate a transfer to the owner of the cluster", func() {
+		Expect(Cmd.Flags().Set("to-user", "creator")).To(Succeed())
+		testRuntime.ApiServer.AppendHandlers(
+			RespondWithJSON(http.StatusOK, clusterList),
+			RespondWithJSON(http.StatusOK, noTransfers),
+			RespondWithJSON(http.StatusOK, subscription),
+		)
+		_, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+		Expect(err).To(MatchError("Cluster 'cluster1' is already owned by user 'creator'"))
+	})
+
+	It("Fails to initiate a transfer when one is pending", func() {
+		Expect(Cmd.Flags().Set("to-user", "other")).To(Succeed())
+		testRuntime.ApiServer.AppendHandlers(
+			RespondWithJSON(http.StatusOK, clusterList),
+			RespondWithJSON(http.StatusOK, pendingList),
+		)
+		_, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+		Expect(err).To(MatchError("Cluster 'cluster1' already has a pending transfer to user 'jdoe', " +
+			"cancel it first with 'rosa transfer cluster -c cluster1 --cancel'"))
+	})
+
+	It("Fails to accept a transfer addressed to another user", func() {
+		args.accept = true
+		testRuntime.ApiServer.AppendHandlers(
+			RespondWithJSON(http.StatusOK, clusterList),
+			RespondWithJSON(http.StatusOK, pendingList),
+			RespondWithJSON(http.StatusOK, currentAccount),
+		)
+		_, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+		Expect(err).To(MatchError("The pending transfer of cluster 'cluster1' is not addressed to user 'owner'"))
+	})
+
+	It("Cancels the pending transfer", func() {
+		args.cancel = true
+		testRuntime.ApiServer.AppendHandlers(
+			RespondWithJSON(http.StatusOK, clusterList),
+			RespondWithJSON(http.StatusOK, pendingList),
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodPatch, "/api/accounts_mgmt/v1/cluster_transfers/transfer1"),
+				RespondWithJSON(http.StatusOK, `{"id": "transfer1", "status": "Rescinded"}`),
+			),
+		)
+		stdout, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(stdout).To(Equal("INFO: Cancelled the transfer of cluster 'cluster1' to user 'jdoe'\n"))
+	})
+
+	It("Returns the errors of the API", func() {
+		args.cancel = true
+		testRuntime.ApiServer.AppendHandlers(
+			RespondWithJSON(http.StatusOK, clusterList),
+			RespondWithJSON(http.StatusForbidden, `{"kind": "Error", "id": "403", "code": "ACCT-MGMT-11", `+
+				`"reason": "Account is not authorized"}`),
+		)
+		_, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("Account is not authorized"))
+	})
+})
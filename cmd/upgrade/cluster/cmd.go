@@ -21,6 +21,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	commonUtils "github.com/openshift-online/ocm-common/pkg/utils"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
@@ -339,6 +340,11 @@ func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command) error {
 			os.Exit(0)
 		}
 	} else {
+		upcomingRuns, err := interactive.DescribeUpcomingUpgradeRuns(currentUpgradeScheduling.Schedule, time.Now())
+		if err != nil {
+			return err
+		}
+		r.Reporter.Infof("%s", upcomingRuns)
 		if r.Reporter.IsTerminal() && !confirm.Confirm("schedule automatic cluster upgrades at '%s'",
 			currentUpgradeScheduling.Schedule) {
 			os.Exit(0)
@@ -0,0 +1,451 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusters
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	ver "github.com/hashicorp/go-version"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/openshift/rosa/pkg/arguments"
+	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+const (
+	targetLatestZ = "latest-z"
+	targetLatest  = "latest"
+	timeFormat    = "2006-01-02 15:04 MST"
+
+	batchSizeFlag = "batch-size"
+)
+
+// clusterPageSize is the number of clusters requested per page, GetClusters keeps requesting pages
+// until it gets one that isn't full so clusters beyond the first page are upgraded as well
+var clusterPageSize = 100
+
+var args struct {
+	filter       string
+	to           string
+	stagger      time.Duration
+	batchSize    int
+	ackGates     bool
+	dryRun       bool
+	scheduleDate string
+	scheduleTime string
+}
+
+var Cmd = &cobra.Command{
+	Use:   "clusters",
+	Short: "Upgrade a fleet of clusters",
+	Long: "Schedule upgrades of all the clusters matching a filter in batches of '--batch-size' clusters, " +
+		"starting each batch '--stagger' after the previous one. The upgrades of a batch aren't waited " +
+		"for, so a long upgrade can still be running when the next batch starts. '--max-concurrent' is " +
+		"accepted as an alias of '--batch-size': it limits how many upgrades start together, not how many " +
+		"run at once.\n\n" +
+		"Filters combine 'field=pattern' and 'field!=pattern' terms with 'and', where patterns are globs. " +
+		"The available fields are channel-group, id, name, region, state, topology ('hcp' or 'classic') and " +
+		"version.\n\n" +
+		"Account and operator role policies of STS clusters aren't upgraded by this command, use " +
+		"'rosa upgrade roles' before upgrading them to a new minor version.",
+	Example: `  # Upgrade the 4.14 clusters in European regions to their latest patch version, three at a time,
+  # every two hours
+  rosa upgrade clusters --filter "version=4.14.* and region=eu-*" --to latest-z --stagger 2h \
+    --batch-size 3
+
+  # Show which hosted clusters would be upgraded to version 4.15.20 and when
+  rosa upgrade clusters --filter "topology=hcp" --to 4.15.20 --dry-run`,
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+// normalizeFlags accepts '--max-concurrent' for the batch size
+func normalizeFlags(flags *pflag.FlagSet, name string) pflag.NormalizedName {
+	if name == "max-concurrent" {
+		name = batchSizeFlag
+	}
+	return arguments.NormalizeFlags(flags, name)
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+	flags.SetNormalizeFunc(normalizeFlags)
+
+	flags.StringVar(
+		&args.filter,
+		"filter",
+		"",
+		"Filter selecting the clusters to upgrade, e.g. \"version=4.14.* and region=eu-*\".",
+	)
+
+	flags.StringVar(
+		&args.to,
+		"to",
+		targetLatestZ,
+		fmt.Sprintf("Version to upgrade the clusters to. Either a version, '%s' for the latest patch version "+
+			"of the current minor version or '%s' for the latest available version.", targetLatestZ, targetLatest),
+	)
+
+	flags.DurationVar(
+		&args.stagger,
+		"stagger",
+		time.Hour,
+		"Time between the start of each batch of upgrades.",
+	)
+
+	flags.IntVar(
+		&args.batchSize,
+		batchSizeFlag,
+		1,
+		"Number of clusters starting their upgrade at the same time. Upgrades of previous batches that are "+
+			"still running aren't counted. Also accepted as '--max-concurrent'.",
+	)
+
+	flags.BoolVar(
+		&args.ackGates,
+		"ack-gates",
+		false,
+		"Acknowledge the version gates required by the upgrades. Clusters requiring acknowledgements are "+
+			"skipped otherwise.",
+	)
+
+	flags.BoolVar(
+		&args.dryRun,
+		"dry-run",
+		false,
+		"Show the rollout without scheduling any upgrade.",
+	)
+
+	flags.StringVar(
+		&args.scheduleDate,
+		"schedule-date",
+		"",
+		"Date the first upgrades should run at the specified UTC time. Format should be 'yyyy-mm-dd'",
+	)
+
+	flags.StringVar(
+		&args.scheduleTime,
+		"schedule-time",
+		"",
+		"UTC time that the first upgrades should run on the specified date. Format should be 'HH:mm'",
+	)
+
+	confirm.AddFlag(flags)
+}
+
+func run(cmd *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS().WithOCM()
+	defer r.Cleanup()
+	err := runWithRuntime(r, cmd)
+	if err != nil {
+		r.Reporter.Errorf(err.Error())
+		os.Exit(1)
+	}
+}
+
+// rollout is the upgrade of one of the clusters of the fleet
+type rollout struct {
+	cluster *cmv1.Cluster
+	current string
+	target  string
+	gates   []*cmv1.VersionGate
+	start   time.Time
+	planned bool
+	failed  bool
+	status  string
+}
+
+func (r *rollout) skip(format string, a ...interface{}) {
+	r.status = "Skipped: " + fmt.Sprintf(format, a...)
+}
+
+func (r *rollout) fail(err error) {
+	r.failed = true
+	r.status = fmt.Sprintf("Failed: %v", err)
+}
+
+func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command) error {
+	if args.filter == "" {
+		return fmt.Errorf("Expected a filter selecting the clusters to upgrade with '--filter'")
+	}
+	filter, err := parseFilter(args.filter)
+	if err != nil {
+		return err
+	}
+	if args.to != targetLatestZ && args.to != targetLatest {
+		_, err = ver.NewVersion(args.to)
+		if err != nil {
+			return fmt.Errorf("Invalid version '%s', expected a version, '%s' or '%s'", args.to, targetLatestZ,
+				targetLatest)
+		}
+	}
+	if args.batchSize < 1 {
+		return fmt.Errorf("The '--batch-size' option must be at least 1")
+	}
+	if args.stagger < 0 {
+		return fmt.Errorf("The '--stagger' option can't be negative")
+	}
+	start, err := interactive.BuildManualUpgradeSchedule(cmd, args.scheduleDate, args.scheduleTime)
+	if err != nil {
+		return err
+	}
+
+	clusters, err := r.OCMClient.GetClusters(r.Creator, clusterPageSize)
+	if err != nil {
+		return fmt.Errorf("Failed to get clusters: %v", err)
+	}
+	var rollouts []*rollout
+	for _, cluster := range clusters {
+		if filter.Matches(cluster) {
+			rollouts = append(rollouts, &rollout{cluster: cluster, current: clusterVersion(cluster)})
+		}
+	}
+	if len(rollouts) == 0 {
+		r.Reporter.Infof("There are no clusters matching the filter '%s'", args.filter)
+		return nil
+	}
+	sort.Slice(rollouts, func(i, j int) bool {
+		return rollouts[i].cluster.Name() < rollouts[j].cluster.Name()
+	})
+
+	// Only the clusters that will be upgraded take a slot in the rollout
+	planned := 0
+	for _, rollout := range rollouts {
+		planRollout(r, rollout, start)
+		if !rollout.planned {
+			continue
+		}
+		rollout.start = start.Add(time.Duration(planned/args.batchSize) * args.stagger)
+		planned++
+	}
+
+	if args.dryRun || planned == 0 {
+		printRollouts(rollouts)
+		return failures(rollouts)
+	}
+	if r.Reporter.IsTerminal() && !confirm.Confirm("schedule the upgrade of %d clusters", planned) {
+		return nil
+	}
+
+	for _, rollout := range rollouts {
+		if !rollout.planned {
+			continue
+		}
+		err = scheduleRollout(r, rollout)
+		if err != nil {
+			rollout.fail(err)
+			continue
+		}
+		rollout.status = "Scheduled"
+	}
+	printRollouts(rollouts)
+	return failures(rollouts)
+}
+
+// planRollout selects the version the cluster will be upgraded to, or why it is skipped
+func planRollout(r *rosa.Runtime, rollout *rollout, start time.Time) {
+	cluster := rollout.cluster
+	if cluster.State() != cmv1.ClusterStateReady {
+		rollout.skip("cluster is not ready")
+		return
+	}
+
+	scheduledVersion, err := scheduledUpgradeVersion(r, cluster)
+	if err != nil {
+		rollout.fail(err)
+		return
+	}
+	if scheduledVersion != "" {
+		rollout.skip("an upgrade to version %s is already scheduled", scheduledVersion)
+		return
+	}
+
+	var availableUpgrades []string
+	if cluster.Hypershift().Enabled() {
+		availableUpgrades = ocm.GetAvailableUpgradesByCluster(cluster)
+	} else {
+		availableUpgrades, err = r.OCMClient.GetAvailableUpgrades(ocm.GetVersionID(cluster))
+		if err != nil {
+			rollout.fail(fmt.Errorf("failed to find available upgrades: %v", err))
+			return
+		}
+	}
+	rollout.target = selectTarget(rollout.current, availableUpgrades)
+	if rollout.target == "" {
+		switch args.to {
+		case targetLatestZ:
+			rollout.skip("no patch version available")
+		case targetLatest:
+			rollout.skip("no upgrade available")
+		default:
+			rollout.skip("version %s is not an available upgrade", args.to)
+		}
+		return
+	}
+
+	if cluster.Hypershift().Enabled() {
+		var policy *cmv1.ControlPlaneUpgradePolicy
+		policy, err = buildControlPlaneUpgradePolicy(rollout.target, start)
+		if err == nil {
+			rollout.gates, err = r.OCMClient.GetMissingGateAgreementsHypershift(cluster.ID(), policy)
+		}
+	} else {
+		var policy *cmv1.UpgradePolicy
+		policy, err = buildUpgradePolicy(rollout.target, start)
+		if err == nil {
+			rollout.gates, err = r.OCMClient.GetMissingGateAgreementsClassic(cluster.ID(), policy)
+		}
+	}
+	if err != nil {
+		rollout.fail(fmt.Errorf("failed to check for missing gate agreements: %v", err))
+		return
+	}
+	if len(rollout.gates) > 0 && !args.ackGates {
+		rollout.skip("%d version gates to acknowledge, use '--ack-gates'", len(rollout.gates))
+		return
+	}
+
+	rollout.planned = true
+	rollout.status = "Planned"
+}
+
+func scheduledUpgradeVersion(r *rosa.Runtime, cluster *cmv1.Cluster) (string, error) {
+	if cluster.Hypershift().Enabled() {
+		scheduledUpgrade, err := r.OCMClient.GetControlPlaneScheduledUpgrade(cluster.ID())
+		if err != nil || scheduledUpgrade == nil {
+			return "", err
+		}
+		return scheduledUpgrade.Version(), nil
+	}
+	scheduledUpgrade, _, err := r.OCMClient.GetScheduledUpgrade(cluster.ID())
+	if err != nil || scheduledUpgrade == nil {
+		return "", err
+	}
+	return scheduledUpgrade.Version(), nil
+}
+
+// selectTarget returns the available upgrade requested with '--to', if any
+func selectTarget(current string, availableUpgrades []string) string {
+	if args.to != targetLatestZ && args.to != targetLatest {
+		for _, availableUpgrade := range availableUpgrades {
+			if availableUpgrade == args.to {
+				return availableUpgrade
+			}
+		}
+		return ""
+	}
+
+	var target *ver.Version
+	for _, availableUpgrade := range availableUpgrades {
+		if args.to == targetLatestZ && ocm.GetVersionMinor(availableUpgrade) != ocm.GetVersionMinor(current) {
+			continue
+		}
+		candidate, err := ver.NewVersion(availableUpgrade)
+		if err != nil {
+			continue
+		}
+		if target == nil || candidate.GreaterThan(target) {
+			target = candidate
+		}
+	}
+	if target == nil {
+		return ""
+	}
+	return target.Original()
+}
+
+func buildControlPlaneUpgradePolicy(version string, nextRun time.Time) (*cmv1.ControlPlaneUpgradePolicy, error) {
+	return cmv1.NewControlPlaneUpgradePolicy().
+		UpgradeType(cmv1.UpgradeTypeControlPlane).
+		ScheduleType(cmv1.ScheduleTypeManual).
+		Version(version).
+		NextRun(nextRun).
+		Build()
+}
+
+func buildUpgradePolicy(version string, nextRun time.Time) (*cmv1.UpgradePolicy, error) {
+	return cmv1.NewUpgradePolicy().
+		ScheduleType(cmv1.ScheduleTypeManual).
+		Version(version).
+		NextRun(nextRun).
+		Build()
+}
+
+func scheduleRollout(r *rosa.Runtime, rollout *rollout) error {
+	cluster := rollout.cluster
+	for _, gate := range rollout.gates {
+		err := r.OCMClient.AckVersionGate(cluster.ID(), gate.ID())
+		if err != nil {
+			return fmt.Errorf("failed to acknowledge version gate '%s': %v", gate.ID(), err)
+		}
+	}
+
+	if cluster.Hypershift().Enabled() {
+		policy, err := buildControlPlaneUpgradePolicy(rollout.target, rollout.start)
+		if err != nil {
+			return err
+		}
+		_, err = r.OCMClient.ScheduleHypershiftControlPlaneUpgrade(cluster.ID(), policy)
+		return err
+	}
+	policy, err := buildUpgradePolicy(rollout.target, rollout.start)
+	if err != nil {
+		return err
+	}
+	return r.OCMClient.ScheduleUpgrade(cluster.ID(), policy)
+}
+
+func failures(rollouts []*rollout) error {
+	failed := 0
+	for _, rollout := range rollouts {
+		if rollout.failed {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("Failed to schedule the upgrade of %d of %d clusters", failed, len(rollouts))
+	}
+	return nil
+}
+
+func printRollouts(rollouts []*rollout) {
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "NAME\tREGION\tCURRENT\tTARGET\tSTART\tSTATUS\n")
+	for _, rollout := range rollouts {
+		target := "-"
+		if rollout.target != "" {
+			target = rollout.target
+		}
+		start := "-"
+		if rollout.planned {
+			start = rollout.start.Format(timeFormat)
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\n", rollout.cluster.Name(), rollout.cluster.Region().ID(),
+			rollout.current, target, start, rollout.status)
+	}
+	writer.Flush()
+}
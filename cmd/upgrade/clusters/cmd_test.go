@@ -0,0 +1,249 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusters
+
+import (
+	"io"
+	"net/http"
+	"regexp"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/ghttp"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	. "github.com/openshift-online/ocm-sdk-go/testing"
+
+	"github.com/openshift/rosa/pkg/test"
+)
+
+var _ = Describe("Upgrade clusters", func() {
+	var (
+		testRuntime test.TestingRuntime
+		mutex       sync.Mutex
+		scheduled   map[string]string
+		acked       []string
+	)
+
+	hostedCluster := func(name string, region string, version string, state cmv1.ClusterState,
+		availableUpgrades ...string) *cmv1.Cluster {
+		return test.MockCluster(func(c *cmv1.ClusterBuilder) {
+			c.ID(name + "-id")
+			c.Name(name)
+			c.State(state)
+			c.Region(cmv1.NewCloudRegion().ID(region))
+			c.Hypershift(cmv1.NewHypershift().Enabled(true))
+			c.Version(cmv1.NewVersion().ID("openshift-v" + version).RawID(version).ChannelGroup("stable").
+				AvailableUpgrades(availableUpgrades...))
+		})
+	}
+	fleet := []*cmv1.Cluster{
+		hostedCluster("eu-e", "eu-north-1", "4.14.9", cmv1.ClusterStateReady, "4.14.15"),
+		hostedCluster("eu-a", "eu-west-1", "4.14.10", cmv1.ClusterStateReady, "4.14.12", "4.14.15", "4.15.3"),
+		hostedCluster("us-a", "us-east-1", "4.14.10", cmv1.ClusterStateReady, "4.14.15"),
+		hostedCluster("eu-b", "eu-central-1", "4.14.11", cmv1.ClusterStateReady, "4.14.15"),
+		hostedCluster("eu-c", "eu-west-1", "4.14.12", cmv1.ClusterStateReady, "4.14.15"),
+		hostedCluster("eu-d", "eu-west-2", "4.14.12", cmv1.ClusterStateReady, "4.14.15"),
+		hostedCluster("eu-f", "eu-west-1", "4.14.12", cmv1.ClusterStateInstalling),
+		hostedCluster("eu-old", "eu-west-1", "4.13.5", cmv1.ClusterStateReady, "4.13.8"),
+		test.MockCluster(func(c *cmv1.ClusterBuilder) {
+			c.ID("eu-classic-id")
+			c.Name("eu-classic")
+			c.State(cmv1.ClusterStateReady)
+			c.Region(cmv1.NewCloudRegion().ID("eu-west-3"))
+			c.Version(cmv1.NewVersion().ID("openshift-v4.14.10").RawID("4.14.10").ChannelGroup("stable"))
+		}),
+	}
+
+	existingUpgrade, err := cmv1.NewControlPlaneUpgradePolicy().UpgradeType(cmv1.UpgradeTypeControlPlane).
+		ScheduleType(cmv1.ScheduleTypeManual).Version("4.14.15").Build()
+	Expect(err).NotTo(HaveOccurred())
+	gateError := `{
+		"kind": "Error",
+		"id": "400",
+		"href": "/api/clusters_mgmt/v1/errors/400",
+		"code": "CLUSTERS-MGMT-400",
+		"reason": "There are missing version gate agreements",
+		"details": [{"kind": "VersionGate", "id": "gate-1", "version_raw_id_prefix": "4.14"}]
+	}`
+
+	clusterRE := regexp.MustCompile(`/clusters/([^/]+)/`)
+	clusterID := func(r *http.Request) string {
+		return clusterRE.FindStringSubmatch(r.URL.Path)[1]
+	}
+
+	BeforeEach(func() {
+		testRuntime.InitRuntime()
+		scheduled = map[string]string{}
+		acked = nil
+		DeferCleanup(func() {
+			args.filter = ""
+			args.to = targetLatestZ
+			args.batchSize = 1
+			args.ackGates = false
+			args.dryRun = false
+			clusterPageSize = 100
+		})
+		args.scheduleDate = "2030-01-01"
+		args.scheduleTime = "10:00"
+		args.stagger = 2 * time.Hour
+
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, test.FormatClusterList(fleet)))
+		testRuntime.ApiServer.RouteToHandler(http.MethodGet,
+			regexp.MustCompile(`/control_plane/upgrade_policies$`),
+			func(w http.ResponseWriter, r *http.Request) {
+				policies := []*cmv1.ControlPlaneUpgradePolicy{}
+				if clusterID(r) == "eu-d-id" {
+					policies = append(policies, existingUpgrade)
+				}
+				RespondWithJSON(http.StatusOK, test.FormatControlPlaneUpgradePolicyList(policies))(w, r)
+			})
+		testRuntime.ApiServer.RouteToHandler(http.MethodPost,
+			regexp.MustCompile(`/control_plane/upgrade_policies$`),
+			func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				Expect(err).NotTo(HaveOccurred())
+				policy, err := cmv1.UnmarshalControlPlaneUpgradePolicy(body)
+				Expect(err).NotTo(HaveOccurred())
+				mutex.Lock()
+				defer mutex.Unlock()
+				if r.URL.Query().Get("dryRun") == "true" {
+					if clusterID(r) == "eu-c-id" && len(acked) == 0 {
+						RespondWithJSON(http.StatusBadRequest, gateError)(w, r)
+						return
+					}
+					RespondWithJSON(http.StatusNoContent, "")(w, r)
+					return
+				}
+				scheduled[clusterID(r)] = policy.Version() + " " + policy.NextRun().Format(timeFormat)
+				RespondWithJSON(http.StatusCreated, string(body))(w, r)
+			})
+		testRuntime.ApiServer.RouteToHandler(http.MethodPost, regexp.MustCompile(`/gate_agreements$`),
+			func(w http.ResponseWriter, r *http.Request) {
+				mutex.Lock()
+				defer mutex.Unlock()
+				acked = append(acked, clusterID(r))
+				RespondWithJSON(http.StatusCreated, "{}")(w, r)
+			})
+		testRuntime.ApiServer.RouteToHandler(http.MethodGet, regexp.MustCompile(`/upgrade_policies$`),
+			func(w http.ResponseWriter, r *http.Request) {
+				RespondWithJSON(http.StatusOK, `{"kind": "UpgradePolicyList", "page": 1, "size": 0, "total": 0, "items": []}`)(w, r)
+			})
+		testRuntime.ApiServer.RouteToHandler(http.MethodGet, regexp.MustCompile(`^/api/clusters_mgmt/v1/versions/`),
+			func(w http.ResponseWriter, r *http.Request) {
+				version, err := cmv1.NewVersion().ID("openshift-v4.14.10").RawID("4.14.10").ChannelGroup("stable").
+					ROSAEnabled(true).Build()
+				Expect(err).NotTo(HaveOccurred())
+				RespondWithJSON(http.StatusOK, test.FormatResource(version))(w, r)
+			})
+	})
+
+	It("Fails without a filter", func() {
+		testRuntime.ApiServer.Reset()
+		_, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+		Expect(err).To(MatchError("Expected a filter selecting the clusters to upgrade with '--filter'"))
+	})
+
+	It("Accepts '--max-concurrent' as the batch size", func() {
+		testRuntime.ApiServer.Reset()
+		Expect(Cmd.Flags().Parse([]string{"--max-concurrent", "3"})).To(Succeed())
+		Expect(args.batchSize).To(Equal(3))
+	})
+
+	It("Fails with an invalid target version", func() {
+		testRuntime.ApiServer.Reset()
+		args.filter = "name=*"
+		args.to = "latest-y"
+		_, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+		Expect(err).To(MatchError("Invalid version 'latest-y', expected a version, 'latest-z' or 'latest'"))
+	})
+
+	It("Reports that no cluster matches the filter", func() {
+		args.filter = "region=ap-*"
+		stdout, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stdout).To(Equal("INFO: There are no clusters matching the filter 'region=ap-*'\n"))
+	})
+
+	It("Shows the rollout without scheduling it", func() {
+		args.filter = "version=4.14.* and region=eu-*"
+		args.batchSize = 2
+		args.dryRun = true
+		stdout, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(scheduled).To(BeEmpty())
+		Expect(stdout).To(Equal(
+			"NAME        REGION        CURRENT  TARGET   START                 STATUS\n" +
+				"eu-a        eu-west-1     4.14.10  4.14.15  2030-01-01 10:00 UTC  Planned\n" +
+				"eu-b        eu-central-1  4.14.11  4.14.15  2030-01-01 10:00 UTC  Planned\n" +
+				"eu-c        eu-west-1     4.14.12  4.14.15  -                     " +
+				"Skipped: 1 version gates to acknowledge, use '--ack-gates'\n" +
+				"eu-classic  eu-west-3     4.14.10  -        -                     Skipped: no patch version available\n" +
+				"eu-d        eu-west-2     4.14.12  -        -                     " +
+				"Skipped: an upgrade to version 4.14.15 is already scheduled\n" +
+				"eu-e        eu-north-1    4.14.9   4.14.15  2030-01-01 12:00 UTC  Planned\n" +
+				"eu-f        eu-west-1     4.14.12  -        -                     Skipped: cluster is not ready\n"))
+	})
+
+	It("Schedules staggered upgrades and acknowledges gates", func() {
+		args.filter = "version=4.14.* and region=eu-* and topology=hcp"
+		args.batchSize = 2
+		args.ackGates = true
+		stdout, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(acked).To(Equal([]string{"eu-c-id"}))
+		Expect(scheduled).To(Equal(map[string]string{
+			"eu-a-id": "4.14.15 2030-01-01 10:00 UTC",
+			"eu-b-id": "4.14.15 2030-01-01 10:00 UTC",
+			"eu-c-id": "4.14.15 2030-01-01 12:00 UTC",
+			"eu-e-id": "4.14.15 2030-01-01 12:00 UTC",
+		}))
+		Expect(stdout).To(ContainSubstring(
+			"eu-c  eu-west-1     4.14.12  4.14.15  2030-01-01 12:00 UTC  Scheduled\n"))
+	})
+
+	It("Upgrades to the latest version", func() {
+		args.filter = "name=eu-a"
+		args.to = targetLatest
+		stdout, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(scheduled).To(Equal(map[string]string{"eu-a-id": "4.15.3 2030-01-01 10:00 UTC"}))
+		Expect(stdout).To(Equal(
+			"NAME  REGION     CURRENT  TARGET  START                 STATUS\n" +
+				"eu-a  eu-west-1  4.14.10  4.15.3  2030-01-01 10:00 UTC  Scheduled\n"))
+	})
+
+	It("Upgrades the clusters of all the pages", func() {
+		clusterPageSize = 5
+		testRuntime.ApiServer.SetHandler(0, CombineHandlers(
+			VerifyFormKV("page", "1"),
+			RespondWithJSON(http.StatusOK, test.FormatClusterList(fleet[:5])),
+		))
+		testRuntime.ApiServer.AppendHandlers(CombineHandlers(
+			VerifyFormKV("page", "2"),
+			RespondWithJSON(http.StatusOK, test.FormatClusterList(fleet[5:])),
+		))
+		args.filter = "name=eu-old"
+		args.dryRun = true
+		stdout, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stdout).To(Equal(
+			"NAME    REGION     CURRENT  TARGET  START                 STATUS\n" +
+				"eu-old  eu-west-1  4.13.5   4.13.8  2030-01-01 10:00 UTC  Planned\n"))
+	})
+})
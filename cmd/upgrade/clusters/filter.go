@@ -0,0 +1,126 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusters
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+const (
+	topologyHostedCP = "hcp"
+	topologyClassic  = "classic"
+)
+
+// clusterFields are the fields of a cluster that a filter can select on
+var clusterFields = map[string]func(*cmv1.Cluster) string{
+	"name": func(cluster *cmv1.Cluster) string {
+		return cluster.Name()
+	},
+	"id": func(cluster *cmv1.Cluster) string {
+		return cluster.ID()
+	},
+	"version": clusterVersion,
+	"region": func(cluster *cmv1.Cluster) string {
+		return cluster.Region().ID()
+	},
+	"channel-group": func(cluster *cmv1.Cluster) string {
+		return cluster.Version().ChannelGroup()
+	},
+	"state": func(cluster *cmv1.Cluster) string {
+		return string(cluster.State())
+	},
+	"topology": func(cluster *cmv1.Cluster) string {
+		if cluster.Hypershift().Enabled() {
+			return topologyHostedCP
+		}
+		return topologyClassic
+	},
+}
+
+var andRE = regexp.MustCompile(`(?i)\s+and\s+`)
+
+type filterTerm struct {
+	field   string
+	pattern string
+	negate  bool
+}
+
+// clusterFilter selects clusters with terms such as 'version=4.14.* and region=eu-*'. Values are glob
+// patterns and all the terms have to match.
+type clusterFilter []filterTerm
+
+func parseFilter(filter string) (clusterFilter, error) {
+	var result clusterFilter
+	for _, expression := range andRE.Split(strings.TrimSpace(filter), -1) {
+		term := filterTerm{}
+		separator := "="
+		if strings.Contains(expression, "!=") {
+			separator = "!="
+			term.negate = true
+		}
+		field, pattern, found := strings.Cut(expression, separator)
+		term.field = strings.ToLower(strings.TrimSpace(field))
+		term.pattern = strings.TrimSpace(pattern)
+		if !found || term.field == "" || term.pattern == "" {
+			return nil, fmt.Errorf("Invalid filter term '%s', expected 'field=value' or 'field!=value'",
+				expression)
+		}
+		if _, ok := clusterFields[term.field]; !ok {
+			return nil, fmt.Errorf("Invalid filter field '%s', expected one of %s", term.field,
+				strings.Join(filterFieldNames(), ", "))
+		}
+		_, err := path.Match(term.pattern, "")
+		if err != nil {
+			return nil, fmt.Errorf("Invalid pattern '%s' for filter field '%s': %v", term.pattern, term.field, err)
+		}
+		result = append(result, term)
+	}
+	return result, nil
+}
+
+func (f clusterFilter) Matches(cluster *cmv1.Cluster) bool {
+	for _, term := range f {
+		// The pattern was validated when parsing the filter
+		matches, _ := path.Match(term.pattern, clusterFields[term.field](cluster))
+		if matches == term.negate {
+			return false
+		}
+	}
+	return true
+}
+
+func filterFieldNames() []string {
+	names := make([]string, 0, len(clusterFields))
+	for name := range clusterFields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func clusterVersion(cluster *cmv1.Cluster) string {
+	if version := cluster.Version().RawID(); version != "" {
+		return version
+	}
+	return cluster.OpenshiftVersion()
+}
@@ -0,0 +1,63 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusters
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/test"
+)
+
+var _ = Describe("Cluster filter", func() {
+	cluster := test.MockCluster(func(c *cmv1.ClusterBuilder) {
+		c.Name("eu-prod-1")
+		c.State(cmv1.ClusterStateReady)
+		c.Region(cmv1.NewCloudRegion().ID("eu-west-1"))
+		c.Version(cmv1.NewVersion().RawID("4.14.10").ChannelGroup("stable"))
+		c.Hypershift(cmv1.NewHypershift().Enabled(true))
+	})
+
+	DescribeTable("Matches clusters",
+		func(filter string, expected bool) {
+			f, err := parseFilter(filter)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(f.Matches(cluster)).To(Equal(expected))
+		},
+		Entry("version and region", "version=4.14.* and region=eu-*", true),
+		Entry("case insensitive conjunction", "version=4.14.* AND region=us-*", false),
+		Entry("negated term", "name!=eu-prod-*", false),
+		Entry("topology", "topology=hcp and state=ready", true),
+		Entry("channel group", "channel-group=candidate", false),
+		Entry("spaces around operators", " region = eu-west-? ", true),
+	)
+
+	DescribeTable("Rejects invalid filters",
+		func(filter string, message string) {
+			_, err := parseFilter(filter)
+			Expect(err).To(MatchError(message))
+		},
+		Entry("missing operator", "version", "Invalid filter term 'version', expected 'field=value' or "+
+			"'field!=value'"),
+		Entry("missing value", "version=", "Invalid filter term 'version=', expected 'field=value' or "+
+			"'field!=value'"),
+		Entry("unknown field", "zone=a", "Invalid filter field 'zone', expected one of channel-group, id, name, "+
+			"region, state, topology, version"),
+		Entry("invalid pattern", "name=[a", "Invalid pattern '[a' for filter field 'name': syntax error in pattern"),
+	)
+})
@@ -21,8 +21,10 @@ import (
 
 	"github.com/openshift/rosa/cmd/upgrade/accountroles"
 	"github.com/openshift/rosa/cmd/upgrade/cluster"
+	"github.com/openshift/rosa/cmd/upgrade/clusters"
 	"github.com/openshift/rosa/cmd/upgrade/machinepool"
 	"github.com/openshift/rosa/cmd/upgrade/operatorroles"
+	"github.com/openshift/rosa/cmd/upgrade/plan"
 	"github.com/openshift/rosa/cmd/upgrade/roles"
 	"github.com/openshift/rosa/pkg/arguments"
 	"github.com/openshift/rosa/pkg/interactive"
@@ -41,6 +43,8 @@ func init() {
 	Cmd.AddCommand(accountroles.Cmd)
 	Cmd.AddCommand(operatorroles.Cmd)
 	Cmd.AddCommand(roles.Cmd)
+	Cmd.AddCommand(plan.Cmd)
+	Cmd.AddCommand(clusters.Cmd)
 
 	flags := Cmd.PersistentFlags()
 	arguments.AddProfileFlag(flags)
@@ -50,6 +54,7 @@ func init() {
 	globallyAvailableCommands := []*cobra.Command{
 		accountroles.Cmd, operatorroles.Cmd,
 		roles.Cmd, machinepool.Cmd, cluster.Cmd,
+		plan.Cmd, clusters.Cmd,
 	}
 	arguments.MarkRegionDeprecated(Cmd, globallyAvailableCommands)
 }
@@ -0,0 +1,304 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinepool
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/helper"
+	"github.com/openshift/rosa/pkg/helper/versions"
+	"github.com/openshift/rosa/pkg/input"
+	"github.com/openshift/rosa/pkg/interactive"
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+// pollInterval is the time to wait between checks of the control plane and machine pool upgrades
+var pollInterval = 30 * time.Second
+
+func validateAllArgs() error {
+	if args.schedule != "" || args.scheduleDate != "" || args.scheduleTime != "" {
+		return fmt.Errorf("The '--all' option can't be used with '--schedule', '--schedule-date' or '--schedule-time'")
+	}
+	if interactive.Enabled() {
+		return fmt.Errorf("The '--all' option can't be used with '--interactive'")
+	}
+	if args.maxParallel < 1 {
+		return fmt.Errorf("The '--max-parallel' option must be at least 1")
+	}
+	if args.waitTimeout <= 0 {
+		return fmt.Errorf("The '--wait-timeout' option must be positive")
+	}
+	return nil
+}
+
+// upgradeAllMachinePools upgrades every machine pool of a hosted cluster to the version of its control plane,
+// once any control plane upgrade in progress has finished, keeping at most '--max-parallel' upgrades running
+func upgradeAllMachinePools(r *rosa.Runtime, cmd *cobra.Command) error {
+	err := validateAllArgs()
+	if err != nil {
+		return err
+	}
+
+	clusterKey := r.GetClusterKey()
+	cluster := r.FetchCluster()
+	input.CheckIfHypershiftClusterOrExit(r, cluster)
+	if cluster.State() != cmv1.ClusterStateReady {
+		return fmt.Errorf("Cluster '%s' is not yet ready", clusterKey)
+	}
+
+	deadline := time.Now().Add(args.waitTimeout)
+	cluster, err = waitForControlPlaneUpgrade(r, clusterKey, cluster, deadline)
+	if err != nil {
+		return err
+	}
+
+	targetVersion := cluster.Version().RawID()
+	if args.version != "" {
+		version := ocm.GetRawVersionId(args.version)
+		supported, err := versions.IsGreaterThanOrEqual(targetVersion, version)
+		if err != nil {
+			return err
+		}
+		if !supported {
+			return fmt.Errorf("Version '%s' is newer than the control plane version '%s' of cluster '%s'",
+				version, targetVersion, clusterKey)
+		}
+		targetVersion = version
+	}
+
+	pending, inProgress, err := planMachinePoolUpgrades(r, clusterKey, cluster, targetVersion)
+	if err != nil {
+		return err
+	}
+	if len(pending) == 0 && len(inProgress) == 0 {
+		r.Reporter.Infof("All machine pools of cluster '%s' are already at version '%s'", clusterKey, targetVersion)
+		return nil
+	}
+	if len(pending) > 0 && r.Reporter.IsTerminal() &&
+		!confirm.Confirm("upgrade %d machine pools of cluster '%s' to version '%s'", len(pending), clusterKey,
+			targetVersion) {
+		return nil
+	}
+
+	total := len(pending) + len(inProgress)
+	upgraded := 0
+	for len(pending) > 0 || len(inProgress) > 0 {
+		for len(pending) > 0 && len(inProgress) < args.maxParallel {
+			machinePoolID := pending[0]
+			pending = pending[1:]
+			err = scheduleMachinePoolUpgrade(r, cmd, clusterKey, cluster, machinePoolID, targetVersion)
+			if err != nil {
+				return err
+			}
+			r.Reporter.Infof("Scheduled the upgrade of machine pool '%s' to version '%s'", machinePoolID,
+				targetVersion)
+			inProgress = append(inProgress, machinePoolID)
+		}
+		if !args.wait {
+			break
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("Timed out after %s waiting for the upgrade of machine pools '%s' of cluster "+
+				"'%s', run this command again to resume", args.waitTimeout, strings.Join(inProgress, "', '"),
+				clusterKey)
+		}
+
+		time.Sleep(pollInterval)
+		var running []string
+		for _, machinePoolID := range inProgress {
+			done, err := isMachinePoolUpgraded(r, clusterKey, cluster, machinePoolID, targetVersion)
+			if err != nil {
+				return err
+			}
+			if !done {
+				running = append(running, machinePoolID)
+				continue
+			}
+			upgraded++
+			r.Reporter.Infof("Machine pool '%s' upgraded to version '%s' (%d of %d)", machinePoolID,
+				targetVersion, upgraded, total)
+		}
+		inProgress = running
+	}
+
+	if !args.wait {
+		if len(pending) > 0 {
+			r.Reporter.Infof("%d machine pools of cluster '%s' are left to upgrade, run this command again "+
+				"once the scheduled upgrades finish or use '--wait'", len(pending), clusterKey)
+		}
+		return nil
+	}
+	r.Reporter.Infof("All machine pools of cluster '%s' upgraded to version '%s'", clusterKey, targetVersion)
+	return nil
+}
+
+// waitForControlPlaneUpgrade waits for a control plane upgrade in progress to finish and returns the
+// cluster with its new version
+func waitForControlPlaneUpgrade(r *rosa.Runtime, clusterKey string, cluster *cmv1.Cluster,
+	deadline time.Time) (*cmv1.Cluster, error) {
+	waited := false
+	for {
+		upgrade, err := r.OCMClient.GetControlPlaneScheduledUpgrade(cluster.ID())
+		if err != nil {
+			return nil, fmt.Errorf("Failed to get the control plane upgrade of cluster '%s': %v", clusterKey, err)
+		}
+		if !isControlPlaneUpgradeInProgress(upgrade) {
+			break
+		}
+		if upgrade.State().Value() == cmv1.UpgradePolicyStateValueFailed {
+			return nil, fmt.Errorf("The control plane upgrade of cluster '%s' to version '%s' failed: %s",
+				clusterKey, upgrade.Version(), upgrade.State().Description())
+		}
+		if !args.wait {
+			return nil, fmt.Errorf("The control plane of cluster '%s' is being upgraded to version '%s', "+
+				"use '--wait' to upgrade the machine pools once it finishes", clusterKey, upgrade.Version())
+		}
+		if !waited {
+			r.Reporter.Infof("Waiting for the control plane of cluster '%s' to be upgraded to version '%s'",
+				clusterKey, upgrade.Version())
+			waited = true
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("Timed out after %s waiting for the control plane of cluster '%s' to be "+
+				"upgraded to version '%s'", args.waitTimeout, clusterKey, upgrade.Version())
+		}
+		time.Sleep(pollInterval)
+	}
+	if !waited {
+		return cluster, nil
+	}
+	cluster, err := r.OCMClient.GetCluster(clusterKey, r.Creator)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get cluster '%s': %v", clusterKey, err)
+	}
+	return cluster, nil
+}
+
+// isControlPlaneUpgradeInProgress returns true when the control plane is being upgraded or a manual
+// upgrade of it is about to run. Recurring automatic upgrades only count once they have started.
+func isControlPlaneUpgradeInProgress(upgrade *cmv1.ControlPlaneUpgradePolicy) bool {
+	if upgrade == nil {
+		return false
+	}
+	switch upgrade.State().Value() {
+	case cmv1.UpgradePolicyStateValueStarted, cmv1.UpgradePolicyStateValueDelayed,
+		cmv1.UpgradePolicyStateValueFailed:
+		return true
+	case cmv1.UpgradePolicyStateValuePending, cmv1.UpgradePolicyStateValueScheduled:
+		return upgrade.ScheduleType() == cmv1.ScheduleTypeManual
+	}
+	return false
+}
+
+// planMachinePoolUpgrades returns the machine pools that have to be upgraded to the target version and
+// the ones that already have a manual upgrade to it scheduled, failing if any of them can't be upgraded
+// to it or has another upgrade scheduled
+func planMachinePoolUpgrades(r *rosa.Runtime, clusterKey string, cluster *cmv1.Cluster,
+	targetVersion string) ([]string, []string, error) {
+	nodePools, err := r.OCMClient.GetNodePools(cluster.ID())
+	if err != nil {
+		return nil, nil, fmt.Errorf("Failed to get machine pools for cluster '%s': %v", clusterKey, err)
+	}
+	sort.Slice(nodePools, func(i, j int) bool {
+		return nodePools[i].ID() < nodePools[j].ID()
+	})
+
+	var pending, inProgress []string
+	for _, nodePool := range nodePools {
+		currentVersion := ocm.GetRawVersionId(nodePool.Version().ID())
+		upToDate, err := versions.IsGreaterThanOrEqual(currentVersion, targetVersion)
+		if err != nil {
+			return nil, nil, err
+		}
+		if upToDate {
+			r.Reporter.Debugf("Machine pool '%s' is already at version '%s'", nodePool.ID(), currentVersion)
+			continue
+		}
+
+		_, scheduledUpgrade, err := r.OCMClient.GetHypershiftNodePoolUpgrade(cluster.ID(), clusterKey,
+			nodePool.ID())
+		if err != nil {
+			return nil, nil, err
+		}
+		if scheduledUpgrade != nil {
+			// Only a manual upgrade to the target version brings the machine pool to it
+			if scheduledUpgrade.Version() != targetVersion ||
+				scheduledUpgrade.ScheduleType() != cmv1.ScheduleTypeManual {
+				return nil, nil, fmt.Errorf("Machine pool '%s' already has a %s %s upgrade to version '%s', "+
+					"cancel it with 'rosa delete upgrade -c %s --machinepool %s' first", nodePool.ID(),
+					scheduledUpgrade.State().Value(), scheduledUpgrade.ScheduleType(), scheduledUpgrade.Version(),
+					clusterKey, nodePool.ID())
+			}
+			r.Reporter.Infof("Machine pool '%s' already has a %s upgrade to version '%s'", nodePool.ID(),
+				scheduledUpgrade.State().Value(), scheduledUpgrade.Version())
+			inProgress = append(inProgress, nodePool.ID())
+			continue
+		}
+
+		if !helper.Contains(ocm.GetNodePoolAvailableUpgrades(nodePool), targetVersion) {
+			return nil, nil, fmt.Errorf("Machine pool '%s' can't be upgraded from version '%s' to version '%s'",
+				nodePool.ID(), currentVersion, targetVersion)
+		}
+		pending = append(pending, nodePool.ID())
+	}
+	return pending, inProgress, nil
+}
+
+func scheduleMachinePoolUpgrade(r *rosa.Runtime, cmd *cobra.Command, clusterKey string, cluster *cmv1.Cluster,
+	machinePoolID string, version string) error {
+	nextRun, err := interactive.BuildManualUpgradeSchedule(cmd, "", "")
+	if err != nil {
+		return err
+	}
+	upgradePolicy, err := r.OCMClient.BuildNodeUpgradePolicy(version, machinePoolID,
+		ocm.UpgradeScheduling{NextRun: nextRun})
+	if err != nil {
+		return errors.Wrapf(err, "Failed to build the upgrade of machine pool '%s' in cluster '%s'",
+			machinePoolID, clusterKey)
+	}
+	_, err = r.OCMClient.ScheduleNodePoolUpgrade(cluster.ID(), machinePoolID, upgradePolicy)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to schedule upgrade for machine pool '%s' in cluster '%s'",
+			machinePoolID, clusterKey)
+	}
+	return nil
+}
+
+// isMachinePoolUpgraded returns true once the machine pool runs the target version, failing if its
+// upgrade failed
+func isMachinePoolUpgraded(r *rosa.Runtime, clusterKey string, cluster *cmv1.Cluster, machinePoolID string,
+	targetVersion string) (bool, error) {
+	nodePool, scheduledUpgrade, err := r.OCMClient.GetHypershiftNodePoolUpgrade(cluster.ID(), clusterKey,
+		machinePoolID)
+	if err != nil {
+		return false, err
+	}
+	if scheduledUpgrade != nil && scheduledUpgrade.State().Value() == cmv1.UpgradePolicyStateValueFailed {
+		return false, fmt.Errorf("The upgrade of machine pool '%s' to version '%s' failed: %s", machinePoolID,
+			scheduledUpgrade.Version(), scheduledUpgrade.State().Description())
+	}
+	return versions.IsGreaterThanOrEqual(ocm.GetRawVersionId(nodePool.Version().ID()), targetVersion)
+}
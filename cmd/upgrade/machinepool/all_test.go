@@ -0,0 +1,299 @@
+package machinepool
+
+import (
+	"io"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	. "github.com/openshift-online/ocm-sdk-go/testing"
+
+	"github.com/openshift/rosa/pkg/test"
+)
+
+var _ = Describe("Upgrade all machine pools", func() {
+	var testRuntime test.TestingRuntime
+
+	hostedCluster := func(version string) string {
+		return test.FormatClusterList([]*cmv1.Cluster{test.MockCluster(func(c *cmv1.ClusterBuilder) {
+			c.State(cmv1.ClusterStateReady)
+			c.Hypershift(cmv1.NewHypershift().Enabled(true))
+			c.Version(cmv1.NewVersion().ID("openshift-v" + version).RawID(version))
+		})})
+	}
+	nodePool := func(id string, version string, availableUpgrades ...string) *cmv1.NodePool {
+		nodePool, err := cmv1.NewNodePool().ID(id).Replicas(2).
+			Version(cmv1.NewVersion().ID("openshift-v" + version).AvailableUpgrades(availableUpgrades...)).Build()
+		Expect(err).To(BeNil())
+		return nodePool
+	}
+	nodePoolUpgradeTo := func(version string, scheduleType cmv1.ScheduleType,
+		state cmv1.UpgradePolicyStateValue) string {
+		policy, err := cmv1.NewNodePoolUpgradePolicy().ScheduleType(scheduleType).
+			UpgradeType(cmv1.UpgradeTypeNodePool).Version(version).
+			State(cmv1.NewUpgradePolicyState().Value(state).Description("Nodes failed to drain")).Build()
+		Expect(err).To(BeNil())
+		return test.FormatNodePoolUpgradePolicyList([]*cmv1.NodePoolUpgradePolicy{policy})
+	}
+	nodePoolUpgrade := func(state cmv1.UpgradePolicyStateValue) string {
+		return nodePoolUpgradeTo("4.12.26", cmv1.ScheduleTypeManual, state)
+	}
+	controlPlaneUpgrade := func(state cmv1.UpgradePolicyStateValue) string {
+		policy, err := cmv1.NewControlPlaneUpgradePolicy().ScheduleType(cmv1.ScheduleTypeManual).
+			UpgradeType(cmv1.UpgradeTypeControlPlane).Version("4.12.26").
+			State(cmv1.NewUpgradePolicyState().Value(state)).Build()
+		Expect(err).To(BeNil())
+		return test.FormatControlPlaneUpgradePolicyList([]*cmv1.ControlPlaneUpgradePolicy{policy})
+	}
+	noControlPlaneUpgrade := test.FormatControlPlaneUpgradePolicyList([]*cmv1.ControlPlaneUpgradePolicy{})
+	noNodePoolUpgrade := test.FormatNodePoolUpgradePolicyList([]*cmv1.NodePoolUpgradePolicy{})
+
+	np1Outdated := nodePool("np1", "4.12.24", "4.12.25", "4.12.26")
+	np1Upgraded := nodePool("np1", "4.12.26")
+	np2Upgraded := nodePool("np2", "4.12.26")
+	np3Outdated := nodePool("np3", "4.12.25", "4.12.26")
+	np3Upgraded := nodePool("np3", "4.12.26")
+
+	var scheduled []string
+	scheduleHandler := func() http.HandlerFunc {
+		return ghttp.CombineHandlers(
+			ghttp.VerifyRequest(http.MethodPost, MatchRegexp("/node_pools/[^/]+/upgrade_policies$")),
+			func(w http.ResponseWriter, req *http.Request) {
+				body, err := io.ReadAll(req.Body)
+				Expect(err).To(BeNil())
+				policy, err := cmv1.UnmarshalNodePoolUpgradePolicy(body)
+				Expect(err).To(BeNil())
+				Expect(policy.Version()).To(Equal("4.12.26"))
+				scheduled = append(scheduled, policy.NodePoolID())
+			},
+			RespondWithJSON(http.StatusCreated, "{}"),
+		)
+	}
+	appendNodePool := func(nodePool *cmv1.NodePool, upgrades string) {
+		testRuntime.ApiServer.AppendHandlers(
+			RespondWithJSON(http.StatusOK, test.FormatResource(nodePool)),
+			RespondWithJSON(http.StatusOK, upgrades),
+		)
+	}
+
+	BeforeEach(func() {
+		testRuntime.InitRuntime()
+		saved := args
+		savedPollInterval := pollInterval
+		DeferCleanup(func() {
+			args = saved
+			pollInterval = savedPollInterval
+		})
+		pollInterval = 0
+		scheduled = nil
+		args.schedule = ""
+		args.scheduleDate = ""
+		args.scheduleTime = ""
+		args.version = ""
+		args.allowMinorVersionUpdates = false
+		args.all = true
+		args.maxParallel = 1
+		args.wait = false
+		args.waitTimeout = time.Hour
+		Cmd.Flags().Set("interactive", "false")
+	})
+
+	It("Fails with the id of a machine pool", func() {
+		err := Cmd.Args(Cmd, []string{"np1"})
+		Expect(err).To(MatchError("the '--all' option can't be used with the id of a machine pool"))
+	})
+
+	It("Fails with a schedule", func() {
+		args.scheduleDate = validScheduleDate
+		err := runWithRuntime(testRuntime.RosaRuntime, Cmd, []string{})
+		Expect(err).To(MatchError("The '--all' option can't be used with '--schedule', '--schedule-date' " +
+			"or '--schedule-time'"))
+	})
+
+	It("Fails to wait without '--all'", func() {
+		args.all = false
+		Cmd.Flags().Set("wait", "true")
+		DeferCleanup(func() {
+			Cmd.Flags().Set("wait", "false")
+			Cmd.Flags().Lookup("wait").Changed = false
+		})
+		err := runWithRuntime(testRuntime.RosaRuntime, Cmd, []string{"np1"})
+		Expect(err).To(MatchError("The '--max-parallel', '--wait' and '--wait-timeout' options can only be " +
+			"used with '--all'"))
+	})
+
+	It("Fails if the control plane is being upgraded without '--wait'", func() {
+		testRuntime.ApiServer.AppendHandlers(
+			RespondWithJSON(http.StatusOK, hostedCluster("4.12.24")),
+			RespondWithJSON(http.StatusOK, controlPlaneUpgrade(cmv1.UpgradePolicyStateValueStarted)),
+		)
+		err := runWithRuntime(testRuntime.RosaRuntime, Cmd, []string{})
+		Expect(err).To(MatchError("The control plane of cluster 'cluster1' is being upgraded to version " +
+			"'4.12.26', use '--wait' to upgrade the machine pools once it finishes"))
+	})
+
+	It("Fails with a version newer than the control plane", func() {
+		args.version = "4.12.27"
+		testRuntime.ApiServer.AppendHandlers(
+			RespondWithJSON(http.StatusOK, hostedCluster("4.12.26")),
+			RespondWithJSON(http.StatusOK, noControlPlaneUpgrade),
+		)
+		err := runWithRuntime(testRuntime.RosaRuntime, Cmd, []string{})
+		Expect(err).To(MatchError("Version '4.12.27' is newer than the control plane version '4.12.26' " +
+			"of cluster 'cluster1'"))
+	})
+
+	It("Fails before scheduling anything if a machine pool can't reach the version", func() {
+		testRuntime.ApiServer.AppendHandlers(
+			RespondWithJSON(http.StatusOK, hostedCluster("4.12.26")),
+			RespondWithJSON(http.StatusOK, noControlPlaneUpgrade),
+			RespondWithJSON(http.StatusOK, test.FormatNodePoolList([]*cmv1.NodePool{
+				np1Outdated, nodePool("np3", "4.12.25"),
+			})),
+		)
+		appendNodePool(np1Outdated, noNodePoolUpgrade)
+		appendNodePool(nodePool("np3", "4.12.25"), noNodePoolUpgrade)
+		err := runWithRuntime(testRuntime.RosaRuntime, Cmd, []string{})
+		Expect(err).To(MatchError("Machine pool 'np3' can't be upgraded from version '4.12.25' to version '4.12.26'"))
+		Expect(scheduled).To(BeEmpty())
+	})
+
+	It("Schedules as many upgrades as allowed without '--wait'", func() {
+		testRuntime.ApiServer.AppendHandlers(
+			RespondWithJSON(http.StatusOK, hostedCluster("4.12.26")),
+			RespondWithJSON(http.StatusOK, noControlPlaneUpgrade),
+			RespondWithJSON(http.StatusOK, test.FormatNodePoolList([]*cmv1.NodePool{
+				np3Outdated, np2Upgraded, np1Outdated,
+			})),
+		)
+		appendNodePool(np1Outdated, noNodePoolUpgrade)
+		appendNodePool(np3Outdated, noNodePoolUpgrade)
+		testRuntime.ApiServer.AppendHandlers(scheduleHandler())
+		stdout, _, err := test.RunWithOutputCaptureAndArgv(runWithRuntime, testRuntime.RosaRuntime, Cmd,
+			&[]string{})
+		Expect(err).To(BeNil())
+		Expect(scheduled).To(Equal([]string{"np1"}))
+		Expect(stdout).To(Equal("INFO: Scheduled the upgrade of machine pool 'np1' to version '4.12.26'\n" +
+			"INFO: 1 machine pools of cluster 'cluster1' are left to upgrade, run this command again once the " +
+			"scheduled upgrades finish or use '--wait'\n"))
+	})
+
+	It("Upgrades the machine pools one at a time after the control plane", func() {
+		args.wait = true
+		testRuntime.ApiServer.AppendHandlers(
+			RespondWithJSON(http.StatusOK, hostedCluster("4.12.24")),
+			RespondWithJSON(http.StatusOK, controlPlaneUpgrade(cmv1.UpgradePolicyStateValueStarted)),
+			RespondWithJSON(http.StatusOK, noControlPlaneUpgrade),
+			RespondWithJSON(http.StatusOK, hostedCluster("4.12.26")),
+			RespondWithJSON(http.StatusOK, test.FormatNodePoolList([]*cmv1.NodePool{
+				np1Outdated, np2Upgraded, np3Outdated,
+			})),
+		)
+		appendNodePool(np1Outdated, noNodePoolUpgrade)
+		appendNodePool(np3Outdated, noNodePoolUpgrade)
+		testRuntime.ApiServer.AppendHandlers(scheduleHandler())
+		appendNodePool(np1Upgraded, noNodePoolUpgrade)
+		testRuntime.ApiServer.AppendHandlers(scheduleHandler())
+		appendNodePool(np3Outdated, nodePoolUpgrade(cmv1.UpgradePolicyStateValueStarted))
+		appendNodePool(np3Upgraded, noNodePoolUpgrade)
+		stdout, _, err := test.RunWithOutputCaptureAndArgv(runWithRuntime, testRuntime.RosaRuntime, Cmd,
+			&[]string{})
+		Expect(err).To(BeNil())
+		Expect(scheduled).To(Equal([]string{"np1", "np3"}))
+		Expect(stdout).To(Equal(
+			"INFO: Waiting for the control plane of cluster 'cluster1' to be upgraded to version '4.12.26'\n" +
+				"INFO: Scheduled the upgrade of machine pool 'np1' to version '4.12.26'\n" +
+				"INFO: Machine pool 'np1' upgraded to version '4.12.26' (1 of 2)\n" +
+				"INFO: Scheduled the upgrade of machine pool 'np3' to version '4.12.26'\n" +
+				"INFO: Machine pool 'np3' upgraded to version '4.12.26' (2 of 2)\n" +
+				"INFO: All machine pools of cluster 'cluster1' upgraded to version '4.12.26'\n"))
+	})
+
+	It("Stops on the first failed upgrade", func() {
+		args.wait = true
+		args.maxParallel = 2
+		testRuntime.ApiServer.AppendHandlers(
+			RespondWithJSON(http.StatusOK, hostedCluster("4.12.26")),
+			RespondWithJSON(http.StatusOK, noControlPlaneUpgrade),
+			RespondWithJSON(http.StatusOK, test.FormatNodePoolList([]*cmv1.NodePool{np1Outdated, np3Outdated})),
+		)
+		appendNodePool(np1Outdated, noNodePoolUpgrade)
+		appendNodePool(np3Outdated, noNodePoolUpgrade)
+		testRuntime.ApiServer.AppendHandlers(scheduleHandler(), scheduleHandler())
+		appendNodePool(np1Outdated, nodePoolUpgrade(cmv1.UpgradePolicyStateValueFailed))
+		err := runWithRuntime(testRuntime.RosaRuntime, Cmd, []string{})
+		Expect(err).To(MatchError("The upgrade of machine pool 'np1' to version '4.12.26' failed: " +
+			"Nodes failed to drain"))
+		Expect(scheduled).To(Equal([]string{"np1", "np3"}))
+	})
+
+	It("Fails if a machine pool has an upgrade to another version", func() {
+		testRuntime.ApiServer.AppendHandlers(
+			RespondWithJSON(http.StatusOK, hostedCluster("4.12.26")),
+			RespondWithJSON(http.StatusOK, noControlPlaneUpgrade),
+			RespondWithJSON(http.StatusOK, test.FormatNodePoolList([]*cmv1.NodePool{np1Outdated})),
+		)
+		appendNodePool(np1Outdated, nodePoolUpgradeTo("4.12.25", cmv1.ScheduleTypeManual,
+			cmv1.UpgradePolicyStateValueScheduled))
+		err := runWithRuntime(testRuntime.RosaRuntime, Cmd, []string{})
+		Expect(err).To(MatchError("Machine pool 'np1' already has a scheduled manual upgrade to version " +
+			"'4.12.25', cancel it with 'rosa delete upgrade -c cluster1 --machinepool np1' first"))
+		Expect(scheduled).To(BeEmpty())
+	})
+
+	It("Fails if a machine pool has a recurring upgrade", func() {
+		testRuntime.ApiServer.AppendHandlers(
+			RespondWithJSON(http.StatusOK, hostedCluster("4.12.26")),
+			RespondWithJSON(http.StatusOK, noControlPlaneUpgrade),
+			RespondWithJSON(http.StatusOK, test.FormatNodePoolList([]*cmv1.NodePool{np1Outdated})),
+		)
+		appendNodePool(np1Outdated, nodePoolUpgradeTo("4.12.26", cmv1.ScheduleTypeAutomatic,
+			cmv1.UpgradePolicyStateValueScheduled))
+		err := runWithRuntime(testRuntime.RosaRuntime, Cmd, []string{})
+		Expect(err).To(MatchError("Machine pool 'np1' already has a scheduled automatic upgrade to version " +
+			"'4.12.26', cancel it with 'rosa delete upgrade -c cluster1 --machinepool np1' first"))
+	})
+
+	It("Times out waiting for the control plane upgrade", func() {
+		args.wait = true
+		args.waitTimeout = time.Nanosecond
+		testRuntime.ApiServer.AppendHandlers(
+			RespondWithJSON(http.StatusOK, hostedCluster("4.12.24")),
+			RespondWithJSON(http.StatusOK, controlPlaneUpgrade(cmv1.UpgradePolicyStateValueStarted)),
+		)
+		_, _, err := test.RunWithOutputCaptureAndArgv(runWithRuntime, testRuntime.RosaRuntime, Cmd, &[]string{})
+		Expect(err).To(MatchError("Timed out after 1ns waiting for the control plane of cluster 'cluster1' " +
+			"to be upgraded to version '4.12.26'"))
+	})
+
+	It("Times out waiting for the machine pool upgrades", func() {
+		args.wait = true
+		args.waitTimeout = time.Nanosecond
+		testRuntime.ApiServer.AppendHandlers(
+			RespondWithJSON(http.StatusOK, hostedCluster("4.12.26")),
+			RespondWithJSON(http.StatusOK, noControlPlaneUpgrade),
+			RespondWithJSON(http.StatusOK, test.FormatNodePoolList([]*cmv1.NodePool{np1Outdated})),
+		)
+		appendNodePool(np1Outdated, noNodePoolUpgrade)
+		testRuntime.ApiServer.AppendHandlers(scheduleHandler())
+		_, _, err := test.RunWithOutputCaptureAndArgv(runWithRuntime, testRuntime.RosaRuntime, Cmd, &[]string{})
+		Expect(err).To(MatchError("Timed out after 1ns waiting for the upgrade of machine pools 'np1' of " +
+			"cluster 'cluster1', run this command again to resume"))
+		Expect(scheduled).To(Equal([]string{"np1"}))
+	})
+
+	It("Does nothing if all the machine pools are upgraded", func() {
+		testRuntime.ApiServer.AppendHandlers(
+			RespondWithJSON(http.StatusOK, hostedCluster("4.12.26")),
+			RespondWithJSON(http.StatusOK, noControlPlaneUpgrade),
+			RespondWithJSON(http.StatusOK, test.FormatNodePoolList([]*cmv1.NodePool{np1Upgraded, np2Upgraded})),
+		)
+		stdout, _, err := test.RunWithOutputCaptureAndArgv(runWithRuntime, testRuntime.RosaRuntime, Cmd,
+			&[]string{})
+		Expect(err).To(BeNil())
+		Expect(stdout).To(Equal("INFO: All machine pools of cluster 'cluster1' are already at version '4.12.26'\n"))
+	})
+})
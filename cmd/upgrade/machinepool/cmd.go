@@ -19,6 +19,7 @@ package machinepool
 import (
 	"fmt"
 	"os"
+	"time"
 
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/pkg/errors"
@@ -37,6 +38,10 @@ var args struct {
 	scheduleTime             string
 	schedule                 string
 	allowMinorVersionUpdates bool
+	all                      bool
+	maxParallel              int
+	wait                     bool
+	waitTimeout              time.Duration
 }
 
 var Cmd = &cobra.Command{
@@ -48,9 +53,19 @@ var Cmd = &cobra.Command{
   rosa upgrade machinepool np1 --cluster=mycluster --interactive
 
   # Schedule a machinepool upgrade within the hour
-  rosa upgrade machinepool np1 -c mycluster --version 4.12.20`,
+  rosa upgrade machinepool np1 -c mycluster --version 4.12.20
+
+  # Upgrade all the machine pools to the control plane version, two at a time, once the
+  # control plane upgrade finishes
+  rosa upgrade machinepools -c mycluster --all --max-parallel 2 --wait`,
 	Run: run,
 	Args: func(_ *cobra.Command, argv []string) error {
+		if args.all {
+			if len(argv) != 0 {
+				return fmt.Errorf("the '--all' option can't be used with the id of a machine pool")
+			}
+			return nil
+		}
 		if len(argv) != 1 {
 			return fmt.Errorf(
 				"expected exactly one command line parameter containing the id of the machine pool",
@@ -106,6 +121,36 @@ func init() {
 	// Hidden for now as not supported yet
 	flags.MarkHidden("allow-minor-version-updates")
 
+	flags.BoolVar(
+		&args.all,
+		"all",
+		false,
+		"Upgrade all the machine pools of the cluster to the version of the control plane, or to '--version', "+
+			"once any control plane upgrade in progress finishes.",
+	)
+
+	flags.IntVar(
+		&args.maxParallel,
+		"max-parallel",
+		1,
+		"Maximum number of machine pools to upgrade at the same time when using '--all'.",
+	)
+
+	flags.BoolVar(
+		&args.wait,
+		"wait",
+		false,
+		"When using '--all', wait for the control plane upgrade to finish and keep scheduling machine pool "+
+			"upgrades until all of them are upgraded. Stops on the first failed upgrade.",
+	)
+
+	flags.DurationVar(
+		&args.waitTimeout,
+		"wait-timeout",
+		6*time.Hour,
+		"Maximum time to wait for the control plane and machine pool upgrades when using '--wait'.",
+	)
+
 	confirm.AddFlag(flags)
 	interactive.AddFlag(flags)
 }
@@ -121,6 +166,15 @@ func run(cmd *cobra.Command, argv []string) {
 }
 
 func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command, argv []string) error {
+	if args.all {
+		return upgradeAllMachinePools(r, cmd)
+	}
+	if cmd.Flags().Changed("max-parallel") || cmd.Flags().Changed("wait") ||
+		cmd.Flags().Changed("wait-timeout") {
+		return fmt.Errorf("The '--max-parallel', '--wait' and '--wait-timeout' options can only be used " +
+			"with '--all'")
+	}
+
 	clusterKey := r.GetClusterKey()
 	cluster := r.FetchCluster()
 	machinePoolID := argv[0]
@@ -300,6 +354,12 @@ func buildAutomaticUpgradePolicy(r *rosa.Runtime, cmd *cobra.Command, currentUpg
 			nodePool.ID(), clusterKey)
 	}
 
+	upcomingRuns, err := interactive.DescribeUpcomingUpgradeRuns(currentUpgradeScheduling.Schedule, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	r.Reporter.Infof("%s", upcomingRuns)
+
 	// Ask for confirmation
 	if r.Reporter.IsTerminal() && !confirm.Confirm("schedule automatic upgrades for machine pool '%s' at '%s'",
 		nodePool.ID(), currentUpgradeScheduling.Schedule) {
@@ -1,6 +1,7 @@
 package machinepool
 
 import (
+	"fmt"
 	"net/http"
 	"time"
 
@@ -235,6 +236,8 @@ var _ = Describe("Upgrade machine pool", func() {
 				Cmd, &[]string{nodePoolName})
 			Expect(err).To(BeNil())
 			Expect(stderr).To(BeEmpty())
+			Expect(stdout).To(ContainSubstring(
+				fmt.Sprintf("Next 5 automatic upgrade runs for schedule '%s':", cronSchedule)))
 			Expect(stdout).To(ContainSubstring(
 				"Upgrade successfully scheduled for the machine pool 'nodepool85' on cluster 'cluster1'"))
 		})
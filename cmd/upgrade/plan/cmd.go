@@ -0,0 +1,389 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	ver "github.com/hashicorp/go-version"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/helper/versions"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+// maxUpgradeHops bounds the search for an upgrade path in case the available upgrades never reach the target
+const maxUpgradeHops = 20
+
+var args struct {
+	to string
+}
+
+var Cmd = &cobra.Command{
+	Use:   "plan",
+	Short: "Plan the upgrade of a cluster to a version",
+	Long: "Compute the path of upgrades from the current version of a cluster to a target version and list, " +
+		"for each hop, the version gates to acknowledge, the account and operator role policies to upgrade, " +
+		"the operator roles to create and, for Hosted Control Planes, the machine pools to upgrade first.",
+	Example: `  # Plan the upgrade of the cluster named "mycluster" to the latest 4.16 version
+  rosa upgrade plan --cluster=mycluster --to 4.16
+
+  # Plan the upgrade of the cluster named "mycluster" to version 4.15.20
+  rosa upgrade plan -c mycluster --to 4.15.20`,
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	ocm.AddClusterFlag(Cmd)
+
+	flags.StringVar(
+		&args.to,
+		"to",
+		"",
+		"Version to upgrade the cluster to. A minor version, e.g. '4.16', targets its latest available "+
+			"patch version.",
+	)
+
+	output.AddFlag(Cmd)
+}
+
+func run(cmd *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS().WithOCM()
+	defer r.Cleanup()
+	err := runWithRuntime(r, cmd)
+	if err != nil {
+		r.Reporter.Errorf(err.Error())
+		os.Exit(1)
+	}
+}
+
+type versionGate struct {
+	ID               string `json:"id"`
+	Description      string `json:"description"`
+	STSOnly          bool   `json:"sts_only"`
+	DocumentationURL string `json:"documentation_url,omitempty"`
+}
+
+type machinePoolVersion struct {
+	ID      string `json:"id"`
+	Version string `json:"version"`
+}
+
+type upgradeHop struct {
+	From                        string               `json:"from"`
+	To                          string               `json:"to"`
+	VersionGates                []versionGate        `json:"version_gates,omitempty"`
+	AccountRolePoliciesUpgrade  bool                 `json:"account_role_policies_upgrade"`
+	OperatorRolePoliciesUpgrade bool                 `json:"operator_role_policies_upgrade"`
+	MissingOperatorRoles        []string             `json:"missing_operator_roles,omitempty"`
+	MachinePoolsToUpgrade       []machinePoolVersion `json:"machine_pools_to_upgrade,omitempty"`
+}
+
+type upgradePlan struct {
+	Cluster string       `json:"cluster"`
+	From    string       `json:"from"`
+	To      string       `json:"to"`
+	Hops    []upgradeHop `json:"hops"`
+}
+
+func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command) error {
+	if args.to == "" {
+		return fmt.Errorf("Expected the version to plan the upgrade to with '--to'")
+	}
+	target := ocm.GetRawVersionId(args.to)
+	_, err := ver.NewVersion(target)
+	if err != nil {
+		return fmt.Errorf("Expected a valid version to plan the upgrade to: %v", err)
+	}
+
+	clusterKey := r.GetClusterKey()
+	cluster := r.FetchCluster()
+	if cluster.State() != cmv1.ClusterStateReady {
+		return fmt.Errorf("Cluster '%s' is not yet ready", clusterKey)
+	}
+
+	current := cluster.Version().RawID()
+	path, err := findUpgradePath(r, current, cluster.Version().ChannelGroup(), target)
+	if err != nil {
+		return fmt.Errorf("Failed to find an upgrade path for cluster '%s': %v", clusterKey, err)
+	}
+
+	hops, err := planHops(r, cluster, path)
+	if err != nil {
+		return fmt.Errorf("Failed to plan the upgrade of cluster '%s': %v", clusterKey, err)
+	}
+	plan := upgradePlan{
+		Cluster: cluster.Name(),
+		From:    current,
+		To:      path[len(path)-1],
+		Hops:    hops,
+	}
+
+	if output.HasFlag() {
+		return output.Print(plan)
+	}
+	printPlan(clusterKey, plan)
+	return nil
+}
+
+// findUpgradePath walks the available upgrades from the current version, taking at each hop the latest
+// version that doesn't go past the target, and returns the versions of the path including the current one
+func findUpgradePath(r *rosa.Runtime, current string, channelGroup string, target string) ([]string, error) {
+	targetVersion, err := ver.NewVersion(target)
+	if err != nil {
+		return nil, err
+	}
+	exactTarget := len(strings.Split(target, ".")) > 2
+	targetMinor := ocm.GetVersionMinor(target)
+
+	currentVersion, err := ver.NewVersion(current)
+	if err != nil {
+		return nil, err
+	}
+	targetMinorVersion, err := ver.NewVersion(targetMinor)
+	if err != nil {
+		return nil, err
+	}
+	currentMinorVersion, err := ver.NewVersion(ocm.GetVersionMinor(current))
+	if err != nil {
+		return nil, err
+	}
+	if exactTarget && !targetVersion.GreaterThan(currentVersion) ||
+		!exactTarget && currentMinorVersion.GreaterThan(targetMinorVersion) {
+		return nil, fmt.Errorf("version '%s' isn't newer than the current version '%s'", target, current)
+	}
+
+	path := []string{current}
+	version := current
+	for hop := 0; hop < maxUpgradeHops; hop++ {
+		availableUpgrades, err := r.OCMClient.GetAvailableUpgrades(ocm.CreateVersionID(version, channelGroup))
+		if err != nil {
+			return nil, err
+		}
+
+		var next *ver.Version
+		for _, availableUpgrade := range availableUpgrades {
+			candidate, err := ver.NewVersion(availableUpgrade)
+			if err != nil {
+				return nil, err
+			}
+			if exactTarget && candidate.GreaterThan(targetVersion) {
+				continue
+			}
+			if !exactTarget {
+				minorVersion, err := ver.NewVersion(ocm.GetVersionMinor(availableUpgrade))
+				if err != nil {
+					return nil, err
+				}
+				if minorVersion.GreaterThan(targetMinorVersion) {
+					continue
+				}
+			}
+			if next == nil || candidate.GreaterThan(next) {
+				next = candidate
+			}
+		}
+		if next == nil {
+			break
+		}
+
+		version = next.Original()
+		path = append(path, version)
+		if exactTarget && next.Equal(targetVersion) {
+			return path, nil
+		}
+		if !exactTarget && ocm.GetVersionMinor(version) == targetMinor {
+			return path, nil
+		}
+	}
+
+	if len(path) == 1 && !exactTarget && ocm.GetVersionMinor(version) == targetMinor {
+		return nil, fmt.Errorf("version '%s' is already the latest version available for '%s'", version, target)
+	}
+	return nil, fmt.Errorf("there are no available upgrades from version '%s' towards version '%s'", version,
+		target)
+}
+
+// planHops lists what each hop of the path requires before it can be scheduled
+func planHops(r *rosa.Runtime, cluster *cmv1.Cluster, path []string) ([]upgradeHop, error) {
+	isSTS := cluster.AWS().STS().RoleARN() != ""
+	checkRolePolicies := isSTS && !cluster.AWS().STS().ManagedPolicies()
+
+	var credRequests map[string]*cmv1.STSOperator
+	var operatorRolePolicyPrefix string
+	var err error
+	if checkRolePolicies {
+		credRequests, err = r.OCMClient.GetCredRequests(cluster.Hypershift().Enabled())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the operator credential requests: %v", err)
+		}
+		operatorRolePolicyPrefix, err = aws.GetOperatorRolePolicyPrefixFromCluster(cluster, r.AWSClient)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the operator role policy prefix: %v", err)
+		}
+	}
+
+	// Versions of the machine pools of hosted clusters, which are upgraded separately from the
+	// control plane
+	machinePools := map[string]string{}
+	if cluster.Hypershift().Enabled() {
+		nodePools, err := r.OCMClient.GetNodePools(cluster.ID())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get machine pools: %v", err)
+		}
+		for _, nodePool := range nodePools {
+			machinePools[nodePool.ID()] = ocm.GetRawVersionId(nodePool.Version().ID())
+		}
+	}
+
+	var hops []upgradeHop
+	for i := 1; i < len(path); i++ {
+		hop := upgradeHop{
+			From: path[i-1],
+			To:   path[i],
+		}
+		// Version gates and role policies only change with the minor version
+		minor := ocm.GetVersionMinor(hop.To)
+		if minor != ocm.GetVersionMinor(hop.From) {
+			gates, err := r.OCMClient.ListOcpGates(minor)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get the version gates of version '%s': %v", minor, err)
+			}
+			if isSTS {
+				stsGates, err := r.OCMClient.ListStsGates(minor)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get the STS version gates of version '%s': %v", minor, err)
+				}
+				gates = append(gates, stsGates...)
+			}
+			for _, gate := range gates {
+				hop.VersionGates = append(hop.VersionGates, versionGate{
+					ID:               gate.ID(),
+					Description:      strings.TrimSpace(gate.Description()),
+					STSOnly:          gate.STSOnly(),
+					DocumentationURL: gate.DocumentationURL(),
+				})
+			}
+		}
+
+		if checkRolePolicies && minor != ocm.GetVersionMinor(hop.From) {
+			hop.AccountRolePoliciesUpgrade, err = r.AWSClient.IsUpgradedNeededForAccountRolePoliciesUsingCluster(
+				cluster, minor)
+			if err != nil {
+				return nil, fmt.Errorf("failed to check the account role policies: %v", err)
+			}
+			hop.OperatorRolePoliciesUpgrade, err = r.AWSClient.IsUpgradedNeededForOperatorRolePoliciesUsingCluster(
+				cluster, r.Creator.Partition, r.Creator.AccountID, minor, credRequests, operatorRolePolicyPrefix)
+			if err != nil {
+				return nil, fmt.Errorf("failed to check the operator role policies: %v", err)
+			}
+		}
+
+		if isSTS {
+			missingRoles, err := r.OCMClient.FindMissingOperatorRolesForUpgrade(cluster, hop.To)
+			if err != nil {
+				return nil, fmt.Errorf("failed to find the operator roles missing for version '%s': %v",
+					hop.To, err)
+			}
+			for _, operator := range missingRoles {
+				hop.MissingOperatorRoles = append(hop.MissingOperatorRoles,
+					fmt.Sprintf("%s/%s", operator.Namespace(), operator.Name()))
+			}
+			sort.Strings(hop.MissingOperatorRoles)
+		}
+
+		if len(machinePools) > 0 {
+			hop.MachinePoolsToUpgrade, err = machinePoolsOutOfSkew(machinePools, hop)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		hops = append(hops, hop)
+	}
+	return hops, nil
+}
+
+// machinePoolsOutOfSkew returns the machine pools that would fall behind the oldest version supported by
+// the control plane after the hop. They are expected to be upgraded to the version the hop starts from,
+// which is what the following hops assume.
+func machinePoolsOutOfSkew(machinePools map[string]string, hop upgradeHop) ([]machinePoolVersion, error) {
+	minimalVersion, err := versions.GetMinimalHostedMachinePoolVersion(hop.To)
+	if err != nil {
+		return nil, err
+	}
+	var outOfSkew []machinePoolVersion
+	for id, version := range machinePools {
+		supported, err := versions.IsGreaterThanOrEqual(version, minimalVersion)
+		if err != nil {
+			return nil, err
+		}
+		if !supported {
+			outOfSkew = append(outOfSkew, machinePoolVersion{ID: id, Version: version})
+			machinePools[id] = hop.From
+		}
+	}
+	sort.Slice(outOfSkew, func(i, j int) bool {
+		return outOfSkew[i].ID < outOfSkew[j].ID
+	})
+	return outOfSkew, nil
+}
+
+func printPlan(clusterKey string, plan upgradePlan) {
+	fmt.Printf("Upgrade path for cluster '%s' from version %s to %s:\n", clusterKey, plan.From, plan.To)
+	for i, hop := range plan.Hops {
+		fmt.Printf("\n%d. %s -> %s\n", i+1, hop.From, hop.To)
+		if len(hop.VersionGates) == 0 {
+			fmt.Printf("   Version gates:                     None\n")
+		} else {
+			fmt.Printf("   Version gates:\n")
+			for _, gate := range hop.VersionGates {
+				fmt.Printf("    - %s\n", gate.Description)
+				if gate.DocumentationURL != "" {
+					fmt.Printf("      %s\n", gate.DocumentationURL)
+				}
+			}
+		}
+		fmt.Printf("   Account role policies upgrade:     %s\n", output.PrintBool(hop.AccountRolePoliciesUpgrade))
+		fmt.Printf("   Operator role policies upgrade:    %s\n", output.PrintBool(hop.OperatorRolePoliciesUpgrade))
+		if len(hop.MissingOperatorRoles) == 0 {
+			fmt.Printf("   Missing operator roles:            None\n")
+		} else {
+			fmt.Printf("   Missing operator roles:\n")
+			for _, role := range hop.MissingOperatorRoles {
+				fmt.Printf("    - %s\n", role)
+			}
+		}
+		if len(hop.MachinePoolsToUpgrade) > 0 {
+			fmt.Printf("   Machine pools to upgrade first:\n")
+			for _, machinePool := range hop.MachinePoolsToUpgrade {
+				fmt.Printf("    - %s (%s)\n", machinePool.ID, machinePool.Version)
+			}
+		}
+	}
+}
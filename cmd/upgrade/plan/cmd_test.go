@@ -0,0 +1,246 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package plan
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	. "github.com/openshift-online/ocm-sdk-go/testing"
+	"go.uber.org/mock/gomock"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/test"
+)
+
+var _ = Describe("Upgrade plan", func() {
+	var (
+		testRuntime   test.TestingRuntime
+		mockAwsClient *aws.MockClient
+	)
+
+	upgradeGraph := map[string][]string{
+		"4.12.24": {"4.12.25", "4.12.50"},
+		"4.12.25": {"4.12.50"},
+		"4.12.50": {"4.13.10", "4.13.30"},
+		"4.13.10": {"4.13.30"},
+		"4.13.30": {"4.13.31", "4.14.8", "4.14.5"},
+		"4.13.31": {"4.14.8"},
+		"4.14.5":  {"4.14.8"},
+		"4.14.8":  {"4.15.2"},
+		"4.15.2":  {},
+	}
+
+	stsCluster := test.MockCluster(func(c *cmv1.ClusterBuilder) {
+		c.State(cmv1.ClusterStateReady)
+		c.Version(cmv1.NewVersion().ID("openshift-v4.12.24").RawID("4.12.24").ChannelGroup("stable"))
+		c.AWS(cmv1.NewAWS().STS(cmv1.NewSTS().
+			RoleARN("arn:aws:iam::123456789012:role/myprefix-Installer-Role").
+			OperatorIAMRoles(cmv1.NewOperatorIAMRole().Namespace("openshift-ingress-operator").
+				Name("cloud-credentials").
+				RoleARN("arn:aws:iam::123456789012:role/myprefix-openshift-ingress-operator-cloud-credentials"))))
+	})
+	hcpCluster := test.MockCluster(func(c *cmv1.ClusterBuilder) {
+		c.State(cmv1.ClusterStateReady)
+		c.Hypershift(cmv1.NewHypershift().Enabled(true))
+		c.Version(cmv1.NewVersion().ID("openshift-v4.12.50").RawID("4.12.50").ChannelGroup("stable"))
+	})
+
+	gates := func(minor string) string {
+		var items []*cmv1.VersionGate
+		if minor == "4.13" {
+			ocpGate, err := cmv1.NewVersionGate().ID("ocp-gate").VersionRawIDPrefix("4.13").
+				Description("Removed Kubernetes APIs").DocumentationURL("https://access.redhat.com/solutions/1").
+				Build()
+			Expect(err).NotTo(HaveOccurred())
+			stsGate, err := cmv1.NewVersionGate().ID("sts-gate").VersionRawIDPrefix("4.13").STSOnly(true).
+				Description("New operator role permissions").Build()
+			Expect(err).NotTo(HaveOccurred())
+			items = append(items, ocpGate, stsGate)
+		}
+		var b bytes.Buffer
+		Expect(cmv1.MarshalVersionGateList(items, &b)).To(Succeed())
+		return fmt.Sprintf(`{"kind": "VersionGateList", "page": 1, "size": %d, "total": %d, "items": %s}`,
+			len(items), len(items), b.String())
+	}
+
+	credRequests := func() string {
+		request, err := cmv1.NewSTSCredentialRequest().Name("cloud_network_config_controller_cloud_credentials").
+			Operator(cmv1.NewSTSOperator().Namespace("openshift-cloud-network-config-controller").
+				Name("cloud-credentials").MinVersion("4.13.0")).Build()
+		Expect(err).NotTo(HaveOccurred())
+		var b bytes.Buffer
+		Expect(cmv1.MarshalSTSCredentialRequestList([]*cmv1.STSCredentialRequest{request}, &b)).To(Succeed())
+		return fmt.Sprintf(`{"kind": "STSCredentialRequestList", "page": 1, "size": 1, "total": 1, "items": %s}`,
+			b.String())
+	}
+
+	BeforeEach(func() {
+		testRuntime.InitRuntime()
+		mockAwsClient = aws.NewMockClient(gomock.NewController(GinkgoT()))
+		testRuntime.RosaRuntime.AWSClient = mockAwsClient
+		DeferCleanup(func() {
+			args.to = ""
+			Cmd.Flags().Set("output", "")
+		})
+
+		testRuntime.ApiServer.RouteToHandler(http.MethodGet, regexp.MustCompile("^/api/clusters_mgmt/v1/versions/"),
+			func(w http.ResponseWriter, r *http.Request) {
+				raw := strings.TrimPrefix(r.URL.Path, "/api/clusters_mgmt/v1/versions/openshift-v")
+				upgrades, ok := upgradeGraph[raw]
+				Expect(ok).To(BeTrue(), "unexpected version %s", raw)
+				version, err := cmv1.NewVersion().ID("openshift-v" + raw).RawID(raw).ChannelGroup("stable").
+					ROSAEnabled(true).AvailableUpgrades(upgrades...).Build()
+				Expect(err).NotTo(HaveOccurred())
+				RespondWithJSON(http.StatusOK, test.FormatResource(version))(w, r)
+			})
+		testRuntime.ApiServer.RouteToHandler(http.MethodGet, "/api/clusters_mgmt/v1/version_gates",
+			func(w http.ResponseWriter, r *http.Request) {
+				search := r.URL.Query().Get("search")
+				minor := strings.TrimSuffix(strings.TrimPrefix(search, "version_raw_id_prefix = '"), "'")
+				RespondWithJSON(http.StatusOK, gates(minor))(w, r)
+			})
+		testRuntime.ApiServer.RouteToHandler(http.MethodGet,
+			"/api/clusters_mgmt/v1/aws_inquiries/sts_credential_requests",
+			func(w http.ResponseWriter, r *http.Request) {
+				RespondWithJSON(http.StatusOK, credRequests())(w, r)
+			})
+	})
+
+	It("Fails without a target version", func() {
+		_, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+		Expect(err).To(MatchError("Expected the version to plan the upgrade to with '--to'"))
+	})
+
+	It("Fails with an older target version", func() {
+		args.to = "4.11"
+		testRuntime.ApiServer.AppendHandlers(
+			RespondWithJSON(http.StatusOK, test.FormatClusterList([]*cmv1.Cluster{stsCluster})))
+		_, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+		Expect(err).To(MatchError("Failed to find an upgrade path for cluster 'cluster1': " +
+			"version '4.11' isn't newer than the current version '4.12.24'"))
+	})
+
+	It("Fails when the available upgrades don't reach the target version", func() {
+		args.to = "4.16"
+		testRuntime.ApiServer.AppendHandlers(
+			RespondWithJSON(http.StatusOK, test.FormatClusterList([]*cmv1.Cluster{stsCluster})))
+		_, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+		Expect(err).To(MatchError("Failed to find an upgrade path for cluster 'cluster1': " +
+			"there are no available upgrades from version '4.15.2' towards version '4.16'"))
+	})
+
+	It("Plans the upgrade of an STS cluster to a minor version", func() {
+		args.to = "4.14"
+		testRuntime.ApiServer.AppendHandlers(
+			RespondWithJSON(http.StatusOK, test.FormatClusterList([]*cmv1.Cluster{stsCluster})))
+		mockAwsClient.EXPECT().IsUpgradedNeededForAccountRolePoliciesUsingCluster(gomock.Any(), "4.13").
+			Return(true, nil)
+		mockAwsClient.EXPECT().IsUpgradedNeededForAccountRolePoliciesUsingCluster(gomock.Any(), "4.14").
+			Return(false, nil)
+		mockAwsClient.EXPECT().IsUpgradedNeededForOperatorRolePoliciesUsingCluster(gomock.Any(), gomock.Any(),
+			gomock.Any(), "4.13", gomock.Any(), "myprefix").Return(false, nil)
+		mockAwsClient.EXPECT().IsUpgradedNeededForOperatorRolePoliciesUsingCluster(gomock.Any(), gomock.Any(),
+			gomock.Any(), "4.14", gomock.Any(), "myprefix").Return(true, nil)
+		stdout, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stdout).To(Equal(`Upgrade path for cluster 'cluster1' from version 4.12.24 to 4.14.8:
+
+1. 4.12.24 -> 4.12.50
+   Version gates:                     None
+   Account role policies upgrade:     No
+   Operator role policies upgrade:    No
+   Missing operator roles:            None
+
+2. 4.12.50 -> 4.13.30
+   Version gates:
+    - Removed Kubernetes APIs
+      https://access.redhat.com/solutions/1
+    - New operator role permissions
+   Account role policies upgrade:     Yes
+   Operator role policies upgrade:    No
+   Missing operator roles:
+    - openshift-cloud-network-config-controller/cloud-credentials
+
+3. 4.13.30 -> 4.14.8
+   Version gates:                     None
+   Account role policies upgrade:     No
+   Operator role policies upgrade:    Yes
+   Missing operator roles:
+    - openshift-cloud-network-config-controller/cloud-credentials
+`))
+	})
+
+	It("Plans the upgrade of a hosted cluster to a version with the machine pools out of skew", func() {
+		args.to = "4.15.2"
+		Cmd.Flags().Set("output", "json")
+		nodePools := []*cmv1.NodePool{}
+		for id, version := range map[string]string{"np1": "4.12.24", "np2": "4.11.40"} {
+			nodePool, err := cmv1.NewNodePool().ID(id).Version(cmv1.NewVersion().ID("openshift-v" + version)).Build()
+			Expect(err).NotTo(HaveOccurred())
+			nodePools = append(nodePools, nodePool)
+		}
+		testRuntime.ApiServer.AppendHandlers(
+			RespondWithJSON(http.StatusOK, test.FormatClusterList([]*cmv1.Cluster{hcpCluster})),
+			RespondWithJSON(http.StatusOK, test.FormatNodePoolList(nodePools)),
+		)
+		stdout, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stdout).To(MatchJSON(`{
+			"cluster": "` + test.MockClusterName + `",
+			"from": "4.12.50",
+			"to": "4.15.2",
+			"hops": [
+				{
+					"from": "4.12.50",
+					"to": "4.13.30",
+					"version_gates": [{
+						"id": "ocp-gate",
+						"description": "Removed Kubernetes APIs",
+						"sts_only": false,
+						"documentation_url": "https://access.redhat.com/solutions/1"
+					}],
+					"account_role_policies_upgrade": false,
+					"operator_role_policies_upgrade": false,
+					"machine_pools_to_upgrade": [{"id": "np2", "version": "4.11.40"}]
+				},
+				{
+					"from": "4.13.30",
+					"to": "4.14.8",
+					"account_role_policies_upgrade": false,
+					"operator_role_policies_upgrade": false
+				},
+				{
+					"from": "4.14.8",
+					"to": "4.15.2",
+					"account_role_policies_upgrade": false,
+					"operator_role_policies_upgrade": false,
+					"machine_pools_to_upgrade": [
+						{"id": "np1", "version": "4.12.24"},
+						{"id": "np2", "version": "4.12.50"}
+					]
+				}
+			]
+		}`))
+	})
+})
@@ -19,11 +19,14 @@ package verify
 import (
 	"github.com/spf13/cobra"
 
+	"github.com/openshift/rosa/cmd/verify/externalauthprovider"
+	"github.com/openshift/rosa/cmd/verify/kmskey"
 	"github.com/openshift/rosa/cmd/verify/network"
 	"github.com/openshift/rosa/cmd/verify/oc"
 	"github.com/openshift/rosa/cmd/verify/permissions"
 	"github.com/openshift/rosa/cmd/verify/quota"
 	"github.com/openshift/rosa/cmd/verify/rosa"
+	"github.com/openshift/rosa/cmd/verify/sharedvpc"
 )
 
 var Cmd = &cobra.Command{
@@ -39,4 +42,7 @@ func init() {
 	Cmd.AddCommand(permissions.Cmd)
 	Cmd.AddCommand(quota.Cmd)
 	Cmd.AddCommand(rosa.NewVerifyRosaCommand())
+	Cmd.AddCommand(sharedvpc.Cmd)
+	Cmd.AddCommand(kmskey.Cmd)
+	Cmd.AddCommand(externalauthprovider.Cmd)
 }
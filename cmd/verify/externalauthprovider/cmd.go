@@ -0,0 +1,137 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package externalauthprovider
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/externalauthprovider"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var args struct {
+	name  string
+	token string
+}
+
+var Cmd = &cobra.Command{
+	Use:     "external-auth-provider",
+	Aliases: []string{"externalauthproviders", "externalauthprovider", "external-auth-providers"},
+	Short:   "Verify the claim mappings of an external authentication provider against a token",
+	Long: "Evaluate the issuer, audiences, claim validation rules and claim mappings of an external " +
+		"authentication provider against a sample token, showing the username and groups the token maps " +
+		"to. The token is evaluated locally and its signature is not verified.",
+	Example: `  # Verify a token against the external authentication provider "entra-id" of a cluster named "mycluster"
+  rosa verify external-auth-provider --cluster=mycluster --name=entra-id --token=$(cat token.jwt)`,
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+	flags.SortFlags = false
+
+	ocm.AddClusterFlag(Cmd)
+	flags.StringVar(
+		&args.name,
+		"name",
+		"",
+		"Name of the external authentication provider of the cluster to verify.",
+	)
+	flags.StringVar(
+		&args.token,
+		"token",
+		"",
+		"Sample JWT issued by the token issuer of the external authentication provider.",
+	)
+	output.AddFlag(Cmd)
+}
+
+func run(cmd *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithOCM()
+	defer r.Cleanup()
+
+	err := runWithRuntime(r, cmd)
+	if err != nil {
+		r.Reporter.Errorf(err.Error())
+		os.Exit(1)
+	}
+}
+
+func runWithRuntime(r *rosa.Runtime, _ *cobra.Command) error {
+	if args.name == "" {
+		return fmt.Errorf("you need to specify an external authentication provider name with '--name' parameter")
+	}
+	if args.token == "" {
+		return fmt.Errorf("you need to specify a token to verify with '--token' parameter")
+	}
+	clusterKey := r.GetClusterKey()
+	cluster := r.FetchCluster()
+
+	externalAuthService := externalauthprovider.NewExternalAuthService(r.OCMClient)
+	err := externalAuthService.IsExternalAuthProviderSupported(cluster, clusterKey)
+	if err != nil {
+		return err
+	}
+
+	externalAuthConfig, exists, err := r.OCMClient.GetExternalAuth(cluster.ID(), args.name)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return fmt.Errorf("external authentication provider '%s' not found", args.name)
+	}
+
+	evaluation, err := externalauthprovider.EvaluateToken(externalAuthConfig, strings.TrimSpace(args.token),
+		time.Now())
+	if err != nil {
+		return err
+	}
+
+	if output.HasFlag() {
+		err = output.Print(evaluation)
+		if err != nil {
+			return err
+		}
+	} else {
+		for _, message := range evaluation.Errors {
+			r.Reporter.Errorf("%s", message)
+		}
+		if evaluation.Username != "" {
+			r.Reporter.Infof("Username: %s", evaluation.Username)
+		}
+		if len(evaluation.Groups) > 0 {
+			r.Reporter.Infof("Groups: %s", strings.Join(evaluation.Groups, ", "))
+		}
+	}
+	if !evaluation.Accepted() {
+		return fmt.Errorf("token would be rejected by external authentication provider '%s' of cluster '%s'",
+			args.name, clusterKey)
+	}
+	if !output.HasFlag() {
+		r.Reporter.Infof("Token would be accepted by external authentication provider '%s' of cluster '%s'",
+			args.name, clusterKey)
+	}
+	return nil
+}
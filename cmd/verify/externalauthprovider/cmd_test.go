@@ -0,0 +1,80 @@
+package externalauthprovider
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	. "github.com/openshift-online/ocm-sdk-go/testing"
+
+	"github.com/openshift/rosa/pkg/test"
+)
+
+func signToken(claims jwt.MapClaims) string {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("secret"))
+	Expect(err).NotTo(HaveOccurred())
+	return token
+}
+
+var _ = Describe("Verify external authentication provider", func() {
+	var testRuntime test.TestingRuntime
+
+	mockClusterReady := test.MockCluster(func(c *cmv1.ClusterBuilder) {
+		c.AWS(cmv1.NewAWS().SubnetIDs("subnet-0b761d44d3d9a4663", "subnet-0f87f640e56934cbc"))
+		c.Region(cmv1.NewCloudRegion().ID("us-east-1"))
+		c.State(cmv1.ClusterStateReady)
+		c.Hypershift(cmv1.NewHypershift().Enabled(true))
+		c.ExternalAuthConfig(cmv1.NewExternalAuthConfig().Enabled(true))
+	})
+	hypershiftClusterReady := test.FormatClusterList([]*cmv1.Cluster{mockClusterReady})
+	externalAuth := test.BuildExternalAuth()
+
+	BeforeEach(func() {
+		testRuntime.InitRuntime()
+		args.name = "microsoft-entra-id"
+		args.token = ""
+		// Reset flag to avoid any side effect on other tests
+		Cmd.Flags().Set("output", "")
+	})
+
+	It("Fails without a token", func() {
+		_, _, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+		Expect(err).To(MatchError("you need to specify a token to verify with '--token' parameter"))
+	})
+
+	It("Shows the username and groups of an accepted token", func() {
+		args.token = signToken(jwt.MapClaims{
+			"iss":      "https://test.com",
+			"aud":      "abc",
+			"exp":      time.Now().Add(time.Hour).Unix(),
+			"username": "user",
+			"groups":   []string{"admins"},
+		})
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, hypershiftClusterReady))
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, test.FormatResource(externalAuth)))
+		stdout, stderr, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stderr).To(BeEmpty())
+		Expect(stdout).To(Equal("INFO: Username: https://test.com#user\n" +
+			"INFO: Groups: admins\n" +
+			"INFO: Token would be accepted by external authentication provider 'microsoft-entra-id' " +
+			"of cluster 'cluster1'\n"))
+	})
+
+	It("Fails with a token that would be rejected", func() {
+		args.token = signToken(jwt.MapClaims{
+			"iss":      "https://test.com",
+			"aud":      "other",
+			"username": "user",
+		})
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, hypershiftClusterReady))
+		testRuntime.ApiServer.AppendHandlers(RespondWithJSON(http.StatusOK, test.FormatResource(externalAuth)))
+		_, stderr, err := test.RunWithOutputCapture(runWithRuntime, testRuntime.RosaRuntime, Cmd)
+		Expect(err).To(MatchError("token would be rejected by external authentication provider " +
+			"'microsoft-entra-id' of cluster 'cluster1'"))
+		Expect(stderr).To(ContainSubstring("ERR: token audiences 'other' do not include any of 'abc'"))
+	})
+})
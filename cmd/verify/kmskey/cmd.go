@@ -0,0 +1,153 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kmskey
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	kmsArnRegexpValidator "github.com/openshift-online/ocm-common/pkg/resource/validations"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/arguments"
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var args struct {
+	kmsKeyARN           string
+	prefix              string
+	operatorRolesPrefix string
+	hostedCP            bool
+}
+
+var Cmd = &cobra.Command{
+	Use:     "kms-key",
+	Aliases: []string{"kmskey"},
+	Short:   "Verify the key policy of a customer managed KMS key",
+	Long: "Verify that the key policy of a customer managed AWS KMS key allows the installer, support and " +
+		"worker account roles and the EBS CSI driver operator role of a cluster to use it. Actions that the " +
+		"key policy only grants to the account are reported as warnings, as the IAM policies of the roles " +
+		"decide on them.",
+	Example: `  # Verify a KMS key for a cluster with operator roles prefix "mycluster-a1b2"
+  rosa verify kms-key --kms-key-arn=arn:aws:kms:us-east-1:123456789012:key/1234abcd-12ab-44cd-86ef-1234567890ab \
+    --operator-roles-prefix=mycluster-a1b2`,
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+
+	flags.StringVar(
+		&args.kmsKeyARN,
+		"kms-key-arn",
+		"",
+		"ARN of the KMS key to verify.",
+	)
+
+	flags.StringVar(
+		&args.prefix,
+		"prefix",
+		aws.DefaultPrefix,
+		"Prefix of the account roles of the cluster.",
+	)
+
+	flags.StringVar(
+		&args.operatorRolesPrefix,
+		"operator-roles-prefix",
+		"",
+		"Prefix of the operator roles of the cluster.",
+	)
+
+	flags.BoolVar(
+		&args.hostedCP,
+		"hosted-cp",
+		false,
+		"Expect the account roles of Hosted Control Plane clusters.",
+	)
+
+	arguments.AddRegionFlag(flags)
+	arguments.AddProfileFlag(flags)
+	output.AddFlag(Cmd)
+}
+
+func run(cmd *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS()
+	defer r.Cleanup()
+
+	err := runWithRuntime(r, cmd)
+	if err != nil {
+		r.Reporter.Errorf(err.Error())
+		os.Exit(1)
+	}
+}
+
+func runWithRuntime(r *rosa.Runtime, _ *cobra.Command) error {
+	if args.kmsKeyARN == "" {
+		return fmt.Errorf("Expected the ARN of the KMS key with '--kms-key-arn'")
+	}
+	kmsKeyARN := args.kmsKeyARN
+	err := kmsArnRegexpValidator.ValidateKMSKeyARN(&kmsKeyARN)
+	if err != nil {
+		return fmt.Errorf("Expected a valid value for kms-key-arn: %s", err)
+	}
+	if args.operatorRolesPrefix == "" {
+		return fmt.Errorf("Expected the prefix of the operator roles with '--operator-roles-prefix'")
+	}
+
+	policy, err := r.AWSClient.GetKMSKeyPolicy(kmsKeyARN)
+	if err != nil {
+		return fmt.Errorf("Failed to get key policy of '%s': %v", kmsKeyARN, err)
+	}
+	keyRoles := aws.GetKMSKeyRoles(r.Creator.Partition, r.Creator.AccountID, args.prefix,
+		args.operatorRolesPrefix, args.hostedCP)
+	missing := aws.GetMissingKMSKeyGrants(policy, keyRoles)
+
+	if output.HasFlag() {
+		err = output.Print(missing)
+		if err != nil {
+			return err
+		}
+	}
+	denied := 0
+	for _, grant := range missing {
+		if len(grant.Actions) > 0 {
+			denied++
+		}
+		if output.HasFlag() {
+			continue
+		}
+		if len(grant.IAMActions) > 0 {
+			r.Reporter.Warnf("Key policy only allows '%s' to %s through the IAM policies of the account, "+
+				"make sure the role is allowed to use the key", grant.RoleARN, strings.Join(grant.IAMActions, ", "))
+		}
+		if len(grant.Actions) > 0 {
+			r.Reporter.Errorf("Key policy does not allow '%s' to %s", grant.RoleARN,
+				strings.Join(grant.Actions, ", "))
+		}
+	}
+	if denied > 0 {
+		return fmt.Errorf("Key policy of '%s' is missing grants for %d roles", kmsKeyARN, denied)
+	}
+	if !output.HasFlag() {
+		r.Reporter.Infof("Key policy of '%s' allows all the cluster roles", kmsKeyARN)
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package kmskey
+
+import (
+	"go.uber.org/mock/gomock"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/test"
+)
+
+var _ = Describe("Verify KMS key", func() {
+	var (
+		t             *test.TestingRuntime
+		mockAwsClient *aws.MockClient
+	)
+
+	const keyARN = "arn:aws:kms:us-east-1:123456789012:key/1234abcd-12ab-44cd-86ef-1234567890ab"
+
+	BeforeEach(func() {
+		t = test.NewTestRuntime()
+		t.RosaRuntime.Creator.Partition = "aws"
+		mockAwsClient = aws.NewMockClient(gomock.NewController(GinkgoT()))
+		t.RosaRuntime.AWSClient = mockAwsClient
+		output.SetOutput("")
+		Cmd.Flags().Set("kms-key-arn", keyARN)
+		Cmd.Flags().Set("operator-roles-prefix", "mycluster-a1b2")
+	})
+
+	It("Passes when the key policy allows the cluster roles", func() {
+		keyRoles := aws.GetKMSKeyRoles("aws", "123", aws.DefaultPrefix, "mycluster-a1b2", false)
+		policy, err := aws.ParsePolicyDocument(aws.KMSKeyPolicy("aws", "123", keyRoles))
+		Expect(err).NotTo(HaveOccurred())
+		mockAwsClient.EXPECT().GetKMSKeyPolicy(keyARN).Return(policy, nil)
+		_, _, err = test.RunWithOutputCapture(runWithRuntime, t.RosaRuntime, Cmd)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("Warns when a role is only granted the key through the account", func() {
+		keyRoles := aws.GetKMSKeyRoles("aws", "123", aws.DefaultPrefix, "other-prefix", false)
+		policy, err := aws.ParsePolicyDocument(aws.KMSKeyPolicy("aws", "123", keyRoles))
+		Expect(err).NotTo(HaveOccurred())
+		mockAwsClient.EXPECT().GetKMSKeyPolicy(keyARN).Return(policy, nil)
+		_, stderr, err := test.RunWithOutputCapture(runWithRuntime, t.RosaRuntime, Cmd)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stderr).To(ContainSubstring("Key policy only allows 'arn:aws:iam::123:role/mycluster-a1b2-openshift"))
+	})
+
+	It("Fails when the key policy is missing grants", func() {
+		keyRoles := aws.GetKMSKeyRoles("aws", "123", aws.DefaultPrefix, "mycluster-a1b2", false)
+		policy, err := aws.ParsePolicyDocument(`{
+			"Version": "2012-10-17",
+			"Statement": [
+				{"Effect": "Allow", "Principal": {"AWS": "arn:aws:iam::123:root"}, "Action": "kms:*", "Resource": "*"},
+				{"Effect": "Deny", "Principal": {"AWS": "` + keyRoles.Worker + `"}, "Action": "kms:*", "Resource": "*"}
+			]
+		}`)
+		Expect(err).NotTo(HaveOccurred())
+		mockAwsClient.EXPECT().GetKMSKeyPolicy(keyARN).Return(policy, nil)
+		_, _, err = test.RunWithOutputCapture(runWithRuntime, t.RosaRuntime, Cmd)
+		Expect(err).To(MatchError("Key policy of '" + keyARN + "' is missing grants for 1 roles"))
+	})
+
+	It("Fails with an invalid key ARN", func() {
+		Cmd.Flags().Set("kms-key-arn", "invalid")
+		_, _, err := test.RunWithOutputCapture(runWithRuntime, t.RosaRuntime, Cmd)
+		Expect(err).To(MatchError(ContainSubstring("Expected a valid value for kms-key-arn")))
+	})
+})
@@ -19,6 +19,7 @@ package network
 import (
 	"encoding/json"
 	"fmt"
+	"net"
 	"os"
 	"strings"
 	"time"
@@ -43,6 +44,15 @@ var args struct {
 	watch      bool
 	tags       []string
 	hostedCp   bool
+
+	preflight     bool
+	preflightOnly bool
+	machineCIDR   net.IPNet
+	serviceCIDR   net.IPNet
+	podCIDR       net.IPNet
+	private       bool
+
+	junitReport string
 }
 
 var Cmd = makeCmd()
@@ -53,7 +63,14 @@ func makeCmd() *cobra.Command {
 		Short: "Verify VPC subnets are configured correctly",
 		Long:  "Verify that the VPC subnets are configured correctly.",
 		Example: `  # Verify two subnets
-	rosa verify network --subnet-ids subnet-03046a9b92b5014fb,subnet-03046a9c92b5014fb`,
+	rosa verify network --subnet-ids subnet-03046a9b92b5014fb,subnet-03046a9c92b5014fb
+
+  # Check the VPC topology of two subnets locally, without the network verifier
+	rosa verify network --preflight-only --region us-east-1 \
+	--subnet-ids subnet-03046a9b92b5014fb,subnet-03046a9c92b5014fb --service-cidr 172.30.0.0/16
+
+  # Wait for the verification of a cluster's subnets and write the results as a JUnit report
+	rosa verify network --cluster mycluster --watch --junit-report network.xml`,
 		Run:  run,
 		Args: cobra.NoArgs,
 	}
@@ -69,6 +86,10 @@ const (
 	watchFlag      = "watch"
 	hostedCpFlag   = "hosted-cp"
 
+	preflightFlag     = "preflight"
+	preflightOnlyFlag = "preflight-only"
+	junitReportFlag   = "junit-report"
+
 	NetworkVerifyPending NetworkVerifyState = "pending"
 	NetworkVerifyRunning NetworkVerifyState = "running"
 	NetworkVerifyPassed  NetworkVerifyState = "passed"
@@ -134,6 +155,58 @@ func initFlags(cmd *cobra.Command) {
 		false,
 		"Run network verifier with hosted control plane platform configuration",
 	)
+
+	flags.BoolVar(
+		&args.preflight,
+		preflightFlag,
+		false,
+		"Check the VPC topology locally before running the network verifier: CIDR overlaps, routes to "+
+			"NAT and internet gateways, availability zone coverage, VPC DNS settings and load balancer tags.",
+	)
+
+	flags.BoolVar(
+		&args.preflightOnly,
+		preflightOnlyFlag,
+		false,
+		"Only check the VPC topology locally, without running the network verifier.",
+	)
+
+	flags.IPNetVar(
+		&args.machineCIDR,
+		"machine-cidr",
+		net.IPNet{},
+		"Machine CIDR checked by the preflight. Defaults to the cluster machine CIDR.",
+	)
+
+	flags.IPNetVar(
+		&args.serviceCIDR,
+		"service-cidr",
+		net.IPNet{},
+		"Service CIDR checked by the preflight. Defaults to the cluster service CIDR.",
+	)
+
+	flags.IPNetVar(
+		&args.podCIDR,
+		"pod-cidr",
+		net.IPNet{},
+		"Pod CIDR checked by the preflight. Defaults to the cluster pod CIDR.",
+	)
+
+	flags.BoolVar(
+		&args.private,
+		"private",
+		false,
+		"Run the preflight for a private cluster, which doesn't need public subnets.",
+	)
+
+	flags.StringVar(
+		&args.junitReport,
+		junitReportFlag,
+		"",
+		"Write the results of the preflight and of the verification of each subnet to a JUnit XML file. "+
+			"Subnets whose verification hasn't completed are reported as skipped and fail the command, "+
+			"use '--watch' to wait for the verification to complete.",
+	)
 }
 
 func run(cmd *cobra.Command, _ []string) {
@@ -172,6 +245,18 @@ func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command) error {
 		return err
 	}
 
+	report := &Report{Region: args.region}
+
+	if args.preflight || args.preflightOnly {
+		report.Preflight, err = runPreflight(r, cluster)
+		if err != nil {
+			return err
+		}
+		if args.preflightOnly || len(report.Preflight.Failures()) > 0 {
+			return writeReport(r, report)
+		}
+	}
+
 	if cmd.Flags().Changed(roleArnFlag) {
 		err := aws.ARNValidator(args.roleArn)
 		if err != nil {
@@ -236,6 +321,7 @@ func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command) error {
 		}
 	}
 
+	subnetIDs := append([]string{}, args.subnetIDs...)
 	if args.watch && len(args.subnetIDs) > 0 {
 		var spin *spinner.Spinner
 		if r.Reporter.IsTerminal() {
@@ -254,6 +340,7 @@ func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command) error {
 					continue
 				}
 				printStatus(r, spin, subnet, status, err)
+				report.add(subnet, status, err)
 
 				// Remove completed subnets, no need to check these again
 				args.subnetIDs[i] = args.subnetIDs[len(args.subnetIDs)-1]
@@ -274,7 +361,9 @@ func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command) error {
 			subnet := args.subnetIDs[i]
 			status, err := r.OCMClient.GetVerifyNetworkSubnet(subnet)
 			printStatus(r, nil, subnet, status, err)
-			if status.State() == string(NetworkVerifyPending) || status.State() == string(NetworkVerifyRunning) {
+			report.add(subnet, status, err)
+			if err == nil && (status.State() == string(NetworkVerifyPending) ||
+				status.State() == string(NetworkVerifyRunning)) {
 				pending = true
 			}
 		}
@@ -290,11 +379,45 @@ func runWithRuntime(r *rosa.Runtime, cmd *cobra.Command) error {
 		}
 	}
 
-	return nil
+	report.sortSubnets(subnetIDs)
+	return writeReport(r, report)
+}
+
+// writeReport prints the report when an output format is requested and writes the JUnit report,
+// then returns an error if any check failed so that the exit code reflects the result. A verification
+// that hasn't completed doesn't pass, and fails the command when a report is requested so that
+// automation doesn't take it for a success.
+func writeReport(r *rosa.Runtime, report *Report) error {
+	reportErr := report.Err()
+	pending := report.pendingSubnets()
+	report.Passed = reportErr == nil && len(pending) == 0
+	if reportErr == nil && len(pending) > 0 && (output.HasFlag() || args.junitReport != "") {
+		reportErr = fmt.Errorf("Network verification has not completed for subnets: %s. Use '--%s' to wait "+
+			"for it to complete", strings.Join(pending, ", "), watchFlag)
+	}
+	if output.HasFlag() {
+		err := output.Print(report)
+		if err != nil {
+			return err
+		}
+	}
+	if args.junitReport != "" {
+		err := report.WriteJUnit(args.junitReport)
+		if err != nil {
+			return err
+		}
+		r.Reporter.Debugf("Wrote JUnit report to '%s'", args.junitReport)
+	}
+	return reportErr
 }
 
 func printStatus(r *rosa.Runtime, spin *spinner.Spinner, subnet string,
 	status *cmv1.SubnetNetworkVerification, err error) {
+	// The statuses of all subnets are printed together in the report
+	if output.HasFlag() {
+		return
+	}
+
 	if spin != nil {
 		spin.Stop()
 	}
@@ -303,11 +426,6 @@ func printStatus(r *rosa.Runtime, spin *spinner.Spinner, subnet string,
 		r.Reporter.Infof("%s: %s", subnet, err.Error())
 	} else if status.State() == string(NetworkVerifyFailed) {
 		r.Reporter.Infof("%s: %s Unable to verify egress to: %v", subnet, status.State(), status.Details())
-	} else if output.HasFlag() {
-		err := output.Print(status)
-		if err != nil {
-			r.Reporter.Debugf("%s: unable to output in %s format - %s", subnet, output.Output(), err.Error())
-		}
 	} else {
 		var tags string
 		if len(status.Tags()) > 0 {
@@ -2,8 +2,12 @@ package network
 
 import (
 	"net/http"
+	"os"
+	"path/filepath"
 	"time"
 
+	"go.uber.org/mock/gomock"
+
 	. "github.com/onsi/ginkgo/v2/dsl/core"
 	. "github.com/onsi/ginkgo/v2/dsl/table"
 	. "github.com/onsi/gomega"
@@ -16,6 +20,7 @@ import (
 
 	"github.com/openshift/rosa/pkg/aws"
 	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
 	"github.com/openshift/rosa/pkg/rosa"
 	"github.com/openshift/rosa/pkg/test"
 )
@@ -113,6 +118,15 @@ var _ = Describe("verify network", func() {
 		"tags": {"t1":"v1"}
 	}
 	` // #nosec G101
+	var subnetFailedSuccess = `
+	{
+		"href": "/api/clusters_mgmt/v1/network_verifications/subnet-0b761d44d3d9a4663/",
+		"id": "subnet-0b761d44d3d9a4663",
+		"state": "failed",
+		"platform": "aws",
+		"details": ["api.openshift.com:443"]
+	}
+	`
 	var successOutputPendingComplete = `INFO: subnet-0b761d44d3d9a4663, platform: aws, tags: {"t1":"v1"}: pending
 INFO: subnet-0f87f640e56934cbc, platform: aws, tags: {"t1":"v1"}: passed
 INFO: Run the following command to wait for verification to all subnets to complete:
@@ -429,4 +443,144 @@ INFO: subnet-0f87f640e56934cbc, platform: aws, tags: {"t1":"v1"}: passed
 			ContainSubstring(
 				"Running the network verifier is only supported for BYO VPC clusters"))
 	})
+	Context("Preflight", func() {
+		var mockAwsClient *aws.MockClient
+
+		BeforeEach(func() {
+			mockAwsClient = aws.NewMockClient(gomock.NewController(GinkgoT()))
+			mockAwsClient.EXPECT().GetRegion().Return("us-east-1").AnyTimes()
+			r.AWSClient = mockAwsClient
+			cmd.Flags().Set(subnetIDsFlag, "subnet-1,subnet-2")
+			cmd.Flags().Set("region", "us-east-1")
+			cmd.Flags().Set(preflightOnlyFlag, "true")
+		})
+
+		It("Succeeds without --role-arn when only running the preflight", func() {
+			cmd.Flags().Set("service-cidr", "172.30.0.0/16")
+			mockAwsClient.EXPECT().RunNetworkPreflight(gomock.Any()).DoAndReturn(
+				func(spec aws.NetworkPreflightSpec) (*aws.NetworkPreflightResult, error) {
+					Expect(spec.SubnetIDs).To(Equal([]string{"subnet-1", "subnet-2"}))
+					Expect(spec.ServiceCIDR.String()).To(Equal("172.30.0.0/16"))
+					Expect(spec.MachineCIDR).To(BeNil())
+					return &aws.NetworkPreflightResult{
+						VpcID: "vpc-1",
+						Checks: []aws.NetworkPreflightCheck{
+							{
+								Name:     aws.PreflightCheckDNS,
+								Passed:   true,
+								Severity: aws.PreflightSeverityError,
+							},
+						},
+					}, nil
+				})
+			err := runWithRuntime(r, cmd)
+			Expect(err).To(BeNil())
+		})
+
+		It("Fails when a preflight check fails", func() {
+			mockAwsClient.EXPECT().RunNetworkPreflight(gomock.Any()).Return(&aws.NetworkPreflightResult{
+				VpcID: "vpc-1",
+				Checks: []aws.NetworkPreflightCheck{
+					{
+						Name:     aws.PreflightCheckDNS,
+						Passed:   false,
+						Severity: aws.PreflightSeverityError,
+						Message:  "DNS hostnames must be enabled on VPC 'vpc-1'",
+					},
+					{
+						Name:     aws.PreflightCheckELBTags,
+						Passed:   false,
+						Severity: aws.PreflightSeverityWarning,
+					},
+				},
+			}, nil)
+			err := runWithRuntime(r, cmd)
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(Equal("Network preflight failed with 1 errors"))
+		})
+	})
+	Context("Report", func() {
+		BeforeEach(func() {
+			cmd.Flags().Set(subnetIDsFlag, "subnet-0b761d44d3d9a4663")
+			cmd.Flags().Set("region", "us-east-1")
+			cmd.Flags().Set(watchFlag, "true")
+			cmd.Flags().Set(statusOnlyFlag, "true")
+		})
+
+		It("Fails and writes the JUnit report when verification fails", func() {
+			// GET /api/clusters_mgmt/v1/network_verifications/subnetA
+			apiServer.AppendHandlers(
+				RespondWithJSON(
+					http.StatusOK,
+					subnetFailedSuccess,
+				),
+			)
+			junitReport := filepath.Join(GinkgoT().TempDir(), "network.xml")
+			cmd.Flags().Set(junitReportFlag, junitReport)
+			output.SetOutput("json")
+			DeferCleanup(output.SetOutput, "")
+
+			stdout, _, err := test.RunWithOutputCapture(runWithRuntime, r, cmd)
+			Expect(err).ToNot(BeNil())
+			Expect(err.Error()).To(Equal("Network verification failed for subnets: subnet-0b761d44d3d9a4663"))
+			Expect(stdout).To(MatchJSON(`{
+				"region": "us-east-1",
+				"passed": false,
+				"subnets": [
+					{
+						"subnet_id": "subnet-0b761d44d3d9a4663",
+						"state": "failed",
+						"platform": "aws",
+						"egress_failures": ["api.openshift.com:443"]
+					}
+				]
+			}`))
+
+			junit, err := os.ReadFile(junitReport)
+			Expect(err).To(BeNil())
+			Expect(string(junit)).To(ContainSubstring(
+				`<testsuites name="rosa verify network" tests="1" failures="1" skipped="0">`))
+			Expect(string(junit)).To(ContainSubstring(
+				`<testcase name="api.openshift.com:443" classname="subnet-0b761d44d3d9a4663">`))
+			Expect(string(junit)).To(ContainSubstring(
+				`<failure message="Unable to verify egress to: api.openshift.com:443" type="failed"></failure>`))
+		})
+
+		It("Fails and reports a verification that hasn't completed without --watch", func() {
+			cmd.Flags().Set(watchFlag, "false")
+			// GET /api/clusters_mgmt/v1/network_verifications/subnetA
+			apiServer.AppendHandlers(
+				RespondWithJSON(
+					http.StatusOK,
+					subnetRunningSuccess,
+				),
+			)
+			junitReport := filepath.Join(GinkgoT().TempDir(), "network.xml")
+			cmd.Flags().Set(junitReportFlag, junitReport)
+			output.SetOutput("json")
+			DeferCleanup(output.SetOutput, "")
+
+			stdout, _, err := test.RunWithOutputCapture(runWithRuntime, r, cmd)
+			Expect(err).To(MatchError("Network verification has not completed for subnets: " +
+				"subnet-0b761d44d3d9a4663. Use '--watch' to wait for it to complete"))
+			Expect(stdout).To(ContainSubstring(`"passed": false`))
+
+			junit, err := os.ReadFile(junitReport)
+			Expect(err).To(BeNil())
+			Expect(string(junit)).To(ContainSubstring(
+				`<testsuites name="rosa verify network" tests="1" failures="0" skipped="1">`))
+		})
+
+		It("Succeeds when verification passes", func() {
+			// GET /api/clusters_mgmt/v1/network_verifications/subnetA
+			apiServer.AppendHandlers(
+				RespondWithJSON(
+					http.StatusOK,
+					subnetPassedSuccess,
+				),
+			)
+			_, _, err := test.RunWithOutputCapture(runWithRuntime, r, cmd)
+			Expect(err).To(BeNil())
+		})
+	})
 })
@@ -0,0 +1,98 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"fmt"
+	"net"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/ocm"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+// runPreflight checks the topology of the VPC of the subnets locally. The OCM network verifier
+// only checks egress, so this catches misconfigurations that would otherwise only show during install.
+func runPreflight(r *rosa.Runtime, cluster *cmv1.Cluster) (*aws.NetworkPreflightResult, error) {
+	spec := aws.NetworkPreflightSpec{
+		SubnetIDs: args.subnetIDs,
+		Private:   args.private,
+		HostedCP:  args.hostedCp,
+	}
+	if cluster != nil {
+		spec.AvailabilityZones = cluster.Nodes().AvailabilityZones()
+		spec.Private = cluster.AWS().PrivateLink()
+		spec.HostedCP = cluster.Hypershift().Enabled()
+		spec.MachineCIDR = parseClusterCIDR(cluster.Network().MachineCIDR())
+		spec.ServiceCIDR = parseClusterCIDR(cluster.Network().ServiceCIDR())
+		spec.PodCIDR = parseClusterCIDR(cluster.Network().PodCIDR())
+	}
+	if !ocm.IsEmptyCIDR(args.machineCIDR) {
+		spec.MachineCIDR = &args.machineCIDR
+	}
+	if !ocm.IsEmptyCIDR(args.serviceCIDR) {
+		spec.ServiceCIDR = &args.serviceCIDR
+	}
+	if !ocm.IsEmptyCIDR(args.podCIDR) {
+		spec.PodCIDR = &args.podCIDR
+	}
+
+	// The subnets may be in a different region than the one of the default AWS client
+	awsClient := r.AWSClient
+	if awsClient.GetRegion() != args.region {
+		var err error
+		awsClient, err = aws.NewClient().
+			Logger(r.Logger).
+			Region(args.region).
+			Build()
+		if err != nil {
+			return nil, fmt.Errorf("Error creating AWS client: %v", err)
+		}
+	}
+
+	if r.Reporter.IsTerminal() && !output.HasFlag() {
+		r.Reporter.Infof("Running local preflight checks of subnet IDs: %v", args.subnetIDs)
+	}
+	result, err := awsClient.RunNetworkPreflight(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	if !output.HasFlag() {
+		for _, check := range result.Warnings() {
+			r.Reporter.Warnf("%s: %s", check.Name, check.Message)
+		}
+		for _, check := range result.Failures() {
+			r.Reporter.Errorf("%s: %s", check.Name, check.Message)
+		}
+		if len(result.Failures()) == 0 {
+			r.Reporter.Infof("Network preflight passed for VPC '%s'", result.VpcID)
+		}
+	}
+	return result, nil
+}
+
+func parseClusterCIDR(cidr string) *net.IPNet {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil
+	}
+	return ipNet
+}
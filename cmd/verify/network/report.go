@@ -0,0 +1,242 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"strings"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/aws"
+)
+
+// Report holds the results of the network verification of all subnets, so that they can be
+// consumed by automation with '--output' or '--junit-report'
+type Report struct {
+	Region    string                      `json:"region"`
+	Passed    bool                        `json:"passed"`
+	Preflight *aws.NetworkPreflightResult `json:"preflight,omitempty"`
+	Subnets   []SubnetReport              `json:"subnets"`
+}
+
+type SubnetReport struct {
+	SubnetID string            `json:"subnet_id"`
+	State    string            `json:"state"`
+	Platform string            `json:"platform,omitempty"`
+	Tags     map[string]string `json:"tags,omitempty"`
+	// Egress targets that couldn't be reached from the subnet
+	EgressFailures []string `json:"egress_failures,omitempty"`
+	Error          string   `json:"error,omitempty"`
+}
+
+func (s SubnetReport) failed() bool {
+	return s.Error != "" || s.State == string(NetworkVerifyFailed)
+}
+
+func (s SubnetReport) pending() bool {
+	return s.State == string(NetworkVerifyPending) || s.State == string(NetworkVerifyRunning)
+}
+
+// add records the verification status of a subnet, replacing a previous status of the same subnet
+func (r *Report) add(subnetID string, status *cmv1.SubnetNetworkVerification, err error) {
+	subnet := SubnetReport{SubnetID: subnetID}
+	if err != nil {
+		subnet.Error = err.Error()
+	} else {
+		subnet.State = status.State()
+		subnet.Platform = string(status.Platform())
+		subnet.Tags = status.Tags()
+		if subnet.State == string(NetworkVerifyFailed) {
+			subnet.EgressFailures = status.Details()
+		}
+	}
+	for i := range r.Subnets {
+		if r.Subnets[i].SubnetID == subnetID {
+			r.Subnets[i] = subnet
+			return
+		}
+	}
+	r.Subnets = append(r.Subnets, subnet)
+}
+
+// sortSubnets orders the subnets as they were given by the user, the watch loop records
+// them as they complete
+func (r *Report) sortSubnets(subnetIDs []string) {
+	sorted := make([]SubnetReport, 0, len(r.Subnets))
+	for _, subnetID := range subnetIDs {
+		for _, subnet := range r.Subnets {
+			if subnet.SubnetID == subnetID {
+				sorted = append(sorted, subnet)
+			}
+		}
+	}
+	r.Subnets = sorted
+}
+
+// Err returns an error if the preflight or the verification of any subnet failed
+func (r *Report) Err() error {
+	if r.Preflight != nil {
+		if failures := r.Preflight.Failures(); len(failures) > 0 {
+			return fmt.Errorf("Network preflight failed with %d errors", len(failures))
+		}
+	}
+	var failed []string
+	for _, subnet := range r.Subnets {
+		if subnet.failed() {
+			failed = append(failed, subnet.SubnetID)
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("Network verification failed for subnets: %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// pendingSubnets returns the subnets whose verification hasn't completed yet
+func (r *Report) pendingSubnets() []string {
+	var pending []string
+	for _, subnet := range r.Subnets {
+		if subnet.pending() {
+			pending = append(pending, subnet.SubnetID)
+		}
+	}
+	return pending
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
+func (s *junitTestSuite) addTestCase(testCase junitTestCase) {
+	s.Tests++
+	if testCase.Failure != nil {
+		s.Failures++
+	}
+	if testCase.Skipped != nil {
+		s.Skipped++
+	}
+	s.TestCases = append(s.TestCases, testCase)
+}
+
+// JUnit converts the report to JUnit test suites: one test case per preflight check, and one
+// test case per subnet, or per unreachable egress target when the verification of a subnet failed
+func (r *Report) JUnit() junitTestSuites {
+	suites := junitTestSuites{Name: "rosa verify network"}
+
+	if r.Preflight != nil {
+		suite := junitTestSuite{Name: "preflight"}
+		for _, check := range r.Preflight.Checks {
+			testCase := junitTestCase{
+				Name:      fmt.Sprintf("%s %s", check.Name, check.Target),
+				ClassName: r.Preflight.VpcID,
+			}
+			if !check.Passed {
+				if check.Severity == aws.PreflightSeverityWarning {
+					testCase.Skipped = &junitSkipped{Message: check.Message}
+				} else {
+					testCase.Failure = &junitFailure{Message: check.Message, Type: check.Name}
+				}
+			}
+			suite.addTestCase(testCase)
+		}
+		suites.Suites = append(suites.Suites, suite)
+	}
+
+	if len(r.Subnets) > 0 {
+		suite := junitTestSuite{Name: "egress"}
+		for _, subnet := range r.Subnets {
+			testCase := junitTestCase{Name: "egress", ClassName: subnet.SubnetID}
+			switch {
+			case subnet.Error != "":
+				testCase.Failure = &junitFailure{Message: subnet.Error, Type: "error"}
+			case subnet.pending():
+				testCase.Skipped = &junitSkipped{Message: fmt.Sprintf("Verification is %s", subnet.State)}
+			case subnet.failed() && len(subnet.EgressFailures) > 0:
+				for _, detail := range subnet.EgressFailures {
+					suite.addTestCase(junitTestCase{
+						Name:      detail,
+						ClassName: subnet.SubnetID,
+						Failure: &junitFailure{
+							Message: fmt.Sprintf("Unable to verify egress to: %s", detail),
+							Type:    string(NetworkVerifyFailed),
+						},
+					})
+				}
+				continue
+			case subnet.failed():
+				testCase.Failure = &junitFailure{Message: "Unable to verify egress", Type: string(NetworkVerifyFailed)}
+			}
+			suite.addTestCase(testCase)
+		}
+		suites.Suites = append(suites.Suites, suite)
+	}
+
+	for _, suite := range suites.Suites {
+		suites.Tests += suite.Tests
+		suites.Failures += suite.Failures
+		suites.Skipped += suite.Skipped
+	}
+	return suites
+}
+
+// WriteJUnit writes the report as a JUnit XML file
+func (r *Report) WriteJUnit(path string) error {
+	data, err := xml.MarshalIndent(r.JUnit(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("Failed to marshal JUnit report: %v", err)
+	}
+	data = append([]byte(xml.Header), data...)
+	data = append(data, '\n')
+	err = os.WriteFile(path, data, 0600)
+	if err != nil {
+		return fmt.Errorf("Failed to write JUnit report '%s': %v", path, err)
+	}
+	return nil
+}
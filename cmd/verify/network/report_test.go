@@ -0,0 +1,64 @@
+package network
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/rosa/pkg/aws"
+)
+
+var _ = Describe("Report", func() {
+	It("Converts preflight checks and subnets to JUnit test cases", func() {
+		report := &Report{
+			Region: "us-east-1",
+			Preflight: &aws.NetworkPreflightResult{
+				VpcID: "vpc-1",
+				Checks: []aws.NetworkPreflightCheck{
+					{Name: aws.PreflightCheckDNS, Target: "vpc-1", Passed: true, Severity: aws.PreflightSeverityError},
+					{Name: aws.PreflightCheckELBTags, Target: "subnet-1", Severity: aws.PreflightSeverityWarning,
+						Message: "missing tag"},
+				},
+			},
+			Subnets: []SubnetReport{
+				{SubnetID: "subnet-1", State: "passed"},
+				{SubnetID: "subnet-2", State: "running"},
+				{SubnetID: "subnet-3", Error: "not found"},
+			},
+		}
+		junit := report.JUnit()
+		Expect(junit.Tests).To(Equal(5))
+		Expect(junit.Failures).To(Equal(1))
+		Expect(junit.Skipped).To(Equal(2))
+		Expect(junit.Suites).To(HaveLen(2))
+		Expect(junit.Suites[0].Name).To(Equal("preflight"))
+		Expect(junit.Suites[0].TestCases[1].Skipped.Message).To(Equal("missing tag"))
+		Expect(junit.Suites[1].Name).To(Equal("egress"))
+		Expect(junit.Suites[1].TestCases[2].Failure.Message).To(Equal("not found"))
+		Expect(report.Err()).To(MatchError("Network verification failed for subnets: subnet-3"))
+	})
+
+	It("Fails when the preflight fails", func() {
+		report := &Report{
+			Preflight: &aws.NetworkPreflightResult{
+				Checks: []aws.NetworkPreflightCheck{
+					{Name: aws.PreflightCheckDNS, Severity: aws.PreflightSeverityError},
+				},
+			},
+		}
+		Expect(report.Err()).To(MatchError("Network preflight failed with 1 errors"))
+	})
+
+	It("Keeps the latest status of each subnet in the given order", func() {
+		report := &Report{}
+		report.add("subnet-2", nil, fmt.Errorf("timeout"))
+		report.add("subnet-1", nil, fmt.Errorf("timeout"))
+		report.add("subnet-2", nil, fmt.Errorf("not found"))
+		report.sortSubnets([]string{"subnet-1", "subnet-2"})
+		Expect(report.Subnets).To(Equal([]SubnetReport{
+			{SubnetID: "subnet-1", Error: "timeout"},
+			{SubnetID: "subnet-2", Error: "not found"},
+		}))
+	})
+})
@@ -81,6 +81,7 @@ func run(_ *cobra.Command, _ []string) {
 		r.OCMClient.LogEvent("ROSAVerifyQuotaInsufficient", nil)
 		r.Reporter.Errorf("Insufficient AWS quotas")
 		r.Reporter.Errorf("%v", err)
+		r.Reporter.Infof("To request the missing quota, run 'rosa request quota-increase'")
 		os.Exit(1)
 	}
 	if r.Reporter.IsTerminal() {
@@ -0,0 +1,202 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sharedvpc
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/arguments"
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+)
+
+var args struct {
+	vpcOwnerProfile     string
+	prefix              string
+	operatorRolesPrefix string
+	hostedCP            bool
+	hostedZoneID        string
+	vpcID               string
+	clusterName         string
+	baseDomain          string
+}
+
+var Cmd = &cobra.Command{
+	Use:     "shared-vpc",
+	Aliases: []string{"sharedvpc"},
+	Short:   "Verify the resources of the VPC owner account needed by a shared VPC cluster",
+	Long: "Verify that the role of the VPC owner account trusts the installer and ingress operator roles " +
+		"of the cluster owner account and can manage DNS records, and that the hosted zone is a private " +
+		"hosted zone associated with the shared VPC. The current AWS credentials must belong to the " +
+		"cluster owner account.",
+	Example: `  # Verify the shared VPC resources for cluster "mycluster"
+  rosa verify shared-vpc --vpc-owner-profile=vpc-owner --operator-roles-prefix=mycluster \
+    --private-hosted-zone-id=Z05646003S02O1ENCDCSN --vpc-id=vpc-0123456789abcdef0 \
+    --cluster-name=mycluster --base-domain=1vo8.p1.openshiftapps.com`,
+	Args: cobra.NoArgs,
+	Run:  run,
+}
+
+func init() {
+	flags := Cmd.Flags()
+
+	flags.StringVar(
+		&args.vpcOwnerProfile,
+		"vpc-owner-profile",
+		"",
+		"AWS profile of the account that owns the shared VPC.",
+	)
+
+	flags.StringVar(
+		&args.prefix,
+		"prefix",
+		aws.DefaultPrefix,
+		"Prefix of the account roles of the cluster owner account.",
+	)
+
+	flags.StringVar(
+		&args.operatorRolesPrefix,
+		"operator-roles-prefix",
+		"",
+		"Prefix of the operator roles of the cluster.",
+	)
+
+	flags.BoolVar(
+		&args.hostedCP,
+		"hosted-cp",
+		false,
+		"Expect the installer role of Hosted Control Plane clusters.",
+	)
+
+	flags.StringVar(
+		&args.hostedZoneID,
+		"private-hosted-zone-id",
+		"",
+		"ID of the private hosted zone in the VPC owner account.",
+	)
+
+	flags.StringVar(
+		&args.vpcID,
+		"vpc-id",
+		"",
+		"ID of the shared VPC the private hosted zone must be associated with.",
+	)
+
+	flags.StringVar(
+		&args.clusterName,
+		"cluster-name",
+		"",
+		"Name of the cluster. Together with '--base-domain' it is used to check the name of the hosted zone.",
+	)
+
+	flags.StringVar(
+		&args.baseDomain,
+		"base-domain",
+		"",
+		"Base DNS domain of the cluster.",
+	)
+
+	arguments.AddRegionFlag(flags)
+	arguments.AddProfileFlag(flags)
+	output.AddFlag(Cmd)
+}
+
+func run(cmd *cobra.Command, _ []string) {
+	r := rosa.NewRuntime().WithAWS()
+	defer r.Cleanup()
+
+	if args.vpcOwnerProfile == "" {
+		r.Reporter.Errorf("Expected the AWS profile of the VPC owner account with '--vpc-owner-profile'")
+		os.Exit(1)
+	}
+
+	vpcOwnerClient, err := aws.NewClient().
+		Logger(r.Logger).
+		Region(r.AWSClient.GetRegion()).
+		Profile(args.vpcOwnerProfile).
+		Build()
+	if err != nil {
+		r.Reporter.Errorf("Error creating AWS client for profile '%s': %v", args.vpcOwnerProfile, err)
+		os.Exit(1)
+	}
+
+	err = runWithRuntime(r, vpcOwnerClient, cmd)
+	if err != nil {
+		r.Reporter.Errorf(err.Error())
+		os.Exit(1)
+	}
+}
+
+func runWithRuntime(r *rosa.Runtime, vpcOwnerClient aws.Client, _ *cobra.Command) error {
+	if args.operatorRolesPrefix == "" {
+		return fmt.Errorf("Expected the prefix of the operator roles with '--operator-roles-prefix'")
+	}
+	if args.hostedZoneID == "" {
+		return fmt.Errorf("Expected the ID of the private hosted zone with '--private-hosted-zone-id'")
+	}
+
+	spec := aws.SharedVPCSpec{
+		RoleName: aws.GetSharedVPCRoleName(args.prefix),
+		TrustedRoleARNs: aws.GetSharedVPCTrustedRoleARNs(r.Creator.Partition, r.Creator.AccountID,
+			args.prefix, args.operatorRolesPrefix, args.hostedCP),
+		HostedZoneID: args.hostedZoneID,
+		VpcID:        args.vpcID,
+	}
+	if args.clusterName != "" && args.baseDomain != "" {
+		spec.HostedZoneName = fmt.Sprintf("%s.%s", args.clusterName, args.baseDomain)
+	}
+
+	if r.Reporter.IsTerminal() && !output.HasFlag() {
+		r.Reporter.Infof("Verifying shared VPC resources using profile '%s'", args.vpcOwnerProfile)
+	}
+	result, err := vpcOwnerClient.VerifySharedVPCResources(spec)
+	if err != nil {
+		return err
+	}
+
+	failures := result.Failures()
+	if output.HasFlag() {
+		err = output.Print(result)
+		if err != nil {
+			return err
+		}
+	} else {
+		for _, check := range result.Warnings() {
+			r.Reporter.Warnf("%s: %s", check.Name, check.Message)
+		}
+		for _, check := range failures {
+			r.Reporter.Errorf("%s: %s", check.Name, check.Message)
+		}
+	}
+
+	if len(failures) > 0 {
+		names := make([]string, 0, len(failures))
+		for _, check := range failures {
+			names = append(names, check.Name)
+		}
+		return fmt.Errorf("Shared VPC verification failed: %s", strings.Join(names, ", "))
+	}
+	if !output.HasFlag() {
+		r.Reporter.Infof("Shared VPC resources are ready for the cluster")
+	}
+	return nil
+}
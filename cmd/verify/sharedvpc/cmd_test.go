@@ -0,0 +1,79 @@
+package sharedvpc
+
+import (
+	"github.com/spf13/cobra"
+	"go.uber.org/mock/gomock"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/openshift/rosa/pkg/aws"
+	"github.com/openshift/rosa/pkg/output"
+	"github.com/openshift/rosa/pkg/rosa"
+	"github.com/openshift/rosa/pkg/test"
+)
+
+var _ = Describe("Verify shared VPC", func() {
+	var (
+		t              *test.TestingRuntime
+		vpcOwnerClient *aws.MockClient
+		run            func(*rosa.Runtime, *cobra.Command) error
+	)
+
+	spec := aws.SharedVPCSpec{
+		RoleName: "ManagedOpenShift-Shared-VPC-Role",
+		TrustedRoleARNs: []string{
+			"arn:aws:iam::123:role/ManagedOpenShift-Installer-Role",
+			"arn:aws:iam::123:role/mycluster-openshift-ingress-operator-cloud-credentials",
+		},
+		HostedZoneID:   "ZPRIVATE",
+		HostedZoneName: "mycluster.example.com",
+		VpcID:          "vpc-1",
+	}
+
+	BeforeEach(func() {
+		t = test.NewTestRuntime()
+		t.RosaRuntime.Creator.Partition = "aws"
+		vpcOwnerClient = aws.NewMockClient(gomock.NewController(GinkgoT()))
+		run = func(r *rosa.Runtime, cmd *cobra.Command) error {
+			return runWithRuntime(r, vpcOwnerClient, cmd)
+		}
+		output.SetOutput("")
+		Cmd.Flags().Set("vpc-owner-profile", "vpc-owner")
+		Cmd.Flags().Set("operator-roles-prefix", "mycluster")
+		Cmd.Flags().Set("private-hosted-zone-id", "ZPRIVATE")
+		Cmd.Flags().Set("vpc-id", "vpc-1")
+		Cmd.Flags().Set("cluster-name", "mycluster")
+		Cmd.Flags().Set("base-domain", "example.com")
+	})
+
+	It("Passes when all checks pass", func() {
+		vpcOwnerClient.EXPECT().VerifySharedVPCResources(spec).Return(&aws.NetworkPreflightResult{
+			VpcID: "vpc-1",
+			Checks: []aws.NetworkPreflightCheck{
+				{Name: aws.SharedVPCCheckRole, Passed: true, Severity: aws.PreflightSeverityError},
+				{Name: aws.SharedVPCCheckPermissions, Passed: false, Severity: aws.PreflightSeverityWarning},
+			},
+		}, nil)
+		_, _, err := test.RunWithOutputCapture(run, t.RosaRuntime, Cmd)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("Fails with the names of the failed checks", func() {
+		vpcOwnerClient.EXPECT().VerifySharedVPCResources(spec).Return(&aws.NetworkPreflightResult{
+			VpcID: "vpc-1",
+			Checks: []aws.NetworkPreflightCheck{
+				{Name: aws.SharedVPCCheckTrust, Passed: false, Severity: aws.PreflightSeverityError},
+				{Name: aws.SharedVPCCheckHostedZone, Passed: false, Severity: aws.PreflightSeverityError},
+			},
+		}, nil)
+		_, _, err := test.RunWithOutputCapture(run, t.RosaRuntime, Cmd)
+		Expect(err).To(MatchError("Shared VPC verification failed: shared-vpc-trust-policy, private-hosted-zone"))
+	})
+
+	It("Fails without the hosted zone ID", func() {
+		Cmd.Flags().Set("private-hosted-zone-id", "")
+		_, _, err := test.RunWithOutputCapture(run, t.RosaRuntime, Cmd)
+		Expect(err).To(MatchError("Expected the ID of the private hosted zone with '--private-hosted-zone-id'"))
+	})
+})
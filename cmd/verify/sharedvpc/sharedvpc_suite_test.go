@@ -0,0 +1,13 @@
+package sharedvpc_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestSharedVPC(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Verify shared VPC Suite")
+}
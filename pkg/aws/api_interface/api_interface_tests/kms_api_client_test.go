@@ -0,0 +1,21 @@
+package aws_test
+
+import (
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	. "github.com/onsi/ginkgo/v2"
+
+	client "github.com/openshift/rosa/pkg/aws/api_interface"
+	m "github.com/openshift/rosa/pkg/aws/mocks"
+)
+
+var _ = Describe("KmsApiClient", func() {
+	It("is implemented by AWS SDK KMS Client", func() {
+		awsKmsClient := &kms.Client{}
+		var _ client.KmsApiClient = awsKmsClient
+	})
+
+	It("is implemented by MockKmsApiClient", func() {
+		mockKmsApiClient := &m.MockKmsApiClient{}
+		var _ client.KmsApiClient = mockKmsApiClient
+	})
+})
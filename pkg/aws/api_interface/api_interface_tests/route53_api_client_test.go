@@ -0,0 +1,21 @@
+package aws_test
+
+import (
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	. "github.com/onsi/ginkgo/v2"
+
+	client "github.com/openshift/rosa/pkg/aws/api_interface"
+	m "github.com/openshift/rosa/pkg/aws/mocks"
+)
+
+var _ = Describe("Route53ApiClient", func() {
+	It("is implemented by AWS SDK Route 53 Client", func() {
+		awsRoute53Client := &route53.Client{}
+		var _ client.Route53ApiClient = awsRoute53Client
+	})
+
+	It("is implemented by MockRoute53ApiClient", func() {
+		mockRoute53ApiClient := &m.MockRoute53ApiClient{}
+		var _ client.Route53ApiClient = mockRoute53ApiClient
+	})
+})
@@ -39,6 +39,9 @@ type Ec2ApiClient interface {
 	DescribeInstanceTypeOfferings(ctx context.Context,
 		params *ec2.DescribeInstanceTypeOfferingsInput, optFns ...func(*ec2.Options),
 	) (*ec2.DescribeInstanceTypeOfferingsOutput, error)
+
+	DescribeVpcs(ctx context.Context, params *ec2.DescribeVpcsInput, optFns ...func(*ec2.Options),
+	) (*ec2.DescribeVpcsOutput, error)
 }
 
 // interface guard to ensure that all methods defined in the Ec2ApiClient
@@ -0,0 +1,36 @@
+package aws
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+)
+
+// Route53ApiClient is an interface that defines the methods that we want to use
+// from the Client type in the AWS SDK ("github.com/aws/aws-sdk-go-v2/service/route53")
+// The aim is to only contain methods that are defined in the AWS SDK's Route 53
+// Client.
+// For the cases where logic is desired to be implemened combining Route 53 calls
+// and other logic use the pkg/aws.Client type.
+// If you need to use a method provided by the AWS SDK's Route 53 Client but it
+// is not defined in this interface then it has to be added and all
+// the types implementing this interface have to implement the new method.
+// The reason this interface has been defined is so we can perform unit testing
+// on methods that make use of the AWS Route 53 service.
+//
+
+type Route53ApiClient interface {
+	CreateHostedZone(ctx context.Context,
+		params *route53.CreateHostedZoneInput, optFns ...func(*route53.Options),
+	) (*route53.CreateHostedZoneOutput, error)
+
+	GetHostedZone(ctx context.Context,
+		params *route53.GetHostedZoneInput, optFns ...func(*route53.Options),
+	) (*route53.GetHostedZoneOutput, error)
+
+	ListHostedZonesByName(ctx context.Context,
+		params *route53.ListHostedZonesByNameInput, optFns ...func(*route53.Options),
+	) (*route53.ListHostedZonesByNameOutput, error)
+}
+
+var _ Route53ApiClient = (*route53.Client)(nil)
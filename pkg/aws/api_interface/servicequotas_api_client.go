@@ -27,6 +27,14 @@ type ServiceQuotasApiClient interface {
 	ListServiceQuotas(ctx context.Context,
 		params *servicequotas.ListServiceQuotasInput, optFns ...func(*servicequotas.Options),
 	) (*servicequotas.ListServiceQuotasOutput, error)
+
+	RequestServiceQuotaIncrease(ctx context.Context,
+		params *servicequotas.RequestServiceQuotaIncreaseInput, optFns ...func(*servicequotas.Options),
+	) (*servicequotas.RequestServiceQuotaIncreaseOutput, error)
+
+	ListRequestedServiceQuotaChangeHistory(ctx context.Context,
+		params *servicequotas.ListRequestedServiceQuotaChangeHistoryInput, optFns ...func(*servicequotas.Options),
+	) (*servicequotas.ListRequestedServiceQuotaChangeHistoryOutput, error)
 }
 
 var _ ServiceQuotasApiClient = (*servicequotas.Client)(nil)
@@ -36,7 +36,9 @@ import (
 	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
 	"github.com/aws/aws-sdk-go-v2/service/iam"
 	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
 	"github.com/aws/aws-sdk-go-v2/service/organizations"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
@@ -106,6 +108,15 @@ type Client interface {
 	ValidateCredentials() (isValid bool, err error)
 	EnsureOsdCcsAdminUser(stackName string, adminUserName string, awsRegion string) (bool, error)
 	DeleteOsdCcsAdminUser(stackName string) error
+	CreateNetworkStack(spec NetworkStackSpec) (*NetworkStack, error)
+	GetNetworkStack(stackName string) (*NetworkStack, error)
+	RunNetworkPreflight(spec NetworkPreflightSpec) (*NetworkPreflightResult, error)
+	EnsureSharedVPCRole(roleName string, trustedRoleARNs []string, tagList map[string]string) (string, error)
+	VerifySharedVPCResources(spec SharedVPCSpec) (*NetworkPreflightResult, error)
+	EnsurePrivateHostedZone(name string, vpcID string, vpcRegion string) (*HostedZone, error)
+	GetHostedZone(id string) (*HostedZone, error)
+	CreateKMSKey(description string, alias string, policy string, tagList map[string]string) (string, error)
+	GetKMSKeyPolicy(keyARN string) (*PolicyDocument, error)
 	AccessKeyGetter
 	GetCreator() (*Creator, error)
 	ValidateSCP(*string, map[string]*cmv1.AWSSTSPolicy) (bool, error)
@@ -116,6 +127,9 @@ type Client interface {
 	GetVPCPrivateSubnets(subnetID string) ([]ec2types.Subnet, error)
 	FilterVPCsPrivateSubnets(subnets []ec2types.Subnet) ([]ec2types.Subnet, error)
 	ValidateQuota() (bool, error)
+	GetQuotaShortfalls() ([]QuotaShortfall, error)
+	RequestServiceQuotaIncrease(shortfall QuotaShortfall) (QuotaIncreaseRequest, error)
+	ListServiceQuotaIncreaseRequests() ([]QuotaIncreaseRequest, error)
 	TagUserRegion(username string, region string) error
 	GetClusterRegionTagForUser(username string) (string, error)
 	EnsureRole(name string, policy string, permissionsBoundary string,
@@ -225,6 +239,7 @@ type ClientBuilder struct {
 	region              *string
 	credentials         *AccessKey
 	useLocalCredentials bool
+	profile             string
 }
 
 type awsClient struct {
@@ -239,6 +254,8 @@ type awsClient struct {
 	cfClient            client.CloudFormationApiClient
 	serviceQuotasClient client.ServiceQuotasApiClient
 	iamQuotaClient      client.ServiceQuotasApiClient
+	route53Client       client.Route53ApiClient
+	kmsClient           client.KmsApiClient
 	awsAccessKeys       *AccessKey
 	useLocalCredentials bool
 }
@@ -272,6 +289,8 @@ func New(
 	cfClient client.CloudFormationApiClient,
 	serviceQuotasClient client.ServiceQuotasApiClient,
 	iamQuotaClient client.ServiceQuotasApiClient,
+	route53Client client.Route53ApiClient,
+	kmsClient client.KmsApiClient,
 	awsAccessKeys *AccessKey,
 	useLocalCredentials bool,
 
@@ -288,6 +307,8 @@ func New(
 		cfClient,
 		serviceQuotasClient,
 		iamQuotaClient,
+		route53Client,
+		kmsClient,
 		awsAccessKeys,
 		useLocalCredentials,
 	}
@@ -304,6 +325,12 @@ func (b *ClientBuilder) Region(value string) *ClientBuilder {
 	return b
 }
 
+// Profile sets the AWS profile used by the client instead of the one given with '--profile'.
+func (b *ClientBuilder) Profile(value string) *ClientBuilder {
+	b.profile = value
+	return b
+}
+
 func (b *ClientBuilder) AccessKeys(value *AccessKey) *ClientBuilder {
 	b.credentials = value
 	return b
@@ -349,7 +376,7 @@ func (b *ClientBuilder) BuildSessionWithOptionsCredentials(value *AccessKey,
 
 func (b *ClientBuilder) BuildSessionWithOptions(logLevel aws.ClientLogMode) (aws.Config, error) {
 	cfg, err := config.LoadDefaultConfig(context.TODO(),
-		config.WithSharedConfigProfile(profile.Profile()),
+		config.WithSharedConfigProfile(b.getProfile()),
 		config.WithRegion(*b.region),
 		config.WithHTTPClient(&http.Client{
 			Transport: http.DefaultTransport,
@@ -377,6 +404,13 @@ func (b *ClientBuilder) BuildSessionWithOptions(logLevel aws.ClientLogMode) (aws
 	return cfg, nil
 }
 
+func (b *ClientBuilder) getProfile() string {
+	if b.profile != "" {
+		return b.profile
+	}
+	return profile.Profile()
+}
+
 func (b *ClientBuilder) BuildSession() (aws.Config, error) {
 	var logLevel aws.ClientLogMode
 	logLevel = 0
@@ -427,8 +461,8 @@ func (b *ClientBuilder) Build() (Client, error) {
 		return nil, fmt.Errorf("region is not set. Use --region to set the region")
 	}
 
-	if profile.Profile() != "" {
-		b.logger.Debugf("Using AWS profile: %s", profile.Profile())
+	if b.getProfile() != "" {
+		b.logger.Debugf("Using AWS profile: %s", b.getProfile())
 	}
 
 	// IAM Service is only available in "us-east-1", need to create specific config for it
@@ -451,6 +485,8 @@ func (b *ClientBuilder) Build() (Client, error) {
 		cfClient:            cloudformation.NewFromConfig(cfg),
 		serviceQuotasClient: servicequotas.NewFromConfig(cfg),
 		iamQuotaClient:      servicequotas.NewFromConfig(iamCfg),
+		route53Client:       route53.NewFromConfig(cfg),
+		kmsClient:           kms.NewFromConfig(cfg),
 		useLocalCredentials: b.useLocalCredentials,
 	}
 
@@ -64,6 +64,8 @@ var _ = Describe("Client", func() {
 			mockCfAPI,
 			mocks.NewMockServiceQuotasApiClient(mockCtrl),
 			mocks.NewMockServiceQuotasApiClient(mockCtrl),
+			mocks.NewMockRoute53ApiClient(mockCtrl),
+			mocks.NewMockKmsApiClient(mockCtrl),
 			&AccessKey{},
 			false,
 		)
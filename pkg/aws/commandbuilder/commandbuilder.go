@@ -15,6 +15,7 @@ const (
 	S3Api Service = "s3api"
 	S3    Service = "s3"
 	SM    Service = "secretsmanager"
+	R53   Service = "route53"
 )
 
 type Command string
@@ -34,6 +35,8 @@ const (
 	CreateOpenIdConnectProvider   Command = "create-open-id-connect-provider"
 	DeleteOpenIdConnectProvider   Command = "delete-open-id-connect-provider"
 	DeleteRolePermissionsBoundary Command = "delete-role-permissions-boundary"
+	PutRolePolicy                 Command = "put-role-policy"
+	UpdateAssumeRolePolicy        Command = "update-assume-role-policy"
 	//S3Api
 	CreateBucket         Command = "create-bucket"
 	PutObject            Command = "put-object"
@@ -46,6 +49,8 @@ const (
 	//SecretsManager
 	CreateSecret Command = "create-secret"
 	DeleteSecret Command = "delete-secret"
+	//Route53
+	CreateHostedZone Command = "create-hosted-zone"
 )
 
 type Param string
@@ -65,6 +70,7 @@ const (
 	ThumbprintList           Param = "thumbprint-list"
 	OpenIdConnectProviderArn Param = "open-id-connect-provider-arn"
 	SetAsDefault             Param = "set-as-default"
+	Profile                  Param = "profile"
 
 	//S3
 	Bucket                         Param = "bucket"
@@ -83,6 +89,11 @@ const (
 	Description  Param = "description"
 	SecretID     Param = "secret-id"
 	Recursive    Param = "recursive"
+
+	//Route53
+	VPC              Param = "vpc"
+	CallerReference  Param = "caller-reference"
+	HostedZoneConfig Param = "hosted-zone-config"
 )
 
 type Redirect string
@@ -191,6 +202,10 @@ func NewSecretsManagerCommandBuilder() *CommandBuilder {
 	return &CommandBuilder{service: SM}
 }
 
+func NewRoute53CommandBuilder() *CommandBuilder {
+	return &CommandBuilder{service: R53}
+}
+
 func createParamString(awsParam Param, value string) string {
 	return fmt.Sprintf("\t--%s %s", awsParam, value)
 }
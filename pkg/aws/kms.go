@@ -0,0 +1,378 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	kmstypes "github.com/aws/aws-sdk-go-v2/service/kms/types"
+	awsCommonUtils "github.com/openshift-online/ocm-common/pkg/aws/utils"
+	common "github.com/openshift-online/ocm-common/pkg/aws/validations"
+
+	"github.com/openshift/rosa/pkg/helper"
+)
+
+const (
+	EBSCSIOperatorNamespace = "openshift-cluster-csi-drivers"
+	EBSCSIOperatorName      = "ebs-cloud-credentials"
+
+	KMSProviderOperatorNamespace = "kube-system"
+	KMSProviderOperatorName      = "kms-provider"
+
+	defaultKeyPolicyName = "default"
+)
+
+// KMSKeyUsageActions are the actions the cluster roles need to encrypt and decrypt volumes with the key
+var KMSKeyUsageActions = []string{
+	"kms:Decrypt",
+	"kms:DescribeKey",
+	"kms:Encrypt",
+	"kms:GenerateDataKey*",
+	"kms:ReEncrypt*",
+}
+
+// KMSKeyGrantActions are the actions the cluster roles need to attach encrypted volumes to instances
+var KMSKeyGrantActions = []string{
+	"kms:CreateGrant",
+	"kms:ListGrants",
+	"kms:RevokeGrant",
+}
+
+// kmsKeyRequiredActions are the concrete actions that the key policy must grant to each role
+var kmsKeyRequiredActions = []string{
+	"kms:CreateGrant",
+	"kms:Decrypt",
+	"kms:DescribeKey",
+	"kms:Encrypt",
+	"kms:GenerateDataKey",
+	"kms:GenerateDataKeyWithoutPlaintext",
+	"kms:ReEncryptFrom",
+	"kms:ReEncryptTo",
+}
+
+// KMSKeyRoles are the roles of the cluster that use a customer managed key. Empty ARNs are ignored.
+type KMSKeyRoles struct {
+	Installer      string
+	Support        string
+	Worker         string
+	EBSCSIOperator string
+}
+
+// ARNs returns the non empty role ARNs
+func (r KMSKeyRoles) ARNs() []string {
+	var arns []string
+	for _, arn := range []string{r.Installer, r.Support, r.Worker, r.EBSCSIOperator} {
+		if arn != "" {
+			arns = append(arns, arn)
+		}
+	}
+	return arns
+}
+
+// GetKMSKeyRoles returns the default ARNs of the roles that use a customer managed key
+func GetKMSKeyRoles(partition string, accountID string, accountRolePrefix string, operatorRolePrefix string,
+	hostedCP bool) KMSKeyRoles {
+	accountRoles := AccountRoles
+	installer, support, worker := InstallerAccountRole, SupportAccountRole, WorkerAccountRole
+	if hostedCP {
+		accountRoles = HCPAccountRoles
+		installer, support, worker = HCPInstallerRole, HCPSupportRole, HCPWorkerRole
+	}
+	roleARN := func(role string) string {
+		return GetRoleARN(accountID, common.GetRoleName(accountRolePrefix, accountRoles[role].Name), "", partition)
+	}
+	ebsCSIOperatorRole := awsCommonUtils.TruncateRoleName(
+		fmt.Sprintf("%s-%s-%s", operatorRolePrefix, EBSCSIOperatorNamespace, EBSCSIOperatorName))
+	return KMSKeyRoles{
+		Installer:      roleARN(installer),
+		Support:        roleARN(support),
+		Worker:         roleARN(worker),
+		EBSCSIOperator: GetRoleARN(accountID, ebsCSIOperatorRole, "", partition),
+	}
+}
+
+// KMSKeyPolicy returns a key policy that keeps the key manageable through IAM in the account and
+// allows the cluster roles to use the key and attach encrypted volumes. The roles are named in an
+// 'aws:PrincipalArn' condition on the account principal, as KMS rejects key policies naming roles
+// that don't exist yet and the operator roles are usually created after the key.
+func KMSKeyPolicy(partition string, accountID string, roles KMSKeyRoles) string {
+	accountARN := fmt.Sprintf("arn:%s:iam::%s:root", partition, accountID)
+	rolesCondition := map[string]interface{}{
+		principalARNConditionKey: roles.ARNs(),
+	}
+	policy := NewPolicyDocument()
+	policy.ID = "rosa-kms-key-policy"
+	policy.Statement = []PolicyStatement{
+		{
+			Sid:       "Enable IAM User Permissions",
+			Effect:    "Allow",
+			Principal: &PolicyStatementPrincipal{AWS: accountARN},
+			Action:    "kms:*",
+			Resource:  "*",
+		},
+		{
+			Sid:       "Allow ROSA use of the key",
+			Effect:    "Allow",
+			Principal: &PolicyStatementPrincipal{AWS: accountARN},
+			Action:    KMSKeyUsageActions,
+			Resource:  "*",
+			Condition: map[string]interface{}{
+				"ArnEquals": rolesCondition,
+			},
+		},
+		{
+			Sid:       "Allow attachment of persistent resources",
+			Effect:    "Allow",
+			Principal: &PolicyStatementPrincipal{AWS: accountARN},
+			Action:    KMSKeyGrantActions,
+			Resource:  "*",
+			Condition: map[string]interface{}{
+				"ArnEquals": rolesCondition,
+				"Bool": map[string]interface{}{
+					"kms:GrantIsForAWSResource": "true",
+				},
+			},
+		},
+	}
+	return marshalPolicyDocument(policy)
+}
+
+// CreateKMSKey creates a symmetric customer managed key with the given key policy and returns its ARN.
+// The alias is optional.
+func (c *awsClient) CreateKMSKey(description string, alias string, policy string,
+	tagList map[string]string) (string, error) {
+	keyTags := make([]kmstypes.Tag, 0, len(tagList))
+	for key, value := range tagList {
+		keyTags = append(keyTags, kmstypes.Tag{
+			TagKey:   aws.String(key),
+			TagValue: aws.String(value),
+		})
+	}
+	sort.Slice(keyTags, func(i, j int) bool {
+		return aws.ToString(keyTags[i].TagKey) < aws.ToString(keyTags[j].TagKey)
+	})
+	output, err := c.kmsClient.CreateKey(context.Background(), &kms.CreateKeyInput{
+		Description: aws.String(description),
+		Policy:      aws.String(policy),
+		Tags:        keyTags,
+	})
+	if err != nil {
+		return "", fmt.Errorf("Failed to create KMS key: %v", err)
+	}
+	keyARN := aws.ToString(output.KeyMetadata.Arn)
+	if alias != "" {
+		_, err = c.kmsClient.CreateAlias(context.Background(), &kms.CreateAliasInput{
+			AliasName:   aws.String(fmt.Sprintf("alias/%s", strings.TrimPrefix(alias, "alias/"))),
+			TargetKeyId: output.KeyMetadata.KeyId,
+		})
+		if err != nil {
+			return keyARN, fmt.Errorf("Created KMS key '%s' but failed to create alias '%s': %v",
+				keyARN, alias, err)
+		}
+	}
+	return keyARN, nil
+}
+
+// GetKMSKeyPolicy returns the key policy of a KMS key
+func (c *awsClient) GetKMSKeyPolicy(keyARN string) (*PolicyDocument, error) {
+	output, err := c.kmsClient.GetKeyPolicy(context.Background(), &kms.GetKeyPolicyInput{
+		KeyId:      aws.String(keyARN),
+		PolicyName: aws.String(defaultKeyPolicyName),
+	})
+	if err != nil {
+		return nil, err
+	}
+	policy, err := ParsePolicyDocument(aws.ToString(output.Policy))
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse key policy of '%s': %v", keyARN, err)
+	}
+	return policy, nil
+}
+
+// MissingKMSKeyGrant lists the actions a key policy doesn't allow to a role
+type MissingKMSKeyGrant struct {
+	RoleARN string `json:"role_arn"`
+	// Actions are denied or not granted by the key policy
+	Actions []string `json:"actions,omitempty"`
+	// IAMActions are only granted to the account, so they depend on the IAM policies of the role
+	IAMActions []string `json:"iam_actions,omitempty"`
+}
+
+// kmsKeyRoleActions are the actions a role needs on a key
+type kmsKeyRoleActions struct {
+	roleARN string
+	actions []string
+}
+
+// etcdKMSKeyInstallerActions are the actions the installer role needs on the etcd encryption key
+var etcdKMSKeyInstallerActions = []string{
+	"kms:CreateGrant",
+	"kms:DescribeKey",
+	"kms:GenerateDataKeyWithoutPlaintext",
+}
+
+// etcdKMSKeyProviderActions are the actions the KMS provider operator role needs on the etcd encryption key
+var etcdKMSKeyProviderActions = []string{
+	"kms:Decrypt",
+	"kms:DescribeKey",
+	"kms:Encrypt",
+}
+
+// GetMissingKMSKeyGrants returns the actions the key policy doesn't allow to each of the roles
+func GetMissingKMSKeyGrants(policy *PolicyDocument, roles KMSKeyRoles) []MissingKMSKeyGrant {
+	var required []kmsKeyRoleActions
+	for _, roleARN := range roles.ARNs() {
+		required = append(required, kmsKeyRoleActions{roleARN: roleARN, actions: kmsKeyRequiredActions})
+	}
+	return getMissingKMSKeyGrants(policy, required)
+}
+
+// GetMissingEtcdKMSKeyGrants returns the actions the policy of the etcd encryption key of a hosted
+// control plane cluster doesn't allow to the installer and KMS provider operator roles. Empty ARNs
+// are ignored.
+func GetMissingEtcdKMSKeyGrants(policy *PolicyDocument, installerRoleARN string,
+	kmsProviderRoleARN string) []MissingKMSKeyGrant {
+	var required []kmsKeyRoleActions
+	if installerRoleARN != "" {
+		required = append(required, kmsKeyRoleActions{roleARN: installerRoleARN, actions: etcdKMSKeyInstallerActions})
+	}
+	if kmsProviderRoleARN != "" {
+		required = append(required, kmsKeyRoleActions{roleARN: kmsProviderRoleARN, actions: etcdKMSKeyProviderActions})
+	}
+	return getMissingKMSKeyGrants(policy, required)
+}
+
+// getMissingKMSKeyGrants evaluates the key policy for each of the roles. An action is missing when
+// a statement denies it or no statement allows it to the role or to all principals. Actions that
+// are only allowed to the account of the role (the 'arn:<partition>:iam::<account>:root' principal
+// of the default key policy) are reported apart, as the IAM policies of the role decide on them.
+func getMissingKMSKeyGrants(policy *PolicyDocument, required []kmsKeyRoleActions) []MissingKMSKeyGrant {
+	var missing []MissingKMSKeyGrant
+	for _, role := range required {
+		grant := MissingKMSKeyGrant{RoleARN: role.roleARN}
+		for _, action := range role.actions {
+			switch evaluateKMSKeyAction(policy, role.roleARN, action) {
+			case kmsKeyActionDelegated:
+				grant.IAMActions = append(grant.IAMActions, action)
+			case kmsKeyActionMissing:
+				grant.Actions = append(grant.Actions, action)
+			}
+		}
+		if len(grant.Actions) > 0 || len(grant.IAMActions) > 0 {
+			missing = append(missing, grant)
+		}
+	}
+	return missing
+}
+
+type kmsKeyActionAccess int
+
+const (
+	kmsKeyActionMissing kmsKeyActionAccess = iota
+	kmsKeyActionDelegated
+	kmsKeyActionAllowed
+)
+
+func evaluateKMSKeyAction(policy *PolicyDocument, roleARN string, wanted string) kmsKeyActionAccess {
+	accountPrincipals := []string{}
+	if parsed, err := arn.Parse(roleARN); err == nil {
+		accountPrincipals = append(accountPrincipals, parsed.AccountID,
+			fmt.Sprintf("arn:%s:iam::%s:root", parsed.Partition, parsed.AccountID))
+	}
+	access := kmsKeyActionMissing
+	for _, statement := range policy.Statement {
+		if statement.Principal == nil || !statementMatchesAction(statement, wanted) {
+			continue
+		}
+		principals := statement.GetAWSPrincipals()
+		matchesRole := helper.Contains(principals, roleARN) || helper.Contains(principals, "*")
+		matchesAccount := false
+		for _, principal := range accountPrincipals {
+			matchesAccount = matchesAccount || helper.Contains(principals, principal)
+		}
+		// An 'aws:PrincipalArn' condition restricts the statement to the roles it lists
+		if conditionARNs := conditionPrincipalARNs(statement.Condition); len(conditionARNs) > 0 {
+			if !matchesRole && !matchesAccount {
+				continue
+			}
+			if !matchesPrincipalARN(conditionARNs, roleARN) {
+				continue
+			}
+			matchesRole, matchesAccount = true, false
+		}
+		switch statement.Effect {
+		case "Deny":
+			// Conditional denies can't be evaluated here, so only the unconditional ones are counted
+			if (matchesRole || matchesAccount) && len(statement.Condition) == 0 {
+				return kmsKeyActionMissing
+			}
+		case "Allow":
+			if matchesRole {
+				access = kmsKeyActionAllowed
+			} else if matchesAccount && access == kmsKeyActionMissing {
+				access = kmsKeyActionDelegated
+			}
+		}
+	}
+	return access
+}
+
+// matchesPrincipalARN checks if the role is one of the ARNs or matches one of their wildcards
+func matchesPrincipalARN(principalARNs []string, roleARN string) bool {
+	for _, principalARN := range principalARNs {
+		matched, err := path.Match(principalARN, roleARN)
+		if principalARN == roleARN || (err == nil && matched) {
+			return true
+		}
+	}
+	return false
+}
+
+func statementMatchesAction(statement PolicyStatement, wanted string) bool {
+	for _, action := range statementActions(statement) {
+		matched, err := path.Match(strings.ToLower(action), strings.ToLower(wanted))
+		if err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+func statementActions(statement PolicyStatement) []string {
+	switch action := statement.Action.(type) {
+	case string:
+		return []string{action}
+	case []string:
+		return action
+	case []interface{}:
+		actions := make([]string, 0, len(action))
+		for _, el := range action {
+			if a, ok := el.(string); ok {
+				actions = append(actions, a)
+			}
+		}
+		return actions
+	}
+	return nil
+}
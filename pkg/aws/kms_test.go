@@ -0,0 +1,202 @@
+package aws
+
+import (
+	gomock "go.uber.org/mock/gomock"
+
+	awsSdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	kmstypes "github.com/aws/aws-sdk-go-v2/service/kms/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/rosa/pkg/aws/mocks"
+)
+
+var _ = Describe("KMS keys", func() {
+	var (
+		client   Client
+		mockCtrl *gomock.Controller
+		mockKms  *mocks.MockKmsApiClient
+	)
+
+	keyRoles := GetKMSKeyRoles("aws", "123456789012", "ManagedOpenShift", "mycluster", false)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockKms = mocks.NewMockKmsApiClient(mockCtrl)
+		client = New(
+			awsSdk.Config{},
+			logrus.New(),
+			mocks.NewMockIamApiClient(mockCtrl),
+			mocks.NewMockEc2ApiClient(mockCtrl),
+			mocks.NewMockOrganizationsApiClient(mockCtrl),
+			mocks.NewMockS3ApiClient(mockCtrl),
+			mocks.NewMockSecretsManagerApiClient(mockCtrl),
+			mocks.NewMockStsApiClient(mockCtrl),
+			mocks.NewMockCloudFormationApiClient(mockCtrl),
+			mocks.NewMockServiceQuotasApiClient(mockCtrl),
+			mocks.NewMockServiceQuotasApiClient(mockCtrl),
+			mocks.NewMockRoute53ApiClient(mockCtrl),
+			mockKms,
+			&AccessKey{},
+			false,
+		)
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	It("Builds the ARNs of the roles that use the key", func() {
+		Expect(keyRoles).To(Equal(KMSKeyRoles{
+			Installer:      "arn:aws:iam::123456789012:role/ManagedOpenShift-Installer-Role",
+			Support:        "arn:aws:iam::123456789012:role/ManagedOpenShift-Support-Role",
+			Worker:         "arn:aws:iam::123456789012:role/ManagedOpenShift-Worker-Role",
+			EBSCSIOperator: "arn:aws:iam::123456789012:role/mycluster-openshift-cluster-csi-drivers-ebs-cloud-credentials",
+		}))
+		hcpRoles := GetKMSKeyRoles("aws", "123456789012", "ManagedOpenShift", "mycluster", true)
+		Expect(hcpRoles.Installer).To(Equal("arn:aws:iam::123456789012:role/ManagedOpenShift-HCP-ROSA-Installer-Role"))
+	})
+
+	It("Names the roles in a condition instead of as principals", func() {
+		policy, err := ParsePolicyDocument(KMSKeyPolicy("aws", "123456789012", keyRoles))
+		Expect(err).NotTo(HaveOccurred())
+		for _, statement := range policy.Statement {
+			Expect(statement.GetAWSPrincipals()).To(Equal([]string{"arn:aws:iam::123456789012:root"}))
+		}
+		Expect(conditionPrincipalARNs(policy.Statement[1].Condition)).To(ConsistOf(keyRoles.ARNs()))
+		Expect(conditionPrincipalARNs(policy.Statement[2].Condition)).To(ConsistOf(keyRoles.ARNs()))
+
+		otherRole := "arn:aws:iam::123456789012:role/other"
+		Expect(GetMissingKMSKeyGrants(policy, KMSKeyRoles{Installer: otherRole})).To(Equal([]MissingKMSKeyGrant{
+			{RoleARN: otherRole, IAMActions: kmsKeyRequiredActions},
+		}))
+	})
+
+	It("Creates the key with its policy and alias", func() {
+		policy := KMSKeyPolicy("aws", "123456789012", keyRoles)
+		mockKms.EXPECT().CreateKey(gomock.Any(), &kms.CreateKeyInput{
+			Description: awsSdk.String("key"),
+			Policy:      awsSdk.String(policy),
+			Tags: []kmstypes.Tag{
+				{TagKey: awsSdk.String("red-hat-managed"), TagValue: awsSdk.String("true")},
+				{TagKey: awsSdk.String("rosa_cluster_name"), TagValue: awsSdk.String("mycluster")},
+			},
+		}).Return(&kms.CreateKeyOutput{KeyMetadata: &kmstypes.KeyMetadata{
+			Arn:   awsSdk.String("arn:aws:kms:us-east-1:123456789012:key/key-id"),
+			KeyId: awsSdk.String("key-id"),
+		}}, nil)
+		mockKms.EXPECT().CreateAlias(gomock.Any(), &kms.CreateAliasInput{
+			AliasName:   awsSdk.String("alias/mycluster"),
+			TargetKeyId: awsSdk.String("key-id"),
+		}).Return(&kms.CreateAliasOutput{}, nil)
+
+		keyARN, err := client.CreateKMSKey("key", "mycluster", policy, map[string]string{
+			"rosa_cluster_name": "mycluster",
+			"red-hat-managed":   "true",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(keyARN).To(Equal("arn:aws:kms:us-east-1:123456789012:key/key-id"))
+	})
+
+	Context("GetMissingKMSKeyGrants", func() {
+		It("Returns no missing grants for the generated key policy", func() {
+			mockKms.EXPECT().GetKeyPolicy(gomock.Any(), gomock.Any()).Return(&kms.GetKeyPolicyOutput{
+				Policy: awsSdk.String(KMSKeyPolicy("aws", "123456789012", keyRoles)),
+			}, nil)
+			policy, err := client.GetKMSKeyPolicy("arn:aws:kms:us-east-1:123456789012:key/key-id")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(GetMissingKMSKeyGrants(policy, keyRoles)).To(BeEmpty())
+		})
+
+		It("Reports the actions only granted to the account root apart", func() {
+			policy, err := ParsePolicyDocument(`{
+				"Version": "2012-10-17",
+				"Statement": [
+					{"Effect": "Allow", "Principal": {"AWS": "arn:aws:iam::123456789012:root"},
+					 "Action": "kms:*", "Resource": "*"},
+					{"Effect": "Allow", "Principal": {"AWS": "arn:aws:iam::123456789012:role/ManagedOpenShift-Installer-Role"},
+					 "Action": ["kms:Encrypt", "kms:Decrypt", "kms:GenerateDataKey*", "kms:DescribeKey"],
+					 "Resource": "*"}
+				]
+			}`)
+			Expect(err).NotTo(HaveOccurred())
+			missing := GetMissingKMSKeyGrants(policy, KMSKeyRoles{
+				Installer: keyRoles.Installer,
+				Worker:    keyRoles.Worker,
+			})
+			Expect(missing).To(Equal([]MissingKMSKeyGrant{
+				{
+					RoleARN:    keyRoles.Installer,
+					IAMActions: []string{"kms:CreateGrant", "kms:ReEncryptFrom", "kms:ReEncryptTo"},
+				},
+				{
+					RoleARN:    keyRoles.Worker,
+					IAMActions: kmsKeyRequiredActions,
+				},
+			}))
+		})
+
+		It("Reports the actions without any grant and the denied actions", func() {
+			policy, err := ParsePolicyDocument(`{
+				"Version": "2012-10-17",
+				"Statement": [
+					{"Effect": "Allow", "Principal": {"AWS": "*"}, "Action": "kms:*", "Resource": "*"},
+					{"Effect": "Deny", "Principal": {"AWS": "arn:aws:iam::123456789012:role/ManagedOpenShift-Worker-Role"},
+					 "Action": "kms:ReEncrypt*", "Resource": "*"},
+					{"Effect": "Deny", "Principal": {"AWS": "*"}, "Action": "kms:CreateGrant", "Resource": "*",
+					 "Condition": {"Bool": {"kms:GrantIsForAWSResource": "false"}}}
+				]
+			}`)
+			Expect(err).NotTo(HaveOccurred())
+			missing := GetMissingKMSKeyGrants(policy, KMSKeyRoles{
+				Installer: keyRoles.Installer,
+				Worker:    keyRoles.Worker,
+			})
+			Expect(missing).To(Equal([]MissingKMSKeyGrant{
+				{
+					RoleARN: keyRoles.Worker,
+					Actions: []string{"kms:ReEncryptFrom", "kms:ReEncryptTo"},
+				},
+			}))
+
+			policy, err = ParsePolicyDocument(`{
+				"Version": "2012-10-17",
+				"Statement": [
+					{"Effect": "Allow", "Principal": {"AWS": "arn:aws:iam::123456789012:role/ManagedOpenShift-Support-Role"},
+					 "Action": "kms:*", "Resource": "*"}
+				]
+			}`)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(GetMissingKMSKeyGrants(policy, KMSKeyRoles{Worker: keyRoles.Worker})).To(Equal([]MissingKMSKeyGrant{
+				{
+					RoleARN: keyRoles.Worker,
+					Actions: kmsKeyRequiredActions,
+				},
+			}))
+		})
+	})
+
+	Context("GetMissingEtcdKMSKeyGrants", func() {
+		installerRoleARN := "arn:aws:iam::123456789012:role/ManagedOpenShift-HCP-ROSA-Installer-Role"
+		kmsProviderRoleARN := "arn:aws:iam::123456789012:role/mycluster-kube-system-kms-provider"
+
+		It("Checks the actions each of the roles needs", func() {
+			policy, err := ParsePolicyDocument(`{
+				"Version": "2012-10-17",
+				"Statement": [
+					{"Effect": "Allow", "Principal": {"AWS": "` + installerRoleARN + `"},
+					 "Action": ["kms:CreateGrant", "kms:DescribeKey", "kms:GenerateDataKeyWithoutPlaintext"],
+					 "Resource": "*"},
+					{"Effect": "Allow", "Principal": {"AWS": "` + kmsProviderRoleARN + `"},
+					 "Action": ["kms:Encrypt", "kms:DescribeKey"], "Resource": "*"}
+				]
+			}`)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(GetMissingEtcdKMSKeyGrants(policy, installerRoleARN, kmsProviderRoleARN)).To(Equal(
+				[]MissingKMSKeyGrant{{RoleARN: kmsProviderRoleARN, Actions: []string{"kms:Decrypt"}}}))
+			Expect(GetMissingEtcdKMSKeyGrants(policy, installerRoleARN, "")).To(BeEmpty())
+		})
+	})
+})
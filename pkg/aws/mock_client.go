@@ -133,6 +133,36 @@ func (mr *MockClientMockRecorder) CheckStackReadyOrNotExisting(stackName any) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckStackReadyOrNotExisting", reflect.TypeOf((*MockClient)(nil).CheckStackReadyOrNotExisting), stackName)
 }
 
+// CreateKMSKey mocks base method.
+func (m *MockClient) CreateKMSKey(description, alias, policy string, tagList map[string]string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateKMSKey", description, alias, policy, tagList)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateKMSKey indicates an expected call of CreateKMSKey.
+func (mr *MockClientMockRecorder) CreateKMSKey(description, alias, policy, tagList any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateKMSKey", reflect.TypeOf((*MockClient)(nil).CreateKMSKey), description, alias, policy, tagList)
+}
+
+// CreateNetworkStack mocks base method.
+func (m *MockClient) CreateNetworkStack(spec NetworkStackSpec) (*NetworkStack, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNetworkStack", spec)
+	ret0, _ := ret[0].(*NetworkStack)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateNetworkStack indicates an expected call of CreateNetworkStack.
+func (mr *MockClientMockRecorder) CreateNetworkStack(spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNetworkStack", reflect.TypeOf((*MockClient)(nil).CreateNetworkStack), spec)
+}
+
 // CreateOpenIDConnectProvider mocks base method.
 func (m *MockClient) CreateOpenIDConnectProvider(issuerURL, thumbprint, clusterID string) (string, error) {
 	m.ctrl.T.Helper()
@@ -376,6 +406,21 @@ func (mr *MockClientMockRecorder) EnsurePolicy(policyArn, document, version, tag
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsurePolicy", reflect.TypeOf((*MockClient)(nil).EnsurePolicy), policyArn, document, version, tagList, path)
 }
 
+// EnsurePrivateHostedZone mocks base method.
+func (m *MockClient) EnsurePrivateHostedZone(name, vpcID, vpcRegion string) (*HostedZone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsurePrivateHostedZone", name, vpcID, vpcRegion)
+	ret0, _ := ret[0].(*HostedZone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnsurePrivateHostedZone indicates an expected call of EnsurePrivateHostedZone.
+func (mr *MockClientMockRecorder) EnsurePrivateHostedZone(name, vpcID, vpcRegion any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsurePrivateHostedZone", reflect.TypeOf((*MockClient)(nil).EnsurePrivateHostedZone), name, vpcID, vpcRegion)
+}
+
 // EnsureRole mocks base method.
 func (m *MockClient) EnsureRole(name, policy, permissionsBoundary, version string, tagList map[string]string, path string, managedPolicies bool) (string, error) {
 	m.ctrl.T.Helper()
@@ -391,6 +436,21 @@ func (mr *MockClientMockRecorder) EnsureRole(name, policy, permissionsBoundary,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureRole", reflect.TypeOf((*MockClient)(nil).EnsureRole), name, policy, permissionsBoundary, version, tagList, path, managedPolicies)
 }
 
+// EnsureSharedVPCRole mocks base method.
+func (m *MockClient) EnsureSharedVPCRole(roleName string, trustedRoleARNs []string, tagList map[string]string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnsureSharedVPCRole", roleName, trustedRoleARNs, tagList)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnsureSharedVPCRole indicates an expected call of EnsureSharedVPCRole.
+func (mr *MockClientMockRecorder) EnsureSharedVPCRole(roleName, trustedRoleARNs, tagList any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnsureSharedVPCRole", reflect.TypeOf((*MockClient)(nil).EnsureSharedVPCRole), roleName, trustedRoleARNs, tagList)
+}
+
 // FetchPublicSubnetMap mocks base method.
 func (m *MockClient) FetchPublicSubnetMap(subnets []types.Subnet) (map[string]bool, error) {
 	m.ctrl.T.Helper()
@@ -691,6 +751,21 @@ func (mr *MockClientMockRecorder) GetDefaultPolicyDocument(policyArn any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDefaultPolicyDocument", reflect.TypeOf((*MockClient)(nil).GetDefaultPolicyDocument), policyArn)
 }
 
+// GetHostedZone mocks base method.
+func (m *MockClient) GetHostedZone(id string) (*HostedZone, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHostedZone", id)
+	ret0, _ := ret[0].(*HostedZone)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHostedZone indicates an expected call of GetHostedZone.
+func (mr *MockClientMockRecorder) GetHostedZone(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHostedZone", reflect.TypeOf((*MockClient)(nil).GetHostedZone), id)
+}
+
 // GetIAMCredentials mocks base method.
 func (m *MockClient) GetIAMCredentials() (aws.Credentials, error) {
 	m.ctrl.T.Helper()
@@ -736,6 +811,21 @@ func (mr *MockClientMockRecorder) GetInstanceProfilesForRole(role any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInstanceProfilesForRole", reflect.TypeOf((*MockClient)(nil).GetInstanceProfilesForRole), role)
 }
 
+// GetKMSKeyPolicy mocks base method.
+func (m *MockClient) GetKMSKeyPolicy(keyARN string) (*PolicyDocument, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetKMSKeyPolicy", keyARN)
+	ret0, _ := ret[0].(*PolicyDocument)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetKMSKeyPolicy indicates an expected call of GetKMSKeyPolicy.
+func (mr *MockClientMockRecorder) GetKMSKeyPolicy(keyARN any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetKMSKeyPolicy", reflect.TypeOf((*MockClient)(nil).GetKMSKeyPolicy), keyARN)
+}
+
 // GetLocalAWSAccessKeys mocks base method.
 func (m *MockClient) GetLocalAWSAccessKeys() (*AccessKey, error) {
 	m.ctrl.T.Helper()
@@ -751,6 +841,21 @@ func (mr *MockClientMockRecorder) GetLocalAWSAccessKeys() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLocalAWSAccessKeys", reflect.TypeOf((*MockClient)(nil).GetLocalAWSAccessKeys))
 }
 
+// GetNetworkStack mocks base method.
+func (m *MockClient) GetNetworkStack(stackName string) (*NetworkStack, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNetworkStack", stackName)
+	ret0, _ := ret[0].(*NetworkStack)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNetworkStack indicates an expected call of GetNetworkStack.
+func (mr *MockClientMockRecorder) GetNetworkStack(stackName any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNetworkStack", reflect.TypeOf((*MockClient)(nil).GetNetworkStack), stackName)
+}
+
 // GetOpenIDConnectProviderByClusterIdTag mocks base method.
 func (m *MockClient) GetOpenIDConnectProviderByClusterIdTag(clusterID string) (string, error) {
 	m.ctrl.T.Helper()
@@ -826,6 +931,21 @@ func (mr *MockClientMockRecorder) GetOperatorRolesFromAccountByPrefix(prefix, cr
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOperatorRolesFromAccountByPrefix", reflect.TypeOf((*MockClient)(nil).GetOperatorRolesFromAccountByPrefix), prefix, credRequest)
 }
 
+// GetQuotaShortfalls mocks base method.
+func (m *MockClient) GetQuotaShortfalls() ([]QuotaShortfall, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQuotaShortfalls")
+	ret0, _ := ret[0].([]QuotaShortfall)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQuotaShortfalls indicates an expected call of GetQuotaShortfalls.
+func (mr *MockClientMockRecorder) GetQuotaShortfalls() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuotaShortfalls", reflect.TypeOf((*MockClient)(nil).GetQuotaShortfalls))
+}
+
 // GetRegion mocks base method.
 func (m *MockClient) GetRegion() string {
 	m.ctrl.T.Helper()
@@ -1230,6 +1350,21 @@ func (mr *MockClientMockRecorder) ListOperatorRoles(version, clusterID any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOperatorRoles", reflect.TypeOf((*MockClient)(nil).ListOperatorRoles), version, clusterID)
 }
 
+// ListServiceQuotaIncreaseRequests mocks base method.
+func (m *MockClient) ListServiceQuotaIncreaseRequests() ([]QuotaIncreaseRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListServiceQuotaIncreaseRequests")
+	ret0, _ := ret[0].([]QuotaIncreaseRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListServiceQuotaIncreaseRequests indicates an expected call of ListServiceQuotaIncreaseRequests.
+func (mr *MockClientMockRecorder) ListServiceQuotaIncreaseRequests() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListServiceQuotaIncreaseRequests", reflect.TypeOf((*MockClient)(nil).ListServiceQuotaIncreaseRequests))
+}
+
 // ListSubnets mocks base method.
 func (m *MockClient) ListSubnets(subnetIds ...string) ([]types.Subnet, error) {
 	m.ctrl.T.Helper()
@@ -1292,6 +1427,36 @@ func (mr *MockClientMockRecorder) PutRolePolicy(roleName, policyName, policy any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutRolePolicy", reflect.TypeOf((*MockClient)(nil).PutRolePolicy), roleName, policyName, policy)
 }
 
+// RequestServiceQuotaIncrease mocks base method.
+func (m *MockClient) RequestServiceQuotaIncrease(shortfall QuotaShortfall) (QuotaIncreaseRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestServiceQuotaIncrease", shortfall)
+	ret0, _ := ret[0].(QuotaIncreaseRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestServiceQuotaIncrease indicates an expected call of RequestServiceQuotaIncrease.
+func (mr *MockClientMockRecorder) RequestServiceQuotaIncrease(shortfall any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestServiceQuotaIncrease", reflect.TypeOf((*MockClient)(nil).RequestServiceQuotaIncrease), shortfall)
+}
+
+// RunNetworkPreflight mocks base method.
+func (m *MockClient) RunNetworkPreflight(spec NetworkPreflightSpec) (*NetworkPreflightResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RunNetworkPreflight", spec)
+	ret0, _ := ret[0].(*NetworkPreflightResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RunNetworkPreflight indicates an expected call of RunNetworkPreflight.
+func (mr *MockClientMockRecorder) RunNetworkPreflight(spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunNetworkPreflight", reflect.TypeOf((*MockClient)(nil).RunNetworkPreflight), spec)
+}
+
 // TagUserRegion mocks base method.
 func (m *MockClient) TagUserRegion(username, region string) error {
 	m.ctrl.T.Helper()
@@ -1450,6 +1615,21 @@ func (mr *MockClientMockRecorder) ValidateSCP(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateSCP", reflect.TypeOf((*MockClient)(nil).ValidateSCP), arg0, arg1)
 }
 
+// VerifySharedVPCResources mocks base method.
+func (m *MockClient) VerifySharedVPCResources(spec SharedVPCSpec) (*NetworkPreflightResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifySharedVPCResources", spec)
+	ret0, _ := ret[0].(*NetworkPreflightResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifySharedVPCResources indicates an expected call of VerifySharedVPCResources.
+func (mr *MockClientMockRecorder) VerifySharedVPCResources(spec any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifySharedVPCResources", reflect.TypeOf((*MockClient)(nil).VerifySharedVPCResources), spec)
+}
+
 // MockAccessKeyGetter is a mock of AccessKeyGetter interface.
 type MockAccessKeyGetter struct {
 	ctrl     *gomock.Controller
@@ -60,6 +60,26 @@ func (mr *MockServiceQuotasApiClientMockRecorder) GetServiceQuota(ctx, params an
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetServiceQuota", reflect.TypeOf((*MockServiceQuotasApiClient)(nil).GetServiceQuota), varargs...)
 }
 
+// ListRequestedServiceQuotaChangeHistory mocks base method.
+func (m *MockServiceQuotasApiClient) ListRequestedServiceQuotaChangeHistory(ctx context.Context, params *servicequotas.ListRequestedServiceQuotaChangeHistoryInput, optFns ...func(*servicequotas.Options)) (*servicequotas.ListRequestedServiceQuotaChangeHistoryOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListRequestedServiceQuotaChangeHistory", varargs...)
+	ret0, _ := ret[0].(*servicequotas.ListRequestedServiceQuotaChangeHistoryOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRequestedServiceQuotaChangeHistory indicates an expected call of ListRequestedServiceQuotaChangeHistory.
+func (mr *MockServiceQuotasApiClientMockRecorder) ListRequestedServiceQuotaChangeHistory(ctx, params any, optFns ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRequestedServiceQuotaChangeHistory", reflect.TypeOf((*MockServiceQuotasApiClient)(nil).ListRequestedServiceQuotaChangeHistory), varargs...)
+}
+
 // ListServiceQuotas mocks base method.
 func (m *MockServiceQuotasApiClient) ListServiceQuotas(ctx context.Context, params *servicequotas.ListServiceQuotasInput, optFns ...func(*servicequotas.Options)) (*servicequotas.ListServiceQuotasOutput, error) {
 	m.ctrl.T.Helper()
@@ -79,3 +99,23 @@ func (mr *MockServiceQuotasApiClientMockRecorder) ListServiceQuotas(ctx, params
 	varargs := append([]any{ctx, params}, optFns...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListServiceQuotas", reflect.TypeOf((*MockServiceQuotasApiClient)(nil).ListServiceQuotas), varargs...)
 }
+
+// RequestServiceQuotaIncrease mocks base method.
+func (m *MockServiceQuotasApiClient) RequestServiceQuotaIncrease(ctx context.Context, params *servicequotas.RequestServiceQuotaIncreaseInput, optFns ...func(*servicequotas.Options)) (*servicequotas.RequestServiceQuotaIncreaseOutput, error) {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx, params}
+	for _, a := range optFns {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RequestServiceQuotaIncrease", varargs...)
+	ret0, _ := ret[0].(*servicequotas.RequestServiceQuotaIncreaseOutput)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestServiceQuotaIncrease indicates an expected call of RequestServiceQuotaIncrease.
+func (mr *MockServiceQuotasApiClientMockRecorder) RequestServiceQuotaIncrease(ctx, params any, optFns ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx, params}, optFns...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestServiceQuotaIncrease", reflect.TypeOf((*MockServiceQuotasApiClient)(nil).RequestServiceQuotaIncrease), varargs...)
+}
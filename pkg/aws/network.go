@@ -0,0 +1,269 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	cloudformationtypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/aws/smithy-go"
+)
+
+const (
+	NetworkTemplatePath = "templates/cloudformation/rosa_network.json"
+
+	// MaxNetworkAvailabilityZones is the number of availability zones supported by the network template
+	MaxNetworkAvailabilityZones = 3
+
+	// NAT gateways take several minutes to become available
+	networkStackMaxWaitDur = 20 * time.Minute
+
+	// The template splits the VPC CIDR in 6 subnets, so 3 bits are reserved for the subnet index
+	networkSubnetIndexBits = 3
+	minNetworkCIDRPrefix   = 16
+	maxNetworkCIDRPrefix   = 24
+)
+
+// NetworkStackSpec describes the network to create with the ROSA network CloudFormation template
+type NetworkStackSpec struct {
+	Name              string
+	VpcCIDR           string
+	AvailabilityZones []string
+}
+
+// NetworkStack holds the resources created by the ROSA network CloudFormation template
+type NetworkStack struct {
+	StackName      string   `json:"stack_name"`
+	VpcID          string   `json:"vpc_id"`
+	PublicSubnets  []string `json:"public_subnets"`
+	PrivateSubnets []string `json:"private_subnets"`
+}
+
+// SubnetIDs returns the subnets to use with '--subnet-ids'. Private clusters only use
+// the private subnets.
+func (n *NetworkStack) SubnetIDs(private bool) []string {
+	if private {
+		return n.PrivateSubnets
+	}
+	return append(append([]string{}, n.PrivateSubnets...), n.PublicSubnets...)
+}
+
+// ValidateNetworkStackSpec checks that the spec can be created by the network template
+func ValidateNetworkStackSpec(spec NetworkStackSpec) error {
+	if spec.Name == "" {
+		return fmt.Errorf("Network name is required")
+	}
+	if len(spec.AvailabilityZones) == 0 || len(spec.AvailabilityZones) > MaxNetworkAvailabilityZones {
+		return fmt.Errorf("Number of availability zones must be between 1 and %d", MaxNetworkAvailabilityZones)
+	}
+	_, err := networkSubnetBits(spec.VpcCIDR)
+	return err
+}
+
+// Returns the number of host bits of each subnet carved out of the VPC CIDR
+func networkSubnetBits(vpcCIDR string) (int, error) {
+	_, ipNet, err := net.ParseCIDR(vpcCIDR)
+	if err != nil {
+		return 0, fmt.Errorf("Invalid VPC CIDR '%s': %v", vpcCIDR, err)
+	}
+	prefix, bits := ipNet.Mask.Size()
+	if bits != 32 {
+		return 0, fmt.Errorf("Invalid VPC CIDR '%s': only IPv4 is supported", vpcCIDR)
+	}
+	if prefix < minNetworkCIDRPrefix || prefix > maxNetworkCIDRPrefix {
+		return 0, fmt.Errorf("Invalid VPC CIDR '%s': prefix length must be between /%d and /%d",
+			vpcCIDR, minNetworkCIDRPrefix, maxNetworkCIDRPrefix)
+	}
+	return bits - prefix - networkSubnetIndexBits, nil
+}
+
+// CreateNetworkStack creates a VPC with public and private subnets in each availability zone using
+// the ROSA network CloudFormation template. If the stack was already created with the same VPC CIDR and
+// availability zones it returns its resources.
+func (c *awsClient) CreateNetworkStack(spec NetworkStackSpec) (*NetworkStack, error) {
+	err := ValidateNetworkStackSpec(spec)
+	if err != nil {
+		return nil, err
+	}
+	subnetBits, _ := networkSubnetBits(spec.VpcCIDR)
+
+	existing, parameters, err := c.getNetworkStack(spec.Name)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		differences := networkStackSpecDifferences(spec, parameters)
+		if len(differences) > 0 {
+			return nil, fmt.Errorf("Network stack '%s' already exists with a different %s",
+				spec.Name, strings.Join(differences, " and "))
+		}
+		return existing, nil
+	}
+
+	cfTemplateBody, err := readCloudFormationTemplate(NetworkTemplatePath)
+	if err != nil {
+		return nil, err
+	}
+
+	// The template selects one availability zone per index, so the list always needs
+	// three entries even when fewer zones are used
+	availabilityZones := append([]string{}, spec.AvailabilityZones...)
+	for len(availabilityZones) < MaxNetworkAvailabilityZones {
+		availabilityZones = append(availabilityZones, spec.AvailabilityZones[0])
+	}
+
+	_, err = c.cfClient.CreateStack(context.Background(), &cloudformation.CreateStackInput{
+		StackName:    aws.String(spec.Name),
+		TemplateBody: aws.String(cfTemplateBody),
+		Parameters: []cloudformationtypes.Parameter{
+			buildStackParameter("NetworkName", spec.Name),
+			buildStackParameter("VpcCidr", spec.VpcCIDR),
+			buildStackParameter("SubnetBits", strconv.Itoa(subnetBits)),
+			buildStackParameter("AvailabilityZones", strings.Join(availabilityZones, ",")),
+			buildStackParameter("AvailabilityZoneCount", strconv.Itoa(len(spec.AvailabilityZones))),
+		},
+		Tags: []cloudformationtypes.Tag{
+			{
+				Key:   aws.String("rosa_network"),
+				Value: aws.String(spec.Name),
+			},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create network stack '%s': %v", spec.Name, err)
+	}
+
+	waiter := cloudformation.NewStackCreateCompleteWaiter(c.cfClient)
+	err = waiter.Wait(context.Background(), buildDescribeStacksInput(spec.Name), networkStackMaxWaitDur)
+	if err != nil {
+		return nil, fmt.Errorf("Failed waiting for network stack '%s' to be created: %v", spec.Name, err)
+	}
+
+	stack, err := c.GetNetworkStack(spec.Name)
+	if err != nil {
+		return nil, err
+	}
+	if stack == nil {
+		return nil, fmt.Errorf("Network stack '%s' not found after creation", spec.Name)
+	}
+	return stack, nil
+}
+
+// GetNetworkStack returns the resources of a network stack, or nil if the stack doesn't exist
+func (c *awsClient) GetNetworkStack(stackName string) (*NetworkStack, error) {
+	stack, _, err := c.getNetworkStack(stackName)
+	return stack, err
+}
+
+// getNetworkStack returns the resources of a network stack and the parameters it was created with
+func (c *awsClient) getNetworkStack(stackName string) (*NetworkStack, map[string]string, error) {
+	output, err := c.cfClient.DescribeStacks(context.Background(), buildDescribeStacksInput(stackName))
+	if err != nil {
+		var apiErr smithy.APIError
+		if errors.As(err, &apiErr) && apiErr.ErrorCode() == "ValidationError" &&
+			strings.Contains(apiErr.ErrorMessage(), "does not exist") {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+	if len(output.Stacks) == 0 || output.Stacks[0].StackStatus == cloudformationtypes.StackStatusDeleteComplete {
+		return nil, nil, nil
+	}
+
+	stack := output.Stacks[0]
+	if stack.StackStatus != cloudformationtypes.StackStatusCreateComplete &&
+		stack.StackStatus != cloudformationtypes.StackStatusUpdateComplete {
+		return nil, nil, fmt.Errorf("Network stack '%s' exists with status %s. Expected status is %s",
+			stackName, stack.StackStatus, cloudformationtypes.StackStatusCreateComplete)
+	}
+
+	parameters := map[string]string{}
+	for _, parameter := range stack.Parameters {
+		parameters[aws.ToString(parameter.ParameterKey)] = aws.ToString(parameter.ParameterValue)
+	}
+	return networkStackFromOutputs(stackName, stack.Outputs), parameters, nil
+}
+
+// networkStackSpecDifferences describes how the parameters of an existing network stack differ from
+// the spec
+func networkStackSpecDifferences(spec NetworkStackSpec, parameters map[string]string) []string {
+	var differences []string
+	vpcCIDR := parameters["VpcCidr"]
+	if canonicalCIDR(vpcCIDR) != canonicalCIDR(spec.VpcCIDR) {
+		differences = append(differences, fmt.Sprintf("VPC CIDR %s instead of %s", vpcCIDR, spec.VpcCIDR))
+	}
+
+	// The template only uses the first 'AvailabilityZoneCount' entries of the zones
+	var availabilityZones []string
+	if parameters["AvailabilityZones"] != "" {
+		availabilityZones = strings.Split(parameters["AvailabilityZones"], ",")
+	}
+	count, err := strconv.Atoi(parameters["AvailabilityZoneCount"])
+	if err == nil && count >= 0 && count < len(availabilityZones) {
+		availabilityZones = availabilityZones[:count]
+	}
+	if strings.Join(availabilityZones, ",") != strings.Join(spec.AvailabilityZones, ",") {
+		differences = append(differences, fmt.Sprintf("availability zones %s instead of %s",
+			strings.Join(availabilityZones, ", "), strings.Join(spec.AvailabilityZones, ", ")))
+	}
+	return differences
+}
+
+func canonicalCIDR(cidr string) string {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return cidr
+	}
+	return ipNet.String()
+}
+
+func networkStackFromOutputs(stackName string, outputs []cloudformationtypes.Output) *NetworkStack {
+	networkStack := &NetworkStack{StackName: stackName}
+	// Sort by key so that subnets are listed in availability zone order
+	sort.Slice(outputs, func(i, j int) bool {
+		return aws.ToString(outputs[i].OutputKey) < aws.ToString(outputs[j].OutputKey)
+	})
+	for _, output := range outputs {
+		key := aws.ToString(output.OutputKey)
+		value := aws.ToString(output.OutputValue)
+		switch {
+		case key == "VpcId":
+			networkStack.VpcID = value
+		case strings.HasPrefix(key, "PublicSubnet"):
+			networkStack.PublicSubnets = append(networkStack.PublicSubnets, value)
+		case strings.HasPrefix(key, "PrivateSubnet"):
+			networkStack.PrivateSubnets = append(networkStack.PrivateSubnets, value)
+		}
+	}
+	return networkStack
+}
+
+func buildStackParameter(key string, value string) cloudformationtypes.Parameter {
+	return cloudformationtypes.Parameter{
+		ParameterKey:   aws.String(key),
+		ParameterValue: aws.String(value),
+	}
+}
@@ -0,0 +1,416 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+
+	"github.com/openshift/rosa/pkg/helper"
+)
+
+type PreflightSeverity string
+
+const (
+	PreflightSeverityError   PreflightSeverity = "error"
+	PreflightSeverityWarning PreflightSeverity = "warning"
+
+	PreflightCheckSingleVPC     = "single-vpc"
+	PreflightCheckDNS           = "vpc-dns"
+	PreflightCheckCIDRs         = "cidr-overlap"
+	PreflightCheckPrivateEgress = "private-subnet-egress"
+	PreflightCheckPublicIngress = "public-subnet-ingress"
+	PreflightCheckAZCoverage    = "availability-zone-coverage"
+	PreflightCheckELBTags       = "elb-role-tags"
+
+	ELBRoleTag         = "kubernetes.io/role/elb"
+	InternalELBRoleTag = "kubernetes.io/role/internal-elb"
+
+	defaultRouteCIDR = "0.0.0.0/0"
+)
+
+// NetworkPreflightSpec describes the cluster networking that is checked against the VPC
+// of the given subnets. Empty CIDRs and availability zones are not checked.
+type NetworkPreflightSpec struct {
+	SubnetIDs         []string
+	AvailabilityZones []string
+	MachineCIDR       *net.IPNet
+	ServiceCIDR       *net.IPNet
+	PodCIDR           *net.IPNet
+	Private           bool
+	HostedCP          bool
+}
+
+type NetworkPreflightCheck struct {
+	Name     string            `json:"name"`
+	Target   string            `json:"target"`
+	Passed   bool              `json:"passed"`
+	Severity PreflightSeverity `json:"severity"`
+	Message  string            `json:"message"`
+}
+
+type NetworkPreflightResult struct {
+	VpcID  string                  `json:"vpc_id"`
+	Checks []NetworkPreflightCheck `json:"checks"`
+}
+
+// Failures returns the checks that didn't pass and block the cluster install
+func (r *NetworkPreflightResult) Failures() []NetworkPreflightCheck {
+	return r.filter(PreflightSeverityError)
+}
+
+// Warnings returns the checks that didn't pass but don't block the cluster install
+func (r *NetworkPreflightResult) Warnings() []NetworkPreflightCheck {
+	return r.filter(PreflightSeverityWarning)
+}
+
+func (r *NetworkPreflightResult) filter(severity PreflightSeverity) []NetworkPreflightCheck {
+	var checks []NetworkPreflightCheck
+	for _, check := range r.Checks {
+		if !check.Passed && check.Severity == severity {
+			checks = append(checks, check)
+		}
+	}
+	return checks
+}
+
+func (r *NetworkPreflightResult) add(name string, target string, passed bool, severity PreflightSeverity,
+	format string, a ...interface{}) {
+	r.Checks = append(r.Checks, NetworkPreflightCheck{
+		Name:     name,
+		Target:   target,
+		Passed:   passed,
+		Severity: severity,
+		Message:  fmt.Sprintf(format, a...),
+	})
+}
+
+// NetworkPreflightData holds the AWS resources the preflight checks are evaluated against
+type NetworkPreflightData struct {
+	Subnets      []ec2types.Subnet
+	Vpc          *ec2types.Vpc
+	DNSSupport   bool
+	DNSHostnames bool
+	RouteTables  []ec2types.RouteTable
+}
+
+// RunNetworkPreflight fetches the VPC of the given subnets and validates its topology locally,
+// without going through the OCM network verifier
+func (c *awsClient) RunNetworkPreflight(spec NetworkPreflightSpec) (*NetworkPreflightResult, error) {
+	if len(spec.SubnetIDs) == 0 {
+		return nil, fmt.Errorf("At least one subnet ID is required")
+	}
+	subnets, err := c.ListSubnets(spec.SubnetIDs...)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get subnets: %v", err)
+	}
+	data := NetworkPreflightData{Subnets: subnets}
+
+	vpcIDs := subnetVpcIDs(subnets)
+	if len(vpcIDs) != 1 {
+		return EvaluateNetworkPreflight(spec, data), nil
+	}
+	vpcID := vpcIDs[0]
+
+	vpcs, err := c.ec2Client.DescribeVpcs(context.Background(), &ec2.DescribeVpcsInput{
+		VpcIds: []string{vpcID},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get VPC '%s': %v", vpcID, err)
+	}
+	if len(vpcs.Vpcs) == 0 {
+		return nil, fmt.Errorf("Failed to find VPC '%s'", vpcID)
+	}
+	data.Vpc = &vpcs.Vpcs[0]
+
+	data.DNSSupport, err = c.getVpcAttribute(vpcID, ec2types.VpcAttributeNameEnableDnsSupport)
+	if err != nil {
+		return nil, err
+	}
+	data.DNSHostnames, err = c.getVpcAttribute(vpcID, ec2types.VpcAttributeNameEnableDnsHostnames)
+	if err != nil {
+		return nil, err
+	}
+
+	routeTables, err := c.ec2Client.DescribeRouteTables(context.Background(), &ec2.DescribeRouteTablesInput{
+		Filters: []ec2types.Filter{
+			{
+				Name:   aws.String("vpc-id"),
+				Values: []string{vpcID},
+			},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to get route tables of VPC '%s': %v", vpcID, err)
+	}
+	data.RouteTables = routeTables.RouteTables
+
+	return EvaluateNetworkPreflight(spec, data), nil
+}
+
+func (c *awsClient) getVpcAttribute(vpcID string, attribute ec2types.VpcAttributeName) (bool, error) {
+	output, err := c.ec2Client.DescribeVpcAttribute(context.Background(), &ec2.DescribeVpcAttributeInput{
+		VpcId:     aws.String(vpcID),
+		Attribute: attribute,
+	})
+	if err != nil {
+		return false, fmt.Errorf("Failed to get attribute '%s' of VPC '%s': %v", attribute, vpcID, err)
+	}
+	switch attribute {
+	case ec2types.VpcAttributeNameEnableDnsSupport:
+		return output.EnableDnsSupport != nil && aws.ToBool(output.EnableDnsSupport.Value), nil
+	case ec2types.VpcAttributeNameEnableDnsHostnames:
+		return output.EnableDnsHostnames != nil && aws.ToBool(output.EnableDnsHostnames.Value), nil
+	}
+	return false, fmt.Errorf("Unsupported VPC attribute '%s'", attribute)
+}
+
+// EvaluateNetworkPreflight runs the preflight checks of the spec against the given AWS resources
+func EvaluateNetworkPreflight(spec NetworkPreflightSpec, data NetworkPreflightData) *NetworkPreflightResult {
+	result := &NetworkPreflightResult{}
+
+	for _, subnetID := range spec.SubnetIDs {
+		if findSubnet(data.Subnets, subnetID) == nil {
+			result.add(PreflightCheckSingleVPC, subnetID, false, PreflightSeverityError,
+				"Subnet '%s' was not found", subnetID)
+		}
+	}
+	vpcIDs := subnetVpcIDs(data.Subnets)
+	if len(vpcIDs) != 1 || data.Vpc == nil {
+		result.add(PreflightCheckSingleVPC, strings.Join(vpcIDs, ","), false, PreflightSeverityError,
+			"Subnets must belong to a single VPC, found VPCs: %s", strings.Join(vpcIDs, ", "))
+		return result
+	}
+	result.VpcID = vpcIDs[0]
+	result.add(PreflightCheckSingleVPC, result.VpcID, true, PreflightSeverityError,
+		"All subnets belong to VPC '%s'", result.VpcID)
+
+	result.add(PreflightCheckDNS, result.VpcID, data.DNSSupport, PreflightSeverityError,
+		"DNS support must be enabled on VPC '%s'", result.VpcID)
+	result.add(PreflightCheckDNS, result.VpcID, data.DNSHostnames, PreflightSeverityError,
+		"DNS hostnames must be enabled on VPC '%s'", result.VpcID)
+
+	evaluateCIDRs(spec, data, result)
+
+	publicAZs := map[string]bool{}
+	privateAZs := map[string]bool{}
+	for _, subnet := range data.Subnets {
+		subnetID := aws.ToString(subnet.SubnetId)
+		routeTable := findSubnetRouteTable(subnetID, data.RouteTables)
+		if routeTable == nil {
+			result.add(PreflightCheckPrivateEgress, subnetID, false, PreflightSeverityError,
+				"Subnet '%s' is not associated with a route table", subnetID)
+			continue
+		}
+		defaultRoute := findDefaultRoute(routeTable)
+		if defaultRoute != nil && strings.HasPrefix(aws.ToString(defaultRoute.GatewayId), "igw") {
+			publicAZs[aws.ToString(subnet.AvailabilityZone)] = true
+			result.add(PreflightCheckPublicIngress, subnetID, true, PreflightSeverityError,
+				"Public subnet '%s' routes to internet gateway '%s'", subnetID, aws.ToString(defaultRoute.GatewayId))
+			result.add(PreflightCheckELBTags, subnetID, hasTag(subnet.Tags, ELBRoleTag), PreflightSeverityWarning,
+				"Public subnet '%s' should be tagged with '%s=1' to host public load balancers", subnetID, ELBRoleTag)
+			continue
+		}
+
+		privateAZs[aws.ToString(subnet.AvailabilityZone)] = true
+		evaluatePrivateEgress(subnetID, defaultRoute, result)
+		result.add(PreflightCheckELBTags, subnetID, hasTag(subnet.Tags, InternalELBRoleTag), PreflightSeverityWarning,
+			"Private subnet '%s' should be tagged with '%s=1' to host internal load balancers",
+			subnetID, InternalELBRoleTag)
+	}
+
+	evaluateAZCoverage(spec, publicAZs, privateAZs, result)
+
+	return result
+}
+
+func evaluateCIDRs(spec NetworkPreflightSpec, data NetworkPreflightData, result *NetworkPreflightResult) {
+	var vpcCIDRs []*net.IPNet
+	for _, association := range data.Vpc.CidrBlockAssociationSet {
+		_, vpcCIDR, err := net.ParseCIDR(aws.ToString(association.CidrBlock))
+		if err == nil {
+			vpcCIDRs = append(vpcCIDRs, vpcCIDR)
+		}
+	}
+
+	if spec.MachineCIDR != nil {
+		for _, subnet := range data.Subnets {
+			_, subnetCIDR, err := net.ParseCIDR(aws.ToString(subnet.CidrBlock))
+			if err != nil {
+				continue
+			}
+			subnetID := aws.ToString(subnet.SubnetId)
+			result.add(PreflightCheckCIDRs, subnetID, cidrContains(spec.MachineCIDR, subnetCIDR), PreflightSeverityError,
+				"Subnet '%s' CIDR %s must be within machine CIDR %s", subnetID, subnetCIDR, spec.MachineCIDR)
+		}
+	}
+
+	clusterCIDRs := []struct {
+		name string
+		cidr *net.IPNet
+	}{
+		{"Service", spec.ServiceCIDR},
+		{"Pod", spec.PodCIDR},
+	}
+	for i, clusterCIDR := range clusterCIDRs {
+		if clusterCIDR.cidr == nil {
+			continue
+		}
+		for _, vpcCIDR := range vpcCIDRs {
+			result.add(PreflightCheckCIDRs, result.VpcID, !cidrsOverlap(clusterCIDR.cidr, vpcCIDR),
+				PreflightSeverityError, "%s CIDR %s must not overlap with VPC CIDR %s",
+				clusterCIDR.name, clusterCIDR.cidr, vpcCIDR)
+		}
+		if spec.MachineCIDR != nil {
+			result.add(PreflightCheckCIDRs, clusterCIDR.name, !cidrsOverlap(clusterCIDR.cidr, spec.MachineCIDR),
+				PreflightSeverityError, "%s CIDR %s must not overlap with machine CIDR %s",
+				clusterCIDR.name, clusterCIDR.cidr, spec.MachineCIDR)
+		}
+		for _, other := range clusterCIDRs[i+1:] {
+			if other.cidr == nil {
+				continue
+			}
+			result.add(PreflightCheckCIDRs, clusterCIDR.name, !cidrsOverlap(clusterCIDR.cidr, other.cidr),
+				PreflightSeverityError, "%s CIDR %s must not overlap with %s CIDR %s",
+				clusterCIDR.name, clusterCIDR.cidr, other.name, other.cidr)
+		}
+	}
+}
+
+func evaluatePrivateEgress(subnetID string, defaultRoute *ec2types.Route, result *NetworkPreflightResult) {
+	switch {
+	case defaultRoute == nil:
+		result.add(PreflightCheckPrivateEgress, subnetID, false, PreflightSeverityError,
+			"Private subnet '%s' has no default route to a NAT gateway", subnetID)
+	case defaultRoute.State == ec2types.RouteStateBlackhole:
+		result.add(PreflightCheckPrivateEgress, subnetID, false, PreflightSeverityError,
+			"Private subnet '%s' default route is a blackhole", subnetID)
+	case aws.ToString(defaultRoute.NatGatewayId) != "":
+		result.add(PreflightCheckPrivateEgress, subnetID, true, PreflightSeverityError,
+			"Private subnet '%s' routes to NAT gateway '%s'", subnetID, aws.ToString(defaultRoute.NatGatewayId))
+	default:
+		// Egress through a transit gateway, a NAT instance or a firewall is valid but can't be
+		// verified locally, the OCM network verifier checks it
+		result.add(PreflightCheckPrivateEgress, subnetID, false, PreflightSeverityWarning,
+			"Private subnet '%s' default route does not target a NAT gateway, make sure it provides egress "+
+				"to the internet", subnetID)
+	}
+}
+
+// evaluateAZCoverage checks that each availability zone of the cluster has the subnets it needs. Hosted
+// control plane clusters only place nodes in the private subnets and the load balancers in any public
+// subnet, so their availability zones are the ones of the private subnets.
+func evaluateAZCoverage(spec NetworkPreflightSpec, publicAZs map[string]bool, privateAZs map[string]bool,
+	result *NetworkPreflightResult) {
+	if spec.HostedCP {
+		result.add(PreflightCheckAZCoverage, result.VpcID, len(privateAZs) > 0, PreflightSeverityError,
+			"Hosted control plane clusters require at least one private subnet")
+		for _, az := range spec.AvailabilityZones {
+			result.add(PreflightCheckAZCoverage, az, privateAZs[az], PreflightSeverityWarning,
+				"Availability zone '%s' has no private subnet, no machine pool can be created in it", az)
+		}
+		if !spec.Private {
+			result.add(PreflightCheckAZCoverage, result.VpcID, len(publicAZs) > 0, PreflightSeverityError,
+				"Public clusters require at least one public subnet")
+		}
+		return
+	}
+
+	availabilityZones := spec.AvailabilityZones
+	if len(availabilityZones) == 0 {
+		availabilityZones = helper.MapKeys(privateAZs)
+		sort.Strings(availabilityZones)
+	}
+	for _, az := range availabilityZones {
+		result.add(PreflightCheckAZCoverage, az, privateAZs[az], PreflightSeverityError,
+			"Availability zone '%s' must have a private subnet", az)
+		if !spec.Private {
+			result.add(PreflightCheckAZCoverage, az, publicAZs[az], PreflightSeverityError,
+				"Availability zone '%s' must have a public subnet for public clusters", az)
+		}
+	}
+}
+
+func subnetVpcIDs(subnets []ec2types.Subnet) []string {
+	vpcIDs := map[string]bool{}
+	for _, subnet := range subnets {
+		vpcIDs[aws.ToString(subnet.VpcId)] = true
+	}
+	ids := helper.MapKeys(vpcIDs)
+	sort.Strings(ids)
+	return ids
+}
+
+func findSubnet(subnets []ec2types.Subnet, subnetID string) *ec2types.Subnet {
+	for i := range subnets {
+		if aws.ToString(subnets[i].SubnetId) == subnetID {
+			return &subnets[i]
+		}
+	}
+	return nil
+}
+
+// Returns the route table explicitly associated with the subnet, or the main route table of the VPC
+func findSubnetRouteTable(subnetID string, routeTables []ec2types.RouteTable) *ec2types.RouteTable {
+	var mainRouteTable *ec2types.RouteTable
+	for i := range routeTables {
+		for _, association := range routeTables[i].Associations {
+			if aws.ToString(association.SubnetId) == subnetID {
+				return &routeTables[i]
+			}
+			if aws.ToBool(association.Main) {
+				mainRouteTable = &routeTables[i]
+			}
+		}
+	}
+	return mainRouteTable
+}
+
+func findDefaultRoute(routeTable *ec2types.RouteTable) *ec2types.Route {
+	for i := range routeTable.Routes {
+		if aws.ToString(routeTable.Routes[i].DestinationCidrBlock) == defaultRouteCIDR {
+			return &routeTable.Routes[i]
+		}
+	}
+	return nil
+}
+
+func hasTag(tags []ec2types.Tag, key string) bool {
+	for _, tag := range tags {
+		if aws.ToString(tag.Key) == key {
+			return true
+		}
+	}
+	return false
+}
+
+func cidrsOverlap(a *net.IPNet, b *net.IPNet) bool {
+	return a.Contains(b.IP) || b.Contains(a.IP)
+}
+
+func cidrContains(outer *net.IPNet, inner *net.IPNet) bool {
+	outerOnes, _ := outer.Mask.Size()
+	innerOnes, _ := inner.Mask.Size()
+	return outer.Contains(inner.IP) && outerOnes <= innerOnes
+}
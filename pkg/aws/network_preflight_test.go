@@ -0,0 +1,229 @@
+package aws
+
+import (
+	"net"
+
+	gomock "go.uber.org/mock/gomock"
+
+	awsSdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	ec2types "github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/rosa/pkg/aws/mocks"
+)
+
+var _ = Describe("Network preflight", func() {
+	parseCIDR := func(cidr string) *net.IPNet {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		Expect(err).NotTo(HaveOccurred())
+		return ipNet
+	}
+
+	subnet := func(id string, az string, cidr string, tags ...string) ec2types.Subnet {
+		var ec2Tags []ec2types.Tag
+		for _, tag := range tags {
+			ec2Tags = append(ec2Tags, ec2types.Tag{Key: awsSdk.String(tag), Value: awsSdk.String("1")})
+		}
+		return ec2types.Subnet{
+			SubnetId:         awsSdk.String(id),
+			VpcId:            awsSdk.String("vpc-1"),
+			AvailabilityZone: awsSdk.String(az),
+			CidrBlock:        awsSdk.String(cidr),
+			Tags:             ec2Tags,
+		}
+	}
+
+	routeTable := func(route ec2types.Route, subnetIDs ...string) ec2types.RouteTable {
+		var associations []ec2types.RouteTableAssociation
+		for _, subnetID := range subnetIDs {
+			associations = append(associations, ec2types.RouteTableAssociation{SubnetId: awsSdk.String(subnetID)})
+		}
+		route.DestinationCidrBlock = awsSdk.String("0.0.0.0/0")
+		return ec2types.RouteTable{
+			Associations: associations,
+			Routes: []ec2types.Route{
+				{DestinationCidrBlock: awsSdk.String("10.0.0.0/16"), GatewayId: awsSdk.String("local")},
+				route,
+			},
+		}
+	}
+
+	var (
+		spec NetworkPreflightSpec
+		data NetworkPreflightData
+	)
+
+	BeforeEach(func() {
+		spec = NetworkPreflightSpec{
+			SubnetIDs:         []string{"subnet-public-a", "subnet-private-a"},
+			AvailabilityZones: []string{"us-east-1a"},
+			MachineCIDR:       parseCIDR("10.0.0.0/16"),
+			ServiceCIDR:       parseCIDR("172.30.0.0/16"),
+			PodCIDR:           parseCIDR("10.128.0.0/14"),
+		}
+		data = NetworkPreflightData{
+			Subnets: []ec2types.Subnet{
+				subnet("subnet-public-a", "us-east-1a", "10.0.0.0/20", ELBRoleTag),
+				subnet("subnet-private-a", "us-east-1a", "10.0.128.0/20", InternalELBRoleTag),
+			},
+			Vpc: &ec2types.Vpc{
+				VpcId: awsSdk.String("vpc-1"),
+				CidrBlockAssociationSet: []ec2types.VpcCidrBlockAssociation{
+					{CidrBlock: awsSdk.String("10.0.0.0/16")},
+				},
+			},
+			DNSSupport:   true,
+			DNSHostnames: true,
+			RouteTables: []ec2types.RouteTable{
+				routeTable(ec2types.Route{GatewayId: awsSdk.String("igw-1")}, "subnet-public-a"),
+				routeTable(ec2types.Route{NatGatewayId: awsSdk.String("nat-1")}, "subnet-private-a"),
+			},
+		}
+	})
+
+	It("Passes with a valid topology", func() {
+		result := EvaluateNetworkPreflight(spec, data)
+		Expect(result.VpcID).To(Equal("vpc-1"))
+		Expect(result.Failures()).To(BeEmpty())
+		Expect(result.Warnings()).To(BeEmpty())
+	})
+
+	It("Fails when subnets belong to different VPCs", func() {
+		data.Subnets[1].VpcId = awsSdk.String("vpc-2")
+		result := EvaluateNetworkPreflight(spec, data)
+		Expect(result.Failures()).To(HaveLen(1))
+		Expect(result.Failures()[0].Message).To(Equal("Subnets must belong to a single VPC, found VPCs: vpc-1, vpc-2"))
+	})
+
+	It("Fails when DNS hostnames are disabled", func() {
+		data.DNSHostnames = false
+		result := EvaluateNetworkPreflight(spec, data)
+		Expect(result.Failures()).To(ConsistOf(HaveField("Message", "DNS hostnames must be enabled on VPC 'vpc-1'")))
+	})
+
+	It("Fails when cluster CIDRs overlap with the VPC or each other", func() {
+		spec.ServiceCIDR = parseCIDR("10.0.0.0/24")
+		spec.PodCIDR = parseCIDR("10.0.0.0/14")
+		result := EvaluateNetworkPreflight(spec, data)
+		Expect(result.Failures()).To(ContainElements(
+			HaveField("Message", "Service CIDR 10.0.0.0/24 must not overlap with VPC CIDR 10.0.0.0/16"),
+			HaveField("Message", "Service CIDR 10.0.0.0/24 must not overlap with machine CIDR 10.0.0.0/16"),
+			HaveField("Message", "Service CIDR 10.0.0.0/24 must not overlap with Pod CIDR 10.0.0.0/14"),
+			HaveField("Message", "Pod CIDR 10.0.0.0/14 must not overlap with VPC CIDR 10.0.0.0/16"),
+		))
+	})
+
+	It("Fails when subnets are outside of the machine CIDR", func() {
+		spec.MachineCIDR = parseCIDR("10.0.0.0/17")
+		result := EvaluateNetworkPreflight(spec, data)
+		Expect(result.Failures()).To(ConsistOf(HaveField("Message",
+			"Subnet 'subnet-private-a' CIDR 10.0.128.0/20 must be within machine CIDR 10.0.0.0/17")))
+	})
+
+	It("Fails when a private subnet has no route to a NAT gateway", func() {
+		data.RouteTables[1].Routes = data.RouteTables[1].Routes[:1]
+		result := EvaluateNetworkPreflight(spec, data)
+		Expect(result.Failures()).To(ConsistOf(HaveField("Message",
+			"Private subnet 'subnet-private-a' has no default route to a NAT gateway")))
+	})
+
+	It("Warns when a private subnet egresses through a transit gateway", func() {
+		data.RouteTables[1] = routeTable(ec2types.Route{TransitGatewayId: awsSdk.String("tgw-1")}, "subnet-private-a")
+		result := EvaluateNetworkPreflight(spec, data)
+		Expect(result.Failures()).To(BeEmpty())
+		Expect(result.Warnings()).To(ConsistOf(HaveField("Name", PreflightCheckPrivateEgress)))
+	})
+
+	It("Uses the main route table for subnets without an explicit association", func() {
+		data.RouteTables[1].Associations = []ec2types.RouteTableAssociation{{Main: awsSdk.Bool(true)}}
+		result := EvaluateNetworkPreflight(spec, data)
+		Expect(result.Failures()).To(BeEmpty())
+	})
+
+	It("Fails when an availability zone is not covered", func() {
+		spec.AvailabilityZones = []string{"us-east-1a", "us-east-1b"}
+		result := EvaluateNetworkPreflight(spec, data)
+		Expect(result.Failures()).To(ConsistOf(
+			HaveField("Message", "Availability zone 'us-east-1b' must have a private subnet"),
+			HaveField("Message", "Availability zone 'us-east-1b' must have a public subnet for public clusters"),
+		))
+	})
+
+	It("Only checks the availability zones of the private subnets for hosted control plane clusters", func() {
+		spec.HostedCP = true
+		spec.AvailabilityZones = []string{"us-east-1a", "us-east-1b"}
+		data.Subnets[0].AvailabilityZone = awsSdk.String("us-east-1b")
+		result := EvaluateNetworkPreflight(spec, data)
+		Expect(result.Failures()).To(BeEmpty())
+		Expect(result.Warnings()).To(ConsistOf(HaveField("Message",
+			"Availability zone 'us-east-1b' has no private subnet, no machine pool can be created in it")))
+	})
+
+	It("Requires a private subnet for hosted control plane clusters", func() {
+		spec.HostedCP = true
+		spec.SubnetIDs = []string{"subnet-public-a"}
+		data.Subnets = data.Subnets[:1]
+		result := EvaluateNetworkPreflight(spec, data)
+		Expect(result.Failures()).To(ConsistOf(HaveField("Message",
+			"Hosted control plane clusters require at least one private subnet")))
+	})
+
+	It("Does not require public subnets for private clusters", func() {
+		spec.Private = true
+		spec.SubnetIDs = []string{"subnet-private-a"}
+		data.Subnets = data.Subnets[1:]
+		result := EvaluateNetworkPreflight(spec, data)
+		Expect(result.Failures()).To(BeEmpty())
+	})
+
+	It("Warns when load balancer role tags are missing", func() {
+		data.Subnets[0].Tags = nil
+		result := EvaluateNetworkPreflight(spec, data)
+		Expect(result.Failures()).To(BeEmpty())
+		Expect(result.Warnings()).To(ConsistOf(HaveField("Message",
+			"Public subnet 'subnet-public-a' should be tagged with 'kubernetes.io/role/elb=1' to host public load balancers")))
+	})
+
+	It("Fetches the VPC resources of the subnets", func() {
+		mockCtrl := gomock.NewController(GinkgoT())
+		mockEc2API := mocks.NewMockEc2ApiClient(mockCtrl)
+		client := New(
+			awsSdk.Config{},
+			logrus.New(),
+			mocks.NewMockIamApiClient(mockCtrl),
+			mockEc2API,
+			mocks.NewMockOrganizationsApiClient(mockCtrl),
+			mocks.NewMockS3ApiClient(mockCtrl),
+			mocks.NewMockSecretsManagerApiClient(mockCtrl),
+			mocks.NewMockStsApiClient(mockCtrl),
+			mocks.NewMockCloudFormationApiClient(mockCtrl),
+			mocks.NewMockServiceQuotasApiClient(mockCtrl),
+			mocks.NewMockServiceQuotasApiClient(mockCtrl),
+			mocks.NewMockRoute53ApiClient(mockCtrl),
+			mocks.NewMockKmsApiClient(mockCtrl),
+			&AccessKey{},
+			false,
+		)
+		mockEc2API.EXPECT().DescribeSubnets(gomock.Any(), gomock.Any()).Return(
+			&ec2.DescribeSubnetsOutput{Subnets: data.Subnets}, nil)
+		mockEc2API.EXPECT().DescribeVpcs(gomock.Any(), gomock.Any()).Return(
+			&ec2.DescribeVpcsOutput{Vpcs: []ec2types.Vpc{*data.Vpc}}, nil)
+		mockEc2API.EXPECT().DescribeVpcAttribute(gomock.Any(), gomock.Any()).Return(
+			&ec2.DescribeVpcAttributeOutput{
+				EnableDnsSupport: &ec2types.AttributeBooleanValue{Value: awsSdk.Bool(true)},
+			}, nil)
+		mockEc2API.EXPECT().DescribeVpcAttribute(gomock.Any(), gomock.Any()).Return(
+			&ec2.DescribeVpcAttributeOutput{
+				EnableDnsHostnames: &ec2types.AttributeBooleanValue{Value: awsSdk.Bool(false)},
+			}, nil)
+		mockEc2API.EXPECT().DescribeRouteTables(gomock.Any(), gomock.Any()).Return(
+			&ec2.DescribeRouteTablesOutput{RouteTables: data.RouteTables}, nil)
+
+		result, err := client.RunNetworkPreflight(spec)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Failures()).To(ConsistOf(HaveField("Message", "DNS hostnames must be enabled on VPC 'vpc-1'")))
+	})
+})
@@ -0,0 +1,203 @@
+package aws
+
+import (
+	"context"
+
+	gomock "go.uber.org/mock/gomock"
+
+	awsSdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudformation"
+	cloudformationtypes "github.com/aws/aws-sdk-go-v2/service/cloudformation/types"
+	"github.com/aws/smithy-go"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/rosa/pkg/aws/mocks"
+)
+
+var _ = Describe("Network stack", func() {
+	var (
+		client    Client
+		mockCtrl  *gomock.Controller
+		mockCfAPI *mocks.MockCloudFormationApiClient
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockCfAPI = mocks.NewMockCloudFormationApiClient(mockCtrl)
+		client = New(
+			awsSdk.Config{},
+			logrus.New(),
+			mocks.NewMockIamApiClient(mockCtrl),
+			mocks.NewMockEc2ApiClient(mockCtrl),
+			mocks.NewMockOrganizationsApiClient(mockCtrl),
+			mocks.NewMockS3ApiClient(mockCtrl),
+			mocks.NewMockSecretsManagerApiClient(mockCtrl),
+			mocks.NewMockStsApiClient(mockCtrl),
+			mockCfAPI,
+			mocks.NewMockServiceQuotasApiClient(mockCtrl),
+			mocks.NewMockServiceQuotasApiClient(mockCtrl),
+			mocks.NewMockRoute53ApiClient(mockCtrl),
+			mocks.NewMockKmsApiClient(mockCtrl),
+			&AccessKey{},
+			false,
+		)
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	stackNotFound := &smithy.GenericAPIError{
+		Code:    "ValidationError",
+		Message: "Stack with id mynetwork does not exist",
+	}
+
+	completeStack := &cloudformation.DescribeStacksOutput{
+		Stacks: []cloudformationtypes.Stack{
+			{
+				StackName:   awsSdk.String("mynetwork"),
+				StackStatus: cloudformationtypes.StackStatusCreateComplete,
+				Parameters: []cloudformationtypes.Parameter{
+					buildStackParameter("NetworkName", "mynetwork"),
+					buildStackParameter("VpcCidr", "10.0.0.0/20"),
+					buildStackParameter("SubnetBits", "9"),
+					buildStackParameter("AvailabilityZones", "us-east-1a,us-east-1b,us-east-1a"),
+					buildStackParameter("AvailabilityZoneCount", "2"),
+				},
+				Outputs: []cloudformationtypes.Output{
+					{OutputKey: awsSdk.String("PrivateSubnet2"), OutputValue: awsSdk.String("subnet-private-2")},
+					{OutputKey: awsSdk.String("PublicSubnet1"), OutputValue: awsSdk.String("subnet-public-1")},
+					{OutputKey: awsSdk.String("PrivateSubnet1"), OutputValue: awsSdk.String("subnet-private-1")},
+					{OutputKey: awsSdk.String("VpcId"), OutputValue: awsSdk.String("vpc-1")},
+					{OutputKey: awsSdk.String("PublicSubnet2"), OutputValue: awsSdk.String("subnet-public-2")},
+				},
+			},
+		},
+	}
+
+	expectedNetwork := &NetworkStack{
+		StackName:      "mynetwork",
+		VpcID:          "vpc-1",
+		PublicSubnets:  []string{"subnet-public-1", "subnet-public-2"},
+		PrivateSubnets: []string{"subnet-private-1", "subnet-private-2"},
+	}
+
+	Context("ValidateNetworkStackSpec", func() {
+		It("Accepts a valid spec", func() {
+			Expect(ValidateNetworkStackSpec(NetworkStackSpec{
+				Name:              "mynetwork",
+				VpcCIDR:           "10.0.0.0/16",
+				AvailabilityZones: []string{"us-east-1a", "us-east-1b", "us-east-1c"},
+			})).To(Succeed())
+		})
+
+		It("Rejects too many availability zones", func() {
+			Expect(ValidateNetworkStackSpec(NetworkStackSpec{
+				Name:              "mynetwork",
+				VpcCIDR:           "10.0.0.0/16",
+				AvailabilityZones: []string{"a", "b", "c", "d"},
+			})).To(MatchError("Number of availability zones must be between 1 and 3"))
+		})
+
+		It("Rejects CIDRs too large or too small", func() {
+			for _, cidr := range []string{"10.0.0.0/8", "10.0.0.0/25", "fd00::/48", "invalid"} {
+				Expect(ValidateNetworkStackSpec(NetworkStackSpec{
+					Name:              "mynetwork",
+					VpcCIDR:           cidr,
+					AvailabilityZones: []string{"us-east-1a"},
+				})).NotTo(Succeed(), cidr)
+			}
+		})
+	})
+
+	Context("GetNetworkStack", func() {
+		It("Returns nil when the stack does not exist", func() {
+			mockCfAPI.EXPECT().DescribeStacks(gomock.Any(), gomock.Any()).Return(nil, stackNotFound)
+			network, err := client.GetNetworkStack("mynetwork")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(network).To(BeNil())
+		})
+
+		It("Returns the subnets in availability zone order", func() {
+			mockCfAPI.EXPECT().DescribeStacks(gomock.Any(), gomock.Any()).Return(completeStack, nil)
+			network, err := client.GetNetworkStack("mynetwork")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(network).To(Equal(expectedNetwork))
+			Expect(network.SubnetIDs(true)).To(Equal([]string{"subnet-private-1", "subnet-private-2"}))
+			Expect(network.SubnetIDs(false)).To(Equal([]string{
+				"subnet-private-1", "subnet-private-2", "subnet-public-1", "subnet-public-2",
+			}))
+		})
+
+		It("Fails when the stack is not complete", func() {
+			mockCfAPI.EXPECT().DescribeStacks(gomock.Any(), gomock.Any()).Return(
+				&cloudformation.DescribeStacksOutput{
+					Stacks: []cloudformationtypes.Stack{
+						{
+							StackName:   awsSdk.String("mynetwork"),
+							StackStatus: cloudformationtypes.StackStatusRollbackComplete,
+						},
+					},
+				}, nil)
+			_, err := client.GetNetworkStack("mynetwork")
+			Expect(err).To(MatchError(ContainSubstring("exists with status ROLLBACK_COMPLETE")))
+		})
+	})
+
+	Context("CreateNetworkStack", func() {
+		spec := NetworkStackSpec{
+			Name:              "mynetwork",
+			VpcCIDR:           "10.0.0.0/20",
+			AvailabilityZones: []string{"us-east-1a", "us-east-1b"},
+		}
+
+		It("Returns the existing stack without creating it again", func() {
+			mockCfAPI.EXPECT().DescribeStacks(gomock.Any(), gomock.Any()).Return(completeStack, nil)
+			network, err := client.CreateNetworkStack(spec)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(network).To(Equal(expectedNetwork))
+		})
+
+		It("Fails when the existing stack has a different CIDR or availability zones", func() {
+			mockCfAPI.EXPECT().DescribeStacks(gomock.Any(), gomock.Any()).Return(completeStack, nil)
+			_, err := client.CreateNetworkStack(NetworkStackSpec{
+				Name:              "mynetwork",
+				VpcCIDR:           "10.1.0.0/16",
+				AvailabilityZones: []string{"us-east-1a", "us-east-1b", "us-east-1c"},
+			})
+			Expect(err).To(MatchError("Network stack 'mynetwork' already exists with a different " +
+				"VPC CIDR 10.0.0.0/20 instead of 10.1.0.0/16 and " +
+				"availability zones us-east-1a, us-east-1b instead of us-east-1a, us-east-1b, us-east-1c"))
+		})
+
+		It("Creates the stack and returns its outputs", func() {
+			gomock.InOrder(
+				mockCfAPI.EXPECT().DescribeStacks(gomock.Any(), gomock.Any()).Return(nil, stackNotFound),
+				mockCfAPI.EXPECT().CreateStack(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, input *cloudformation.CreateStackInput,
+						_ ...func(*cloudformation.Options)) (*cloudformation.CreateStackOutput, error) {
+						parameters := map[string]string{}
+						for _, parameter := range input.Parameters {
+							parameters[*parameter.ParameterKey] = *parameter.ParameterValue
+						}
+						Expect(*input.StackName).To(Equal("mynetwork"))
+						Expect(parameters).To(Equal(map[string]string{
+							"NetworkName":           "mynetwork",
+							"VpcCidr":               "10.0.0.0/20",
+							"SubnetBits":            "9",
+							"AvailabilityZones":     "us-east-1a,us-east-1b,us-east-1a",
+							"AvailabilityZoneCount": "2",
+						}))
+						return &cloudformation.CreateStackOutput{}, nil
+					}),
+				mockCfAPI.EXPECT().DescribeStacks(gomock.Any(), gomock.Any(), gomock.Any()).Return(completeStack, nil),
+				mockCfAPI.EXPECT().DescribeStacks(gomock.Any(), gomock.Any()).Return(completeStack, nil),
+			)
+			network, err := client.CreateNetworkStack(spec)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(network).To(Equal(expectedNetwork))
+		})
+	})
+})
@@ -44,19 +44,21 @@ type PolicyStatement struct {
 	// you do not include this element, then the resource to which the action applies is the
 	// resource to which the policy is attached.
 	Resource interface{} `json:"Resource,omitempty"`
+	// Specify the circumstances under which the policy grants permission.
+	Condition map[string]interface{} `json:"Condition,omitempty"`
 }
 
 type PolicyStatementPrincipal struct {
 	// A service principal is an identifier that is used to grant permissions to a service.
 	// The identifier for a service principal includes the service name, and is usually in the
 	// following format: service-name.amazonaws.com
-	Service []string `json:"Service,omitempty"`
+	Service interface{} `json:"Service,omitempty"`
 	// You can specify an individual IAM role ARN (or array of role ARNs) as the principal.
 	// In IAM roles, the Principal element in the role's trust policy specifies who can assume the role.
 	// When you specify more than one principal in the element, you grant permissions to each principal.
 	AWS interface{} `json:"AWS,omitempty"`
 	// A federated principal uses a web identity token or SAML federation
-	Federated string `json:"Federated,omitempty"`
+	Federated interface{} `json:"Federated,omitempty"`
 }
 
 func NewPolicyDocument() *PolicyDocument {
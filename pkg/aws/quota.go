@@ -4,10 +4,13 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/servicequotas"
 	servicequotastypes "github.com/aws/aws-sdk-go-v2/service/servicequotas/types"
+
+	"github.com/openshift/rosa/pkg/helper"
 )
 
 type quota struct {
@@ -90,36 +93,156 @@ var serviceQuotaServices = []quota{
 	},
 }
 
+// QuotaShortfall describes a service quota whose applied value is lower than
+// the value required for cluster installs
+type QuotaShortfall struct {
+	ServiceCode  string  `json:"service_code"`
+	QuotaCode    string  `json:"quota_code"`
+	QuotaName    string  `json:"quota_name"`
+	CurrentValue float64 `json:"current_value"`
+	DesiredValue float64 `json:"desired_value"`
+}
+
+// QuotaIncreaseRequest describes a service quota increase request filed with AWS
+type QuotaIncreaseRequest struct {
+	ID           string     `json:"id"`
+	CaseID       string     `json:"case_id,omitempty"`
+	ServiceCode  string     `json:"service_code"`
+	QuotaCode    string     `json:"quota_code"`
+	QuotaName    string     `json:"quota_name"`
+	DesiredValue float64    `json:"desired_value"`
+	Status       string     `json:"status"`
+	Created      *time.Time `json:"created,omitempty"`
+	LastUpdated  *time.Time `json:"last_updated,omitempty"`
+}
+
+// IsOpen returns true if AWS has not yet resolved the quota increase request
+func (r QuotaIncreaseRequest) IsOpen() bool {
+	return r.Status == string(servicequotastypes.RequestStatusPending) ||
+		r.Status == string(servicequotastypes.RequestStatusCaseOpened)
+}
+
 // ValidateQuota
 func (c *awsClient) ValidateQuota() (bool, error) {
-	var invalidQuotas []string
+	shortfalls, err := c.GetQuotaShortfalls()
+	if err != nil {
+		return false, err
+	}
+
+	if len(shortfalls) > 0 {
+		var invalidQuotas []string
+		for _, shortfall := range shortfalls {
+			invalidQuotas = append(invalidQuotas, fmt.Sprintf(
+				"- Service %s quota code %s %s not valid, expected quota of at least %d, but got %d",
+				shortfall.ServiceCode, shortfall.QuotaCode, shortfall.QuotaName,
+				int(shortfall.DesiredValue), int(shortfall.CurrentValue)))
+		}
+		return false, fmt.Errorf("Service quota is insufficient for the following service quota codes:\n%s",
+			strings.Join(invalidQuotas, "\n"))
+	}
+
+	return true, nil
+}
+
+// GetQuotaShortfalls returns the service quotas needed for cluster installs whose
+// applied value is lower than the desired value
+func (c *awsClient) GetQuotaShortfalls() ([]QuotaShortfall, error) {
+	var shortfalls []QuotaShortfall
 	for _, quota := range serviceQuotaServices {
 		serviceQuotas, err := ListServiceQuotas(c, quota.ServiceCode)
 		if err != nil {
-			return false, fmt.Errorf("Error listing AWS service quotas: %s %v", quota.ServiceCode, err)
+			return nil, fmt.Errorf("Error listing AWS service quotas: %s %v", quota.ServiceCode, err)
 		}
 
 		serviceQuota, err := GetServiceQuota(serviceQuotas, quota.QuotaCode)
 		if err != nil || (serviceQuota).Value == nil {
-			return false, fmt.Errorf("Error getting AWS service quota: %s %v", quota.ServiceCode, err)
+			return nil, fmt.Errorf("Error getting AWS service quota: %s %v", quota.ServiceCode, err)
 		}
 
 		if *serviceQuota.Value < *quota.DesiredValue {
-			invalidQuotas = append(invalidQuotas, fmt.Sprintf(
-				"- Service %s quota code %s %s not valid, expected quota of at least %d, but got %d",
-				quota.ServiceCode, quota.QuotaCode, quota.QuotaName,
-				int(*quota.DesiredValue), int(*serviceQuota.Value)))
+			shortfalls = append(shortfalls, QuotaShortfall{
+				ServiceCode:  quota.ServiceCode,
+				QuotaCode:    quota.QuotaCode,
+				QuotaName:    quota.QuotaName,
+				CurrentValue: *serviceQuota.Value,
+				DesiredValue: *quota.DesiredValue,
+			})
+			continue
 		}
 
 		c.logger.Debug(fmt.Sprintf("Service %s quota code %s is ok", quota.ServiceCode, quota.QuotaCode))
 	}
 
-	if len(invalidQuotas) > 0 {
-		return false, fmt.Errorf("Service quota is insufficient for the following service quota codes:\n%s",
-			strings.Join(invalidQuotas, "\n"))
+	return shortfalls, nil
+}
+
+// RequestServiceQuotaIncrease files a request with AWS to raise the quota to the desired value
+func (c *awsClient) RequestServiceQuotaIncrease(shortfall QuotaShortfall) (QuotaIncreaseRequest, error) {
+	output, err := c.serviceQuotasClient.RequestServiceQuotaIncrease(context.Background(),
+		&servicequotas.RequestServiceQuotaIncreaseInput{
+			ServiceCode:  aws.String(shortfall.ServiceCode),
+			QuotaCode:    aws.String(shortfall.QuotaCode),
+			DesiredValue: aws.Float64(shortfall.DesiredValue),
+		})
+	if err != nil {
+		return QuotaIncreaseRequest{}, fmt.Errorf("Error requesting increase of AWS service quota: %s %s %v",
+			shortfall.ServiceCode, shortfall.QuotaCode, err)
+	}
+	if output.RequestedQuota == nil {
+		return QuotaIncreaseRequest{}, fmt.Errorf("No quota increase request returned for AWS service quota: %s %s",
+			shortfall.ServiceCode, shortfall.QuotaCode)
 	}
 
-	return true, nil
+	return quotaIncreaseRequestFromChange(*output.RequestedQuota), nil
+}
+
+// ListServiceQuotaIncreaseRequests returns the quota increase requests filed for the
+// service quotas verified for cluster installs
+func (c *awsClient) ListServiceQuotaIncreaseRequests() ([]QuotaIncreaseRequest, error) {
+	trackedQuotas := map[string]bool{}
+	var serviceCodes []string
+	for _, quota := range serviceQuotaServices {
+		if !helper.Contains(serviceCodes, quota.ServiceCode) {
+			serviceCodes = append(serviceCodes, quota.ServiceCode)
+		}
+		trackedQuotas[quota.ServiceCode+"/"+quota.QuotaCode] = true
+	}
+
+	var requests []QuotaIncreaseRequest
+	for _, serviceCode := range serviceCodes {
+		paginator := servicequotas.NewListRequestedServiceQuotaChangeHistoryPaginator(
+			c.serviceQuotasClient, &servicequotas.ListRequestedServiceQuotaChangeHistoryInput{
+				ServiceCode: aws.String(serviceCode),
+			})
+		for paginator.HasMorePages() {
+			page, err := paginator.NextPage(context.Background())
+			if err != nil {
+				return nil, fmt.Errorf("Error listing AWS service quota increase requests: %s %v", serviceCode, err)
+			}
+			for _, change := range page.RequestedQuotas {
+				if !trackedQuotas[serviceCode+"/"+aws.ToString(change.QuotaCode)] {
+					continue
+				}
+				requests = append(requests, quotaIncreaseRequestFromChange(change))
+			}
+		}
+	}
+
+	return requests, nil
+}
+
+func quotaIncreaseRequestFromChange(change servicequotastypes.RequestedServiceQuotaChange) QuotaIncreaseRequest {
+	return QuotaIncreaseRequest{
+		ID:           aws.ToString(change.Id),
+		CaseID:       aws.ToString(change.CaseId),
+		ServiceCode:  aws.ToString(change.ServiceCode),
+		QuotaCode:    aws.ToString(change.QuotaCode),
+		QuotaName:    aws.ToString(change.QuotaName),
+		DesiredValue: aws.ToFloat64(change.DesiredValue),
+		Status:       string(change.Status),
+		Created:      change.Created,
+		LastUpdated:  change.LastUpdated,
+	}
 }
 
 // ListServiceQuotas list available quotas for service
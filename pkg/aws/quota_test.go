@@ -0,0 +1,174 @@
+package aws
+
+import (
+	"context"
+	"time"
+
+	gomock "go.uber.org/mock/gomock"
+
+	awsSdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/servicequotas"
+	servicequotastypes "github.com/aws/aws-sdk-go-v2/service/servicequotas/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/rosa/pkg/aws/mocks"
+)
+
+var _ = Describe("Service quotas", func() {
+	var (
+		client            Client
+		mockCtrl          *gomock.Controller
+		mockServiceQuotas *mocks.MockServiceQuotasApiClient
+	)
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockServiceQuotas = mocks.NewMockServiceQuotasApiClient(mockCtrl)
+		client = New(
+			awsSdk.Config{},
+			logrus.New(),
+			mocks.NewMockIamApiClient(mockCtrl),
+			mocks.NewMockEc2ApiClient(mockCtrl),
+			mocks.NewMockOrganizationsApiClient(mockCtrl),
+			mocks.NewMockS3ApiClient(mockCtrl),
+			mocks.NewMockSecretsManagerApiClient(mockCtrl),
+			mocks.NewMockStsApiClient(mockCtrl),
+			mocks.NewMockCloudFormationApiClient(mockCtrl),
+			mockServiceQuotas,
+			mocks.NewMockServiceQuotasApiClient(mockCtrl),
+			mocks.NewMockRoute53ApiClient(mockCtrl),
+			mocks.NewMockKmsApiClient(mockCtrl),
+			&AccessKey{},
+			false,
+		)
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	mockServiceQuotaValues := func(values map[string]float64) {
+		mockServiceQuotas.EXPECT().ListServiceQuotas(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, input *servicequotas.ListServiceQuotasInput,
+				_ ...func(*servicequotas.Options)) (*servicequotas.ListServiceQuotasOutput, error) {
+				var quotas []servicequotastypes.ServiceQuota
+				for _, quota := range serviceQuotaServices {
+					if quota.ServiceCode != *input.ServiceCode {
+						continue
+					}
+					value := *quota.DesiredValue
+					if override, ok := values[quota.QuotaCode]; ok {
+						value = override
+					}
+					quotas = append(quotas, servicequotastypes.ServiceQuota{
+						QuotaCode: awsSdk.String(quota.QuotaCode),
+						Value:     awsSdk.Float64(value),
+					})
+				}
+				return &servicequotas.ListServiceQuotasOutput{Quotas: quotas}, nil
+			}).AnyTimes()
+	}
+
+	Context("GetQuotaShortfalls", func() {
+		It("Returns no shortfalls when all quotas are sufficient", func() {
+			mockServiceQuotaValues(map[string]float64{})
+			shortfalls, err := client.GetQuotaShortfalls()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(shortfalls).To(BeEmpty())
+
+			valid, err := client.ValidateQuota()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(valid).To(BeTrue())
+		})
+		It("Returns the quotas that are lower than desired", func() {
+			mockServiceQuotaValues(map[string]float64{"L-1216C47A": 32, "L-F678F1CE": 5})
+			shortfalls, err := client.GetQuotaShortfalls()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(shortfalls).To(Equal([]QuotaShortfall{
+				{
+					ServiceCode:  "ec2",
+					QuotaCode:    "L-1216C47A",
+					QuotaName:    "Running On-Demand Standard (A, C, D, H, I, M, R, T, Z) instances",
+					CurrentValue: 32,
+					DesiredValue: 100,
+				},
+			}))
+
+			valid, err := client.ValidateQuota()
+			Expect(valid).To(BeFalse())
+			Expect(err).To(MatchError(ContainSubstring(
+				"Service ec2 quota code L-1216C47A Running On-Demand Standard (A, C, D, H, I, M, R, T, Z) " +
+					"instances not valid, expected quota of at least 100, but got 32")))
+		})
+	})
+
+	Context("RequestServiceQuotaIncrease", func() {
+		It("Files a request for the desired value", func() {
+			created := time.Date(2024, 4, 1, 10, 0, 0, 0, time.UTC)
+			mockServiceQuotas.EXPECT().RequestServiceQuotaIncrease(gomock.Any(),
+				&servicequotas.RequestServiceQuotaIncreaseInput{
+					ServiceCode:  awsSdk.String("vpc"),
+					QuotaCode:    awsSdk.String("L-F678F1CE"),
+					DesiredValue: awsSdk.Float64(5),
+				}).Return(&servicequotas.RequestServiceQuotaIncreaseOutput{
+				RequestedQuota: &servicequotastypes.RequestedServiceQuotaChange{
+					Id:           awsSdk.String("request-id"),
+					ServiceCode:  awsSdk.String("vpc"),
+					QuotaCode:    awsSdk.String("L-F678F1CE"),
+					QuotaName:    awsSdk.String("VPCs per Region"),
+					DesiredValue: awsSdk.Float64(5),
+					Status:       servicequotastypes.RequestStatusPending,
+					Created:      &created,
+				},
+			}, nil)
+			request, err := client.RequestServiceQuotaIncrease(QuotaShortfall{
+				ServiceCode:  "vpc",
+				QuotaCode:    "L-F678F1CE",
+				QuotaName:    "VPCs per Region",
+				CurrentValue: 1,
+				DesiredValue: 5,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(request.ID).To(Equal("request-id"))
+			Expect(request.Status).To(Equal("PENDING"))
+			Expect(request.IsOpen()).To(BeTrue())
+			Expect(request.Created).To(Equal(&created))
+		})
+	})
+
+	Context("ListServiceQuotaIncreaseRequests", func() {
+		It("Returns only the requests for quotas needed by cluster installs", func() {
+			mockServiceQuotas.EXPECT().ListRequestedServiceQuotaChangeHistory(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, input *servicequotas.ListRequestedServiceQuotaChangeHistoryInput,
+					_ ...func(*servicequotas.Options)) (*servicequotas.ListRequestedServiceQuotaChangeHistoryOutput,
+					error) {
+					if *input.ServiceCode != "ec2" {
+						return &servicequotas.ListRequestedServiceQuotaChangeHistoryOutput{}, nil
+					}
+					return &servicequotas.ListRequestedServiceQuotaChangeHistoryOutput{
+						RequestedQuotas: []servicequotastypes.RequestedServiceQuotaChange{
+							{
+								Id:          awsSdk.String("tracked"),
+								ServiceCode: awsSdk.String("ec2"),
+								QuotaCode:   awsSdk.String("L-1216C47A"),
+								Status:      servicequotastypes.RequestStatusApproved,
+							},
+							{
+								Id:          awsSdk.String("untracked"),
+								ServiceCode: awsSdk.String("ec2"),
+								QuotaCode:   awsSdk.String("L-34B43A08"),
+								Status:      servicequotastypes.RequestStatusPending,
+							},
+						},
+					}, nil
+				}).Times(4)
+			requests, err := client.ListServiceQuotaIncreaseRequests()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(requests).To(HaveLen(1))
+			Expect(requests[0].ID).To(Equal("tracked"))
+			Expect(requests[0].IsOpen()).To(BeFalse())
+		})
+	})
+})
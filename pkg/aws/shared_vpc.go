@@ -0,0 +1,530 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package aws
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	route53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	awserr "github.com/openshift-online/ocm-common/pkg/aws/errors"
+	awsCommonUtils "github.com/openshift-online/ocm-common/pkg/aws/utils"
+	common "github.com/openshift-online/ocm-common/pkg/aws/validations"
+
+	"github.com/openshift/rosa/pkg/helper"
+)
+
+const (
+	SharedVPCRole     = "Shared-VPC"
+	SharedVPCRoleType = "shared_vpc"
+
+	SharedVPCCheckRole        = "shared-vpc-role"
+	SharedVPCCheckTrust       = "shared-vpc-trust-policy"
+	SharedVPCCheckPermissions = "shared-vpc-permissions"
+	SharedVPCCheckHostedZone  = "private-hosted-zone"
+
+	ingressOperatorNamespace = "openshift-ingress-operator"
+	ingressOperatorName      = "cloud-credentials"
+)
+
+// SharedVPCRoute53Actions are the Route 53 permissions the cluster needs in the VPC owner account
+// to manage the DNS records of the private hosted zone
+var SharedVPCRoute53Actions = []string{
+	"route53:ChangeResourceRecordSets",
+	"route53:ChangeTagsForResource",
+	"route53:GetAccountLimit",
+	"route53:GetChange",
+	"route53:GetHostedZone",
+	"route53:ListHostedZones",
+	"route53:ListHostedZonesByName",
+	"route53:ListResourceRecordSets",
+	"route53:ListTagsForResource",
+	"route53:UpdateHostedZoneComment",
+	"tag:GetResources",
+	"tag:UntagResources",
+}
+
+// GetSharedVPCRoleName returns the name of the role created in the VPC owner account
+func GetSharedVPCRoleName(prefix string) string {
+	return common.GetRoleName(prefix, SharedVPCRole)
+}
+
+// GetSharedVPCTrustedRoleARNs returns the roles of the cluster owner account that assume the shared VPC
+// role: the installer account role and the ingress operator role
+func GetSharedVPCTrustedRoleARNs(partition string, accountID string, accountRolePrefix string,
+	operatorRolePrefix string, hostedCP bool) []string {
+	installerRole := AccountRoles[InstallerAccountRole].Name
+	if hostedCP {
+		installerRole = HCPAccountRoles[HCPInstallerRole].Name
+	}
+	ingressRoleName := awsCommonUtils.TruncateRoleName(
+		fmt.Sprintf("%s-%s-%s", operatorRolePrefix, ingressOperatorNamespace, ingressOperatorName))
+	return []string{
+		GetRoleARN(accountID, common.GetRoleName(accountRolePrefix, installerRole), "", partition),
+		GetRoleARN(accountID, ingressRoleName, "", partition),
+	}
+}
+
+// SharedVPCTrustPolicy returns the trust policy allowing the given roles to assume the shared VPC role.
+// The principal is the account of the roles, restricted to the roles with an 'aws:PrincipalArn'
+// condition, as IAM rejects trust policies naming roles that don't exist yet and the ingress operator
+// role is usually created after the shared VPC role.
+func SharedVPCTrustPolicy(trustedRoleARNs []string) string {
+	statement := sharedVPCTrustStatement(trustedRoleARNs)
+	statement.Sid = "AssumeRole"
+	policy := NewPolicyDocument()
+	policy.Statement = append(policy.Statement, statement)
+	return marshalPolicyDocument(policy)
+}
+
+// sharedVPCTrustStatement returns the statement allowing the given roles to assume the shared VPC role
+func sharedVPCTrustStatement(trustedRoleARNs []string) PolicyStatement {
+	accounts := map[string]bool{}
+	for _, roleARN := range trustedRoleARNs {
+		accountARN, err := accountRootARN(roleARN)
+		if err == nil {
+			accounts[accountARN] = true
+		}
+	}
+	accountARNs := helper.MapKeys(accounts)
+	sort.Strings(accountARNs)
+
+	return PolicyStatement{
+		Effect:    "Allow",
+		Principal: &PolicyStatementPrincipal{AWS: accountARNs},
+		Action:    "sts:AssumeRole",
+		Condition: map[string]interface{}{
+			"ArnEquals": map[string]interface{}{
+				principalARNConditionKey: trustedRoleARNs,
+			},
+		},
+	}
+}
+
+// appendSharedVPCTrustStatement adds a statement trusting the given roles to a trust policy. The
+// existing statements and the other elements of the policy are kept as they are.
+func appendSharedVPCTrustStatement(document string, trustedRoleARNs []string) (string, error) {
+	policy := map[string]json.RawMessage{}
+	err := json.Unmarshal([]byte(document), &policy)
+	if err != nil {
+		return "", fmt.Errorf("Failed to parse trust policy: %v", err)
+	}
+	var statements []json.RawMessage
+	existing := bytes.TrimSpace(policy["Statement"])
+	switch {
+	case len(existing) == 0:
+	case existing[0] == '{':
+		statements = append(statements, existing)
+	default:
+		err = json.Unmarshal(existing, &statements)
+		if err != nil {
+			return "", fmt.Errorf("Failed to parse statements of trust policy: %v", err)
+		}
+	}
+	statement, err := json.Marshal(sharedVPCTrustStatement(trustedRoleARNs))
+	if err != nil {
+		return "", err
+	}
+	policy["Statement"], err = json.Marshal(append(statements, statement))
+	if err != nil {
+		return "", err
+	}
+	if _, ok := policy["Version"]; !ok {
+		policy["Version"] = json.RawMessage(`"2012-10-17"`)
+	}
+	data, err := json.MarshalIndent(policy, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// SharedVPCPermissionPolicy returns the Route 53 permissions of the shared VPC role
+func SharedVPCPermissionPolicy() string {
+	policy := NewPolicyDocument()
+	policy.AllowActions(SharedVPCRoute53Actions...)
+	return marshalPolicyDocument(policy)
+}
+
+// EnsureSharedVPCRole creates the shared VPC role or adds a statement trusting the missing roles to the
+// trust policy of the existing role, and sets its Route 53 permissions. It returns the ARN of the role.
+func (c *awsClient) EnsureSharedVPCRole(roleName string, trustedRoleARNs []string,
+	tagList map[string]string) (string, error) {
+	var roleARN string
+	output, err := c.iamClient.GetRole(context.Background(), &iam.GetRoleInput{
+		RoleName: aws.String(roleName),
+	})
+	switch {
+	case err != nil && awserr.IsNoSuchEntityException(err):
+		roleARN, err = c.createRole(roleName, SharedVPCTrustPolicy(trustedRoleARNs), "", tagList, "")
+		if err != nil {
+			return "", fmt.Errorf("Failed to create role '%s': %v", roleName, err)
+		}
+	case err != nil:
+		return "", err
+	default:
+		roleARN = aws.ToString(output.Role.Arn)
+		encodedPolicy := aws.ToString(output.Role.AssumeRolePolicyDocument)
+		principals, err := GetTrustedAWSPrincipals(encodedPolicy)
+		if err != nil {
+			return "", err
+		}
+		missing := missingTrustedRoles(principals, trustedRoleARNs)
+		if len(missing) > 0 {
+			document, err := url.QueryUnescape(encodedPolicy)
+			if err != nil {
+				return "", err
+			}
+			document, err = appendSharedVPCTrustStatement(document, missing)
+			if err != nil {
+				return "", err
+			}
+			_, err = c.iamClient.UpdateAssumeRolePolicy(context.Background(), &iam.UpdateAssumeRolePolicyInput{
+				RoleName:       aws.String(roleName),
+				PolicyDocument: aws.String(document),
+			})
+			if err != nil {
+				return "", fmt.Errorf("Failed to update trust policy of role '%s': %v", roleName, err)
+			}
+		}
+	}
+
+	err = c.PutRolePolicy(roleName, GetPolicyName(roleName), SharedVPCPermissionPolicy())
+	if err != nil {
+		return "", fmt.Errorf("Failed to set permissions of role '%s': %v", roleName, err)
+	}
+	return roleARN, nil
+}
+
+// GetTrustedAWSPrincipals returns the AWS principals allowed by a URL encoded trust policy. Statements
+// restricted with an 'aws:PrincipalArn' condition return the ARNs of the condition instead of their
+// principals.
+func GetTrustedAWSPrincipals(encodedPolicy string) ([]string, error) {
+	document, err := url.QueryUnescape(encodedPolicy)
+	if err != nil {
+		return nil, err
+	}
+	policy, err := ParsePolicyDocument(document)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse trust policy: %v", err)
+	}
+	var principals []string
+	for _, statement := range policy.Statement {
+		if statement.Effect != "Allow" || statement.Principal == nil {
+			continue
+		}
+		if principalARNs := conditionPrincipalARNs(statement.Condition); len(principalARNs) > 0 {
+			principals = append(principals, principalARNs...)
+			continue
+		}
+		principals = append(principals, statement.GetAWSPrincipals()...)
+	}
+	return principals, nil
+}
+
+const principalARNConditionKey = "aws:PrincipalArn"
+
+func conditionPrincipalARNs(condition map[string]interface{}) []string {
+	var arns []string
+	for operator, keys := range condition {
+		if !helper.Contains([]string{"ArnEquals", "ArnLike", "StringEquals", "StringLike"}, operator) {
+			continue
+		}
+		keyValues, ok := keys.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for key, value := range keyValues {
+			if !strings.EqualFold(key, principalARNConditionKey) {
+				continue
+			}
+			switch value := value.(type) {
+			case string:
+				arns = append(arns, value)
+			case []interface{}:
+				for _, el := range value {
+					if arn, ok := el.(string); ok {
+						arns = append(arns, arn)
+					}
+				}
+			}
+		}
+	}
+	sort.Strings(arns)
+	return arns
+}
+
+// accountRootARN returns the ARN of the root of the account of a role
+func accountRootARN(roleARN string) (string, error) {
+	parsed, err := arn.Parse(roleARN)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("arn:%s:iam::%s:root", parsed.Partition, parsed.AccountID), nil
+}
+
+// missingTrustedRoles returns the roles that the trusted principals don't cover. A principal covers
+// the role with the same ARN, the roles matching its wildcards, or all the roles of its account when
+// it is the account root.
+func missingTrustedRoles(principals []string, roleARNs []string) []string {
+	var missing []string
+	for _, roleARN := range roleARNs {
+		accountARN, _ := accountRootARN(roleARN)
+		trusted := false
+		for _, principal := range principals {
+			matched, err := path.Match(principal, roleARN)
+			if principal == roleARN || principal == accountARN || (err == nil && matched) {
+				trusted = true
+				break
+			}
+		}
+		if !trusted {
+			missing = append(missing, roleARN)
+		}
+	}
+	return missing
+}
+
+// getRoleInlinePolicy returns the document of an inline policy of a role
+func (c *awsClient) getRoleInlinePolicy(roleName string, policyName string) (*PolicyDocument, error) {
+	output, err := c.iamClient.GetRolePolicy(context.Background(), &iam.GetRolePolicyInput{
+		RoleName:   aws.String(roleName),
+		PolicyName: aws.String(policyName),
+	})
+	if err != nil {
+		return nil, err
+	}
+	document, err := url.QueryUnescape(aws.ToString(output.PolicyDocument))
+	if err != nil {
+		return nil, err
+	}
+	return ParsePolicyDocument(document)
+}
+
+// HostedZone holds the details of a Route 53 hosted zone that matter for shared VPC clusters
+type HostedZone struct {
+	ID      string   `json:"id"`
+	Name    string   `json:"name"`
+	Private bool     `json:"private"`
+	VpcIDs  []string `json:"vpc_ids"`
+}
+
+// EnsurePrivateHostedZone returns the private hosted zone with the given name associated with the VPC,
+// creating it if it doesn't exist
+func (c *awsClient) EnsurePrivateHostedZone(name string, vpcID string, vpcRegion string) (*HostedZone, error) {
+	dnsName := strings.TrimSuffix(name, ".") + "."
+	zones, err := c.route53Client.ListHostedZonesByName(context.Background(), &route53.ListHostedZonesByNameInput{
+		DNSName: aws.String(dnsName),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to list hosted zones: %v", err)
+	}
+	for _, zone := range zones.HostedZones {
+		if aws.ToString(zone.Name) != dnsName || zone.Config == nil || !zone.Config.PrivateZone {
+			continue
+		}
+		hostedZone, err := c.GetHostedZone(aws.ToString(zone.Id))
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range hostedZone.VpcIDs {
+			if id == vpcID {
+				return hostedZone, nil
+			}
+		}
+	}
+
+	output, err := c.route53Client.CreateHostedZone(context.Background(), &route53.CreateHostedZoneInput{
+		Name:            aws.String(dnsName),
+		CallerReference: aws.String(fmt.Sprintf("rosa-%s-%d", strings.TrimSuffix(name, "."), time.Now().Unix())),
+		HostedZoneConfig: &route53types.HostedZoneConfig{
+			PrivateZone: true,
+			Comment:     aws.String("Private hosted zone for ROSA shared VPC cluster"),
+		},
+		VPC: &route53types.VPC{
+			VPCId:     aws.String(vpcID),
+			VPCRegion: route53types.VPCRegion(vpcRegion),
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create hosted zone '%s': %v", name, err)
+	}
+	return &HostedZone{
+		ID:      trimHostedZoneID(aws.ToString(output.HostedZone.Id)),
+		Name:    aws.ToString(output.HostedZone.Name),
+		Private: true,
+		VpcIDs:  []string{vpcID},
+	}, nil
+}
+
+// GetHostedZone returns the hosted zone with the given ID and the VPCs it is associated with
+func (c *awsClient) GetHostedZone(id string) (*HostedZone, error) {
+	output, err := c.route53Client.GetHostedZone(context.Background(), &route53.GetHostedZoneInput{
+		Id: aws.String(id),
+	})
+	if err != nil {
+		return nil, err
+	}
+	hostedZone := &HostedZone{
+		ID:      trimHostedZoneID(aws.ToString(output.HostedZone.Id)),
+		Name:    aws.ToString(output.HostedZone.Name),
+		Private: output.HostedZone.Config != nil && output.HostedZone.Config.PrivateZone,
+	}
+	for _, vpc := range output.VPCs {
+		hostedZone.VpcIDs = append(hostedZone.VpcIDs, aws.ToString(vpc.VPCId))
+	}
+	sort.Strings(hostedZone.VpcIDs)
+	return hostedZone, nil
+}
+
+// SharedVPCSpec describes the resources of the VPC owner account a shared VPC cluster relies on.
+// An empty hosted zone name or VPC ID is not checked.
+type SharedVPCSpec struct {
+	RoleName        string
+	TrustedRoleARNs []string
+	HostedZoneID    string
+	HostedZoneName  string
+	VpcID           string
+}
+
+// SharedVPCData holds the AWS resources the shared VPC checks are evaluated against. A nil role,
+// policy or hosted zone means that it doesn't exist.
+type SharedVPCData struct {
+	Role       *iamtypes.Role
+	Policy     *PolicyDocument
+	HostedZone *HostedZone
+}
+
+// VerifySharedVPCResources checks that the role and private hosted zone of the VPC owner account
+// are set up for the cluster owner account
+func (c *awsClient) VerifySharedVPCResources(spec SharedVPCSpec) (*NetworkPreflightResult, error) {
+	data := SharedVPCData{}
+
+	output, err := c.iamClient.GetRole(context.Background(), &iam.GetRoleInput{
+		RoleName: aws.String(spec.RoleName),
+	})
+	if err != nil && !awserr.IsNoSuchEntityException(err) {
+		return nil, fmt.Errorf("Failed to get role '%s': %v", spec.RoleName, err)
+	}
+	if err == nil {
+		data.Role = output.Role
+		data.Policy, err = c.getRoleInlinePolicy(spec.RoleName, GetPolicyName(spec.RoleName))
+		if err != nil && !awserr.IsNoSuchEntityException(err) {
+			return nil, fmt.Errorf("Failed to get permissions of role '%s': %v", spec.RoleName, err)
+		}
+	}
+
+	data.HostedZone, err = c.GetHostedZone(spec.HostedZoneID)
+	if err != nil {
+		var notFound *route53types.NoSuchHostedZone
+		if !errors.As(err, &notFound) {
+			return nil, fmt.Errorf("Failed to get hosted zone '%s': %v", spec.HostedZoneID, err)
+		}
+		data.HostedZone = nil
+	}
+
+	return EvaluateSharedVPCResources(spec, data)
+}
+
+// EvaluateSharedVPCResources runs the shared VPC checks against already fetched AWS resources
+func EvaluateSharedVPCResources(spec SharedVPCSpec, data SharedVPCData) (*NetworkPreflightResult, error) {
+	result := &NetworkPreflightResult{VpcID: spec.VpcID}
+
+	if data.Role == nil {
+		result.add(SharedVPCCheckRole, spec.RoleName, false, PreflightSeverityError,
+			"Role '%s' does not exist in the VPC owner account", spec.RoleName)
+	} else {
+		result.add(SharedVPCCheckRole, spec.RoleName, true, PreflightSeverityError,
+			"Role '%s' exists", spec.RoleName)
+
+		principals, err := GetTrustedAWSPrincipals(aws.ToString(data.Role.AssumeRolePolicyDocument))
+		if err != nil {
+			return nil, err
+		}
+		missing := missingTrustedRoles(principals, spec.TrustedRoleARNs)
+		if len(missing) > 0 {
+			result.add(SharedVPCCheckTrust, spec.RoleName, false, PreflightSeverityError,
+				"Trust policy of role '%s' does not allow %s to assume it", spec.RoleName,
+				strings.Join(missing, ", "))
+		} else {
+			result.add(SharedVPCCheckTrust, spec.RoleName, true, PreflightSeverityError,
+				"Trust policy of role '%s' allows the installer and ingress operator roles", spec.RoleName)
+		}
+
+		switch {
+		case data.Policy == nil:
+			result.add(SharedVPCCheckPermissions, spec.RoleName, false, PreflightSeverityWarning,
+				"Role '%s' has no inline policy '%s', attached policies are not checked",
+				spec.RoleName, GetPolicyName(spec.RoleName))
+		case !data.Policy.IsActionAllowed("route53:ChangeResourceRecordSets"):
+			result.add(SharedVPCCheckPermissions, spec.RoleName, false, PreflightSeverityError,
+				"Role '%s' is not allowed to change Route 53 records", spec.RoleName)
+		default:
+			result.add(SharedVPCCheckPermissions, spec.RoleName, true, PreflightSeverityError,
+				"Role '%s' is allowed to change Route 53 records", spec.RoleName)
+		}
+	}
+
+	zone := data.HostedZone
+	zoneName := ""
+	if zone != nil {
+		zoneName = strings.TrimSuffix(zone.Name, ".")
+	}
+	expectedName := strings.TrimSuffix(spec.HostedZoneName, ".")
+	switch {
+	case zone == nil:
+		result.add(SharedVPCCheckHostedZone, spec.HostedZoneID, false, PreflightSeverityError,
+			"Hosted zone '%s' does not exist in the VPC owner account", spec.HostedZoneID)
+	case !zone.Private:
+		result.add(SharedVPCCheckHostedZone, spec.HostedZoneID, false, PreflightSeverityError,
+			"Hosted zone '%s' is not private", spec.HostedZoneID)
+	case spec.VpcID != "" && !helper.Contains(zone.VpcIDs, spec.VpcID):
+		result.add(SharedVPCCheckHostedZone, spec.HostedZoneID, false, PreflightSeverityError,
+			"Hosted zone '%s' is not associated with VPC '%s'", spec.HostedZoneID, spec.VpcID)
+	case expectedName != "" && zoneName != expectedName:
+		result.add(SharedVPCCheckHostedZone, spec.HostedZoneID, false, PreflightSeverityError,
+			"Hosted zone '%s' is named '%s' but '%s' is expected", spec.HostedZoneID, zoneName, expectedName)
+	default:
+		result.add(SharedVPCCheckHostedZone, spec.HostedZoneID, true, PreflightSeverityError,
+			"Hosted zone '%s' is a private hosted zone named '%s'", spec.HostedZoneID, zoneName)
+	}
+
+	return result, nil
+}
+
+// Route 53 returns hosted zone IDs prefixed with '/hostedzone/'
+func trimHostedZoneID(id string) string {
+	return strings.TrimPrefix(id, "/hostedzone/")
+}
+
+func marshalPolicyDocument(policy *PolicyDocument) string {
+	data, _ := json.MarshalIndent(policy, "", "  ")
+	return string(data)
+}
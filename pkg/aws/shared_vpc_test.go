@@ -0,0 +1,304 @@
+package aws
+
+import (
+	"encoding/json"
+	"net/url"
+
+	gomock "go.uber.org/mock/gomock"
+
+	awsSdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/iam"
+	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	route53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/rosa/pkg/aws/mocks"
+)
+
+var _ = Describe("Shared VPC", func() {
+	var (
+		client      Client
+		mockCtrl    *gomock.Controller
+		mockIam     *mocks.MockIamApiClient
+		mockRoute53 *mocks.MockRoute53ApiClient
+	)
+
+	const (
+		roleName     = "ManagedOpenShift-Shared-VPC-Role"
+		installerARN = "arn:aws:iam::123456789012:role/ManagedOpenShift-Installer-Role"
+		ingressARN   = "arn:aws:iam::123456789012:role/mycluster-openshift-ingress-operator-cloud-credentials"
+	)
+	trustedRoleARNs := []string{installerARN, ingressARN}
+
+	BeforeEach(func() {
+		mockCtrl = gomock.NewController(GinkgoT())
+		mockIam = mocks.NewMockIamApiClient(mockCtrl)
+		mockRoute53 = mocks.NewMockRoute53ApiClient(mockCtrl)
+		client = New(
+			awsSdk.Config{},
+			logrus.New(),
+			mockIam,
+			mocks.NewMockEc2ApiClient(mockCtrl),
+			mocks.NewMockOrganizationsApiClient(mockCtrl),
+			mocks.NewMockS3ApiClient(mockCtrl),
+			mocks.NewMockSecretsManagerApiClient(mockCtrl),
+			mocks.NewMockStsApiClient(mockCtrl),
+			mocks.NewMockCloudFormationApiClient(mockCtrl),
+			mocks.NewMockServiceQuotasApiClient(mockCtrl),
+			mocks.NewMockServiceQuotasApiClient(mockCtrl),
+			mockRoute53,
+			mocks.NewMockKmsApiClient(mockCtrl),
+			&AccessKey{},
+			false,
+		)
+	})
+
+	AfterEach(func() {
+		mockCtrl.Finish()
+	})
+
+	It("Builds the trusted role ARNs of the cluster owner account", func() {
+		Expect(GetSharedVPCRoleName("ManagedOpenShift")).To(Equal(roleName))
+		Expect(GetSharedVPCTrustedRoleARNs("aws", "123456789012", "ManagedOpenShift", "mycluster", false)).
+			To(Equal(trustedRoleARNs))
+		Expect(GetSharedVPCTrustedRoleARNs("aws", "123456789012", "ManagedOpenShift", "mycluster", true)[0]).
+			To(Equal("arn:aws:iam::123456789012:role/ManagedOpenShift-HCP-ROSA-Installer-Role"))
+	})
+
+	Context("Trust policy", func() {
+		It("Trusts the account of the roles restricted to the roles", func() {
+			policy, err := ParsePolicyDocument(SharedVPCTrustPolicy(trustedRoleARNs))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(policy.Statement).To(HaveLen(1))
+			Expect(policy.Statement[0].GetAWSPrincipals()).To(Equal([]string{"arn:aws:iam::123456789012:root"}))
+			Expect(GetTrustedAWSPrincipals(SharedVPCTrustPolicy(trustedRoleARNs))).To(Equal(trustedRoleARNs))
+		})
+
+		It("Understands the trust policies naming the roles and the accounts", func() {
+			rolePolicy := `{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", ` +
+				`"Principal": {"AWS": "` + installerARN + `"}, "Action": "sts:AssumeRole"}]}`
+			principals, err := GetTrustedAWSPrincipals(rolePolicy)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(missingTrustedRoles(principals, trustedRoleARNs)).To(Equal([]string{ingressARN}))
+
+			accountPolicy := `{"Version": "2012-10-17", "Statement": [{"Effect": "Allow", ` +
+				`"Principal": {"AWS": "arn:aws:iam::123456789012:root"}, "Action": "sts:AssumeRole"}]}`
+			principals, err = GetTrustedAWSPrincipals(accountPolicy)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(missingTrustedRoles(principals, trustedRoleARNs)).To(BeEmpty())
+		})
+	})
+
+	Context("EnsureSharedVPCRole", func() {
+		It("Creates the role when it doesn't exist", func() {
+			mockIam.EXPECT().GetRole(gomock.Any(), gomock.Any()).
+				Return(nil, &iamtypes.NoSuchEntityException{})
+			mockIam.EXPECT().CreateRole(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ interface{}, input *iam.CreateRoleInput, _ ...func(*iam.Options)) (*iam.CreateRoleOutput,
+					error) {
+					Expect(*input.RoleName).To(Equal(roleName))
+					Expect(GetTrustedAWSPrincipals(*input.AssumeRolePolicyDocument)).To(Equal(trustedRoleARNs))
+					return &iam.CreateRoleOutput{Role: &iamtypes.Role{
+						Arn: awsSdk.String("arn:aws:iam::210987654321:role/" + roleName),
+					}}, nil
+				})
+			mockIam.EXPECT().PutRolePolicy(gomock.Any(), gomock.Any()).Return(&iam.PutRolePolicyOutput{}, nil)
+
+			roleARN, err := client.EnsureSharedVPCRole(roleName, trustedRoleARNs, map[string]string{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(roleARN).To(Equal("arn:aws:iam::210987654321:role/" + roleName))
+		})
+
+		It("Adds the missing trusted roles to an existing role", func() {
+			existing := url.QueryEscape(SharedVPCTrustPolicy([]string{installerARN}))
+			mockIam.EXPECT().GetRole(gomock.Any(), gomock.Any()).Return(&iam.GetRoleOutput{Role: &iamtypes.Role{
+				Arn:                      awsSdk.String("arn:aws:iam::210987654321:role/" + roleName),
+				AssumeRolePolicyDocument: awsSdk.String(existing),
+			}}, nil)
+			mockIam.EXPECT().UpdateAssumeRolePolicy(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ interface{}, input *iam.UpdateAssumeRolePolicyInput,
+					_ ...func(*iam.Options)) (*iam.UpdateAssumeRolePolicyOutput, error) {
+					Expect(GetTrustedAWSPrincipals(*input.PolicyDocument)).To(Equal(trustedRoleARNs))
+					return &iam.UpdateAssumeRolePolicyOutput{}, nil
+				})
+			mockIam.EXPECT().PutRolePolicy(gomock.Any(), gomock.Any()).Return(&iam.PutRolePolicyOutput{}, nil)
+
+			_, err := client.EnsureSharedVPCRole(roleName, trustedRoleARNs, map[string]string{})
+			Expect(err).NotTo(HaveOccurred())
+		})
+		It("Keeps the existing statements of the trust policy", func() {
+			existing := `{"Version": "2012-10-17", "Statement": [` +
+				`{"Effect": "Allow", "Principal": {"AWS": "arn:aws:iam::999999999999:root"}, ` +
+				`"Action": "sts:AssumeRole", "Condition": {"StringEquals": {"sts:ExternalId": "my-id"}}}, ` +
+				`{"Effect": "Allow", "Principal": {"Service": "ec2.amazonaws.com"}, "Action": "sts:AssumeRole"}, ` +
+				`{"Effect": "Deny", "Principal": {"AWS": "*"}, "Action": "sts:TagSession"}]}`
+			mockIam.EXPECT().GetRole(gomock.Any(), gomock.Any()).Return(&iam.GetRoleOutput{Role: &iamtypes.Role{
+				Arn:                      awsSdk.String("arn:aws:iam::210987654321:role/" + roleName),
+				AssumeRolePolicyDocument: awsSdk.String(url.QueryEscape(existing)),
+			}}, nil)
+			mockIam.EXPECT().UpdateAssumeRolePolicy(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ interface{}, input *iam.UpdateAssumeRolePolicyInput,
+					_ ...func(*iam.Options)) (*iam.UpdateAssumeRolePolicyOutput, error) {
+					var policy, original struct {
+						Statement []map[string]interface{}
+					}
+					Expect(json.Unmarshal([]byte(*input.PolicyDocument), &policy)).To(Succeed())
+					Expect(json.Unmarshal([]byte(existing), &original)).To(Succeed())
+					Expect(policy.Statement).To(HaveLen(4))
+					Expect(policy.Statement[:3]).To(Equal(original.Statement))
+					Expect(policy.Statement[3]["Condition"]).To(Equal(map[string]interface{}{
+						"ArnEquals": map[string]interface{}{
+							"aws:PrincipalArn": []interface{}{installerARN, ingressARN},
+						},
+					}))
+					return &iam.UpdateAssumeRolePolicyOutput{}, nil
+				})
+			mockIam.EXPECT().PutRolePolicy(gomock.Any(), gomock.Any()).Return(&iam.PutRolePolicyOutput{}, nil)
+
+			_, err := client.EnsureSharedVPCRole(roleName, trustedRoleARNs, map[string]string{})
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Context("EnsurePrivateHostedZone", func() {
+		It("Reuses a private hosted zone associated with the VPC", func() {
+			mockRoute53.EXPECT().ListHostedZonesByName(gomock.Any(), gomock.Any()).Return(
+				&route53.ListHostedZonesByNameOutput{HostedZones: []route53types.HostedZone{
+					{
+						Id:     awsSdk.String("/hostedzone/ZPUBLIC"),
+						Name:   awsSdk.String("mycluster.example.com."),
+						Config: &route53types.HostedZoneConfig{PrivateZone: false},
+					},
+					{
+						Id:     awsSdk.String("/hostedzone/ZPRIVATE"),
+						Name:   awsSdk.String("mycluster.example.com."),
+						Config: &route53types.HostedZoneConfig{PrivateZone: true},
+					},
+				}}, nil)
+			mockRoute53.EXPECT().GetHostedZone(gomock.Any(), gomock.Any()).Return(&route53.GetHostedZoneOutput{
+				HostedZone: &route53types.HostedZone{
+					Id:     awsSdk.String("/hostedzone/ZPRIVATE"),
+					Name:   awsSdk.String("mycluster.example.com."),
+					Config: &route53types.HostedZoneConfig{PrivateZone: true},
+				},
+				VPCs: []route53types.VPC{{VPCId: awsSdk.String("vpc-1")}},
+			}, nil)
+
+			zone, err := client.EnsurePrivateHostedZone("mycluster.example.com", "vpc-1", "us-east-1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(zone).To(Equal(&HostedZone{
+				ID:      "ZPRIVATE",
+				Name:    "mycluster.example.com.",
+				Private: true,
+				VpcIDs:  []string{"vpc-1"},
+			}))
+		})
+
+		It("Creates a private hosted zone associated with the VPC", func() {
+			mockRoute53.EXPECT().ListHostedZonesByName(gomock.Any(), gomock.Any()).
+				Return(&route53.ListHostedZonesByNameOutput{}, nil)
+			mockRoute53.EXPECT().CreateHostedZone(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(_ interface{}, input *route53.CreateHostedZoneInput,
+					_ ...func(*route53.Options)) (*route53.CreateHostedZoneOutput, error) {
+					Expect(input.HostedZoneConfig.PrivateZone).To(BeTrue())
+					Expect(*input.VPC.VPCId).To(Equal("vpc-1"))
+					Expect(input.VPC.VPCRegion).To(Equal(route53types.VPCRegionUsEast1))
+					return &route53.CreateHostedZoneOutput{HostedZone: &route53types.HostedZone{
+						Id:   awsSdk.String("/hostedzone/ZNEW"),
+						Name: input.Name,
+					}}, nil
+				})
+
+			zone, err := client.EnsurePrivateHostedZone("mycluster.example.com", "vpc-1", "us-east-1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(zone.ID).To(Equal("ZNEW"))
+			Expect(zone.Name).To(Equal("mycluster.example.com."))
+		})
+	})
+
+	Context("EvaluateSharedVPCResources", func() {
+		spec := SharedVPCSpec{
+			RoleName:        roleName,
+			TrustedRoleARNs: trustedRoleARNs,
+			HostedZoneID:    "ZPRIVATE",
+			HostedZoneName:  "mycluster.example.com",
+			VpcID:           "vpc-1",
+		}
+		permissions := NewPolicyDocument()
+		permissions.AllowActions(SharedVPCRoute53Actions...)
+		permissions, _ = ParsePolicyDocument(marshalPolicyDocument(permissions))
+
+		validData := func() SharedVPCData {
+			return SharedVPCData{
+				Role: &iamtypes.Role{
+					AssumeRolePolicyDocument: awsSdk.String(url.QueryEscape(SharedVPCTrustPolicy(trustedRoleARNs))),
+				},
+				Policy: permissions,
+				HostedZone: &HostedZone{
+					ID:      "ZPRIVATE",
+					Name:    "mycluster.example.com.",
+					Private: true,
+					VpcIDs:  []string{"vpc-1"},
+				},
+			}
+		}
+
+		It("Passes when the resources are set up", func() {
+			result, err := EvaluateSharedVPCResources(spec, validData())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Checks).To(HaveLen(4))
+			Expect(result.Failures()).To(BeEmpty())
+			Expect(result.Warnings()).To(BeEmpty())
+		})
+
+		It("Fails when the ingress operator role is not trusted", func() {
+			data := validData()
+			data.Role.AssumeRolePolicyDocument = awsSdk.String(SharedVPCTrustPolicy([]string{installerARN}))
+			result, err := EvaluateSharedVPCResources(spec, data)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Failures()).To(HaveLen(1))
+			Expect(result.Failures()[0].Name).To(Equal(SharedVPCCheckTrust))
+			Expect(result.Failures()[0].Message).To(ContainSubstring(ingressARN))
+		})
+
+		It("Warns when the role has no inline policy", func() {
+			data := validData()
+			data.Policy = nil
+			result, err := EvaluateSharedVPCResources(spec, data)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Failures()).To(BeEmpty())
+			Expect(result.Warnings()).To(HaveLen(1))
+			Expect(result.Warnings()[0].Name).To(Equal(SharedVPCCheckPermissions))
+		})
+
+		It("Fails when the role doesn't exist", func() {
+			data := validData()
+			data.Role = nil
+			result, err := EvaluateSharedVPCResources(spec, data)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Failures()).To(HaveLen(1))
+			Expect(result.Failures()[0].Name).To(Equal(SharedVPCCheckRole))
+		})
+
+		It("Fails when the hosted zone is not associated with the VPC", func() {
+			data := validData()
+			data.HostedZone.VpcIDs = []string{"vpc-2"}
+			result, err := EvaluateSharedVPCResources(spec, data)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Failures()).To(HaveLen(1))
+			Expect(result.Failures()[0].Message).To(Equal("Hosted zone 'ZPRIVATE' is not associated with VPC 'vpc-1'"))
+		})
+
+		It("Fails when the hosted zone is public", func() {
+			data := validData()
+			data.HostedZone.Private = false
+			result, err := EvaluateSharedVPCResources(spec, data)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Failures()).To(HaveLen(1))
+			Expect(result.Failures()[0].Message).To(Equal("Hosted zone 'ZPRIVATE' is not private"))
+		})
+	})
+})
@@ -153,3 +153,23 @@ func IsBreakGlassCredentialSetViaCLI(cmd *pflag.FlagSet) bool {
 
 	return false
 }
+
+// GetStatus returns the status of the credential, reporting issued credentials past their expiration
+// as expired as the status is only updated periodically
+func GetStatus(credential *cmv1.BreakGlassCredential, now time.Time) cmv1.BreakGlassCredentialStatus {
+	expiration, ok := credential.GetExpirationTimestamp()
+	if credential.Status() == cmv1.BreakGlassCredentialStatusIssued && ok && !expiration.IsZero() &&
+		expiration.Before(now) {
+		return cmv1.BreakGlassCredentialStatusExpired
+	}
+	return credential.Status()
+}
+
+// IsUsable returns true when the credential is issued, or about to be, and not expired
+func IsUsable(credential *cmv1.BreakGlassCredential, now time.Time) bool {
+	switch GetStatus(credential, now) {
+	case cmv1.BreakGlassCredentialStatusCreated, cmv1.BreakGlassCredentialStatusIssued:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,231 @@
+package breakglasscredential
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ghodss/yaml"
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+)
+
+const (
+	kubeconfigOutFlag  = "kubeconfig-out"
+	mergeFlag          = "merge"
+	contextNameFlag    = "context-name"
+	kubeconfigEnvVar   = "KUBECONFIG"
+	kubeconfigFileMode = 0600
+)
+
+type KubeconfigArgs struct {
+	out         string
+	merge       bool
+	contextName string
+}
+
+// AddKubeconfigFlags adds the flags to write the kubeconfig of a break glass credential to a file
+// instead of printing it
+func AddKubeconfigFlags(cmd *cobra.Command) *KubeconfigArgs {
+	args := &KubeconfigArgs{}
+
+	cmd.Flags().StringVar(
+		&args.out,
+		kubeconfigOutFlag,
+		"",
+		"Write the kubeconfig of the break glass credential to this file instead of printing it. "+
+			"With '--merge' the kubeconfig is merged into this file.",
+	)
+
+	cmd.Flags().BoolVar(
+		&args.merge,
+		mergeFlag,
+		false,
+		"Merge the kubeconfig of the break glass credential into the kubeconfig file and switch to its context. "+
+			"Defaults to the first file of the KUBECONFIG environment variable or '~/.kube/config'.",
+	)
+
+	cmd.Flags().StringVar(
+		&args.contextName,
+		contextNameFlag,
+		"",
+		"Name of the context to merge the kubeconfig as. Defaults to '<cluster name>-break-glass-<username>'.",
+	)
+	return args
+}
+
+// Enabled returns true when the kubeconfig has to be written to a file
+func (a *KubeconfigArgs) Enabled() bool {
+	return a.out != "" || a.merge
+}
+
+// ConfirmOverwrite asks the user before the kubeconfig replaces an existing file. Merging keeps the
+// content of the file, so it isn't confirmed.
+func (a *KubeconfigArgs) ConfirmOverwrite() bool {
+	if a.merge || a.out == "" {
+		return true
+	}
+	if _, err := os.Stat(a.out); err != nil {
+		return true
+	}
+	return confirm.Confirm("overwrite the existing file '%s'", a.out)
+}
+
+// SaveKubeconfig writes or merges the kubeconfig as requested by the flags and returns the path of the file
+// and the name of the context the kubeconfig was merged as, if any
+func (a *KubeconfigArgs) SaveKubeconfig(kubeconfig string, clusterName string, username string) (string,
+	string, error) {
+	if !a.merge {
+		err := writeKubeconfigFile(a.out, []byte(kubeconfig))
+		return a.out, "", err
+	}
+
+	path := a.out
+	if path == "" {
+		var err error
+		path, err = DefaultKubeconfigPath()
+		if err != nil {
+			return "", "", err
+		}
+	}
+	contextName := a.contextName
+	if contextName == "" {
+		contextName = fmt.Sprintf("%s-break-glass-%s", clusterName, username)
+	}
+	err := MergeKubeconfig(path, kubeconfig, contextName)
+	return path, contextName, err
+}
+
+// DefaultKubeconfigPath returns the kubeconfig file used by kubectl and oc
+func DefaultKubeconfigPath() (string, error) {
+	for _, path := range filepath.SplitList(os.Getenv(kubeconfigEnvVar)) {
+		if path != "" {
+			return path, nil
+		}
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("Failed to find the home directory: %v", err)
+	}
+	return filepath.Join(home, ".kube", "config"), nil
+}
+
+// kubeconfigEntry is a named cluster, context or user of a kubeconfig file. Kubeconfig files are handled
+// as generic maps so that the fields that are not merged, like preferences and extensions, are kept.
+type kubeconfigEntry = map[string]interface{}
+
+// MergeKubeconfig adds the cluster, user and context of a single context kubeconfig to the kubeconfig
+// file, all of them named as the given context, and makes it the current context. Entries with the
+// same name are replaced.
+func MergeKubeconfig(path string, kubeconfig string, contextName string) error {
+	credential := map[string]interface{}{}
+	err := yaml.Unmarshal([]byte(kubeconfig), &credential)
+	if err != nil {
+		return fmt.Errorf("Failed to parse the kubeconfig of the break glass credential: %v", err)
+	}
+	contexts := getKubeconfigEntries(credential, "contexts")
+	if len(contexts) != 1 {
+		return fmt.Errorf("Expected the kubeconfig of the break glass credential to have one context, "+
+			"found %d", len(contexts))
+	}
+	context, _ := contexts[0]["context"].(map[string]interface{})
+	cluster := findKubeconfigEntry(getKubeconfigEntries(credential, "clusters"), fmt.Sprint(context["cluster"]))
+	user := findKubeconfigEntry(getKubeconfigEntries(credential, "users"), fmt.Sprint(context["user"]))
+	if cluster == nil || user == nil {
+		return fmt.Errorf("Expected the kubeconfig of the break glass credential to have the cluster " +
+			"and user of its context")
+	}
+
+	existing := map[string]interface{}{}
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("Failed to read kubeconfig '%s': %v", path, err)
+	}
+	if len(data) > 0 {
+		err = yaml.Unmarshal(data, &existing)
+		if err != nil {
+			return fmt.Errorf("Failed to parse kubeconfig '%s': %v", path, err)
+		}
+	}
+	if existing["apiVersion"] == nil {
+		existing["apiVersion"] = "v1"
+	}
+	if existing["kind"] == nil {
+		existing["kind"] = "Config"
+	}
+
+	mergedContext := map[string]interface{}{}
+	for key, value := range context {
+		mergedContext[key] = value
+	}
+	mergedContext["cluster"] = contextName
+	mergedContext["user"] = contextName
+	setKubeconfigEntry(existing, "clusters", renameKubeconfigEntry(cluster, contextName))
+	setKubeconfigEntry(existing, "users", renameKubeconfigEntry(user, contextName))
+	mergedContextEntry := renameKubeconfigEntry(contexts[0], contextName)
+	mergedContextEntry["context"] = mergedContext
+	setKubeconfigEntry(existing, "contexts", mergedContextEntry)
+	existing["current-context"] = contextName
+
+	data, err = yaml.Marshal(existing)
+	if err != nil {
+		return err
+	}
+	return writeKubeconfigFile(path, data)
+}
+
+// getKubeconfigEntries returns the entries of a list of a kubeconfig file, ignoring malformed ones
+func getKubeconfigEntries(config map[string]interface{}, list string) []kubeconfigEntry {
+	items, _ := config[list].([]interface{})
+	entries := make([]kubeconfigEntry, 0, len(items))
+	for _, item := range items {
+		if entry, ok := item.(map[string]interface{}); ok {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+func findKubeconfigEntry(entries []kubeconfigEntry, name string) kubeconfigEntry {
+	for _, entry := range entries {
+		if entry["name"] == name {
+			return entry
+		}
+	}
+	return nil
+}
+
+func renameKubeconfigEntry(entry kubeconfigEntry, name string) kubeconfigEntry {
+	renamed := kubeconfigEntry{}
+	for key, value := range entry {
+		renamed[key] = value
+	}
+	renamed["name"] = name
+	return renamed
+}
+
+// setKubeconfigEntry replaces the entry with the same name in a list of a kubeconfig file, or adds it
+func setKubeconfigEntry(config map[string]interface{}, list string, entry kubeconfigEntry) {
+	items, _ := config[list].([]interface{})
+	for i, item := range items {
+		if existing, ok := item.(map[string]interface{}); ok && existing["name"] == entry["name"] {
+			items[i] = entry
+			config[list] = items
+			return
+		}
+	}
+	config[list] = append(items, entry)
+}
+
+func writeKubeconfigFile(path string, data []byte) error {
+	err := os.MkdirAll(filepath.Dir(path), 0700)
+	if err != nil {
+		return fmt.Errorf("Failed to create directory of kubeconfig '%s': %v", path, err)
+	}
+	err = os.WriteFile(path, data, kubeconfigFileMode)
+	if err != nil {
+		return fmt.Errorf("Failed to write kubeconfig '%s': %v", path, err)
+	}
+	return nil
+}
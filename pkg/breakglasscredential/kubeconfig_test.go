@@ -0,0 +1,231 @@
+package breakglasscredential
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ghodss/yaml"
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	"github.com/spf13/pflag"
+
+	"github.com/openshift/rosa/pkg/interactive/confirm"
+)
+
+const credentialKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: cluster
+  cluster:
+    server: https://api.mycluster.example.com:443
+contexts:
+- name: admin
+  context:
+    cluster: cluster
+    user: admin
+    namespace: default
+users:
+- name: admin
+  user:
+    client-certificate-data: Y2VydA==
+    client-key-data: a2V5
+current-context: admin
+`
+
+const existingKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: other
+  cluster:
+    server: https://api.other.example.com:443
+contexts:
+- name: other
+  context:
+    cluster: other
+    user: other
+users:
+- name: other
+  user:
+    token: abc
+current-context: other
+`
+
+func readKubeconfig(path string) map[string]interface{} {
+	data, err := os.ReadFile(path)
+	Expect(err).NotTo(HaveOccurred())
+	config := map[string]interface{}{}
+	Expect(yaml.Unmarshal(data, &config)).To(Succeed())
+	return config
+}
+
+var _ = Describe("Break glass credential kubeconfig", func() {
+	var dir string
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+	})
+
+	Context("SaveKubeconfig", func() {
+		It("Writes the kubeconfig to the file", func() {
+			path := filepath.Join(dir, "kube", "config")
+			args := KubeconfigArgs{out: path}
+			saved, contextName, err := args.SaveKubeconfig(credentialKubeconfig, "mycluster", "admin")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(saved).To(Equal(path))
+			Expect(contextName).To(BeEmpty())
+			data, err := os.ReadFile(path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(Equal(credentialKubeconfig))
+			info, err := os.Stat(path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.Mode().Perm()).To(Equal(os.FileMode(0600)))
+		})
+
+		It("Merges the kubeconfig with a default context name", func() {
+			path := filepath.Join(dir, "config")
+			args := KubeconfigArgs{out: path, merge: true}
+			_, contextName, err := args.SaveKubeconfig(credentialKubeconfig, "mycluster", "admin")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(contextName).To(Equal("mycluster-break-glass-admin"))
+			config := readKubeconfig(path)
+			Expect(config["current-context"]).To(Equal("mycluster-break-glass-admin"))
+			Expect(getKubeconfigEntries(config, "contexts")).To(HaveLen(1))
+		})
+
+		It("Merges the kubeconfig into the file from the KUBECONFIG environment variable", func() {
+			path := filepath.Join(dir, "config")
+			GinkgoT().Setenv(kubeconfigEnvVar, path+string(os.PathListSeparator)+filepath.Join(dir, "other"))
+			args := KubeconfigArgs{merge: true, contextName: "break-glass"}
+			saved, contextName, err := args.SaveKubeconfig(credentialKubeconfig, "mycluster", "admin")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(saved).To(Equal(path))
+			Expect(contextName).To(Equal("break-glass"))
+			Expect(readKubeconfig(path)["current-context"]).To(Equal("break-glass"))
+		})
+	})
+
+	Context("ConfirmOverwrite", func() {
+		It("Doesn't ask when the file doesn't exist or is merged", func() {
+			path := filepath.Join(dir, "config")
+			Expect((&KubeconfigArgs{out: path}).ConfirmOverwrite()).To(BeTrue())
+			Expect(os.WriteFile(path, []byte(existingKubeconfig), 0600)).To(Succeed())
+			Expect((&KubeconfigArgs{out: path, merge: true}).ConfirmOverwrite()).To(BeTrue())
+		})
+
+		It("Overwrites an existing file when confirmed", func() {
+			path := filepath.Join(dir, "config")
+			Expect(os.WriteFile(path, []byte(existingKubeconfig), 0600)).To(Succeed())
+			flags := pflag.NewFlagSet("confirm", pflag.ContinueOnError)
+			confirm.AddFlag(flags)
+			Expect(flags.Set("yes", "true")).To(Succeed())
+			DeferCleanup(func() {
+				flags.Set("yes", "false")
+			})
+			Expect((&KubeconfigArgs{out: path}).ConfirmOverwrite()).To(BeTrue())
+		})
+	})
+
+	Context("MergeKubeconfig", func() {
+		It("Keeps the existing entries and adds the credential ones", func() {
+			path := filepath.Join(dir, "config")
+			Expect(os.WriteFile(path, []byte(existingKubeconfig), 0600)).To(Succeed())
+			Expect(MergeKubeconfig(path, credentialKubeconfig, "break-glass")).To(Succeed())
+
+			config := readKubeconfig(path)
+			Expect(config["current-context"]).To(Equal("break-glass"))
+			Expect(getKubeconfigEntries(config, "clusters")).To(HaveLen(2))
+			Expect(getKubeconfigEntries(config, "users")).To(HaveLen(2))
+			contexts := getKubeconfigEntries(config, "contexts")
+			Expect(contexts).To(HaveLen(2))
+			Expect(contexts[0]["name"]).To(Equal("other"))
+			context := findKubeconfigEntry(contexts, "break-glass")
+			Expect(context).NotTo(BeNil())
+			Expect(context["context"]).To(Equal(map[string]interface{}{
+				"cluster":   "break-glass",
+				"user":      "break-glass",
+				"namespace": "default",
+			}))
+			cluster := findKubeconfigEntry(getKubeconfigEntries(config, "clusters"), "break-glass")
+			Expect(cluster).NotTo(BeNil())
+			Expect(cluster["cluster"]).To(HaveKeyWithValue("server", "https://api.mycluster.example.com:443"))
+			user := findKubeconfigEntry(getKubeconfigEntries(config, "users"), "break-glass")
+			Expect(user).NotTo(BeNil())
+			Expect(user["user"]).To(HaveKeyWithValue("client-key-data", "a2V5"))
+		})
+
+		It("Keeps the fields it doesn't merge", func() {
+			path := filepath.Join(dir, "config")
+			Expect(os.WriteFile(path, []byte(existingKubeconfig+`preferences:
+  colors: true
+extensions:
+- name: tool
+  extension:
+    setting: value
+`), 0600)).To(Succeed())
+			Expect(MergeKubeconfig(path, credentialKubeconfig, "break-glass")).To(Succeed())
+
+			config := readKubeconfig(path)
+			Expect(config["preferences"]).To(Equal(map[string]interface{}{"colors": true}))
+			Expect(config["extensions"]).To(HaveLen(1))
+			Expect(config["apiVersion"]).To(Equal("v1"))
+			Expect(config["kind"]).To(Equal("Config"))
+		})
+
+		It("Replaces the entries with the same name", func() {
+			path := filepath.Join(dir, "config")
+			Expect(os.WriteFile(path, []byte(existingKubeconfig), 0600)).To(Succeed())
+			Expect(MergeKubeconfig(path, credentialKubeconfig, "other")).To(Succeed())
+
+			config := readKubeconfig(path)
+			Expect(config["current-context"]).To(Equal("other"))
+			Expect(getKubeconfigEntries(config, "clusters")).To(HaveLen(1))
+			Expect(getKubeconfigEntries(config, "users")).To(HaveLen(1))
+			Expect(getKubeconfigEntries(config, "contexts")).To(HaveLen(1))
+			Expect(getKubeconfigEntries(config, "users")[0]["user"]).NotTo(HaveKey("token"))
+			Expect(getKubeconfigEntries(config, "clusters")[0]["cluster"]).To(
+				HaveKeyWithValue("server", "https://api.mycluster.example.com:443"))
+		})
+
+		It("Fails when the credential kubeconfig has several contexts", func() {
+			path := filepath.Join(dir, "config")
+			err := MergeKubeconfig(path, existingKubeconfig+`- name: second
+  context:
+    cluster: other
+    user: other
+`, "break-glass")
+			Expect(err).To(HaveOccurred())
+			_, err = os.Stat(path)
+			Expect(os.IsNotExist(err)).To(BeTrue())
+		})
+	})
+
+	Context("GetStatus", func() {
+		now := time.Now()
+
+		It("Reports issued credentials past their expiration as expired", func() {
+			credential, err := cmv1.NewBreakGlassCredential().Status(cmv1.BreakGlassCredentialStatusIssued).
+				ExpirationTimestamp(now.Add(-time.Minute)).Build()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(GetStatus(credential, now)).To(Equal(cmv1.BreakGlassCredentialStatusExpired))
+			Expect(IsUsable(credential, now)).To(BeFalse())
+		})
+
+		It("Keeps the status of credentials that are not expired", func() {
+			credential, err := cmv1.NewBreakGlassCredential().Status(cmv1.BreakGlassCredentialStatusIssued).
+				ExpirationTimestamp(now.Add(time.Minute)).Build()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(GetStatus(credential, now)).To(Equal(cmv1.BreakGlassCredentialStatusIssued))
+			Expect(IsUsable(credential, now)).To(BeTrue())
+		})
+
+		It("Reports revoked credentials as not usable", func() {
+			credential, err := cmv1.NewBreakGlassCredential().Status(cmv1.BreakGlassCredentialStatusRevoked).
+				ExpirationTimestamp(now.Add(time.Minute)).Build()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(GetStatus(credential, now)).To(Equal(cmv1.BreakGlassCredentialStatusRevoked))
+			Expect(IsUsable(credential, now)).To(BeFalse())
+		})
+	})
+})
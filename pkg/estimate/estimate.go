@@ -0,0 +1,231 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package estimate
+
+import (
+	"fmt"
+)
+
+const (
+	DefaultHoursPerMonth = 730
+	DefaultDiskSizeGiB   = 300
+
+	CategoryEC2  = "EC2"
+	CategoryEBS  = "EBS"
+	CategoryELB  = "ELB"
+	CategoryNAT  = "NAT"
+	CategoryROSA = "ROSA"
+
+	controlPlaneNodes       = 3
+	controlPlaneDiskSizeGiB = 350
+	infraDiskSizeGiB        = 300
+
+	// Classic clusters expose the API through an external and an internal load balancer
+	// and the default ingress controller through a third one
+	classicLoadBalancers = 3
+	// Hosted control plane clusters only run the default ingress load balancer in the
+	// customer account
+	hostedCPLoadBalancers = 1
+)
+
+// Spec describes the cluster or machine pool to estimate
+type Spec struct {
+	Region   string
+	HostedCP bool
+	MultiAZ  bool
+	// MachinePoolOnly estimates only the cost of a machine pool added to an existing cluster
+	MachinePoolOnly bool
+	InstanceType    string
+	InstanceVCPU    int
+	MinReplicas     int
+	MaxReplicas     int
+	DiskSizeGiB     int
+	HoursPerMonth   float64
+}
+
+// LineItem is a single priced resource of an estimate
+type LineItem struct {
+	Category    string  `json:"category"`
+	Description string  `json:"description"`
+	MinQuantity float64 `json:"min_quantity"`
+	MaxQuantity float64 `json:"max_quantity"`
+	Unit        string  `json:"unit"`
+	UnitPrice   float64 `json:"unit_price"`
+	MinHourly   float64 `json:"min_hourly"`
+	MaxHourly   float64 `json:"max_hourly"`
+}
+
+// Estimate is the result of pricing a Spec
+type Estimate struct {
+	Region        string     `json:"region"`
+	Currency      string     `json:"currency"`
+	EffectiveDate string     `json:"pricing_effective_date,omitempty"`
+	HoursPerMonth float64    `json:"hours_per_month"`
+	Items         []LineItem `json:"items"`
+	MinHourly     float64    `json:"min_hourly"`
+	MaxHourly     float64    `json:"max_hourly"`
+	MinMonthly    float64    `json:"min_monthly"`
+	MaxMonthly    float64    `json:"max_monthly"`
+}
+
+// IsRange returns true if the estimate depends on the number of autoscaled nodes
+func (e *Estimate) IsRange() bool {
+	return e.MinHourly != e.MaxHourly
+}
+
+// Calculate prices the spec using the given pricing table
+func Calculate(spec Spec, table *PricingTable) (*Estimate, error) {
+	if spec.MinReplicas < 0 || spec.MaxReplicas < spec.MinReplicas {
+		return nil, fmt.Errorf("Invalid number of replicas: min %d, max %d", spec.MinReplicas, spec.MaxReplicas)
+	}
+	if spec.HoursPerMonth <= 0 {
+		spec.HoursPerMonth = DefaultHoursPerMonth
+	}
+	if spec.DiskSizeGiB <= 0 {
+		spec.DiskSizeGiB = DefaultDiskSizeGiB
+	}
+
+	region, err := table.Region(spec.Region)
+	if err != nil {
+		return nil, err
+	}
+	workerPrice, err := region.InstancePrice(spec.Region, spec.InstanceType)
+	if err != nil {
+		return nil, err
+	}
+
+	estimate := &Estimate{
+		Region:        spec.Region,
+		Currency:      table.Currency,
+		EffectiveDate: table.EffectiveDate,
+		HoursPerMonth: spec.HoursPerMonth,
+	}
+	gibHour := region.EBSGp3GiBMonth / spec.HoursPerMonth
+	minWorkers := float64(spec.MinReplicas)
+	maxWorkers := float64(spec.MaxReplicas)
+
+	estimate.add(CategoryEC2, fmt.Sprintf("Worker nodes (%s)", spec.InstanceType),
+		minWorkers, maxWorkers, "instance-hour", workerPrice)
+	estimate.add(CategoryEBS, fmt.Sprintf("Worker root volumes (%d GiB gp3)", spec.DiskSizeGiB),
+		minWorkers*float64(spec.DiskSizeGiB), maxWorkers*float64(spec.DiskSizeGiB), "GiB-hour", gibHour)
+
+	if !spec.MachinePoolOnly && !spec.HostedCP {
+		// Control plane and infra nodes run in the customer account and are sized by the
+		// number of worker nodes they need to support
+		controlPlaneType := classicControlPlaneInstanceType(spec.MaxReplicas)
+		controlPlanePrice, err := region.InstancePrice(spec.Region, controlPlaneType)
+		if err != nil {
+			return nil, err
+		}
+		estimate.add(CategoryEC2, fmt.Sprintf("Control plane nodes (%s)", controlPlaneType),
+			controlPlaneNodes, controlPlaneNodes, "instance-hour", controlPlanePrice)
+
+		infraNodes := float64(infraNodeCount(spec.MultiAZ))
+		infraType := classicInfraInstanceType(spec.MaxReplicas)
+		infraPrice, err := region.InstancePrice(spec.Region, infraType)
+		if err != nil {
+			return nil, err
+		}
+		estimate.add(CategoryEC2, fmt.Sprintf("Infra nodes (%s)", infraType),
+			infraNodes, infraNodes, "instance-hour", infraPrice)
+
+		systemDisks := controlPlaneNodes*controlPlaneDiskSizeGiB + infraNodes*infraDiskSizeGiB
+		estimate.add(CategoryEBS, "Control plane and infra root volumes (gp3)",
+			systemDisks, systemDisks, "GiB-hour", gibHour)
+	}
+
+	if !spec.MachinePoolOnly {
+		loadBalancers := float64(classicLoadBalancers)
+		if spec.HostedCP {
+			loadBalancers = hostedCPLoadBalancers
+		}
+		estimate.add(CategoryELB, "API and ingress load balancers",
+			loadBalancers, loadBalancers, "hour", region.LoadBalancerHour)
+
+		natGateways := float64(availabilityZoneCount(spec.MultiAZ))
+		estimate.add(CategoryNAT, "NAT gateways (one per availability zone)",
+			natGateways, natGateways, "hour", region.NATGatewayHour)
+
+		if spec.HostedCP {
+			estimate.add(CategoryROSA, "Hosted control plane fee",
+				1, 1, "hour", table.Rosa.HostedControlPlaneHour)
+		}
+	}
+
+	vcpus := float64(spec.InstanceVCPU)
+	estimate.add(CategoryROSA, fmt.Sprintf("Worker service fee (%d vCPU per node)", spec.InstanceVCPU),
+		minWorkers*vcpus, maxWorkers*vcpus, "vCPU-hour", table.Rosa.WorkerVCPUHour)
+
+	estimate.MinMonthly = estimate.MinHourly * spec.HoursPerMonth
+	estimate.MaxMonthly = estimate.MaxHourly * spec.HoursPerMonth
+	return estimate, nil
+}
+
+func (e *Estimate) add(category string, description string, minQuantity float64, maxQuantity float64,
+	unit string, unitPrice float64) {
+	item := LineItem{
+		Category:    category,
+		Description: description,
+		MinQuantity: minQuantity,
+		MaxQuantity: maxQuantity,
+		Unit:        unit,
+		UnitPrice:   unitPrice,
+		MinHourly:   minQuantity * unitPrice,
+		MaxHourly:   maxQuantity * unitPrice,
+	}
+	e.Items = append(e.Items, item)
+	e.MinHourly += item.MinHourly
+	e.MaxHourly += item.MaxHourly
+}
+
+func availabilityZoneCount(multiAZ bool) int {
+	if multiAZ {
+		return 3
+	}
+	return 1
+}
+
+func infraNodeCount(multiAZ bool) int {
+	if multiAZ {
+		return 3
+	}
+	return 2
+}
+
+// Sizing of the control plane nodes of classic clusters, based on the number of worker nodes
+func classicControlPlaneInstanceType(workers int) string {
+	switch {
+	case workers <= 25:
+		return "m5.2xlarge"
+	case workers <= 100:
+		return "m5.4xlarge"
+	default:
+		return "m5.8xlarge"
+	}
+}
+
+// Sizing of the infra nodes of classic clusters, based on the number of worker nodes
+func classicInfraInstanceType(workers int) string {
+	switch {
+	case workers <= 25:
+		return "r5.xlarge"
+	case workers <= 100:
+		return "r5.2xlarge"
+	default:
+		return "r5.4xlarge"
+	}
+}
@@ -0,0 +1,165 @@
+package estimate
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+const testPricingTable = `
+currency: USD
+effective_date: "2024-01-01"
+rosa:
+  worker_vcpu_hour: 0.05
+  hosted_control_plane_hour: 0.25
+regions:
+  us-east-1:
+    ebs_gp3_gib_month: 0.073
+    load_balancer_hour: 0.02
+    nat_gateway_hour: 0.04
+    instances:
+      m5.xlarge: 0.2
+      m5.2xlarge: 0.4
+      r5.xlarge: 0.25
+`
+
+var _ = Describe("Estimate", func() {
+	var table *PricingTable
+
+	BeforeEach(func() {
+		var err error
+		table, err = ParsePricingTable([]byte(testPricingTable))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Context("Pricing table", func() {
+		It("Loads the bundled pricing table", func() {
+			defaultTable, err := DefaultPricingTable()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(defaultTable.Currency).To(Equal("USD"))
+			region, err := defaultTable.Region("us-east-1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(region.Instances).To(HaveKey("m5.xlarge"))
+		})
+		It("Loads the pricing table from a file", func() {
+			path := filepath.Join(GinkgoT().TempDir(), "pricing.yaml")
+			Expect(os.WriteFile(path, []byte(testPricingTable), 0600)).To(Succeed())
+			loaded, err := LoadPricingTable(path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(loaded).To(Equal(table))
+		})
+		It("Rejects a pricing table that can't be downloaded", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(testPricingTable))
+			}))
+			DeferCleanup(server.Close)
+			_, err := RefreshPricingTable(server.URL)
+			Expect(err).To(MatchError("Failed to download pricing table: 404 Not Found"))
+		})
+		It("Times out downloading a pricing table", func() {
+			release := make(chan struct{})
+			server := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+				<-release
+			}))
+			// Cleanups run in reverse order, so the handler is released before the server is closed
+			DeferCleanup(server.Close)
+			DeferCleanup(func() {
+				close(release)
+			})
+			timeout := pricingHTTPClient.Timeout
+			pricingHTTPClient.Timeout = 10 * time.Millisecond
+			DeferCleanup(func() {
+				pricingHTTPClient.Timeout = timeout
+			})
+			_, err := RefreshPricingTable(server.URL)
+			Expect(err).To(MatchError(ContainSubstring("Failed to download pricing table")))
+			Expect(err).To(MatchError(ContainSubstring("Client.Timeout exceeded")))
+		})
+		It("Rejects a pricing table without regions", func() {
+			_, err := ParsePricingTable([]byte("currency: USD\n"))
+			Expect(err).To(MatchError("Pricing table does not contain any region"))
+		})
+		It("Fails for unknown regions and instance types", func() {
+			_, err := table.Region("ap-south-2")
+			Expect(err).To(MatchError(ContainSubstring("no prices for region 'ap-south-2'")))
+			region, err := table.Region("us-east-1")
+			Expect(err).NotTo(HaveOccurred())
+			_, err = region.InstancePrice("us-east-1", "x2.huge")
+			Expect(err).To(MatchError(ContainSubstring("no price for instance type 'x2.huge'")))
+		})
+	})
+
+	Context("Calculate", func() {
+		It("Estimates a single AZ classic cluster", func() {
+			result, err := Calculate(Spec{
+				Region:        "us-east-1",
+				InstanceType:  "m5.xlarge",
+				InstanceVCPU:  4,
+				MinReplicas:   2,
+				MaxReplicas:   2,
+				HoursPerMonth: 730,
+			}, table)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.IsRange()).To(BeFalse())
+			Expect(result.Items).To(HaveLen(8))
+			// Workers 2*0.2, control plane 3*0.4, infra 2*0.25,
+			// volumes (2*300 + 3*350 + 2*300) GiB at 0.073/730, 3 load balancers,
+			// 1 NAT gateway and 8 vCPU of service fee
+			expected := 0.4 + 1.2 + 0.5 + 2250*0.0001 + 0.06 + 0.04 + 0.4
+			Expect(result.MinHourly).To(BeNumerically("~", expected, 1e-9))
+			Expect(result.MaxMonthly).To(BeNumerically("~", expected*730, 1e-6))
+		})
+		It("Estimates a hosted control plane cluster with autoscaling", func() {
+			result, err := Calculate(Spec{
+				Region:       "us-east-1",
+				HostedCP:     true,
+				InstanceType: "m5.2xlarge",
+				InstanceVCPU: 8,
+				MinReplicas:  2,
+				MaxReplicas:  4,
+				DiskSizeGiB:  100,
+			}, table)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.IsRange()).To(BeTrue())
+			Expect(result.HoursPerMonth).To(BeEquivalentTo(DefaultHoursPerMonth))
+			for _, item := range result.Items {
+				Expect(item.Description).NotTo(ContainSubstring("Control plane nodes"))
+			}
+			fixed := 0.02 + 0.04 + 0.25
+			perNode := 0.4 + 100*0.0001 + 8*0.05
+			Expect(result.MinHourly).To(BeNumerically("~", fixed+2*perNode, 1e-9))
+			Expect(result.MaxHourly).To(BeNumerically("~", fixed+4*perNode, 1e-9))
+		})
+		It("Estimates only the machine pool resources", func() {
+			result, err := Calculate(Spec{
+				Region:          "us-east-1",
+				MultiAZ:         true,
+				MachinePoolOnly: true,
+				InstanceType:    "r5.xlarge",
+				InstanceVCPU:    4,
+				MinReplicas:     3,
+				MaxReplicas:     3,
+			}, table)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Items).To(HaveLen(3))
+			Expect(result.Items[0].Category).To(Equal(CategoryEC2))
+			Expect(result.Items[1].Category).To(Equal(CategoryEBS))
+			Expect(result.Items[2].Category).To(Equal(CategoryROSA))
+		})
+		It("Fails with an invalid replica range", func() {
+			_, err := Calculate(Spec{
+				Region:       "us-east-1",
+				InstanceType: "m5.xlarge",
+				MinReplicas:  3,
+				MaxReplicas:  2,
+			}, table)
+			Expect(err).To(MatchError("Invalid number of replicas: min 3, max 2"))
+		})
+	})
+})
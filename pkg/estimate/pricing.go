@@ -0,0 +1,195 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package estimate
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/ghodss/yaml"
+
+	"github.com/openshift/rosa/assets"
+)
+
+const (
+	// DefaultPricingAsset is the pricing table bundled with the binary
+	DefaultPricingAsset = "templates/pricing/aws_pricing.yaml"
+
+	// PricingFileEnvVar allows overriding the pricing table without passing a flag
+	PricingFileEnvVar = "ROSA_PRICING_FILE"
+
+	cachedPricingFile = "pricing.yaml"
+)
+
+// pricingHTTPClient downloads the pricing tables, the timeout keeps a refresh from hanging on an
+// unresponsive server
+var pricingHTTPClient = &http.Client{
+	Timeout: 30 * time.Second,
+}
+
+// PricingTable holds the prices used to estimate the cost of a cluster
+type PricingTable struct {
+	Currency      string                   `json:"currency"`
+	EffectiveDate string                   `json:"effective_date"`
+	Rosa          RosaPricing              `json:"rosa"`
+	Regions       map[string]RegionPricing `json:"regions"`
+}
+
+// RosaPricing holds the ROSA service fees
+type RosaPricing struct {
+	WorkerVCPUHour         float64 `json:"worker_vcpu_hour"`
+	HostedControlPlaneHour float64 `json:"hosted_control_plane_hour"`
+}
+
+// RegionPricing holds the AWS infrastructure prices of a single region
+type RegionPricing struct {
+	EBSGp3GiBMonth   float64            `json:"ebs_gp3_gib_month"`
+	LoadBalancerHour float64            `json:"load_balancer_hour"`
+	NATGatewayHour   float64            `json:"nat_gateway_hour"`
+	Instances        map[string]float64 `json:"instances"`
+}
+
+// Region returns the prices for the region or an error if the table doesn't include it
+func (p *PricingTable) Region(region string) (RegionPricing, error) {
+	pricing, ok := p.Regions[region]
+	if !ok {
+		return RegionPricing{}, fmt.Errorf("Pricing table has no prices for region '%s'. "+
+			"Use '--pricing-file' to provide a pricing table that includes it", region)
+	}
+	return pricing, nil
+}
+
+// InstancePrice returns the hourly price of the instance type in the region
+func (p *RegionPricing) InstancePrice(region string, instanceType string) (float64, error) {
+	price, ok := p.Instances[instanceType]
+	if !ok {
+		return 0, fmt.Errorf("Pricing table has no price for instance type '%s' in region '%s'. "+
+			"Use '--pricing-file' to provide a pricing table that includes it", instanceType, region)
+	}
+	return price, nil
+}
+
+// ParsePricingTable parses and validates a pricing table in YAML or JSON format
+func ParsePricingTable(data []byte) (*PricingTable, error) {
+	table := &PricingTable{}
+	err := yaml.Unmarshal(data, table)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to parse pricing table: %v", err)
+	}
+	if len(table.Regions) == 0 {
+		return nil, fmt.Errorf("Pricing table does not contain any region")
+	}
+	if table.Currency == "" {
+		table.Currency = "USD"
+	}
+	return table, nil
+}
+
+// DefaultPricingTable returns the pricing table bundled with the binary
+func DefaultPricingTable() (*PricingTable, error) {
+	data, err := assets.Asset(DefaultPricingAsset)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePricingTable(data)
+}
+
+// CachedPricingTablePath returns the location of the pricing table saved by RefreshPricingTable
+func CachedPricingTablePath() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "rosa", cachedPricingFile), nil
+}
+
+// LoadPricingTable returns the pricing table to use. In order of preference it loads the
+// given file, the file referenced by the ROSA_PRICING_FILE environment variable, the
+// table saved by the last refresh and finally the table bundled with the binary.
+func LoadPricingTable(path string) (*PricingTable, error) {
+	if path == "" {
+		path = os.Getenv(PricingFileEnvVar)
+	}
+	if path == "" {
+		cachedPath, err := CachedPricingTablePath()
+		if err == nil {
+			if _, err = os.Stat(cachedPath); err == nil {
+				path = cachedPath
+			}
+		}
+	}
+	if path == "" {
+		return DefaultPricingTable()
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read pricing table '%s': %v", path, err)
+	}
+	return ParsePricingTable(data)
+}
+
+// RefreshPricingTable fetches a pricing table from a URL or a local file, validates it and
+// saves it so that it is used by subsequent estimates. It returns the location it was saved to.
+func RefreshPricingTable(source string) (string, error) {
+	var data []byte
+	var err error
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		// nolint:gosec
+		resp, err := pricingHTTPClient.Get(source)
+		if err != nil {
+			return "", fmt.Errorf("Failed to download pricing table: %v", err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return "", fmt.Errorf("Failed to download pricing table: %s", resp.Status)
+		}
+		data, err = io.ReadAll(resp.Body)
+		if err != nil {
+			return "", fmt.Errorf("Failed to download pricing table: %v", err)
+		}
+	} else {
+		data, err = os.ReadFile(source)
+		if err != nil {
+			return "", fmt.Errorf("Failed to read pricing table '%s': %v", source, err)
+		}
+	}
+
+	_, err = ParsePricingTable(data)
+	if err != nil {
+		return "", err
+	}
+
+	path, err := CachedPricingTablePath()
+	if err != nil {
+		return "", err
+	}
+	err = os.MkdirAll(filepath.Dir(path), 0700)
+	if err != nil {
+		return "", err
+	}
+	err = os.WriteFile(path, data, 0600)
+	if err != nil {
+		return "", err
+	}
+	return path, nil
+}
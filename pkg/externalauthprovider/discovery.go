@@ -0,0 +1,166 @@
+package externalauthprovider
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/openshift/rosa/pkg/helper"
+)
+
+const (
+	openIDConfigurationPath = "/.well-known/openid-configuration"
+	discoveryTimeout        = 30 * time.Second
+)
+
+// OpenIDConfiguration is the part of the OpenID provider metadata of a token issuer that is validated
+type OpenIDConfiguration struct {
+	Issuer                           string   `json:"issuer"`
+	JWKSURI                          string   `json:"jwks_uri"`
+	ClaimsSupported                  []string `json:"claims_supported,omitempty"`
+	IDTokenSigningAlgValuesSupported []string `json:"id_token_signing_alg_values_supported,omitempty"`
+}
+
+// JSONWebKey is a key of the JWKS of a token issuer
+type JSONWebKey struct {
+	KeyID     string `json:"kid,omitempty"`
+	KeyType   string `json:"kty"`
+	Use       string `json:"use,omitempty"`
+	Algorithm string `json:"alg,omitempty"`
+}
+
+// IssuerDiscovery holds the OpenID configuration and signing keys discovered from a token issuer
+type IssuerDiscovery struct {
+	Configuration *OpenIDConfiguration `json:"configuration"`
+	Keys          []JSONWebKey         `json:"keys"`
+}
+
+// DiscoverIssuer fetches the OpenID configuration and the JWKS of the token issuer, trusting the
+// certificates of the CA file when given, and validates that tokens of the issuer can be verified
+func DiscoverIssuer(issuerUrl string, caFile string) (*IssuerDiscovery, error) {
+	parsed, err := url.ParseRequestURI(issuerUrl)
+	if err != nil || parsed.Host == "" {
+		return nil, fmt.Errorf("expected a valid issuer url, got '%s'", issuerUrl)
+	}
+	if parsed.Scheme != "https" {
+		return nil, fmt.Errorf("expected issuer url '%s' to use https", issuerUrl)
+	}
+
+	client, err := newIssuerClient(caFile)
+	if err != nil {
+		return nil, err
+	}
+
+	configuration := &OpenIDConfiguration{}
+	err = getJSON(client, strings.TrimSuffix(issuerUrl, "/")+openIDConfigurationPath, configuration)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSuffix(configuration.Issuer, "/") != strings.TrimSuffix(issuerUrl, "/") {
+		return nil, fmt.Errorf("issuer '%s' of the OpenID configuration does not match issuer url '%s'",
+			configuration.Issuer, issuerUrl)
+	}
+	if configuration.JWKSURI == "" {
+		return nil, fmt.Errorf("OpenID configuration of issuer '%s' has no 'jwks_uri'", issuerUrl)
+	}
+
+	jwks := &struct {
+		Keys []JSONWebKey `json:"keys"`
+	}{}
+	err = getJSON(client, configuration.JWKSURI, jwks)
+	if err != nil {
+		return nil, err
+	}
+	var keys []JSONWebKey
+	for _, key := range jwks.Keys {
+		if key.KeyType != "" && (key.Use == "" || key.Use == "sig") {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("JWKS '%s' of issuer '%s' has no signing keys", configuration.JWKSURI, issuerUrl)
+	}
+
+	return &IssuerDiscovery{
+		Configuration: configuration,
+		Keys:          keys,
+	}, nil
+}
+
+// UnsupportedClaims returns the claims that are not listed in the claims supported by the issuer. Issuers
+// that don't list the claims they support have no unsupported claims.
+func (d *IssuerDiscovery) UnsupportedClaims(claims ...string) []string {
+	if len(d.Configuration.ClaimsSupported) == 0 {
+		return nil
+	}
+	var unsupported []string
+	for _, claim := range claims {
+		if claim != "" && !helper.Contains(d.Configuration.ClaimsSupported, claim) {
+			unsupported = append(unsupported, claim)
+		}
+	}
+	return unsupported
+}
+
+// DiscoverIssuer discovers the token issuer of the external authentication provider
+func (a *ExternalAuthProvidersArgs) DiscoverIssuer() (*IssuerDiscovery, error) {
+	return DiscoverIssuer(a.issuerUrl, a.issuerCaFile)
+}
+
+// ClaimMappings returns the claims used to map tokens to usernames and groups
+func (a *ExternalAuthProvidersArgs) ClaimMappings() []string {
+	return []string{a.claimMappingUsernameClaim, a.claimMappingGroupsClaim}
+}
+
+func newIssuerClient(caFile string) (*http.Client, error) {
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if caFile != "" {
+		cert, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("expected a valid certificate bundle: %s", err)
+		}
+		if !pool.AppendCertsFromPEM(cert) {
+			return nil, fmt.Errorf("expected a valid certificate bundle: no certificates found in '%s'", caFile)
+		}
+	}
+	return &http.Client{
+		Timeout: discoveryTimeout,
+		Transport: &http.Transport{
+			Proxy: http.ProxyFromEnvironment,
+			TLSClientConfig: &tls.Config{
+				RootCAs:    pool,
+				MinVersion: tls.VersionTLS12,
+			},
+		},
+	}, nil
+}
+
+func getJSON(client *http.Client, url string, value interface{}) error {
+	response, err := client.Get(url)
+	if err != nil {
+		return fmt.Errorf("failed to get '%s': %s", url, err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to get '%s': unexpected status %s", url, response.Status)
+	}
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read '%s': %s", url, err)
+	}
+	err = json.Unmarshal(body, value)
+	if err != nil {
+		return fmt.Errorf("failed to parse '%s': %s", url, err)
+	}
+	return nil
+}
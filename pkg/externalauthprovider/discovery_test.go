@@ -0,0 +1,77 @@
+package externalauthprovider
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Issuer discovery", func() {
+	var (
+		server        *httptest.Server
+		caFile        string
+		configuration string
+		jwks          string
+	)
+
+	BeforeEach(func() {
+		configuration = ""
+		jwks = `{"keys": [{"kid": "1", "kty": "RSA", "use": "sig"}, {"kid": "2", "kty": "RSA", "use": "enc"}]}`
+		server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case openIDConfigurationPath:
+				w.Write([]byte(configuration))
+			case "/keys":
+				w.Write([]byte(jwks))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		DeferCleanup(server.Close)
+
+		caFile = filepath.Join(GinkgoT().TempDir(), "ca.pem")
+		err := os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{
+			Type:  "CERTIFICATE",
+			Bytes: server.Certificate().Raw,
+		}), 0600)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("Discovers the OpenID configuration and signing keys", func() {
+		configuration = `{"issuer": "` + server.URL + `", "jwks_uri": "` + server.URL + `/keys", ` +
+			`"claims_supported": ["sub", "email"]}`
+		discovery, err := DiscoverIssuer(server.URL, caFile)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(discovery.Keys).To(Equal([]JSONWebKey{{KeyID: "1", KeyType: "RSA", Use: "sig"}}))
+		Expect(discovery.UnsupportedClaims("email", "groups", "")).To(Equal([]string{"groups"}))
+	})
+
+	It("Fails when the CA of the issuer is not trusted", func() {
+		_, err := DiscoverIssuer(server.URL, "")
+		Expect(err).To(MatchError(ContainSubstring("failed to get")))
+	})
+
+	It("Fails when the issuer does not match", func() {
+		configuration = `{"issuer": "https://other.example.com", "jwks_uri": "` + server.URL + `/keys"}`
+		_, err := DiscoverIssuer(server.URL, caFile)
+		Expect(err).To(MatchError("issuer 'https://other.example.com' of the OpenID configuration does not " +
+			"match issuer url '" + server.URL + "'"))
+	})
+
+	It("Fails when the JWKS has no signing keys", func() {
+		configuration = `{"issuer": "` + server.URL + `/", "jwks_uri": "` + server.URL + `/keys"}`
+		jwks = `{"keys": []}`
+		_, err := DiscoverIssuer(server.URL, caFile)
+		Expect(err).To(MatchError(ContainSubstring("has no signing keys")))
+	})
+
+	It("Fails with an issuer url that is not https", func() {
+		_, err := DiscoverIssuer("http://issuer.example.com", "")
+		Expect(err).To(MatchError("expected issuer url 'http://issuer.example.com' to use https"))
+	})
+})
@@ -0,0 +1,140 @@
+package externalauthprovider
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/helper"
+	"github.com/openshift/rosa/pkg/ocm"
+)
+
+const (
+	usernamePrefixPolicyPrefix   = "Prefix"
+	usernamePrefixPolicyNoPrefix = "NoPrefix"
+)
+
+// TokenEvaluation is the cluster identity a token maps to with the claim mappings of an external
+// authentication provider, and the reasons the token would be rejected, if any
+type TokenEvaluation struct {
+	Username string   `json:"username,omitempty"`
+	Groups   []string `json:"groups,omitempty"`
+	Errors   []string `json:"errors,omitempty"`
+}
+
+// Accepted returns true when the token would authenticate the user
+func (e *TokenEvaluation) Accepted() bool {
+	return len(e.Errors) == 0
+}
+
+// EvaluateToken evaluates the issuer, audiences, claim validation rules and claim mappings of the
+// external authentication provider against the claims of the token. The signature of the token is
+// not verified.
+func EvaluateToken(externalAuth *cmv1.ExternalAuth, token string, now time.Time) (*TokenEvaluation, error) {
+	claims := jwt.MapClaims{}
+	_, _, err := jwt.NewParser().ParseUnverified(token, claims)
+	if err != nil {
+		return nil, fmt.Errorf("expected a valid JWT: %s", err)
+	}
+
+	result := &TokenEvaluation{}
+	issuer := externalAuth.Issuer()
+
+	if !claims.VerifyIssuer(issuer.URL(), true) {
+		result.Errors = append(result.Errors, fmt.Sprintf("token issuer '%v' does not match issuer url '%s'",
+			claims["iss"], issuer.URL()))
+	}
+	if !hasAudience(claims, issuer.Audiences()) {
+		result.Errors = append(result.Errors, fmt.Sprintf("token audiences '%v' do not include any of '%s'",
+			claims["aud"], strings.Join(issuer.Audiences(), ", ")))
+	}
+	if !claims.VerifyExpiresAt(now.Unix(), false) {
+		result.Errors = append(result.Errors, "token is expired")
+	}
+
+	var rules []string
+	for _, rule := range externalAuth.Claim().ValidationRules() {
+		rules = append(rules, fmt.Sprintf("%s:%s", rule.Claim(), rule.RequiredValue()))
+		if !helper.Contains(claimValues(claims[rule.Claim()]), rule.RequiredValue()) {
+			result.Errors = append(result.Errors, fmt.Sprintf("claim '%s' is required to be '%s', got '%v'",
+				rule.Claim(), rule.RequiredValue(), claims[rule.Claim()]))
+		}
+	}
+	err = ocm.ValidateClaimValidationRules(strings.Join(rules, ","))
+	if err != nil {
+		result.Errors = append(result.Errors, err.Error())
+	}
+
+	username, err := mapUsername(externalAuth.Claim().Mappings().UserName(), issuer.URL(), claims)
+	if err != nil {
+		result.Errors = append(result.Errors, err.Error())
+	}
+	result.Username = username
+
+	groupsClaim := externalAuth.Claim().Mappings().Groups()
+	if groupsClaim.Claim() != "" {
+		for _, group := range claimValues(claims[groupsClaim.Claim()]) {
+			result.Groups = append(result.Groups, groupsClaim.Prefix()+group)
+		}
+	}
+
+	return result, nil
+}
+
+func mapUsername(usernameClaim *cmv1.UsernameClaim, issuerUrl string, claims jwt.MapClaims) (string, error) {
+	claim := usernameClaim.Claim()
+	if claim == "" {
+		return "", fmt.Errorf("no username claim mapping is configured")
+	}
+	value, ok := claims[claim].(string)
+	if !ok || value == "" {
+		return "", fmt.Errorf("username claim '%s' is missing from the token or is not a string", claim)
+	}
+	if claim == "email" {
+		if verified, ok := claims["email_verified"].(bool); ok && !verified {
+			return "", fmt.Errorf("username claim 'email' is not verified, 'email_verified' is false")
+		}
+	}
+
+	switch usernameClaim.PrefixPolicy() {
+	case usernamePrefixPolicyPrefix:
+		return usernameClaim.Prefix() + value, nil
+	case usernamePrefixPolicyNoPrefix:
+		return value, nil
+	}
+	// Usernames from claims other than email are prefixed with the issuer url by default so they
+	// can't collide with users of other identity providers
+	if claim == "email" {
+		return value, nil
+	}
+	return fmt.Sprintf("%s#%s", issuerUrl, value), nil
+}
+
+func hasAudience(claims jwt.MapClaims, audiences []string) bool {
+	for _, audience := range audiences {
+		if claims.VerifyAudience(audience, true) {
+			return true
+		}
+	}
+	return false
+}
+
+// claimValues returns the string values of a claim that is either a string or a list of strings
+func claimValues(claim interface{}) []string {
+	switch value := claim.(type) {
+	case string:
+		return []string{value}
+	case []interface{}:
+		var values []string
+		for _, item := range value {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
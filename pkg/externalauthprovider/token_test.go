@@ -0,0 +1,127 @@
+package externalauthprovider
+
+import (
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	. "github.com/onsi/ginkgo/v2/dsl/core"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+func signToken(claims jwt.MapClaims) string {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("secret"))
+	Expect(err).NotTo(HaveOccurred())
+	return token
+}
+
+var _ = Describe("Token evaluation", func() {
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	buildExternalAuth := func(usernameClaim *cmv1.UsernameClaimBuilder,
+		rules ...*cmv1.TokenClaimValidationRuleBuilder) *cmv1.ExternalAuth {
+		externalAuth, err := cmv1.NewExternalAuth().ID("entra-id").
+			Issuer(cmv1.NewTokenIssuer().URL("https://issuer.example.com").Audiences("rosa", "console")).
+			Claim(cmv1.NewExternalAuthClaim().
+				Mappings(cmv1.NewTokenClaimMappings().
+					UserName(usernameClaim).
+					Groups(cmv1.NewGroupsClaim().Claim("groups").Prefix("entra:"))).
+				ValidationRules(rules...)).
+			Build()
+		Expect(err).NotTo(HaveOccurred())
+		return externalAuth
+	}
+
+	validClaims := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"iss":            "https://issuer.example.com",
+			"aud":            []string{"other", "rosa"},
+			"exp":            now.Add(time.Hour).Unix(),
+			"sub":            "1234",
+			"email":          "user@example.com",
+			"email_verified": true,
+			"groups":         []string{"admins", "devs"},
+			"tenant":         "abc",
+		}
+	}
+
+	It("Maps the username and groups of an accepted token", func() {
+		externalAuth := buildExternalAuth(cmv1.NewUsernameClaim().Claim("email"),
+			cmv1.NewTokenClaimValidationRule().Claim("tenant").RequiredValue("abc"))
+		evaluation, err := EvaluateToken(externalAuth, signToken(validClaims()), now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(evaluation.Errors).To(BeEmpty())
+		Expect(evaluation.Accepted()).To(BeTrue())
+		Expect(evaluation.Username).To(Equal("user@example.com"))
+		Expect(evaluation.Groups).To(Equal([]string{"entra:admins", "entra:devs"}))
+	})
+
+	It("Prefixes usernames from claims other than email with the issuer url by default", func() {
+		externalAuth := buildExternalAuth(cmv1.NewUsernameClaim().Claim("sub"))
+		evaluation, err := EvaluateToken(externalAuth, signToken(validClaims()), now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(evaluation.Username).To(Equal("https://issuer.example.com#1234"))
+	})
+
+	It("Applies the username prefix policy", func() {
+		externalAuth := buildExternalAuth(cmv1.NewUsernameClaim().Claim("sub").
+			PrefixPolicy("Prefix").Prefix("entra:"))
+		evaluation, err := EvaluateToken(externalAuth, signToken(validClaims()), now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(evaluation.Username).To(Equal("entra:1234"))
+
+		externalAuth = buildExternalAuth(cmv1.NewUsernameClaim().Claim("sub").PrefixPolicy("NoPrefix"))
+		evaluation, err = EvaluateToken(externalAuth, signToken(validClaims()), now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(evaluation.Username).To(Equal("1234"))
+	})
+
+	It("Reports the reasons a token is rejected", func() {
+		claims := validClaims()
+		claims["iss"] = "https://other.example.com"
+		claims["aud"] = "other"
+		claims["exp"] = now.Add(-time.Hour).Unix()
+		claims["tenant"] = "xyz"
+		delete(claims, "email")
+		externalAuth := buildExternalAuth(cmv1.NewUsernameClaim().Claim("email"),
+			cmv1.NewTokenClaimValidationRule().Claim("tenant").RequiredValue("abc"))
+		evaluation, err := EvaluateToken(externalAuth, signToken(claims), now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(evaluation.Accepted()).To(BeFalse())
+		Expect(evaluation.Errors).To(Equal([]string{
+			"token issuer 'https://other.example.com' does not match issuer url 'https://issuer.example.com'",
+			"token audiences 'other' do not include any of 'rosa, console'",
+			"token is expired",
+			"claim 'tenant' is required to be 'abc', got 'xyz'",
+			"username claim 'email' is missing from the token or is not a string",
+		}))
+	})
+
+	It("Rejects unverified emails", func() {
+		claims := validClaims()
+		claims["email_verified"] = false
+		externalAuth := buildExternalAuth(cmv1.NewUsernameClaim().Claim("email"))
+		evaluation, err := EvaluateToken(externalAuth, signToken(claims), now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(evaluation.Errors).To(Equal([]string{
+			"username claim 'email' is not verified, 'email_verified' is false",
+		}))
+	})
+
+	It("Reports invalid claim validation rules", func() {
+		claims := validClaims()
+		claims["Tenant"] = "abc"
+		externalAuth := buildExternalAuth(cmv1.NewUsernameClaim().Claim("email"),
+			cmv1.NewTokenClaimValidationRule().Claim("Tenant").RequiredValue("abc"))
+		evaluation, err := EvaluateToken(externalAuth, signToken(claims), now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(evaluation.Errors).To(HaveLen(1))
+		Expect(evaluation.Errors[0]).To(ContainSubstring("invalid identifier 'Tenant:abc'"))
+	})
+
+	It("Fails with a malformed token", func() {
+		externalAuth := buildExternalAuth(cmv1.NewUsernameClaim().Claim("email"))
+		_, err := EvaluateToken(externalAuth, "not-a-token", now)
+		Expect(err).To(MatchError(ContainSubstring("expected a valid JWT")))
+	})
+})
@@ -0,0 +1,100 @@
+package machinepools
+
+import (
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+// CopyMachinePool returns a builder with the settings of the machine pool. The ID and the subnets and
+// availability zones are left out as they depend on the cluster the copy is created in. The additional
+// security groups belong to the VPC of the cluster, so they are only copied when requested.
+func CopyMachinePool(source *cmv1.MachinePool, securityGroups bool) *cmv1.MachinePoolBuilder {
+	builder := cmv1.NewMachinePool().
+		InstanceType(source.InstanceType()).
+		Labels(source.Labels()).
+		Taints(copyTaints(source.Taints())...)
+
+	if autoscaling, ok := source.GetAutoscaling(); ok {
+		builder.Autoscaling(cmv1.NewMachinePoolAutoscaling().
+			MinReplicas(autoscaling.MinReplicas()).
+			MaxReplicas(autoscaling.MaxReplicas()))
+	} else {
+		builder.Replicas(source.Replicas())
+	}
+
+	awsBuilder := cmv1.NewAWSMachinePool()
+	if spot, ok := source.AWS().GetSpotMarketOptions(); ok {
+		spotBuilder := cmv1.NewAWSSpotMarketOptions()
+		if maxPrice, ok := spot.GetMaxPrice(); ok {
+			spotBuilder.MaxPrice(maxPrice)
+		}
+		awsBuilder.SpotMarketOptions(spotBuilder)
+	}
+	if tags := source.AWS().Tags(); len(tags) > 0 {
+		awsBuilder.Tags(tags)
+	}
+	if securityGroupIds := source.AWS().AdditionalSecurityGroupIds(); securityGroups && len(securityGroupIds) > 0 {
+		awsBuilder.AdditionalSecurityGroupIds(securityGroupIds...)
+	}
+	if !awsBuilder.Empty() {
+		builder.AWS(awsBuilder)
+	}
+
+	if size := source.RootVolume().AWS().Size(); size > 0 {
+		builder.RootVolume(cmv1.NewRootVolume().AWS(cmv1.NewAWSVolume().Size(size)))
+	}
+	return builder
+}
+
+// CopyNodePool returns a builder with the settings of the node pool. The ID, the subnet and the version
+// are left out as they depend on the cluster the copy is created in. The additional security groups are
+// only copied when requested.
+func CopyNodePool(source *cmv1.NodePool, securityGroups bool) *cmv1.NodePoolBuilder {
+	builder := cmv1.NewNodePool().
+		Labels(source.Labels()).
+		Taints(copyTaints(source.Taints())...).
+		AutoRepair(source.AutoRepair())
+
+	if autoscaling, ok := source.GetAutoscaling(); ok {
+		builder.Autoscaling(cmv1.NewNodePoolAutoscaling().
+			MinReplica(autoscaling.MinReplica()).
+			MaxReplica(autoscaling.MaxReplica()))
+	} else {
+		builder.Replicas(source.Replicas())
+	}
+
+	builder.AWSNodePool(CopyAWSNodePool(source.AWSNodePool(), securityGroups))
+
+	if tuningConfigs := source.TuningConfigs(); len(tuningConfigs) > 0 {
+		builder.TuningConfigs(tuningConfigs...)
+	}
+	if gracePeriod, ok := source.GetNodeDrainGracePeriod(); ok {
+		builder.NodeDrainGracePeriod(cmv1.NewValue().
+			Value(gracePeriod.Value()).
+			Unit(gracePeriod.Unit()))
+	}
+	return builder
+}
+
+// CopyAWSNodePool returns a builder with the instance type, tags and, when requested, the additional
+// security groups of the AWS settings of a node pool
+func CopyAWSNodePool(source *cmv1.AWSNodePool, securityGroups bool) *cmv1.AWSNodePoolBuilder {
+	builder := cmv1.NewAWSNodePool().InstanceType(source.InstanceType())
+	if tags := source.Tags(); len(tags) > 0 {
+		builder.Tags(tags)
+	}
+	if securityGroupIds := source.AdditionalSecurityGroupIds(); securityGroups && len(securityGroupIds) > 0 {
+		builder.AdditionalSecurityGroupIds(securityGroupIds...)
+	}
+	return builder
+}
+
+func copyTaints(taints []*cmv1.Taint) []*cmv1.TaintBuilder {
+	builders := []*cmv1.TaintBuilder{}
+	for _, taint := range taints {
+		builders = append(builders, cmv1.NewTaint().
+			Key(taint.Key()).
+			Value(taint.Value()).
+			Effect(taint.Effect()))
+	}
+	return builders
+}
@@ -0,0 +1,87 @@
+package machinepools
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+var _ = Describe("Copy", func() {
+	taint := cmv1.NewTaint().Key("dedicated").Value("gpu").Effect("NoSchedule")
+
+	It("Copies the settings of a machine pool", func() {
+		source, err := cmv1.NewMachinePool().ID("mp-1").
+			InstanceType("m5.xlarge").
+			Autoscaling(cmv1.NewMachinePoolAutoscaling().MinReplicas(2).MaxReplicas(6)).
+			Labels(map[string]string{"role": "worker"}).
+			Taints(taint).
+			AvailabilityZones("us-east-1a").
+			Subnets("subnet-1").
+			AWS(cmv1.NewAWSMachinePool().
+				SpotMarketOptions(cmv1.NewAWSSpotMarketOptions().MaxPrice(0.5)).
+				Tags(map[string]string{"team": "data"}).
+				AdditionalSecurityGroupIds("sg-1")).
+			RootVolume(cmv1.NewRootVolume().AWS(cmv1.NewAWSVolume().Size(500))).
+			Build()
+		Expect(err).NotTo(HaveOccurred())
+
+		machinePool, err := CopyMachinePool(source, false).ID("mp-2").Build()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(machinePool.ID()).To(Equal("mp-2"))
+		Expect(machinePool.InstanceType()).To(Equal("m5.xlarge"))
+		Expect(machinePool.Autoscaling().MinReplicas()).To(Equal(2))
+		Expect(machinePool.Autoscaling().MaxReplicas()).To(Equal(6))
+		Expect(machinePool.Labels()).To(Equal(map[string]string{"role": "worker"}))
+		Expect(machinePool.Taints()).To(HaveLen(1))
+		Expect(machinePool.Taints()[0].Key()).To(Equal("dedicated"))
+		Expect(machinePool.Taints()[0].Effect()).To(Equal("NoSchedule"))
+		Expect(machinePool.AWS().SpotMarketOptions().MaxPrice()).To(Equal(0.5))
+		Expect(machinePool.AWS().Tags()).To(Equal(map[string]string{"team": "data"}))
+		Expect(machinePool.AWS().AdditionalSecurityGroupIds()).To(BeEmpty())
+		Expect(machinePool.RootVolume().AWS().Size()).To(Equal(500))
+		Expect(machinePool.AvailabilityZones()).To(BeEmpty())
+		Expect(machinePool.Subnets()).To(BeEmpty())
+
+		machinePool, err = CopyMachinePool(source, true).Build()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(machinePool.AWS().AdditionalSecurityGroupIds()).To(Equal([]string{"sg-1"}))
+	})
+
+	It("Copies the settings of a node pool", func() {
+		source, err := cmv1.NewNodePool().ID("np-1").
+			Replicas(3).
+			Labels(map[string]string{"role": "worker"}).
+			Taints(taint).
+			AutoRepair(true).
+			Subnet("subnet-1").
+			Version(cmv1.NewVersion().ID("openshift-v4.14.1")).
+			AWSNodePool(cmv1.NewAWSNodePool().
+				InstanceType("m5.xlarge").
+				Tags(map[string]string{"team": "data"}).
+				AdditionalSecurityGroupIds("sg-1")).
+			TuningConfigs("tuned-1").
+			NodeDrainGracePeriod(cmv1.NewValue().Value(30).Unit("minutes")).
+			Build()
+		Expect(err).NotTo(HaveOccurred())
+
+		nodePool, err := CopyNodePool(source, false).ID("np-2").Build()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nodePool.ID()).To(Equal("np-2"))
+		Expect(nodePool.Replicas()).To(Equal(3))
+		Expect(nodePool.Labels()).To(Equal(map[string]string{"role": "worker"}))
+		Expect(nodePool.Taints()).To(HaveLen(1))
+		Expect(nodePool.AutoRepair()).To(BeTrue())
+		Expect(nodePool.AWSNodePool().InstanceType()).To(Equal("m5.xlarge"))
+		Expect(nodePool.AWSNodePool().Tags()).To(Equal(map[string]string{"team": "data"}))
+		Expect(nodePool.AWSNodePool().AdditionalSecurityGroupIds()).To(BeEmpty())
+		Expect(nodePool.TuningConfigs()).To(Equal([]string{"tuned-1"}))
+		Expect(nodePool.NodeDrainGracePeriod().Value()).To(Equal(float64(30)))
+		Expect(nodePool.NodeDrainGracePeriod().Unit()).To(Equal("minutes"))
+		Expect(nodePool.Subnet()).To(BeEmpty())
+		Expect(nodePool.Version()).To(BeNil())
+
+		nodePool, err = CopyNodePool(source, true).Build()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nodePool.AWSNodePool().AdditionalSecurityGroupIds()).To(Equal([]string{"sg-1"}))
+	})
+})
@@ -2,6 +2,7 @@ package interactive
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/robfig/cron/v3"
@@ -69,14 +70,57 @@ func BuildManualUpgradeSchedule(cmd *cobra.Command, scheduleDate string, schedul
 	return nextRun, nil
 }
 
+// UpcomingUpgradeRuns is the number of automatic upgrade occurrences shown before scheduling
+const UpcomingUpgradeRuns = 5
+
+// ParseUpgradeSchedule validates a cron expression for automatic upgrades, which are always evaluated in UTC
+func ParseUpgradeSchedule(schedule string) (cron.Schedule, error) {
+	cronParser := cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
+	cronSchedule, err := cronParser.Parse(fmt.Sprintf("CRON_TZ=UTC %s", schedule))
+	if err != nil {
+		return nil, fmt.Errorf("Schedule '%s' is not a valid cron expression", schedule)
+	}
+	if cronSchedule.Next(time.Now().UTC()).IsZero() {
+		return nil, fmt.Errorf("Schedule '%s' never matches a valid date", schedule)
+	}
+	return cronSchedule, nil
+}
+
+// GetUpcomingUpgradeRuns returns the next 'count' occurrences of the schedule after 'from'
+func GetUpcomingUpgradeRuns(schedule string, from time.Time, count int) ([]time.Time, error) {
+	cronSchedule, err := ParseUpgradeSchedule(schedule)
+	if err != nil {
+		return nil, err
+	}
+	runs := make([]time.Time, 0, count)
+	next := from
+	for len(runs) < count {
+		next = cronSchedule.Next(next)
+		if next.IsZero() {
+			break
+		}
+		runs = append(runs, next)
+	}
+	return runs, nil
+}
+
+// FormatUpgradeRun prints an upgrade run in UTC followed by the local time of the user
+func FormatUpgradeRun(run time.Time) string {
+	utc := run.UTC().Format("2006-01-02 15:04 MST")
+	local := run.Local().Format("2006-01-02 15:04 MST")
+	if utc == local {
+		return utc
+	}
+	return fmt.Sprintf("%s (%s)", utc, local)
+}
+
 func BuildAutomaticUpgradeSchedule(cmd *cobra.Command, schedule string) (string, error) {
 	// Check automatic upgrade scheduling
-	cronParser := cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
 	var err error
 	if schedule != "" {
-		_, err = cronParser.Parse(fmt.Sprintf("CRON_TZ=UTC %s", schedule))
+		_, err = ParseUpgradeSchedule(schedule)
 		if err != nil {
-			return schedule, fmt.Errorf("Schedule '%s' is not a valid cron expression", schedule)
+			return schedule, err
 		}
 	}
 	if Enabled() {
@@ -89,11 +133,24 @@ func BuildAutomaticUpgradeSchedule(cmd *cobra.Command, schedule string) (string,
 		if err != nil {
 			return schedule, fmt.Errorf("Expected a valid automatic schedule: %s", err)
 		}
-		_, err = cronParser.Parse(fmt.Sprintf("CRON_TZ=UTC %s", schedule))
+		_, err = ParseUpgradeSchedule(schedule)
 		if err != nil {
-			return schedule, fmt.Errorf("Schedule '%s' is not a valid cron expression", schedule)
+			return schedule, err
 		}
 	}
 
 	return schedule, nil
 }
+
+// DescribeUpcomingUpgradeRuns builds a message listing the next automatic upgrade runs of the schedule
+func DescribeUpcomingUpgradeRuns(schedule string, from time.Time) (string, error) {
+	runs, err := GetUpcomingUpgradeRuns(schedule, from, UpcomingUpgradeRuns)
+	if err != nil {
+		return "", err
+	}
+	builder := []string{fmt.Sprintf("Next %d automatic upgrade runs for schedule '%s':", len(runs), schedule)}
+	for _, run := range runs {
+		builder = append(builder, fmt.Sprintf("  %s", FormatUpgradeRun(run)))
+	}
+	return strings.Join(builder, "\n"), nil
+}
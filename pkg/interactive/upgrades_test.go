@@ -0,0 +1,72 @@
+package interactive
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Upgrade schedule", func() {
+	var from time.Time
+
+	BeforeEach(func() {
+		var err error
+		from, err = time.Parse(time.RFC3339, "2024-03-01T10:00:00Z")
+		Expect(err).ToNot(HaveOccurred())
+		local := time.Local
+		DeferCleanup(func() {
+			time.Local = local
+		})
+		time.Local = time.UTC
+	})
+
+	Context("ParseUpgradeSchedule", func() {
+		It("Accepts a valid cron expression", func() {
+			_, err := ParseUpgradeSchedule("30 2 * * 6")
+			Expect(err).ToNot(HaveOccurred())
+		})
+		It("Rejects an invalid cron expression", func() {
+			_, err := ParseUpgradeSchedule("every saturday")
+			Expect(err).To(MatchError("Schedule 'every saturday' is not a valid cron expression"))
+		})
+		It("Rejects a cron expression that never fires", func() {
+			_, err := ParseUpgradeSchedule("0 0 30 2 *")
+			Expect(err).To(MatchError("Schedule '0 0 30 2 *' never matches a valid date"))
+		})
+	})
+
+	Context("GetUpcomingUpgradeRuns", func() {
+		It("Returns the next runs in UTC", func() {
+			runs, err := GetUpcomingUpgradeRuns("30 2 * * 6", from, 3)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(runs).To(HaveLen(3))
+			Expect(runs[0].Format(time.RFC3339)).To(Equal("2024-03-02T02:30:00Z"))
+			Expect(runs[1].Format(time.RFC3339)).To(Equal("2024-03-09T02:30:00Z"))
+			Expect(runs[2].Format(time.RFC3339)).To(Equal("2024-03-16T02:30:00Z"))
+		})
+	})
+
+	Context("FormatUpgradeRun", func() {
+		It("Only shows UTC when the local time zone is UTC", func() {
+			Expect(FormatUpgradeRun(from)).To(Equal("2024-03-01 10:00 UTC"))
+		})
+		It("Shows the local time next to UTC", func() {
+			time.Local = time.FixedZone("CET", 3600)
+			Expect(FormatUpgradeRun(from)).To(Equal("2024-03-01 10:00 UTC (2024-03-01 11:00 CET)"))
+		})
+	})
+
+	Context("DescribeUpcomingUpgradeRuns", func() {
+		It("Lists the upcoming runs of the schedule", func() {
+			message, err := DescribeUpcomingUpgradeRuns("0 0 1 * *", from)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(message).To(Equal("Next 5 automatic upgrade runs for schedule '0 0 1 * *':\n" +
+				"  2024-04-01 00:00 UTC\n" +
+				"  2024-05-01 00:00 UTC\n" +
+				"  2024-06-01 00:00 UTC\n" +
+				"  2024-07-01 00:00 UTC\n" +
+				"  2024-08-01 00:00 UTC"))
+		})
+	})
+})
@@ -16,6 +16,24 @@ var ROSAHypershiftQuota = "cluster|byoc|moa|marketplace"
 var awsAccountRegexp = regexp.MustCompile(`^[0-9]{12}$`)
 
 func (c *Client) GetBillingAccounts() ([]*v1.CloudAccount, error) {
+	billingAccounts, err := c.GetCloudAccounts()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(billingAccounts) == 0 {
+		return billingAccounts, errors.New("No valid billing account associated." +
+			" Go to https://console.aws.amazon.com/rosa/home#/get-started" +
+			" to enable ROSA with HCP for your intended billing account." +
+			" You must have a valid billing account associated to continue.")
+	}
+
+	return billingAccounts, nil
+}
+
+// GetCloudAccounts returns the AWS marketplace billing accounts linked to the organization of the
+// current account, with their contracts
+func (c *Client) GetCloudAccounts() ([]*v1.CloudAccount, error) {
 	acctResponse, err := c.ocm.AccountsMgmt().V1().CurrentAccount().
 		Get().
 		Send()
@@ -37,18 +55,10 @@ func (c *Client) GetBillingAccounts() ([]*v1.CloudAccount, error) {
 		return nil, handleErr(quotaCostResponse.Error(), err)
 	}
 
-	var billingAccounts []*v1.CloudAccount
+	billingAccounts := []*v1.CloudAccount{}
 	for _, item := range quotaCostResponse.Items().Slice() {
 		billingAccounts = append(billingAccounts, item.CloudAccounts()...)
 	}
-
-	if len(billingAccounts) == 0 {
-		return billingAccounts, errors.New("No valid billing account associated." +
-			" Go to https://console.aws.amazon.com/rosa/home#/get-started" +
-			" to enable ROSA with HCP for your intended billing account." +
-			" You must have a valid billing account associated to continue.")
-	}
-
 	return billingAccounts, nil
 }
 
@@ -12,6 +12,9 @@ import (
 
 const pollKubeconfigInterval = 200 * time.Second
 
+// kubeconfigPollInterval is the interval between two polls of a break glass credential, tests shorten it
+var kubeconfigPollInterval = pollKubeconfigInterval
+
 func (c *Client) CreateBreakGlassCredential(clusterID string,
 	breakGlassCredential *cmv1.BreakGlassCredential) (*cmv1.BreakGlassCredential, error) {
 	response, err := c.ocm.ClustersMgmt().V1().
@@ -58,6 +61,17 @@ func (c *Client) DeleteBreakGlassCredentials(clusterID string) error {
 }
 
 func (c *Client) PollKubeconfig(clusterID string, credentialID string) (kubeconfig string, err error) {
+	credential, err := c.PollBreakGlassCredential(clusterID, credentialID)
+	if err != nil {
+		return "", err
+	}
+	return credential.Kubeconfig(), nil
+}
+
+// PollBreakGlassCredential waits until the credential has been issued and its kubeconfig is available,
+// or until it can't be issued anymore
+func (c *Client) PollBreakGlassCredential(clusterID string,
+	credentialID string) (credential *cmv1.BreakGlassCredential, err error) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
 	defer func() {
 		cancel()
@@ -66,7 +80,10 @@ func (c *Client) PollKubeconfig(clusterID string, credentialID string) (kubeconf
 	credentialClient := c.ocm.ClustersMgmt().V1().Clusters().
 		Cluster(clusterID).BreakGlassCredentials().BreakGlassCredential(credentialID)
 	response, err := credentialClient.Poll().
-		Interval(pollKubeconfigInterval).
+		Interval(kubeconfigPollInterval).
+		Predicate(func(response *cmv1.BreakGlassCredentialGetResponse) bool {
+			return isBreakGlassCredentialSettled(response.Body())
+		}).
 		StartContext(ctx)
 	if err != nil {
 		err = fmt.Errorf("Failed to poll kubeconfig for cluster '%s' with break glass credential '%s': %v",
@@ -78,5 +95,21 @@ func (c *Client) PollKubeconfig(clusterID string, credentialID string) (kubeconf
 		return
 	}
 
-	return response.Body().Kubeconfig(), nil
+	credential = response.Body()
+	if credential.Kubeconfig() == "" {
+		return nil, fmt.Errorf("Break glass credential '%s' for cluster '%s' is %s and has no kubeconfig",
+			credentialID, clusterID, credential.Status())
+	}
+	return credential, nil
+}
+
+func isBreakGlassCredentialSettled(credential *cmv1.BreakGlassCredential) bool {
+	if credential.Kubeconfig() != "" {
+		return true
+	}
+	switch credential.Status() {
+	case cmv1.BreakGlassCredentialStatusCreated, "":
+		return false
+	}
+	return true
 }
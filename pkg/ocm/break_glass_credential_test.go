@@ -173,11 +173,46 @@ var _ = Describe("BreakGlassCredential", Ordered, func() {
 		apiServer.AppendHandlers(
 			RespondWithJSON(
 				http.StatusOK,
-				body,
+				formatBreakGlassCredential(cmv1.BreakGlassCredentialStatusIssued, "kubeconfig"),
 			),
 		)
-		_, err := ocmClient.PollKubeconfig(clusterId, breakGlassCredential.ID())
+		kubeconfig, err := ocmClient.PollKubeconfig(clusterId, breakGlassCredential.ID())
 		Expect(err).ToNot(HaveOccurred())
+		Expect(kubeconfig).To(Equal("kubeconfig"))
+	})
+
+	It("OK: PollBreakGlassCredential waits until the credential is issued", func() {
+		interval := kubeconfigPollInterval
+		kubeconfigPollInterval = 10 * time.Millisecond
+		DeferCleanup(func() {
+			kubeconfigPollInterval = interval
+		})
+		apiServer.AppendHandlers(
+			RespondWithJSON(
+				http.StatusOK,
+				formatBreakGlassCredential(cmv1.BreakGlassCredentialStatusCreated, ""),
+			),
+			RespondWithJSON(
+				http.StatusOK,
+				formatBreakGlassCredential(cmv1.BreakGlassCredentialStatusIssued, "kubeconfig"),
+			),
+		)
+		credential, err := ocmClient.PollBreakGlassCredential(clusterId, breakGlassCredential.ID())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(credential.Status()).To(Equal(cmv1.BreakGlassCredentialStatusIssued))
+		Expect(credential.Kubeconfig()).To(Equal("kubeconfig"))
+	})
+
+	It("KO: PollBreakGlassCredential fails when the credential has been revoked", func() {
+		apiServer.AppendHandlers(
+			RespondWithJSON(
+				http.StatusOK,
+				formatBreakGlassCredential(cmv1.BreakGlassCredentialStatusRevoked, ""),
+			),
+		)
+		_, err := ocmClient.PollBreakGlassCredential(clusterId, breakGlassCredential.ID())
+		Expect(err).To(MatchError("Break glass credential 'test-break-glass-credential' for cluster 'foo' " +
+			"is revoked and has no kubeconfig"))
 	})
 
 	It("KO: fails to get PollKubeconfig", func() {
@@ -211,3 +246,12 @@ func CreateBreakGlassCredential() (*cmv1.BreakGlassCredential, string, error) {
 
 	return breakGlassCredentialConfig, buf.String(), nil
 }
+
+func formatBreakGlassCredential(status cmv1.BreakGlassCredentialStatus, kubeconfig string) string {
+	credential, err := cmv1.NewBreakGlassCredential().ID(breakGlassCredentialId).Status(status).
+		Kubeconfig(kubeconfig).Build()
+	Expect(err).NotTo(HaveOccurred())
+	var buf bytes.Buffer
+	Expect(cmv1.MarshalBreakGlassCredential(credential, &buf)).To(Succeed())
+	return buf.String()
+}
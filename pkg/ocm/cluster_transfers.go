@@ -0,0 +1,138 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ocm
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	ocmerrors "github.com/openshift-online/ocm-sdk-go/errors"
+)
+
+const clusterTransfersPath = "/api/accounts_mgmt/v1/cluster_transfers"
+
+const (
+	ClusterTransferStatusPending   = "Pending"
+	ClusterTransferStatusAccepted  = "Accepted"
+	ClusterTransferStatusRescinded = "Rescinded"
+)
+
+// ClusterTransfer is a request to transfer the ownership of a cluster to another user. The
+// version of the SDK used doesn't provide a type for the cluster transfers API yet, so its
+// requests are sent through the generic methods of the connection.
+type ClusterTransfer struct {
+	ID             string    `json:"id"`
+	ClusterUUID    string    `json:"cluster_uuid"`
+	Owner          string    `json:"owner"`
+	Recipient      string    `json:"recipient"`
+	Status         string    `json:"status"`
+	ExpirationDate time.Time `json:"expiration_date"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+func (c *Client) CreateClusterTransfer(clusterUUID string, owner string, recipient string) (*ClusterTransfer, error) {
+	body, err := json.Marshal(map[string]string{
+		"cluster_uuid": clusterUUID,
+		"owner":        owner,
+		"recipient":    recipient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	response, err := sendRawRequest(c.ocm.Post().Path(clusterTransfersPath).
+		Header("Content-Type", "application/json").Bytes(body))
+	if err != nil {
+		return nil, err
+	}
+	transfer := &ClusterTransfer{}
+	err = json.Unmarshal(response, transfer)
+	if err != nil {
+		return nil, err
+	}
+	return transfer, nil
+}
+
+// GetClusterTransfers returns the transfers of the cluster, most recent first
+func (c *Client) GetClusterTransfers(clusterUUID string) ([]*ClusterTransfer, error) {
+	transfers := []*ClusterTransfer{}
+	page := 1
+	size := 100
+	for {
+		response, err := sendRawRequest(c.ocm.Get().Path(clusterTransfersPath).
+			Parameter("search", fmt.Sprintf("cluster_uuid = '%s'", clusterUUID)).
+			Parameter("order", "created_at desc").
+			Parameter("page", page).
+			Parameter("size", size))
+		if err != nil {
+			return nil, err
+		}
+		list := struct {
+			Size  int                `json:"size"`
+			Items []*ClusterTransfer `json:"items"`
+		}{}
+		err = json.Unmarshal(response, &list)
+		if err != nil {
+			return nil, err
+		}
+		transfers = append(transfers, list.Items...)
+		if list.Size < size {
+			break
+		}
+		page++
+	}
+	return transfers, nil
+}
+
+func (c *Client) UpdateClusterTransferStatus(transferID string, status string) (*ClusterTransfer, error) {
+	body, err := json.Marshal(map[string]string{
+		"status": status,
+	})
+	if err != nil {
+		return nil, err
+	}
+	response, err := sendRawRequest(c.ocm.Patch().Path(fmt.Sprintf("%s/%s", clusterTransfersPath, transferID)).
+		Header("Content-Type", "application/json").Bytes(body))
+	if err != nil {
+		return nil, err
+	}
+	transfer := &ClusterTransfer{}
+	err = json.Unmarshal(response, transfer)
+	if err != nil {
+		return nil, err
+	}
+	return transfer, nil
+}
+
+// sendRawRequest sends a request built with the generic methods of the connection and turns the
+// error responses into errors, as the typed clients of the SDK do
+func sendRawRequest(request *sdk.Request) ([]byte, error) {
+	response, err := request.Send()
+	if err != nil {
+		return nil, err
+	}
+	if response.Status() >= http.StatusBadRequest {
+		res, err := ocmerrors.UnmarshalErrorStatus(response.Bytes(), response.Status())
+		if err != nil {
+			return nil, fmt.Errorf("unexpected status %d: %s", response.Status(), response.String())
+		}
+		return nil, handleErr(res, res)
+	}
+	return response.Bytes(), nil
+}
@@ -364,6 +364,7 @@ func (c *Client) GetCluster(clusterKey string, creator *aws.Creator) (*cmv1.Clus
 func (c *Client) GetSubscriptionBySubscriptionID(id string) (*amv1.Subscription, bool, error) {
 	response, err := c.ocm.AccountsMgmt().V1().Subscriptions().Subscription(id).
 		Get().
+		Parameter("fetchAccounts", true).
 		Send()
 
 	if err != nil {
@@ -489,6 +490,45 @@ func (c *Client) HasAClusterUsingOidcEndpointUrl(issuerUrl string) (bool, error)
 	return false, nil
 }
 
+// HasOtherClusterUsingOperatorRolesPrefix checks if a cluster other than the given one uses operator
+// roles with the prefix
+func (c *Client) HasOtherClusterUsingOperatorRolesPrefix(prefix string, clusterID string) (bool, error) {
+	return c.hasOtherCluster(fmt.Sprintf(
+		"aws.sts.operator_iam_roles.role_arn like '%%/%s-%%'", prefix,
+	), clusterID)
+}
+
+// HasOtherClusterUsingOidcProvider checks if a cluster other than the given one uses the OIDC
+// provider of the issuer URL in the AWS account
+func (c *Client) HasOtherClusterUsingOidcProvider(issuerUrl string, curAccountId string,
+	clusterID string) (bool, error) {
+	return c.hasOtherCluster(fmt.Sprintf(
+		"aws.sts.oidc_endpoint_url = '%s' AND aws.sts.role_arn like '%%%s%%'",
+		issuerUrl, curAccountId,
+	), clusterID)
+}
+
+// HasOtherClusterUsingOidcEndpointUrl checks if a cluster other than the given one uses the OIDC
+// configuration of the issuer URL
+func (c *Client) HasOtherClusterUsingOidcEndpointUrl(issuerUrl string, clusterID string) (bool, error) {
+	return c.hasOtherCluster(fmt.Sprintf(
+		"aws.sts.oidc_endpoint_url = '%s'", issuerUrl,
+	), clusterID)
+}
+
+func (c *Client) hasOtherCluster(query string, clusterID string) (bool, error) {
+	query = fmt.Sprintf("%s AND id != '%s'", query, clusterID)
+	response, err := c.ocm.ClustersMgmt().V1().Clusters().List().
+		Search(query).
+		Page(1).
+		Size(1).
+		Send()
+	if err != nil {
+		return false, handleErr(response.Error(), err)
+	}
+	return response.Total() > 0, nil
+}
+
 func (c *Client) IsSTSClusterExists(creator *aws.Creator, count int, roleARN string) (exists bool, err error) {
 	if count < 1 {
 		err = errors.Errorf("Cannot fetch fewer than 1 cluster")
@@ -526,8 +566,11 @@ func (c *Client) GetClusterState(clusterID string) (cmv1.ClusterState, error) {
 		Status().
 		Get().
 		Send()
-	if err != nil || response.Body() == nil {
-		return cmv1.ClusterState(""), err
+	if err != nil {
+		return cmv1.ClusterState(""), handleErr(response.Error(), err)
+	}
+	if response.Body() == nil {
+		return cmv1.ClusterState(""), nil
 	}
 	return response.Body().State(), nil
 }
@@ -63,6 +63,19 @@ func (c *Client) CreateIdentityProvider(clusterID string, idp *cmv1.IdentityProv
 	return response.Body(), nil
 }
 
+func (c *Client) UpdateIdentityProvider(clusterID string, idpID string,
+	idp *cmv1.IdentityProvider) (*cmv1.IdentityProvider, error) {
+	response, err := c.ocm.ClustersMgmt().V1().
+		Clusters().Cluster(clusterID).
+		IdentityProviders().IdentityProvider(idpID).
+		Update().Body(idp).
+		Send()
+	if err != nil {
+		return nil, handleErr(response.Error(), err)
+	}
+	return response.Body(), nil
+}
+
 func (c *Client) GetHTPasswdUserList(clusterID, htpasswdIDPId string) (*cmv1.HTPasswdUserList, error) {
 	listResponse, err := c.ocm.ClustersMgmt().V1().Clusters().Cluster(clusterID).
 		IdentityProviders().IdentityProvider(htpasswdIDPId).HtpasswdUsers().List().Send()
@@ -98,6 +111,20 @@ func (c *Client) AddHTPasswdUsers(userList *cmv1.HTPasswdUserList, clusterID, id
 	return nil
 }
 
+func (c *Client) UpdateHTPasswdUser(clusterID, idpID, userID, hashedPassword string) error {
+	htpasswdUser, err := cmv1.NewHTPasswdUser().HashedPassword(hashedPassword).Build()
+	if err != nil {
+		return err
+	}
+	response, err := c.ocm.ClustersMgmt().V1().Clusters().Cluster(clusterID).
+		IdentityProviders().IdentityProvider(idpID).HtpasswdUsers().HtpasswdUser(userID).
+		Update().Body(htpasswdUser).Send()
+	if err != nil {
+		return handleErr(response.Error(), err)
+	}
+	return nil
+}
+
 func (c *Client) DeleteHTPasswdUser(username, clusterID string, htpasswdIDP *cmv1.IdentityProvider) error {
 	var userID string
 
@@ -139,6 +166,35 @@ func (c *Client) DeleteIdentityProvider(clusterID string, idpID string) error {
 	return nil
 }
 
+// FindHTPasswdIdentityProvider returns the HTPasswd identity provider with the given name, or the only
+// HTPasswd identity provider of the cluster when no name is given
+func FindHTPasswdIdentityProvider(idps []*cmv1.IdentityProvider, idpName string) (*cmv1.IdentityProvider, error) {
+	var htpasswdIDPs []*cmv1.IdentityProvider
+	for _, idp := range idps {
+		if idpName != "" && idp.Name() == idpName {
+			if idp.Type() != cmv1.IdentityProviderTypeHtpasswd {
+				return nil, fmt.Errorf("Identity provider '%s' is of type '%s', expected '%s'",
+					idpName, IdentityProviderType(idp), HTPasswdIDPType)
+			}
+			return idp, nil
+		}
+		if idp.Type() == cmv1.IdentityProviderTypeHtpasswd {
+			htpasswdIDPs = append(htpasswdIDPs, idp)
+		}
+	}
+	if idpName != "" {
+		return nil, fmt.Errorf("Identity provider '%s' not found", idpName)
+	}
+	switch len(htpasswdIDPs) {
+	case 0:
+		return nil, fmt.Errorf("No %s identity provider found", HTPasswdIDPType)
+	case 1:
+		return htpasswdIDPs[0], nil
+	}
+	return nil, fmt.Errorf("Found %d %s identity providers, expected the name of one of them",
+		len(htpasswdIDPs), HTPasswdIDPType)
+}
+
 func IdentityProviderType(idp *cmv1.IdentityProvider) string {
 	switch idp.Type() {
 	case cmv1.IdentityProviderTypeGithub:
@@ -59,4 +59,39 @@ var _ = Describe("IDPs", func() {
 			})
 		})
 	})
+
+	Context("FindHTPasswdIdentityProvider", func() {
+		buildIdp := func(name string, idpType cmv1.IdentityProviderType) *cmv1.IdentityProvider {
+			idp, err := cmv1.NewIdentityProvider().ID(name + "-id").Name(name).Type(idpType).Build()
+			Expect(err).To(BeNil())
+			return idp
+		}
+		github := buildIdp("github-1", cmv1.IdentityProviderTypeGithub)
+		htpasswd := buildIdp("htpasswd-1", cmv1.IdentityProviderTypeHtpasswd)
+		clusterAdmin := buildIdp("cluster-admin", cmv1.IdentityProviderTypeHtpasswd)
+
+		It("Finds the identity provider by name", func() {
+			idp, err := FindHTPasswdIdentityProvider([]*cmv1.IdentityProvider{github, htpasswd, clusterAdmin},
+				"htpasswd-1")
+			Expect(err).To(BeNil())
+			Expect(idp).To(Equal(htpasswd))
+		})
+		It("Fails when the named identity provider is not HTPasswd", func() {
+			_, err := FindHTPasswdIdentityProvider([]*cmv1.IdentityProvider{github, htpasswd}, "github-1")
+			Expect(err).To(MatchError("Identity provider 'github-1' is of type 'GitHub', expected 'HTPasswd'"))
+		})
+		It("Fails when the named identity provider does not exist", func() {
+			_, err := FindHTPasswdIdentityProvider([]*cmv1.IdentityProvider{github, htpasswd}, "htpasswd-2")
+			Expect(err).To(MatchError("Identity provider 'htpasswd-2' not found"))
+		})
+		It("Returns the only HTPasswd identity provider without name", func() {
+			idp, err := FindHTPasswdIdentityProvider([]*cmv1.IdentityProvider{github, htpasswd}, "")
+			Expect(err).To(BeNil())
+			Expect(idp).To(Equal(htpasswd))
+		})
+		It("Fails without name when there are several HTPasswd identity providers", func() {
+			_, err := FindHTPasswdIdentityProvider([]*cmv1.IdentityProvider{github, htpasswd, clusterAdmin}, "")
+			Expect(err).To(MatchError("Found 2 HTPasswd identity providers, expected the name of one of them"))
+		})
+	})
 })
@@ -19,6 +19,7 @@ package ocm
 import (
 	"errors"
 	"fmt"
+	"regexp"
 	"strings"
 
 	amsv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
@@ -29,6 +30,14 @@ import (
 
 const AcceleratedComputing = "accelerated_computing"
 
+const (
+	ArchitectureAmd64 = "amd64"
+	ArchitectureArm64 = "arm64"
+)
+
+// AWS Graviton instance families have a 'g' right after the generation number, e.g. 'm6g' or 'c7gn'
+var armInstanceTypeRE = regexp.MustCompile(`^(a1|[a-z]+[0-9]+g[a-z-]*)\.`)
+
 func (c *Client) GetMachineTypesInRegion(cloudProviderData *cmv1.CloudProviderData) (MachineTypeList, error) {
 	collection := c.ocm.ClustersMgmt().V1().AWSInquiries().MachineTypes()
 	page := 1
@@ -112,6 +121,14 @@ type MachineType struct {
 	availableQuota int
 }
 
+// Architecture returns the CPU architecture of the machine type, derived from its instance family
+func (mt MachineType) Architecture() string {
+	if armInstanceTypeRE.MatchString(mt.MachineType.ID()) {
+		return ArchitectureArm64
+	}
+	return ArchitectureAmd64
+}
+
 func (mt MachineType) HasQuota(multiAZ bool) bool {
 	return mt.MachineType.Category() != AcceleratedComputing || mt.availableQuota > getDefaultNodes(multiAZ)
 }
@@ -166,27 +183,7 @@ func (c *Client) GetAvailableMachineTypes() (MachineTypeList, error) {
 }
 
 func (c *Client) getQuotaCosts() (*amsv1.QuotaCostList, error) {
-	acctResponse, err := c.ocm.AccountsMgmt().V1().CurrentAccount().
-		Get().
-		Send()
-	if err != nil {
-		return nil, handleErr(acctResponse.Error(), err)
-	}
-	organization := acctResponse.Body().Organization().ID()
-	quotaCostResponse, err := c.ocm.AccountsMgmt().V1().Organizations().
-		Organization(organization).
-		QuotaCost().
-		List().
-		Parameter("fetchRelatedResources", true).
-		Parameter("search", "quota_id~='gpu'").
-		Page(1).
-		Size(-1).
-		Send()
-	if err != nil {
-		return nil, handleErr(quotaCostResponse.Error(), err)
-	}
-	quotaCosts := quotaCostResponse.Items()
-	return quotaCosts, nil
+	return c.listQuotaCosts("quota_id~='gpu'")
 }
 
 // A list of MachineTypes with additional information
@@ -0,0 +1,42 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ocm
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
+
+var _ = Describe("Machine types", func() {
+	DescribeTable("Architecture",
+		func(id string, expected string) {
+			machineType, err := cmv1.NewMachineType().ID(id).Build()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(MachineType{MachineType: machineType}.Architecture()).To(Equal(expected))
+		},
+		Entry("Graviton general purpose", "m6g.xlarge", ArchitectureArm64),
+		Entry("Graviton with local storage", "r6gd.2xlarge", ArchitectureArm64),
+		Entry("Graviton with enhanced networking", "c7gn.4xlarge", ArchitectureArm64),
+		Entry("First generation Graviton", "a1.large", ArchitectureArm64),
+		Entry("Graviton GPU", "g5g.xlarge", ArchitectureArm64),
+		Entry("Intel general purpose", "m5.xlarge", ArchitectureAmd64),
+		Entry("Intel GPU", "g4dn.12xlarge", ArchitectureAmd64),
+		Entry("Intel with local storage", "m6id.large", ArchitectureAmd64),
+		Entry("AMD", "r6a.2xlarge", ArchitectureAmd64),
+	)
+})
@@ -1,6 +1,12 @@
 package ocm
 
-import cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+import (
+	"context"
+	"fmt"
+	"time"
+
+	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+)
 
 func (c *Client) CreateNodePool(clusterID string, nodePool *cmv1.NodePool) (*cmv1.NodePool, error) {
 	response, err := c.ocm.ClustersMgmt().V1().
@@ -65,3 +71,27 @@ func (c *Client) DeleteNodePool(clusterID string, nodePoolID string) error {
 	}
 	return nil
 }
+
+// PollNodePool waits until the node pool satisfies the predicate or the timeout expires
+func (c *Client) PollNodePool(clusterID string, nodePoolID string, interval time.Duration, timeout time.Duration,
+	predicate func(*cmv1.NodePool) bool) (*cmv1.NodePool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	response, err := c.ocm.ClustersMgmt().V1().
+		Clusters().Cluster(clusterID).
+		NodePools().NodePool(nodePoolID).
+		Poll().
+		Interval(interval).
+		Predicate(func(response *cmv1.NodePoolGetResponse) bool {
+			return predicate(response.Body())
+		}).
+		StartContext(ctx)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("Timed out after %s waiting for node pool '%s'", timeout, nodePoolID)
+		}
+		return nil, handleErr(response.Error(), err)
+	}
+	return response.Body(), nil
+}
@@ -0,0 +1,71 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ocm
+
+import (
+	"encoding/json"
+	"fmt"
+
+	amsv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
+)
+
+// The notification contacts of a subscription aren't available in the version of the SDK used, so
+// their requests are sent through the generic methods of the connection
+func notificationContactsPath(subscriptionID string) string {
+	return fmt.Sprintf("/api/accounts_mgmt/v1/subscriptions/%s/notification_contacts", subscriptionID)
+}
+
+func (c *Client) GetNotificationContacts(subscriptionID string) ([]*amsv1.Account, error) {
+	response, err := sendRawRequest(c.ocm.Get().Path(notificationContactsPath(subscriptionID)))
+	if err != nil {
+		return nil, err
+	}
+	list := struct {
+		Items json.RawMessage `json:"items"`
+	}{}
+	err = json.Unmarshal(response, &list)
+	if err != nil {
+		return nil, err
+	}
+	if len(list.Items) == 0 {
+		return []*amsv1.Account{}, nil
+	}
+	return amsv1.UnmarshalAccountList([]byte(list.Items))
+}
+
+// AddNotificationContact adds the account with the given username or email to the notification
+// contacts of the subscription
+func (c *Client) AddNotificationContact(subscriptionID string, accountIdentifier string) (*amsv1.Account, error) {
+	body, err := json.Marshal(map[string]string{
+		"account_identifier": accountIdentifier,
+	})
+	if err != nil {
+		return nil, err
+	}
+	response, err := sendRawRequest(c.ocm.Post().Path(notificationContactsPath(subscriptionID)).
+		Header("Content-Type", "application/json").Bytes(body))
+	if err != nil {
+		return nil, err
+	}
+	return amsv1.UnmarshalAccount(response)
+}
+
+func (c *Client) DeleteNotificationContact(subscriptionID string, accountID string) error {
+	_, err := sendRawRequest(c.ocm.Delete().Path(fmt.Sprintf("%s/%s", notificationContactsPath(subscriptionID),
+		accountID)))
+	return err
+}
@@ -0,0 +1,97 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ocm
+
+import (
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	sdk "github.com/openshift-online/ocm-sdk-go"
+	"github.com/openshift-online/ocm-sdk-go/logging"
+	. "github.com/openshift-online/ocm-sdk-go/testing"
+)
+
+var _ = Describe("Notification contacts", func() {
+	var apiServer *ghttp.Server
+	var ocmClient *Client
+
+	BeforeEach(func() {
+		apiServer = MakeTCPServer()
+		accessToken := MakeTokenString("Bearer", 15*time.Minute)
+		logger, err := logging.NewGoLoggerBuilder().
+			Debug(true).
+			Build()
+		Expect(err).To(BeNil())
+		connection, err := sdk.NewConnectionBuilder().
+			Logger(logger).
+			Tokens(accessToken).
+			URL(apiServer.URL()).
+			Build()
+		Expect(err).To(BeNil())
+		ocmClient = &Client{ocm: connection}
+	})
+
+	AfterEach(func() {
+		apiServer.Close()
+		Expect(ocmClient.Close()).To(Succeed())
+	})
+
+	It("Lists the notification contacts of a subscription", func() {
+		apiServer.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodGet, "/api/accounts_mgmt/v1/subscriptions/sub1/notification_contacts"),
+				RespondWithJSON(http.StatusOK, `{"kind": "AccountList", "page": 1, "size": 1, "total": 1, `+
+					`"items": [{"kind": "Account", "id": "acc1", "username": "jdoe", "email": "jdoe@example.com"}]}`),
+			),
+		)
+		contacts, err := ocmClient.GetNotificationContacts("sub1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(contacts).To(HaveLen(1))
+		Expect(contacts[0].ID()).To(Equal("acc1"))
+		Expect(contacts[0].Username()).To(Equal("jdoe"))
+	})
+
+	It("Adds a notification contact by username", func() {
+		apiServer.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodPost, "/api/accounts_mgmt/v1/subscriptions/sub1/notification_contacts"),
+				ghttp.VerifyJSON(`{"account_identifier": "jdoe"}`),
+				RespondWithJSON(http.StatusCreated, `{"kind": "Account", "id": "acc1", "username": "jdoe"}`),
+			),
+		)
+		account, err := ocmClient.AddNotificationContact("sub1", "jdoe")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(account.ID()).To(Equal("acc1"))
+	})
+
+	It("Returns the error of a failed removal", func() {
+		apiServer.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodDelete,
+					"/api/accounts_mgmt/v1/subscriptions/sub1/notification_contacts/acc1"),
+				RespondWithJSON(http.StatusNotFound, `{"kind": "Error", "id": "404", "code": "ACCT-MGMT-7", `+
+					`"reason": "Notification contact not found"}`),
+			),
+		)
+		err := ocmClient.DeleteNotificationContact("sub1", "acc1")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("Notification contact not found"))
+	})
+})
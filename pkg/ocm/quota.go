@@ -0,0 +1,119 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ocm
+
+import (
+	"sort"
+
+	amsv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
+)
+
+// QuotaUsage is the usage of the quota of an organization for one kind of resource
+type QuotaUsage struct {
+	QuotaID      string `json:"quota_id"`
+	ResourceType string `json:"resource_type"`
+	ResourceName string `json:"resource_name"`
+	BillingModel string `json:"billing_model"`
+	Product      string `json:"product"`
+	Allowed      int    `json:"allowed"`
+	Consumed     int    `json:"consumed"`
+	// Cost is the number of units of the quota used by each resource, a cost of zero means that
+	// the resources don't consume the quota
+	Cost int `json:"cost"`
+}
+
+// Unlimited returns true when the resources don't consume the quota
+func (q *QuotaUsage) Unlimited() bool {
+	return q.Cost == 0
+}
+
+// Remaining returns the number of resources that can still be created with the quota
+func (q *QuotaUsage) Remaining() int {
+	if q.Unlimited() || q.Allowed <= q.Consumed {
+		return 0
+	}
+	return (q.Allowed - q.Consumed) / q.Cost
+}
+
+// GetQuotaCosts returns all the quota costs of the organization of the current account, including
+// the resources they apply to
+func (c *Client) GetQuotaCosts() ([]*amsv1.QuotaCost, error) {
+	quotaCosts, err := c.listQuotaCosts("")
+	if err != nil {
+		return nil, err
+	}
+	return quotaCosts.Slice(), nil
+}
+
+func (c *Client) listQuotaCosts(search string) (*amsv1.QuotaCostList, error) {
+	acctResponse, err := c.ocm.AccountsMgmt().V1().CurrentAccount().
+		Get().
+		Send()
+	if err != nil {
+		return nil, handleErr(acctResponse.Error(), err)
+	}
+	organization := acctResponse.Body().Organization().ID()
+	request := c.ocm.AccountsMgmt().V1().Organizations().
+		Organization(organization).
+		QuotaCost().
+		List().
+		Parameter("fetchRelatedResources", true).
+		Page(1).
+		Size(-1)
+	if search != "" {
+		request = request.Parameter("search", search)
+	}
+	quotaCostResponse, err := request.Send()
+	if err != nil {
+		return nil, handleErr(quotaCostResponse.Error(), err)
+	}
+	return quotaCostResponse.Items(), nil
+}
+
+// GetQuotaUsages returns the usage of the quota costs for each kind of resource they apply to,
+// sorted by resource type and name
+func GetQuotaUsages(quotaCosts []*amsv1.QuotaCost) []*QuotaUsage {
+	usages := []*QuotaUsage{}
+	seen := map[QuotaUsage]bool{}
+	for _, quotaCost := range quotaCosts {
+		for _, relatedResource := range quotaCost.RelatedResources() {
+			usage := QuotaUsage{
+				QuotaID:      quotaCost.QuotaID(),
+				ResourceType: relatedResource.ResourceType(),
+				ResourceName: relatedResource.ResourceName(),
+				BillingModel: relatedResource.BillingModel(),
+				Product:      relatedResource.Product(),
+				Allowed:      quotaCost.Allowed(),
+				Consumed:     quotaCost.Consumed(),
+				Cost:         relatedResource.Cost(),
+			}
+			// The same resource is usually listed once per availability zone type and BYOC option
+			if seen[usage] {
+				continue
+			}
+			seen[usage] = true
+			usages = append(usages, &usage)
+		}
+	}
+	sort.SliceStable(usages, func(i, j int) bool {
+		if usages[i].ResourceType != usages[j].ResourceType {
+			return usages[i].ResourceType < usages[j].ResourceType
+		}
+		return usages[i].ResourceName < usages[j].ResourceName
+	})
+	return usages
+}
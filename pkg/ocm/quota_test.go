@@ -0,0 +1,62 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ocm
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	amsv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
+)
+
+var _ = Describe("Quota", func() {
+	Context("GetQuotaUsages", func() {
+		It("Lists each resource once, sorted by type and name", func() {
+			quotaCost, err := amsv1.NewQuotaCost().QuotaID("compute.node|gpu").Allowed(40).Consumed(16).
+				RelatedResources(
+					amsv1.NewRelatedResource().ResourceType("compute.node").ResourceName("gpu-8").
+						BillingModel("standard").Product("ROSA").Cost(8).AvailabilityZoneType("single"),
+					amsv1.NewRelatedResource().ResourceType("compute.node").ResourceName("gpu-8").
+						BillingModel("standard").Product("ROSA").Cost(8).AvailabilityZoneType("multi"),
+					amsv1.NewRelatedResource().ResourceType("compute.node").ResourceName("gpu-4").
+						BillingModel("standard").Product("ROSA").Cost(4),
+				).Build()
+			Expect(err).ToNot(HaveOccurred())
+			clusterCost, err := amsv1.NewQuotaCost().QuotaID("cluster|byoc|moa").
+				RelatedResources(
+					amsv1.NewRelatedResource().ResourceType("cluster").ResourceName("any").
+						BillingModel("marketplace").Product("ROSA").Cost(0),
+				).Build()
+			Expect(err).ToNot(HaveOccurred())
+
+			usages := GetQuotaUsages([]*amsv1.QuotaCost{quotaCost, clusterCost})
+			Expect(usages).To(HaveLen(3))
+			Expect(usages[0].ResourceType).To(Equal("cluster"))
+			Expect(usages[0].Unlimited()).To(BeTrue())
+			Expect(usages[1].ResourceName).To(Equal("gpu-4"))
+			Expect(usages[1].Remaining()).To(Equal(6))
+			Expect(usages[2].ResourceName).To(Equal("gpu-8"))
+			Expect(usages[2].Remaining()).To(Equal(3))
+		})
+	})
+
+	Context("Remaining", func() {
+		It("Doesn't go below zero when the quota is exceeded", func() {
+			usage := &QuotaUsage{Allowed: 4, Consumed: 8, Cost: 4}
+			Expect(usage.Remaining()).To(Equal(0))
+		})
+	})
+})
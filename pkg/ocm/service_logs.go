@@ -0,0 +1,131 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ocm
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	slv1 "github.com/openshift-online/ocm-sdk-go/servicelogs/v1"
+
+	"github.com/openshift/rosa/pkg/helper"
+)
+
+// ServiceLogSeverities are the severities of the service logs, from the least to the most severe
+var ServiceLogSeverities = []string{
+	string(slv1.SeverityDebug),
+	string(slv1.SeverityInfo),
+	string(slv1.SeverityWarning),
+	string(slv1.SeverityError),
+	string(slv1.SeverityFatal),
+}
+
+// ServiceLogFilter narrows down the service logs of a cluster
+type ServiceLogFilter struct {
+	Severities []string
+	Since      time.Time
+	Search     string
+}
+
+// Query builds the search query of the service logs API for the filter
+func (f ServiceLogFilter) Query() string {
+	terms := []string{}
+	if len(f.Severities) > 0 {
+		severities := make([]string, 0, len(f.Severities))
+		for _, severity := range f.Severities {
+			severities = append(severities, fmt.Sprintf("'%s'", escapeSearchValue(severity)))
+		}
+		terms = append(terms, fmt.Sprintf("severity IN (%s)", strings.Join(severities, ", ")))
+	}
+	if !f.Since.IsZero() {
+		terms = append(terms, fmt.Sprintf("timestamp >= '%s'", f.Since.UTC().Format(time.RFC3339)))
+	}
+	if f.Search != "" {
+		search := escapeSearchValue(f.Search)
+		terms = append(terms, fmt.Sprintf("(summary ILIKE '%%%s%%' OR description ILIKE '%%%s%%')", search, search))
+	}
+	return strings.Join(terms, " AND ")
+}
+
+func escapeSearchValue(value string) string {
+	return strings.ReplaceAll(value, "'", "''")
+}
+
+// ValidateServiceLogSeverities checks the severities and returns them with the casing used by the API
+func ValidateServiceLogSeverities(severities []string) ([]string, error) {
+	result := make([]string, 0, len(severities))
+	for _, severity := range severities {
+		found := false
+		for _, valid := range ServiceLogSeverities {
+			if strings.EqualFold(severity, valid) {
+				if !helper.Contains(result, valid) {
+					result = append(result, valid)
+				}
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("invalid severity '%s', valid severities are %s", severity,
+				strings.Join(ServiceLogSeverities, ", "))
+		}
+	}
+	return result, nil
+}
+
+// GetServiceLogs returns the service logs of the cluster matching the filter, oldest first
+func (c *Client) GetServiceLogs(clusterID string, filter ServiceLogFilter) ([]*slv1.LogEntry, error) {
+	collection := c.ocm.ServiceLogs().V1().Clusters().ClusterLogs()
+	query := filter.Query()
+	logEntries := []*slv1.LogEntry{}
+	page := 1
+	size := 100
+	for {
+		request := collection.List().
+			ClusterID(clusterID).
+			Order("timestamp asc").
+			Page(page).
+			Size(size)
+		if query != "" {
+			request = request.Search(query)
+		}
+		response, err := request.Send()
+		if err != nil {
+			return nil, handleErr(response.Error(), err)
+		}
+		logEntries = append(logEntries, response.Items().Slice()...)
+		if response.Size() < size {
+			break
+		}
+		page++
+	}
+	return logEntries, nil
+}
+
+// GetServiceLog returns the service log with the given ID, it returns false if it doesn't exist
+func (c *Client) GetServiceLog(id string) (*slv1.LogEntry, bool, error) {
+	response, err := c.ocm.ServiceLogs().V1().ClusterLogs().LogEntry(id).Get().Send()
+	if response.Status() == http.StatusNotFound {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, handleErr(response.Error(), err)
+	}
+	return response.Body(), true, nil
+}
@@ -0,0 +1,57 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ocm
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Service logs", func() {
+	Context("ServiceLogFilter", func() {
+		It("Builds an empty query without filters", func() {
+			Expect(ServiceLogFilter{}.Query()).To(BeEmpty())
+		})
+		It("Combines severities, date and text", func() {
+			since, err := time.Parse(time.RFC3339, "2024-01-31T10:00:00+02:00")
+			Expect(err).ToNot(HaveOccurred())
+			filter := ServiceLogFilter{
+				Severities: []string{"Warning", "Error"},
+				Since:      since,
+				Search:     "customer's",
+			}
+			Expect(filter.Query()).To(Equal("severity IN ('Warning', 'Error') AND " +
+				"timestamp >= '2024-01-31T08:00:00Z' AND " +
+				"(summary ILIKE '%customer''s%' OR description ILIKE '%customer''s%')"))
+		})
+	})
+
+	Context("ValidateServiceLogSeverities", func() {
+		It("Normalizes the severities", func() {
+			severities, err := ValidateServiceLogSeverities([]string{"error", "WARNING", "Error"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(severities).To(Equal([]string{"Error", "Warning"}))
+		})
+		It("Fails with an unknown severity", func() {
+			_, err := ValidateServiceLogSeverities([]string{"critical"})
+			Expect(err).To(MatchError("invalid severity 'critical', valid severities are " +
+				"Debug, Info, Warning, Error, Fatal"))
+		})
+	})
+})
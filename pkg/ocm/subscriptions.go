@@ -0,0 +1,148 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ocm
+
+import (
+	"fmt"
+	"strings"
+
+	amsv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
+
+	"github.com/openshift/rosa/pkg/helper"
+)
+
+// SubscriptionStatuses are the statuses of the subscriptions of the clusters
+var SubscriptionStatuses = []string{
+	"Active",
+	"Deprovisioned",
+	"Disconnected",
+	"Reserved",
+	"Stale",
+	"Archived",
+}
+
+// SubscriptionSupportLevels are the support levels of the subscriptions of the clusters
+var SubscriptionSupportLevels = []string{
+	"Eval",
+	"Standard",
+	"Premium",
+	"Self-Support",
+	"None",
+}
+
+// SubscriptionFilter narrows down the subscriptions of the ROSA clusters of an organization
+type SubscriptionFilter struct {
+	OrganizationID string
+	Statuses       []string
+	BillingModels  []string
+	SupportLevels  []string
+	Search         string
+}
+
+// Query builds the search query of the subscriptions API for the filter
+func (f SubscriptionFilter) Query() string {
+	terms := []string{"plan.id IN ('MOA', 'MOA-HostedControlPlane')"}
+	if f.OrganizationID != "" {
+		terms = append(terms, fmt.Sprintf("organization_id = '%s'", escapeSearchValue(f.OrganizationID)))
+	}
+	if len(f.Statuses) > 0 {
+		terms = append(terms, fmt.Sprintf("status IN (%s)", quoteSearchValues(f.Statuses)))
+	}
+	if len(f.BillingModels) > 0 {
+		terms = append(terms, fmt.Sprintf("cluster_billing_model IN (%s)", quoteSearchValues(f.BillingModels)))
+	}
+	if len(f.SupportLevels) > 0 {
+		terms = append(terms, fmt.Sprintf("support_level IN (%s)", quoteSearchValues(f.SupportLevels)))
+	}
+	if f.Search != "" {
+		search := escapeSearchValue(f.Search)
+		terms = append(terms, fmt.Sprintf("(display_name ILIKE '%%%s%%' OR cluster_id ILIKE '%%%s%%' OR "+
+			"external_cluster_id ILIKE '%%%s%%')", search, search, search))
+	}
+	return strings.Join(terms, " AND ")
+}
+
+func quoteSearchValues(values []string) string {
+	quoted := make([]string, 0, len(values))
+	for _, value := range values {
+		quoted = append(quoted, fmt.Sprintf("'%s'", escapeSearchValue(value)))
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// ValidateSubscriptionValues checks the values of the given kind and returns them with the casing
+// used by the API
+func ValidateSubscriptionValues(kind string, values []string, valid []string) ([]string, error) {
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		found := false
+		for _, validValue := range valid {
+			if strings.EqualFold(value, validValue) {
+				if !helper.Contains(result, validValue) {
+					result = append(result, validValue)
+				}
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("invalid %s '%s', valid values are %s", kind, value,
+				strings.Join(valid, ", "))
+		}
+	}
+	return result, nil
+}
+
+// GetSubscriptions returns the subscriptions matching the filter, including the accounts of their
+// creators, most recent first
+func (c *Client) GetSubscriptions(filter SubscriptionFilter) ([]*amsv1.Subscription, error) {
+	collection := c.ocm.AccountsMgmt().V1().Subscriptions()
+	subscriptions := []*amsv1.Subscription{}
+	page := 1
+	size := 100
+	for {
+		response, err := collection.List().
+			Search(filter.Query()).
+			FetchAccounts(true).
+			Order("created_at desc").
+			Page(page).
+			Size(size).
+			Send()
+		if err != nil {
+			return nil, handleErr(response.Error(), err)
+		}
+		subscriptions = append(subscriptions, response.Items().Slice()...)
+		if response.Size() < size {
+			break
+		}
+		page++
+	}
+	return subscriptions, nil
+}
+
+// FormatSubscriptionCreator returns the username and email of the creator of a subscription,
+// falling back to the ID of the account when it wasn't fetched
+func FormatSubscriptionCreator(subscription *amsv1.Subscription) string {
+	creator := subscription.Creator()
+	switch {
+	case creator.Username() != "" && creator.Email() != "":
+		return fmt.Sprintf("%s (%s)", creator.Username(), creator.Email())
+	case creator.Username() != "":
+		return creator.Username()
+	}
+	return creator.ID()
+}
@@ -0,0 +1,76 @@
+/*
+Copyright (c) 2024 Red Hat, Inc.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+  http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ocm
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	amsv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
+)
+
+var _ = Describe("Subscriptions", func() {
+	Context("SubscriptionFilter", func() {
+		It("Only selects ROSA clusters without filters", func() {
+			Expect(SubscriptionFilter{}.Query()).To(Equal("plan.id IN ('MOA', 'MOA-HostedControlPlane')"))
+		})
+		It("Combines organization, statuses, billing models, support levels and text", func() {
+			filter := SubscriptionFilter{
+				OrganizationID: "org1",
+				Statuses:       []string{"Active", "Stale"},
+				BillingModels:  []string{"marketplace-aws"},
+				SupportLevels:  []string{"Premium"},
+				Search:         "o'prod",
+			}
+			Expect(filter.Query()).To(Equal("plan.id IN ('MOA', 'MOA-HostedControlPlane') AND " +
+				"organization_id = 'org1' AND status IN ('Active', 'Stale') AND " +
+				"cluster_billing_model IN ('marketplace-aws') AND support_level IN ('Premium') AND " +
+				"(display_name ILIKE '%o''prod%' OR cluster_id ILIKE '%o''prod%' OR " +
+				"external_cluster_id ILIKE '%o''prod%')"))
+		})
+	})
+
+	Context("ValidateSubscriptionValues", func() {
+		It("Normalizes the values", func() {
+			statuses, err := ValidateSubscriptionValues("status", []string{"active", "STALE", "Active"},
+				SubscriptionStatuses)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(statuses).To(Equal([]string{"Active", "Stale"}))
+		})
+		It("Fails with an unknown value", func() {
+			_, err := ValidateSubscriptionValues("support level", []string{"gold"}, SubscriptionSupportLevels)
+			Expect(err).To(MatchError("invalid support level 'gold', valid values are " +
+				"Eval, Standard, Premium, Self-Support, None"))
+		})
+	})
+
+	Context("FormatSubscriptionCreator", func() {
+		It("Shows the username and email of the creator", func() {
+			subscription, err := amsv1.NewSubscription().
+				Creator(amsv1.NewAccount().ID("acc1").Username("jdoe").Email("jdoe@example.com")).
+				Build()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(FormatSubscriptionCreator(subscription)).To(Equal("jdoe (jdoe@example.com)"))
+		})
+		It("Falls back to the ID of the account", func() {
+			subscription, err := amsv1.NewSubscription().
+				Creator(amsv1.NewAccount().ID("acc1")).
+				Build()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(FormatSubscriptionCreator(subscription)).To(Equal("acc1"))
+		})
+	})
+})
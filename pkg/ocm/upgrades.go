@@ -54,23 +54,31 @@ func (c *Client) GetScheduledUpgrade(clusterID string) (*cmv1.UpgradePolicy, *cm
 	}
 	for _, upgradePolicy := range upgradePolicies {
 		if upgradePolicy.UpgradeType() == cmv1.UpgradeTypeOSD {
-			state, err := c.ocm.ClustersMgmt().V1().
-				Clusters().Cluster(clusterID).
-				UpgradePolicies().UpgradePolicy(upgradePolicy.ID()).
-				State().
-				Get().
-				Send()
+			state, err := c.GetUpgradePolicyState(clusterID, upgradePolicy.ID())
 			if err != nil {
 				return nil, nil, err
 			}
 
-			return upgradePolicy, state.Body(), nil
+			return upgradePolicy, state, nil
 		}
 	}
 
 	return nil, nil, nil
 }
 
+func (c *Client) GetUpgradePolicyState(clusterID string, upgradePolicyID string) (*cmv1.UpgradePolicyState, error) {
+	response, err := c.ocm.ClustersMgmt().V1().
+		Clusters().Cluster(clusterID).
+		UpgradePolicies().UpgradePolicy(upgradePolicyID).
+		State().
+		Get().
+		Send()
+	if err != nil {
+		return nil, err
+	}
+	return response.Body(), nil
+}
+
 func (c *Client) ScheduleUpgrade(clusterID string, upgradePolicy *cmv1.UpgradePolicy) error {
 	response, err := c.ocm.ClustersMgmt().V1().
 		Clusters().Cluster(clusterID).
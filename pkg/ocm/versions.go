@@ -416,6 +416,26 @@ func CheckAndParseVersion(availableUpgrades []string, version string, cluster *c
 }
 
 func (c *Client) IsVersionCloseToEol(daysAwayToCheck int, version string, channelGroup string) error {
+	endOfLife, err := c.GetVersionEndOfLife(version, channelGroup)
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	if !endOfLife.IsZero() &&
+		endOfLife.Compare(
+			now.Add(time.Duration(daysAwayToCheck)*OneDayHourDuration*time.Hour)) <= 0 {
+		return fmt.Errorf(
+			"The version of Red Hat OpenShift Service on AWS that you are installing will no longer be supported after '%s'."+
+				" Red Hat recommends selecting a newer version. For more information,"+
+				" see https://docs.openshift.com/rosa/rosa_policy/rosa-life-cycle.html",
+			endOfLife.Format(time.DateOnly),
+		)
+	}
+	return nil
+}
+
+// GetVersionEndOfLife returns the end of life of the version, which is zero when it isn't known yet
+func (c *Client) GetVersionEndOfLife(version string, channelGroup string) (time.Time, error) {
 	collection := c.ocm.ClustersMgmt().V1().Versions()
 	filter := fmt.Sprintf("raw_id='%s'", GetRawVersionId(version))
 	if channelGroup != "" {
@@ -427,21 +447,9 @@ func (c *Client) IsVersionCloseToEol(daysAwayToCheck int, version string, channe
 		Size(1).
 		Send()
 	if err != nil {
-		return handleErr(response.Error(), err)
+		return time.Time{}, handleErr(response.Error(), err)
 	}
-	ocmVersion := response.Items().Get(0)
-	now := time.Now().UTC()
-	if !ocmVersion.EndOfLifeTimestamp().IsZero() &&
-		ocmVersion.EndOfLifeTimestamp().Compare(
-			now.Add(time.Duration(daysAwayToCheck)*OneDayHourDuration*time.Hour)) <= 0 {
-		return fmt.Errorf(
-			"The version of Red Hat OpenShift Service on AWS that you are installing will no longer be supported after '%s'."+
-				" Red Hat recommends selecting a newer version. For more information,"+
-				" see https://docs.openshift.com/rosa/rosa_policy/rosa-life-cycle.html",
-			ocmVersion.EndOfLifeTimestamp().Format(time.DateOnly),
-		)
-	}
-	return nil
+	return response.Items().Get(0).EndOfLifeTimestamp(), nil
 }
 
 // Validate OpenShift versions
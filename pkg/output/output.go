@@ -26,7 +26,9 @@ import (
 	"reflect"
 
 	"github.com/ghodss/yaml"
+	amsv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
 	cmv1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
+	slv1 "github.com/openshift-online/ocm-sdk-go/servicelogs/v1"
 	msv1 "github.com/openshift-online/ocm-sdk-go/servicemgmt/v1"
 
 	"gitlab.com/c0b/go-ordered-json"
@@ -152,6 +154,26 @@ func Print(resource interface{}) error {
 		if subnetNetworkVerification, ok := resource.(*cmv1.SubnetNetworkVerification); ok {
 			cmv1.MarshalSubnetNetworkVerification(subnetNetworkVerification, &b)
 		}
+	case "[]*v1.LogEntry":
+		if logEntries, ok := resource.([]*slv1.LogEntry); ok {
+			slv1.MarshalLogEntryList(logEntries, &b)
+		}
+	case "*v1.LogEntry":
+		if logEntry, ok := resource.(*slv1.LogEntry); ok {
+			slv1.MarshalLogEntry(logEntry, &b)
+		}
+	case "[]*v1.Subscription":
+		if subscriptions, ok := resource.([]*amsv1.Subscription); ok {
+			amsv1.MarshalSubscriptionList(subscriptions, &b)
+		}
+	case "*v1.Subscription":
+		if subscription, ok := resource.(*amsv1.Subscription); ok {
+			amsv1.MarshalSubscription(subscription, &b)
+		}
+	case "[]*v1.CloudAccount":
+		if cloudAccounts, ok := resource.([]*amsv1.CloudAccount); ok {
+			amsv1.MarshalCloudAccountList(cloudAccounts, &b)
+		}
 	case "[]aws.Role", "[]aws.OidcProviderOutput":
 		{
 			err := defaultEncode(resource, &b)
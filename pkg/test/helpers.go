@@ -17,6 +17,7 @@ import (
 	amsv1 "github.com/openshift-online/ocm-sdk-go/accountsmgmt/v1"
 	v1 "github.com/openshift-online/ocm-sdk-go/clustersmgmt/v1"
 	"github.com/openshift-online/ocm-sdk-go/logging"
+	slv1 "github.com/openshift-online/ocm-sdk-go/servicelogs/v1"
 	. "github.com/openshift-online/ocm-sdk-go/testing"
 	"github.com/spf13/cobra"
 
@@ -209,6 +210,21 @@ func FormatHtpasswdUserList(htpasswdUsers []*v1.HTPasswdUser) string {
 	}`, len(htpasswdUsers), len(htpasswdUsers), htpasswdUserJson.String())
 }
 
+func FormatUserList(users []*v1.User) string {
+	var userJson bytes.Buffer
+
+	v1.MarshalUserList(users, &userJson)
+
+	return fmt.Sprintf(`
+	{
+		"kind": "UserList",
+		"page": 1,
+		"size": %d,
+		"total": %d,
+		"items": %s
+	}`, len(users), len(users), userJson.String())
+}
+
 func FormatBreakGlassCredentialList(credentials []*v1.BreakGlassCredential) string {
 	var outputJson bytes.Buffer
 	v1.MarshalBreakGlassCredentialList(credentials, &outputJson)
@@ -252,6 +268,96 @@ func FormatNodePoolUpgradePolicyList(upgrades []*v1.NodePoolUpgradePolicy) strin
 	}`, len(upgrades), len(upgrades), outputJson.String())
 }
 
+func FormatNodePoolList(nodePools []*v1.NodePool) string {
+	var outputJson bytes.Buffer
+
+	v1.MarshalNodePoolList(nodePools, &outputJson)
+
+	return fmt.Sprintf(`
+	{
+		"kind": "NodePoolList",
+		"page": 1,
+		"size": %d,
+		"total": %d,
+		"items": %s
+	}`, len(nodePools), len(nodePools), outputJson.String())
+}
+
+func FormatControlPlaneUpgradePolicyList(upgrades []*v1.ControlPlaneUpgradePolicy) string {
+	var outputJson bytes.Buffer
+
+	v1.MarshalControlPlaneUpgradePolicyList(upgrades, &outputJson)
+
+	return fmt.Sprintf(`
+	{
+		"kind": "ControlPlaneUpgradePolicyList",
+		"page": 1,
+		"size": %d,
+		"total": %d,
+		"items": %s
+	}`, len(upgrades), len(upgrades), outputJson.String())
+}
+
+func FormatUpgradePolicyList(upgrades []*v1.UpgradePolicy) string {
+	var outputJson bytes.Buffer
+
+	v1.MarshalUpgradePolicyList(upgrades, &outputJson)
+
+	return fmt.Sprintf(`
+	{
+		"kind": "UpgradePolicyList",
+		"page": 1,
+		"size": %d,
+		"total": %d,
+		"items": %s
+	}`, len(upgrades), len(upgrades), outputJson.String())
+}
+
+func FormatServiceLogList(logEntries []*slv1.LogEntry) string {
+	var outputJson bytes.Buffer
+
+	slv1.MarshalLogEntryList(logEntries, &outputJson)
+
+	return fmt.Sprintf(`
+	{
+		"kind": "ClusterLogList",
+		"page": 1,
+		"size": %d,
+		"total": %d,
+		"items": %s
+	}`, len(logEntries), len(logEntries), outputJson.String())
+}
+
+func FormatSubscriptionList(subscriptions []*amsv1.Subscription) string {
+	var outputJson bytes.Buffer
+
+	amsv1.MarshalSubscriptionList(subscriptions, &outputJson)
+
+	return fmt.Sprintf(`
+	{
+		"kind": "SubscriptionList",
+		"page": 1,
+		"size": %d,
+		"total": %d,
+		"items": %s
+	}`, len(subscriptions), len(subscriptions), outputJson.String())
+}
+
+func FormatQuotaCostList(quotaCosts []*amsv1.QuotaCost) string {
+	var outputJson bytes.Buffer
+
+	amsv1.MarshalQuotaCostList(quotaCosts, &outputJson)
+
+	return fmt.Sprintf(`
+	{
+		"kind": "QuotaCostList",
+		"page": 1,
+		"size": %d,
+		"total": %d,
+		"items": %s
+	}`, len(quotaCosts), len(quotaCosts), outputJson.String())
+}
+
 // FormatResource wraps the SDK marshalling and returns a string starting from an object
 func FormatResource(resource interface{}) string {
 	var outputJson bytes.Buffer
@@ -281,6 +387,14 @@ func FormatResource(resource interface{}) string {
 		if res, ok := resource.(*v1.ExternalAuth); ok {
 			err = v1.MarshalExternalAuth(res, &outputJson)
 		}
+	case "*v1.LogEntry":
+		if res, ok := resource.(*slv1.LogEntry); ok {
+			err = slv1.MarshalLogEntry(res, &outputJson)
+		}
+	case "*v1.Subscription":
+		if res, ok := resource.(*amsv1.Subscription); ok {
+			err = amsv1.MarshalSubscription(res, &outputJson)
+		}
 	case "*v1.BreakGlassCredential":
 		if res, ok := resource.(*v1.BreakGlassCredential); ok {
 			err = v1.MarshalBreakGlassCredential(res, &outputJson)